@@ -73,6 +73,146 @@ func (ns *NATSStatus) UpdateConditionAvailabilityZones(
 	meta.SetStatusCondition(&ns.Conditions, condition)
 }
 
+func (ns *NATSStatus) UpdateConditionCertificatesValid(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionCertificatesValid),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ns.Conditions, condition)
+}
+
+func (ns *NATSStatus) UpdateConditionEncryptionKey(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionEncryptionKey),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ns.Conditions, condition)
+}
+
+func (ns *NATSStatus) UpdateConditionUpgrading(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionUpgrading),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ns.Conditions, condition)
+}
+
+func (ns *NATSStatus) UpdateConditionDrifted(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionDrifted),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ns.Conditions, condition)
+}
+
+func (ns *NATSStatus) UpdateConditionClusterFormed(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionClusterFormed),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ns.Conditions, condition)
+}
+
+func (ns *NATSStatus) UpdateConditionJetStreamHealthy(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionJetStreamHealthy),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ns.Conditions, condition)
+}
+
+func (ns *NATSStatus) UpdateConditionStorageCapacity(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionStorageCapacity),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ns.Conditions, condition)
+}
+
+func (ns *NATSStatus) UpdateConditionExpandingStorage(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionExpandingStorage),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ns.Conditions, condition)
+}
+
+func (ns *NATSStatus) UpdateConditionScalingDown(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionScalingDown),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ns.Conditions, condition)
+}
+
+// RemoveCondition removes the condition of the given type, e.g. if the feature it reports on is disabled.
+func (ns *NATSStatus) RemoveCondition(conditionType ConditionType) {
+	meta.RemoveStatusCondition(&ns.Conditions, string(conditionType))
+}
+
 func (ns *NATSStatus) SetStateReady() {
 	ns.State = StateReady
 	ns.UpdateConditionStatefulSet(kmetav1.ConditionTrue,
@@ -236,6 +236,93 @@ func Test_UpdateConditionAvailabilityZones(t *testing.T) {
 	})
 }
 
+func Test_UpdateConditionCertificatesValid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should update the CertificatesValid condition", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		natsStatus1 := &NATSStatus{
+			Conditions: []kmetav1.Condition{
+				{
+					Type:   string(ConditionCertificatesValid),
+					Status: kmetav1.ConditionTrue,
+					Reason: string(ConditionReasonCertificatesValid),
+				},
+			},
+			State: StateReady,
+		}
+
+		givenStatus := kmetav1.ConditionFalse
+		givenReason := ConditionReasonCertificateExpired
+		givenMessage := "testxyz"
+
+		// when
+		natsStatus1.UpdateConditionCertificatesValid(givenStatus, givenReason, givenMessage)
+
+		// then
+		gotCondition := natsStatus1.Conditions[0]
+		require.Equal(t, string(ConditionCertificatesValid), gotCondition.Type)
+		require.Equal(t, givenStatus, gotCondition.Status)
+		require.Equal(t, string(givenReason), gotCondition.Reason)
+		require.Equal(t, givenMessage, gotCondition.Message)
+	})
+}
+
+func Test_UpdateConditionEncryptionKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should update the EncryptionKey condition", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		natsStatus1 := &NATSStatus{
+			Conditions: []kmetav1.Condition{
+				{
+					Type:   string(ConditionEncryptionKey),
+					Status: kmetav1.ConditionTrue,
+					Reason: string(ConditionReasonKeyActive),
+				},
+			},
+			State: StateReady,
+		}
+
+		givenStatus := kmetav1.ConditionFalse
+		givenReason := ConditionReasonKeyRotating
+		givenMessage := "testxyz"
+
+		// when
+		natsStatus1.UpdateConditionEncryptionKey(givenStatus, givenReason, givenMessage)
+
+		// then
+		gotCondition := natsStatus1.Conditions[0]
+		require.Equal(t, string(ConditionEncryptionKey), gotCondition.Type)
+		require.Equal(t, givenStatus, gotCondition.Status)
+		require.Equal(t, string(givenReason), gotCondition.Reason)
+		require.Equal(t, givenMessage, gotCondition.Message)
+	})
+}
+
+func Test_RemoveCondition(t *testing.T) {
+	t.Parallel()
+
+	// given
+	natsStatus := &NATSStatus{
+		Conditions: []kmetav1.Condition{
+			{Type: string(ConditionAvailable), Status: kmetav1.ConditionTrue},
+			{Type: string(ConditionCertificatesValid), Status: kmetav1.ConditionTrue},
+		},
+	}
+
+	// when
+	natsStatus.RemoveCondition(ConditionCertificatesValid)
+
+	// then
+	require.Nil(t, natsStatus.FindCondition(ConditionCertificatesValid))
+	require.NotNil(t, natsStatus.FindCondition(ConditionAvailable))
+}
+
 func Test_SetStateReady(t *testing.T) {
 	t.Parallel()
 
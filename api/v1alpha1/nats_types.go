@@ -20,11 +20,84 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	kcorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// GeneratedTLSSecretSuffix is appended to the name of the NATS CR to name the Secret
+// with the certificate which is generated by the NATS manager.
+const GeneratedTLSSecretSuffix = "-tls"
+
+// AccountsSecretSuffix is appended to the name of the NATS CR to name the Secret with the resolver configuration,
+// i.e. the NATS accounts and their users.
+const AccountsSecretSuffix = "-secret"
+
+// EncryptionKeySecretSuffix is appended to the name of the NATS CR to name the Secret with the encryption keys
+// of the JetStream file store which the NATS manager copies from the Secret referenced in the NATS CR.
+const EncryptionKeySecretSuffix = "-jetstream-encryption"
+
+// OperatorSecretSuffix is appended to the name of the NATS CR to name the Secret with the operator, the system
+// account and the account of the NATS manager, which the NATS manager generates in the JWT authentication mode.
+const OperatorSecretSuffix = "-operator"
+
+// PlanConfigMapSuffix is appended to the name of the NATS CR to name the ConfigMap with the change plan
+// which the NATS manager computes in the dry-run mode.
+const PlanConfigMapSuffix = "-plan"
+
+// DryRunAnnotation is the annotation of the NATS CR which makes the NATS manager plan the changes of the managed
+// resources instead of applying them, if its value is "true".
+const DryRunAnnotation = "nats.operator.kyma-project.io/dry-run"
+
+const (
+	// DriftPolicyAnnotation is the annotation of the NATS CR which selects how the NATS manager handles
+	// changes of the managed resources which were not made by the NATS manager, e.g. with kubectl edit.
+	DriftPolicyAnnotation = "nats.operator.kyma-project.io/drift-policy"
+	// DriftPolicyAutoCorrect reverts the changes of the managed resources. This is the default.
+	DriftPolicyAutoCorrect = "auto-correct"
+	// DriftPolicyReportOnly reports the changed resources but does not apply them anymore.
+	DriftPolicyReportOnly = "report-only"
+)
+
+const (
+	// AuthModeMemory configures the accounts and their users in the NATS configuration.
+	AuthModeMemory = "memory"
+	// AuthModeJWT runs NATS in operator mode, in which the accounts are JWTs pushed to the full resolver.
+	AuthModeJWT = "jwt"
+)
+
+const (
+	// EncryptionKeySecretKey is the key of the encryption key in the Secret referenced in the NATS CR.
+	EncryptionKeySecretKey = "key"
+	// EncryptionPreviousKeySecretKey is the key of the previous encryption key in the Secret managed by
+	// the NATS manager during a key rotation.
+	EncryptionPreviousKeySecretKey = "previousKey"
+
+	EncryptionCipherChaCha = "ChaCha"
+	EncryptionCipherAES    = "AES"
+)
+
+// DeletionPolicy defines what happens to the NATS cluster and its data when the NATS CR is deleted.
+type DeletionPolicy string
+
+const (
+	DeletionPolicyBlock    DeletionPolicy = "Block"
+	DeletionPolicyRetain   DeletionPolicy = "Retain"
+	DeletionPolicySnapshot DeletionPolicy = "Snapshot"
+	DeletionPolicyForce    DeletionPolicy = "Force"
+
+	// DefaultSnapshotDeletionGracePeriod is the time to wait for the snapshots of the PVCs,
+	// unless a deletion grace period is set.
+	DefaultSnapshotDeletionGracePeriod = 10 * time.Minute
+
+	// DefaultProtectedStreams and DefaultIgnoredStreams are the stream patterns of the deletion protection
+	// unless set otherwise. The stream of Kyma eventing only blocks the deletion if it has consumers.
+	DefaultProtectedStreams = "*"
+	DefaultIgnoredStreams   = "sap"
+)
+
 type ConditionReason string
 
 type ConditionType string
@@ -40,6 +113,18 @@ const (
 	ConditionStatefulSet       ConditionType = "StatefulSet"
 	ConditionDeleted           ConditionType = "Deleted"
 	ConditionAvailabilityZones ConditionType = "AvailabilityZones"
+	ConditionSynced            ConditionType = "Synced"
+	ConditionCertificatesValid ConditionType = "CertificatesValid"
+	ConditionEncryptionKey     ConditionType = "EncryptionKey"
+	ConditionUpgrading         ConditionType = "Upgrading"
+	ConditionDrifted           ConditionType = "Drifted"
+	ConditionCompleted         ConditionType = "Completed"
+	ConditionScheduled         ConditionType = "Scheduled"
+	ConditionClusterFormed     ConditionType = "ClusterFormed"
+	ConditionJetStreamHealthy  ConditionType = "JetStreamHealthy"
+	ConditionStorageCapacity   ConditionType = "StorageCapacity"
+	ConditionExpandingStorage  ConditionType = "ExpandingFileStorage"
+	ConditionScalingDown       ConditionType = "ScalingDown"
 
 	ConditionReasonProcessing           ConditionReason = "Processing"
 	ConditionReasonDeploying            ConditionReason = "Deploying"
@@ -54,6 +139,54 @@ const (
 	ConditionReasonDeletionError        ConditionReason = "DeletionError"
 	ConditionReasonNotConfigured        ConditionReason = "NotConfigured"
 	ConditionReasonUnknown              ConditionReason = "Unknown"
+	ConditionReasonCreated              ConditionReason = "Created"
+	ConditionReasonInSync               ConditionReason = "InSync"
+	ConditionReasonDriftCorrected       ConditionReason = "DriftCorrected"
+	ConditionReasonNATSNotReady         ConditionReason = "NATSNotReady"
+	ConditionReasonStreamNotReady       ConditionReason = "StreamNotReady"
+	ConditionReasonCertificatesValid    ConditionReason = "Valid"
+	ConditionReasonCertificateExpiring  ConditionReason = "CertificateExpiring"
+	ConditionReasonCertificateExpired   ConditionReason = "CertificateExpired"
+	ConditionReasonCertificateInvalid   ConditionReason = "InvalidCertificate"
+	ConditionReasonKeyActive            ConditionReason = "KeyActive"
+	ConditionReasonKeyRotating          ConditionReason = "KeyRotating"
+	ConditionReasonKeyInvalid           ConditionReason = "InvalidKey"
+	ConditionReasonAccountInvalid       ConditionReason = "InvalidAccount"
+	ConditionReasonUpgrading            ConditionReason = "Upgrading"
+	ConditionReasonUpgradeWaiting       ConditionReason = "WaitingForHealthyServers"
+	ConditionReasonUpgraded             ConditionReason = "Upgraded"
+	ConditionReasonDriftDetected        ConditionReason = "DriftDetected"
+	ConditionReasonPlanned              ConditionReason = "Planned"
+	ConditionReasonNATSUnreachable      ConditionReason = "NATSUnreachable"
+	ConditionReasonSnapshotting         ConditionReason = "Snapshotting"
+	ConditionReasonSnapshotted          ConditionReason = "Snapshotted"
+	ConditionReasonSnapshotsUnavailable ConditionReason = "SnapshotsUnavailable"
+	ConditionReasonPVCsRetained         ConditionReason = "PVCsRetained"
+	ConditionReasonDeletionEscalated    ConditionReason = "DeletionEscalated"
+	ConditionReasonBackingUp            ConditionReason = "BackingUp"
+	ConditionReasonBackedUp             ConditionReason = "BackedUp"
+	ConditionReasonSnapshotsMissing     ConditionReason = "SnapshotsMissing"
+	ConditionReasonBackupNotReady       ConditionReason = "BackupNotReady"
+	ConditionReasonRestoring            ConditionReason = "Restoring"
+	ConditionReasonRestored             ConditionReason = "Restored"
+	ConditionReasonScheduled            ConditionReason = "Scheduled"
+	ConditionReasonSuspended            ConditionReason = "Suspended"
+	ConditionReasonBackupFailed         ConditionReason = "BackupFailed"
+	ConditionReasonScheduleInvalid      ConditionReason = "InvalidSchedule"
+	ConditionReasonBackupExpired        ConditionReason = "BackupExpired"
+	ConditionReasonRoutesEstablished    ConditionReason = "RoutesEstablished"
+	ConditionReasonRoutesMissing        ConditionReason = "RoutesMissing"
+	ConditionReasonJetStreamHealthy     ConditionReason = "Healthy"
+	ConditionReasonJetStreamUnhealthy   ConditionReason = "Unhealthy"
+	ConditionReasonStorageAvailable     ConditionReason = "StorageAvailable"
+	ConditionReasonStorageLow           ConditionReason = "StorageLow"
+	ConditionReasonExpanding            ConditionReason = "Expanding"
+	ConditionReasonExpanded             ConditionReason = "Expanded"
+	ConditionReasonExpansionUnsupported ConditionReason = "ExpansionNotSupported"
+	ConditionReasonAutoGrowing          ConditionReason = "AutoGrowing"
+	ConditionReasonScalingDown          ConditionReason = "ScalingDown"
+	ConditionReasonScaledDown           ConditionReason = "ScaledDown"
+	ConditionReasonScaleDownRefused     ConditionReason = "ScaleDownRefused"
 )
 
 /*
@@ -98,6 +231,90 @@ type NATSStatus struct {
 	URL                   string              `json:"url,omitempty"`
 	AvailabilityZonesUsed int                 `json:"availabilityZonesUsed,omitempty"`
 	Conditions            []kmetav1.Condition `json:"conditions,omitempty"`
+	// Gateways are the gateways of the supercluster as reported by the NATS monitoring endpoint.
+	Gateways []GatewayStatus `json:"gateways,omitempty"`
+	// Servers are the NATS servers of the cluster, one for each Pod of the StatefulSet.
+	Servers []ServerStatus `json:"servers,omitempty"`
+	// JetStream is the JetStream usage of all accounts.
+	JetStream *JetStreamStatus `json:"jetStream,omitempty"`
+	// FileStorageSize is the size to which the file storage was grown automatically. It is only set while it is
+	// larger than spec.jetStream.fileStorage.size.
+	FileStorageSize *resource.Quantity `json:"fileStorageSize,omitempty"`
+	// Inventory lists the objects rendered from the NATS chart which were applied in the last reconciliation.
+	// Objects which are no longer rendered are deleted.
+	Inventory []InventoryEntry `json:"inventory,omitempty"`
+	// Plan references the change plan which was computed in the dry-run mode instead of applying the changes.
+	Plan *PlanStatus `json:"plan,omitempty"`
+}
+
+// PlanStatus references the ConfigMap with the change plan of the dry-run mode.
+type PlanStatus struct {
+	// ConfigMapName is the name of the ConfigMap in the namespace of the NATS CR which contains the plan.
+	ConfigMapName string `json:"configMapName"`
+	// ObservedGeneration is the generation of the NATS CR which the plan was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Changes is the number of objects which would be created, modified or deleted.
+	Changes int `json:"changes"`
+	// Disruptive is true if any of the changes disrupts the NATS servers, e.g. by restarting them.
+	Disruptive bool `json:"disruptive"`
+}
+
+// InventoryEntry identifies an object which was applied for the NATS CR.
+type InventoryEntry struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+}
+
+// GatewayStatus defines the observed state of a remote gateway.
+type GatewayStatus struct {
+	// Name of the remote gateway.
+	Name string `json:"name"`
+	// Connected is true if NATS has an outbound connection to the remote gateway.
+	Connected bool `json:"connected"`
+	// InboundConnections is the number of connections from the remote gateway.
+	InboundConnections int `json:"inboundConnections,omitempty"`
+}
+
+// ServerStatus defines the observed state of a NATS server as reported by its Pod and its monitoring endpoint.
+type ServerStatus struct {
+	// Pod is the name of the Pod of the NATS server.
+	Pod string `json:"pod"`
+	// ServerName is the name of the NATS server, which is used in the routes and the JetStream meta group.
+	ServerName string `json:"serverName,omitempty"`
+	// Zone is the availability zone of the node of the Pod.
+	Zone string `json:"zone,omitempty"`
+	// Node is the name of the node of the Pod.
+	Node string `json:"node,omitempty"`
+	// Version of the NATS server.
+	Version string `json:"version,omitempty"`
+	// Routes is the number of routes to the other NATS servers of the cluster.
+	Routes int `json:"routes,omitempty"`
+	// Connections is the number of client connections to the NATS server.
+	Connections int `json:"connections,omitempty"`
+	// JetStreamStorageBytes is the size of the file storage which JetStream uses on the NATS server.
+	JetStreamStorageBytes int64 `json:"jetStreamStorageBytes,omitempty"`
+	// MetaLeader is true if the NATS server is the leader of the JetStream meta group.
+	MetaLeader bool `json:"metaLeader,omitempty"`
+	// Error is the reason why the monitoring endpoint of the NATS server could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// JetStreamStatus defines the observed JetStream usage of all accounts against the limits of the NATS servers.
+type JetStreamStatus struct {
+	// MemoryBytes is the size of the memory storage which all accounts use on all NATS servers.
+	MemoryBytes int64 `json:"memoryBytes"`
+	// MaxMemoryBytes is the sum of max_memory_store of all NATS servers.
+	MaxMemoryBytes int64 `json:"maxMemoryBytes,omitempty"`
+	// StorageBytes is the size of the file storage which all accounts use on all NATS servers.
+	StorageBytes int64 `json:"storageBytes"`
+	// MaxStorageBytes is the sum of max_file_store of all NATS servers.
+	MaxStorageBytes int64 `json:"maxStorageBytes,omitempty"`
+	// Streams is the number of streams of all accounts.
+	Streams int `json:"streams"`
+	// Consumers is the number of consumers of all accounts.
+	Consumers int `json:"consumers"`
 }
 
 // NATSSpec defines the desired state of NATS.
@@ -123,6 +340,75 @@ type NATSSpec struct {
 
 	// Labels allows to add Labels to NATS.
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// TLS defines the TLS configuration of the NATS listeners.
+	TLS TLS `json:"tls,omitempty"`
+
+	// LeafNodes defines the leaf node listener and the remote NATS clusters to which NATS connects as leaf node.
+	LeafNodes LeafNodes `json:"leafNodes,omitempty"`
+
+	// Gateways defines the gateway configuration to connect NATS with other NATS clusters to a supercluster.
+	Gateways Gateways `json:"gateways,omitempty"`
+
+	// Auth defines how NATS authenticates clients.
+	// +kubebuilder:default:={mode:"memory"}
+	Auth Auth `json:"auth,omitempty"`
+
+	// DeletionPolicy defines what happens to the NATS cluster and its data when the NATS CR is deleted.
+	// Block keeps the NATS CR as long as streams, consumers or buckets exist.
+	// Retain deletes the NATS cluster but keeps the PVCs with the data.
+	// Snapshot stops the NATS servers and takes a VolumeSnapshot of every PVC before the PVCs are deleted.
+	// It requires the VolumeSnapshot CRDs of snapshot.storage.k8s.io/v1 and a CSI driver which supports snapshots.
+	// Force deletes the NATS cluster and the PVCs regardless of the data.
+	// +kubebuilder:default:=Block
+	// +kubebuilder:validation:Enum=Block;Retain;Snapshot;Force
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DeletionGracePeriod is how long the deletion may not proceed before the NATS manager escalates it.
+	// With Block, the PVCs are deleted once the NATS servers were unreachable for the grace period, by default
+	// immediately. With Snapshot, the PVCs are retained if the snapshots are not ready within the grace period,
+	// by default 10m.
+	DeletionGracePeriod *kmetav1.Duration `json:"deletionGracePeriod,omitempty"`
+
+	// VolumeSnapshotClassName is the VolumeSnapshotClass of the snapshots taken with the Snapshot deletion policy.
+	// If it is empty, the default VolumeSnapshotClass is used. The VolumeSnapshotClass must belong to the CSI driver
+	// of the PVCs.
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+
+	// DeletionProtection defines which streams block the deletion with the Block deletion policy.
+	// +kubebuilder:default:={protectedStreams:{"*"},ignoredStreams:{"sap"}}
+	DeletionProtection DeletionProtection `json:"deletionProtection,omitempty"`
+}
+
+// DeletionProtection defines which streams block the deletion of the NATS CR. A pattern is either a glob,
+// e.g. orders-*, or a regular expression enclosed in slashes, e.g. /^orders-[0-9]+$/.
+type DeletionProtection struct {
+	// ProtectedStreams are the patterns of the streams which block the deletion.
+	// +kubebuilder:default:={"*"}
+	ProtectedStreams []string `json:"protectedStreams,omitempty"`
+
+	// IgnoredStreams are the patterns of the streams which only block the deletion if they have consumers.
+	// They take precedence over the protected streams.
+	// +kubebuilder:default:={"sap"}
+	IgnoredStreams []string `json:"ignoredStreams,omitempty"`
+}
+
+// Auth defines how NATS authenticates clients.
+type Auth struct {
+	// Mode defines how the accounts and users are configured in NATS.
+	// With memory, the accounts are part of the NATS configuration and clients may connect without credentials.
+	// With jwt, NATS runs in operator mode with a full resolver. The NATS manager generates the operator and the
+	// system account, and issues the JWTs of the accounts declared by NATSAccount CRs. All clients need credentials.
+	// +kubebuilder:default:=memory
+	// +kubebuilder:validation:Enum=memory;jwt
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="mode is immutable once it was set"
+	Mode string `json:"mode,omitempty"`
+
+	// DefaultAccount is the account of the clients which connect without credentials in the memory mode.
+	// NATS only enables JetStream for the global account of these clients as long as no other account is
+	// configured, so the NATSAccount CRs are only applied once DefaultAccount names a NATSAccount with JetStream.
+	// The streams of the global account are not moved to the default account.
+	DefaultAccount string `json:"defaultAccount,omitempty"`
 }
 
 // Cluster defines configurations that are specific to NATS clusters.
@@ -145,6 +431,27 @@ type JetStream struct {
 	// FileStorage defines configurations to file storage in NATS JetStream.
 	// +kubebuilder:default:={storageClassName:"default"}
 	FileStorage `json:"fileStorage,omitempty"`
+
+	// Encryption defines the encryption at rest of the file storage in NATS JetStream.
+	Encryption JetStreamEncryption `json:"encryption,omitempty"`
+}
+
+// JetStreamEncryption defines the encryption at rest of the file storage in NATS JetStream.
+// +kubebuilder:validation:XValidation:rule="!has(self.enabled) || !self.enabled || (has(self.secretName) && self.secretName != '')", message="secretName is required if encryption is enabled"
+type JetStreamEncryption struct {
+	// Enabled allows the encryption of the file storage.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// SecretName is the name of a Secret in the namespace of the NATS CR with the encryption key in the key `key`.
+	// To rotate the encryption key, the key in the Secret is replaced. The NATS manager then restarts the
+	// NATS servers one by one, so that they re-encrypt the stored data with the new key.
+	SecretName string `json:"secretName,omitempty"`
+
+	// Cipher defines the cipher used to encrypt the file storage.
+	// +kubebuilder:default:="ChaCha"
+	// +kubebuilder:validation:Enum=ChaCha;AES
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cipher is immutable once it was set"
+	Cipher string `json:"cipher,omitempty"`
 }
 
 // MemStorage defines configurations to memory storage in NATS JetStream.
@@ -159,6 +466,7 @@ type MemStorage struct {
 }
 
 // FileStorage defines configurations to file storage in NATS JetStream.
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.size) || has(self.size)",message="size cannot be removed once it was set"
 type FileStorage struct {
 	// StorageClassName defines the file storage class name.
 	// +kubebuilder:default:="default"
@@ -167,9 +475,136 @@ type FileStorage struct {
 
 	// Size defines the file storage size.
 	// If not set, defaults to 20Gi on alicloud and 1Gi on all other providers.
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="fileStorage is immutable once it was set"
+	// The size can only be increased and cannot be removed once it was set. The NATS manager then expands
+	// the PVCs of the NATS servers, which requires a StorageClass that allows volume expansion.
+	// +kubebuilder:validation:XValidation:rule="quantity(string(self)).compareTo(quantity(string(oldSelf))) >= 0",message="size can only be increased"
 	// +kubebuilder:validation:Optional
 	Size resource.Quantity `json:"size,omitempty"`
+
+	// AutoGrow defines how the file storage grows automatically once it runs low.
+	AutoGrow FileStorageAutoGrow `json:"autoGrow,omitempty"`
+}
+
+// FileStorageAutoGrow defines how the file storage grows automatically once it runs low.
+// +kubebuilder:validation:XValidation:rule="!has(self.enabled) || !self.enabled || has(self.maxSize)", message="maxSize is required if autoGrow is enabled"
+type FileStorageAutoGrow struct {
+	// Enabled allows the file storage to grow once a NATS server uses the warning percentage of its file storage,
+	// i.e. when the StorageCapacity condition would turn false.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MaxSize is the size up to which the file storage grows.
+	MaxSize resource.Quantity `json:"maxSize,omitempty"`
+
+	// IncrementPercent is by how many percent the file storage grows at a time.
+	// +kubebuilder:default:=50
+	// +kubebuilder:validation:Minimum:=10
+	// +kubebuilder:validation:Maximum:=100
+	IncrementPercent int `json:"incrementPercent,omitempty"`
+}
+
+// TLS defines the TLS configuration of the NATS listeners.
+// +kubebuilder:validation:XValidation:rule="!has(self.monitoring) || !has(self.monitoring.enabled) || !self.monitoring.enabled || (has(self.client) && has(self.client.enabled) && self.client.enabled)", message="monitoring TLS requires TLS for the client listener"
+type TLS struct {
+	// Client defines the TLS configuration of the client listener.
+	Client TLSListener `json:"client,omitempty"`
+
+	// Cluster defines the TLS configuration of the listener for the routes between the NATS servers.
+	Cluster TLSListener `json:"cluster,omitempty"`
+
+	// Monitoring defines the TLS configuration of the monitoring listener.
+	// NATS serves the monitoring endpoint with the certificate of the client listener.
+	Monitoring MonitoringTLS `json:"monitoring,omitempty"`
+}
+
+// TLSListener defines the TLS configuration of a NATS listener.
+type TLSListener struct {
+	// Enabled allows the enablement of TLS for the listener.
+	// +kubebuilder:default:=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// SecretName is the name of a Secret in the namespace of the NATS CR with the keys tls.crt, tls.key and ca.crt.
+	// If not set, the NATS manager generates a self-signed CA and a certificate for the listener.
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// MonitoringTLS defines the TLS configuration of the NATS monitoring listener.
+type MonitoringTLS struct {
+	// Enabled allows the enablement of HTTPS for the monitoring listener.
+	// +kubebuilder:default:=false
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// LeafNodes defines the leaf node configuration of NATS.
+// +kubebuilder:validation:XValidation:rule="!has(self.tls) || !has(self.tls.enabled) || !self.tls.enabled || (has(self.enabled) && self.enabled)", message="TLS requires the leaf node listener to be enabled"
+type LeafNodes struct {
+	// Enabled allows the enablement of the leaf node listener, so that other NATS servers can connect as leaf nodes.
+	// +kubebuilder:default:=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Port of the leaf node listener.
+	// +kubebuilder:default:=7422
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	Port int `json:"port,omitempty"`
+
+	// AuthSecretName is the name of a Secret in the namespace of the NATS CR with the keys username and password.
+	// If set, leaf nodes must use these credentials to connect to the leaf node listener.
+	AuthSecretName string `json:"authSecretName,omitempty"`
+
+	// TLS defines the TLS configuration of the leaf node listener.
+	TLS TLSListener `json:"tls,omitempty"`
+
+	// Remotes are the NATS clusters to which NATS connects as leaf node.
+	Remotes []LeafNodeRemote `json:"remotes,omitempty"`
+}
+
+// LeafNodeRemote defines a remote NATS cluster to which NATS connects as leaf node.
+type LeafNodeRemote struct {
+	// URLs of the leaf node listeners of the remote NATS cluster, for example, tls://nats.example.com:7422.
+	// +kubebuilder:validation:MinItems:=1
+	URLs []string `json:"urls"`
+
+	// CredentialsSecretName is the name of a Secret in the namespace of the NATS CR with the key leafnode.creds,
+	// which contains the NATS credentials file to authenticate at the remote NATS cluster.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// TLSSecretName is the name of a Secret in the namespace of the NATS CR with the keys tls.crt, tls.key and ca.crt,
+	// which are used to connect to the remote NATS cluster with TLS.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// Gateways defines the gateway configuration of NATS.
+// +kubebuilder:validation:XValidation:rule="!has(self.enabled) || !self.enabled || (has(self.name) && size(self.name) > 0)", message="name is required if gateways are enabled"
+type Gateways struct {
+	// Enabled allows the enablement of the gateway listener and the connections to the remote gateways.
+	// +kubebuilder:default:=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Name of the gateway, which must be unique in the supercluster.
+	// NATS uses the name of the gateway as name of the cluster.
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9_-]*$"
+	Name string `json:"name,omitempty"`
+
+	// Port of the gateway listener.
+	// +kubebuilder:default:=7522
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	Port int `json:"port,omitempty"`
+
+	// Remotes are the gateways of the other NATS clusters in the supercluster.
+	Remotes []RemoteGateway `json:"remotes,omitempty"`
+}
+
+// RemoteGateway defines the gateway of another NATS cluster in the supercluster.
+type RemoteGateway struct {
+	// Name of the remote gateway.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9_-]*$"
+	Name string `json:"name"`
+
+	// URLs of the remote gateway, for example, nats://nats-us.example.com:7522.
+	// +kubebuilder:validation:MinItems:=1
+	URLs []string `json:"urls"`
 }
 
 // Logging defines logging options.
@@ -196,6 +631,102 @@ func (n *NATS) IsInDeletion() bool {
 	return !n.DeletionTimestamp.IsZero()
 }
 
+// IsClientTLSEnabled checks if the clients must connect to NATS using TLS.
+func (n *NATS) IsClientTLSEnabled() bool {
+	return n.Spec.TLS.Client.Enabled
+}
+
+// TLSSecretName returns the name of the Secret with the certificate of the given listener.
+func (n *NATS) TLSSecretName(listener TLSListener) string {
+	if listener.SecretName != "" {
+		return listener.SecretName
+	}
+	return n.Name + GeneratedTLSSecretSuffix
+}
+
+// NeedsGeneratedCertificate checks if any listener uses TLS without a referenced Secret.
+// In this case the NATS manager has to generate the certificate.
+func (n *NATS) NeedsGeneratedCertificate() bool {
+	for _, listener := range n.TLSListeners() {
+		if listener.Enabled && listener.SecretName == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// TLSListeners returns the TLS configuration of all NATS listeners which are served with a certificate.
+func (n *NATS) TLSListeners() []TLSListener {
+	return []TLSListener{n.Spec.TLS.Client, n.Spec.TLS.Cluster, n.Spec.LeafNodes.TLS}
+}
+
+// EncryptionKeySecretName returns the name of the Secret with the encryption keys used by the NATS servers.
+func (n *NATS) EncryptionKeySecretName() string {
+	return n.Name + EncryptionKeySecretSuffix
+}
+
+// IsJWTAuthEnabled checks if NATS runs in operator mode with JWT authentication.
+func (n *NATS) IsJWTAuthEnabled() bool {
+	return n.Spec.Auth.Mode == AuthModeJWT
+}
+
+// OperatorSecretName returns the name of the Secret with the operator generated by the NATS manager.
+func (n *NATS) OperatorSecretName() string {
+	return n.Name + OperatorSecretSuffix
+}
+
+// GetDeletionPolicy returns the deletion policy, which is Block unless set otherwise.
+func (n *NATS) GetDeletionPolicy() DeletionPolicy {
+	if n.Spec.DeletionPolicy == "" {
+		return DeletionPolicyBlock
+	}
+	return n.Spec.DeletionPolicy
+}
+
+// GetProtectedStreams returns the patterns of the streams which block the deletion, which are all streams
+// unless set otherwise.
+func (n *NATS) GetProtectedStreams() []string {
+	if n.Spec.DeletionProtection.ProtectedStreams == nil {
+		return []string{DefaultProtectedStreams}
+	}
+	return n.Spec.DeletionProtection.ProtectedStreams
+}
+
+// GetIgnoredStreams returns the patterns of the streams which only block the deletion if they have consumers,
+// which is the stream of Kyma eventing unless set otherwise.
+func (n *NATS) GetIgnoredStreams() []string {
+	if n.Spec.DeletionProtection.IgnoredStreams == nil {
+		return []string{DefaultIgnoredStreams}
+	}
+	return n.Spec.DeletionProtection.IgnoredStreams
+}
+
+// GetDeletionGracePeriod returns how long the deletion may not proceed before it is escalated.
+func (n *NATS) GetDeletionGracePeriod() time.Duration {
+	if n.Spec.DeletionGracePeriod != nil {
+		return n.Spec.DeletionGracePeriod.Duration
+	}
+	if n.GetDeletionPolicy() == DeletionPolicySnapshot {
+		return DefaultSnapshotDeletionGracePeriod
+	}
+	return 0
+}
+
+// IsDryRun checks if the changes of the managed resources are only planned instead of applied.
+func (n *NATS) IsDryRun() bool {
+	return n.Annotations[DryRunAnnotation] == "true"
+}
+
+// PlanConfigMapName returns the name of the ConfigMap with the change plan of the dry-run mode.
+func (n *NATS) PlanConfigMapName() string {
+	return n.Name + PlanConfigMapSuffix
+}
+
+// IsDriftReportOnly checks if the changes of the managed resources are only reported instead of reverted.
+func (n *NATS) IsDriftReportOnly() bool {
+	return n.Annotations[DriftPolicyAnnotation] == DriftPolicyReportOnly
+}
+
 func init() { //nolint:gochecknoinits //called in external function
 	SchemeBuilder.Register(&NATS{}, &NATSList{})
 }
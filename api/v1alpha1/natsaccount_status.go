@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (as *NATSAccountStatus) IsEqual(status NATSAccountStatus) bool {
+	thisWithoutCond := as.DeepCopy()
+	statusWithoutCond := status.DeepCopy()
+
+	// remove conditions, so that we don't compare them
+	thisWithoutCond.Conditions = []kmetav1.Condition{}
+	statusWithoutCond.Conditions = []kmetav1.Condition{}
+
+	return reflect.DeepEqual(thisWithoutCond, statusWithoutCond) &&
+		ConditionsEquals(as.Conditions, status.Conditions)
+}
+
+func (as *NATSAccountStatus) UpdateConditionSynced(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionSynced),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&as.Conditions, condition)
+}
+
+func (as *NATSAccountStatus) SetStateReady(reason ConditionReason, message string) {
+	as.State = StateReady
+	as.UpdateConditionSynced(kmetav1.ConditionTrue, reason, message)
+}
+
+func (as *NATSAccountStatus) SetStateProcessing(reason ConditionReason, message string) {
+	as.State = StateProcessing
+	as.UpdateConditionSynced(kmetav1.ConditionFalse, reason, message)
+}
+
+func (as *NATSAccountStatus) SetStateError(reason ConditionReason, message string) {
+	as.State = StateError
+	as.UpdateConditionSynced(kmetav1.ConditionFalse, reason, message)
+}
+
+func (as *NATSAccountStatus) SetStateDeleting() {
+	as.State = StateDeleting
+}
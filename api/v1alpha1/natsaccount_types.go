@@ -0,0 +1,211 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:lll //this is annotation
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NATSAccount is the Schema for the NATSAccount API.
+// A NATSAccount declares a NATS account with its users which the NATS manager renders into the NATS configuration.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=natsaccounts
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kyma-nats}
+// +kubebuilder:printcolumn:name="Account",type="string",JSONPath=".spec.account",description="Name of the account in NATS"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of the account"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age of the resource"
+type NATSAccount struct {
+	kmetav1.TypeMeta   `json:",inline"`
+	kmetav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NATSAccountSpec   `json:"spec,omitempty"`
+	Status NATSAccountStatus `json:"status,omitempty"`
+}
+
+// NATSAccountSpec defines the desired state of a NATS account.
+type NATSAccountSpec struct {
+	// Account is the name of the account in NATS.
+	// If not set, the name of the NATSAccount resource is used.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="account is immutable once it was set"
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	Account string `json:"account,omitempty"`
+
+	// Users are the users which connect to NATS with this account.
+	// +listType=map
+	// +listMapKey=name
+	Users []NATSAccountUser `json:"users,omitempty"`
+
+	// JetStream defines the JetStream limits of the account. If not set, JetStream is disabled for the account.
+	JetStream *NATSAccountJetStream `json:"jetStream,omitempty"`
+
+	// Exports are the subjects which the account shares with other accounts.
+	Exports []NATSAccountExport `json:"exports,omitempty"`
+
+	// Imports are the subjects of other accounts which the account uses.
+	Imports []NATSAccountImport `json:"imports,omitempty"`
+}
+
+// NATSAccountUser defines a user of a NATS account.
+type NATSAccountUser struct {
+	// Name of the user. The user connects to NATS with the username `<account>.<name>`.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Permissions restrict the subjects which the user may publish and subscribe to.
+	// If not set, the user may use all subjects of the account.
+	Permissions NATSPermissions `json:"permissions,omitempty"`
+
+	// CredentialsSecret defines the Secrets to which the credentials of the user are written.
+	CredentialsSecret CredentialsSecret `json:"credentialsSecret,omitempty"`
+}
+
+// NATSPermissions defines the subjects which a user may publish and subscribe to.
+type NATSPermissions struct {
+	// Publish defines the subjects which the user may publish to.
+	Publish NATSPermission `json:"publish,omitempty"`
+
+	// Subscribe defines the subjects which the user may subscribe to.
+	Subscribe NATSPermission `json:"subscribe,omitempty"`
+}
+
+// NATSPermission defines the subjects which are allowed and denied. Subjects may contain wildcards.
+type NATSPermission struct {
+	// Allow lists the allowed subjects. If not set, all subjects which are not denied are allowed.
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny lists the denied subjects.
+	Deny []string `json:"deny,omitempty"`
+}
+
+// CredentialsSecret defines the Secrets with the credentials of a user.
+type CredentialsSecret struct {
+	// Name of the Secrets. If not set, `<NATSAccount name>-<user name>` is used.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name,omitempty"`
+
+	// Namespaces in which the Secrets are created. If not set, the namespace of the NATSAccount is used.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// NATSAccountJetStream defines the JetStream limits of a NATS account.
+type NATSAccountJetStream struct {
+	// MaxMemory defines how much memory storage the account may use. If not set, it is unlimited.
+	MaxMemory *resource.Quantity `json:"maxMemory,omitempty"`
+
+	// MaxFile defines how much file storage the account may use. If not set, it is unlimited.
+	MaxFile *resource.Quantity `json:"maxFile,omitempty"`
+
+	// MaxStreams defines how many streams the account may create. If not set, it is unlimited.
+	// +kubebuilder:validation:Minimum:=1
+	MaxStreams int `json:"maxStreams,omitempty"`
+
+	// MaxConsumers defines how many consumers the account may create. If not set, it is unlimited.
+	// +kubebuilder:validation:Minimum:=1
+	MaxConsumers int `json:"maxConsumers,omitempty"`
+}
+
+// NATSAccountExport defines the subjects which an account shares with other accounts.
+// +kubebuilder:validation:XValidation:rule="has(self.stream) != has(self.service)", message="exactly one of stream or service must be set"
+type NATSAccountExport struct {
+	// Stream is the subject of the messages which other accounts may subscribe to.
+	Stream string `json:"stream,omitempty"`
+
+	// Service is the subject of the requests which other accounts may send.
+	Service string `json:"service,omitempty"`
+
+	// Accounts lists the accounts which may import the export. If not set, all accounts may import it.
+	Accounts []string `json:"accounts,omitempty"`
+}
+
+// NATSAccountImport defines the subjects of another account which an account uses.
+// +kubebuilder:validation:XValidation:rule="has(self.stream) != has(self.service)", message="exactly one of stream or service must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.prefix) || has(self.stream)", message="prefix can only be set for streams"
+// +kubebuilder:validation:XValidation:rule="!has(self.to) || has(self.service)", message="to can only be set for services"
+type NATSAccountImport struct {
+	// Account is the name of the account in NATS which exports the subject.
+	// +kubebuilder:validation:Required
+	Account string `json:"account"`
+
+	// Stream is the subject of the exported stream.
+	Stream string `json:"stream,omitempty"`
+
+	// Service is the subject of the exported service.
+	Service string `json:"service,omitempty"`
+
+	// Prefix is prepended to the subjects of the imported stream.
+	Prefix string `json:"prefix,omitempty"`
+
+	// To is the subject under which the imported service is available in the account.
+	To string `json:"to,omitempty"`
+}
+
+// NATSAccountStatus defines the observed state of a NATS account.
+type NATSAccountStatus struct {
+	State      string              `json:"state,omitempty"`
+	Conditions []kmetav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NATSAccountList contains a list of NATSAccount.
+type NATSAccountList struct {
+	kmetav1.TypeMeta `json:",inline"`
+	kmetav1.ListMeta `json:"metadata,omitempty"`
+	Items            []NATSAccount `json:"items"`
+}
+
+// AccountName returns the name of the account in NATS.
+func (a *NATSAccount) AccountName() string {
+	if a.Spec.Account != "" {
+		return a.Spec.Account
+	}
+	return a.Name
+}
+
+// Username returns the name with which the given user connects to NATS.
+// Users are global in NATS, so the name of the account is part of it.
+func (a *NATSAccount) Username(user NATSAccountUser) string {
+	return a.AccountName() + "." + user.Name
+}
+
+// CredentialsSecretName returns the name of the Secrets with the credentials of the given user.
+func (a *NATSAccount) CredentialsSecretName(user NATSAccountUser) string {
+	if user.CredentialsSecret.Name != "" {
+		return user.CredentialsSecret.Name
+	}
+	return a.Name + "-" + user.Name
+}
+
+// CredentialsSecretNamespaces returns the namespaces of the Secrets with the credentials of the given user.
+func (a *NATSAccount) CredentialsSecretNamespaces(user NATSAccountUser) []string {
+	if len(user.CredentialsSecret.Namespaces) > 0 {
+		return user.CredentialsSecret.Namespaces
+	}
+	return []string{a.Namespace}
+}
+
+func (a *NATSAccount) IsInDeletion() bool {
+	return !a.DeletionTimestamp.IsZero()
+}
+
+func init() { //nolint:gochecknoinits //called in external function
+	SchemeBuilder.Register(&NATSAccount{}, &NATSAccountList{})
+}
@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (bs *NATSBackupStatus) IsEqual(status NATSBackupStatus) bool {
+	thisWithoutCond := bs.DeepCopy()
+	statusWithoutCond := status.DeepCopy()
+
+	// remove conditions, so that we don't compare them
+	thisWithoutCond.Conditions = []kmetav1.Condition{}
+	statusWithoutCond.Conditions = []kmetav1.Condition{}
+
+	return reflect.DeepEqual(thisWithoutCond, statusWithoutCond) &&
+		ConditionsEquals(bs.Conditions, status.Conditions)
+}
+
+func (bs *NATSBackupStatus) UpdateConditionCompleted(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionCompleted),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&bs.Conditions, condition)
+}
+
+func (bs *NATSBackupStatus) SetStateReady(reason ConditionReason, message string) {
+	bs.State = StateReady
+	bs.UpdateConditionCompleted(kmetav1.ConditionTrue, reason, message)
+}
+
+func (bs *NATSBackupStatus) SetStateProcessing(reason ConditionReason, message string) {
+	bs.State = StateProcessing
+	bs.UpdateConditionCompleted(kmetav1.ConditionFalse, reason, message)
+}
+
+func (bs *NATSBackupStatus) SetStateError(reason ConditionReason, message string) {
+	bs.State = StateError
+	bs.UpdateConditionCompleted(kmetav1.ConditionFalse, reason, message)
+}
+
+func (bs *NATSBackupStatus) SetStateDeleting() {
+	bs.State = StateDeleting
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:lll //this is annotation
+package v1alpha1
+
+import (
+	"path"
+
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NATSBackup is the Schema for the NATSBackup API.
+// A NATSBackup takes snapshots of NATS JetStream streams and stores them in a storage backend.
+// The snapshots are deleted together with the NATSBackup.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=natsbackups
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kyma-nats}
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of the backup"
+// +kubebuilder:printcolumn:name="Size",type="integer",JSONPath=".status.size",description="Size of the snapshots in bytes"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age of the resource"
+type NATSBackup struct {
+	kmetav1.TypeMeta   `json:",inline"`
+	kmetav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NATSBackupSpec   `json:"spec,omitempty"`
+	Status NATSBackupStatus `json:"status,omitempty"`
+}
+
+// NATSBackupSpec defines the desired state of a backup of NATS JetStream streams.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type NATSBackupSpec struct {
+	// Streams are the names of the streams to back up. If not set, all streams are backed up, which fails if
+	// other accounts than the one of the NATS manager have streams.
+	Streams []string `json:"streams,omitempty"`
+
+	// Storage defines where the snapshots are stored.
+	Storage BackupStorage `json:"storage"`
+}
+
+// BackupStorage defines where the snapshots of a backup are stored. Exactly one storage backend must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.filesystem)",message="a storage backend must be set"
+type BackupStorage struct {
+	// Filesystem stores the snapshots in the backup directory of the NATS manager, for example,
+	// on a PVC which is mounted into the NATS manager.
+	Filesystem *FilesystemBackupStorage `json:"filesystem,omitempty"`
+}
+
+// FilesystemBackupStorage stores the snapshots in the backup directory of the NATS manager.
+type FilesystemBackupStorage struct {
+	// Path is the directory of the snapshots relative to the backup directory of the NATS manager.
+	// If not set, the snapshots are stored in the backup directory.
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('/') && !self.matches('(^|/)[.][.](/|$)')",message="path must be relative and must not contain .."
+	Path string `json:"path,omitempty"`
+}
+
+// NATSBackupStatus defines the observed state of a backup of NATS JetStream streams.
+type NATSBackupStatus struct {
+	State string `json:"state,omitempty"`
+	// Location is the key prefix of the snapshots in the storage backend.
+	Location string `json:"location,omitempty"`
+	// StartTime is the time when the backup started.
+	StartTime *kmetav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time when the backup completed.
+	CompletionTime *kmetav1.Time `json:"completionTime,omitempty"`
+	// Streams are the streams in the backup.
+	Streams []BackupStream `json:"streams,omitempty"`
+	// Size is the size of all snapshots in bytes.
+	Size       int64               `json:"size,omitempty"`
+	Conditions []kmetav1.Condition `json:"conditions,omitempty"`
+}
+
+// BackupStream is a stream in a backup.
+type BackupStream struct {
+	Name string `json:"name"`
+	// Messages and Bytes are the number of messages and the bytes of the stream at the time of the snapshot.
+	Messages uint64 `json:"messages,omitempty"`
+	Bytes    uint64 `json:"bytes,omitempty"`
+	// Size is the size of the snapshot in bytes.
+	Size int64 `json:"size,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NATSBackupList contains a list of NATSBackup.
+type NATSBackupList struct {
+	kmetav1.TypeMeta `json:",inline"`
+	kmetav1.ListMeta `json:"metadata,omitempty"`
+	Items            []NATSBackup `json:"items"`
+}
+
+// StorageLocation returns the key prefix of the snapshots of the backup in the storage backend.
+func (b *NATSBackup) StorageLocation() string {
+	return path.Join(b.Namespace, b.Name)
+}
+
+// IsCompleted returns true if all snapshots of the backup were stored.
+func (b *NATSBackup) IsCompleted() bool {
+	return b.Status.CompletionTime != nil
+}
+
+func (b *NATSBackup) IsInDeletion() bool {
+	return !b.DeletionTimestamp.IsZero()
+}
+
+func init() { //nolint:gochecknoinits //called in external function
+	SchemeBuilder.Register(&NATSBackup{}, &NATSBackupList{})
+}
@@ -0,0 +1,55 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (ss *NATSBackupScheduleStatus) IsEqual(status NATSBackupScheduleStatus) bool {
+	thisWithoutCond := ss.DeepCopy()
+	statusWithoutCond := status.DeepCopy()
+
+	// remove conditions, so that we don't compare them
+	thisWithoutCond.Conditions = []kmetav1.Condition{}
+	statusWithoutCond.Conditions = []kmetav1.Condition{}
+
+	return reflect.DeepEqual(thisWithoutCond, statusWithoutCond) &&
+		ConditionsEquals(ss.Conditions, status.Conditions)
+}
+
+func (ss *NATSBackupScheduleStatus) UpdateConditionScheduled(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionScheduled),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ss.Conditions, condition)
+}
+
+func (ss *NATSBackupScheduleStatus) SetStateReady(reason ConditionReason, message string) {
+	ss.State = StateReady
+	ss.UpdateConditionScheduled(kmetav1.ConditionTrue, reason, message)
+}
+
+// SetStateWarning sets the Warning state, but keeps the schedule running.
+func (ss *NATSBackupScheduleStatus) SetStateWarning(reason ConditionReason, message string) {
+	ss.State = StateWarning
+	ss.UpdateConditionScheduled(kmetav1.ConditionTrue, reason, message)
+}
+
+func (ss *NATSBackupScheduleStatus) SetStateError(reason ConditionReason, message string) {
+	ss.State = StateError
+	ss.UpdateConditionScheduled(kmetav1.ConditionFalse, reason, message)
+}
+
+func (ss *NATSBackupScheduleStatus) SetStateDeleting() {
+	ss.State = StateDeleting
+}
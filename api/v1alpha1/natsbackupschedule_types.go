@@ -0,0 +1,124 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:lll //this is annotation
+package v1alpha1
+
+import (
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DefaultBackupRetentionCount = 7
+)
+
+// NATSBackupSchedule is the Schema for the NATSBackupSchedule API.
+// A NATSBackupSchedule creates NATSBackups on a cron schedule and deletes them once they expire.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=natsbackupschedules
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kyma-nats}
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule",description="Cron schedule of the backups"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of the backup schedule"
+// +kubebuilder:printcolumn:name="Last Success",type="date",JSONPath=".status.lastSuccessfulTime",description="Completion time of the last successful backup"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age of the resource"
+type NATSBackupSchedule struct {
+	kmetav1.TypeMeta   `json:",inline"`
+	kmetav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NATSBackupScheduleSpec   `json:"spec,omitempty"`
+	Status NATSBackupScheduleStatus `json:"status,omitempty"`
+}
+
+// NATSBackupScheduleSpec defines the desired state of scheduled backups of NATS JetStream streams.
+type NATSBackupScheduleSpec struct {
+	// Schedule is the cron expression of the backups, for example, "0 2 * * *", or a macro like "@daily".
+	// The schedule is evaluated in UTC.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Suspend stops the creation of new backups. The existing backups are kept and still expire.
+	// +kubebuilder:default:=false
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Streams are the names of the streams to back up. If not set, all streams are backed up, which fails if
+	// other accounts than the one of the NATS manager have streams.
+	Streams []string `json:"streams,omitempty"`
+
+	// Storage defines where the snapshots are stored.
+	Storage BackupStorage `json:"storage"`
+
+	// Retention defines when the backups of the schedule expire.
+	// +kubebuilder:default:={}
+	Retention BackupRetention `json:"retention,omitempty"`
+}
+
+// BackupRetention defines when the backups of a schedule expire. An expired backup is deleted with its snapshots.
+type BackupRetention struct {
+	// Count is the number of completed backups which are kept. Older completed backups are deleted.
+	// +kubebuilder:default:=7
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count,omitempty"`
+
+	// MaxAge defines how long backups are kept, for example, "720h". If not set, backups do not expire by age.
+	// The last completed backup never expires by age.
+	MaxAge *kmetav1.Duration `json:"maxAge,omitempty"`
+}
+
+// NATSBackupScheduleStatus defines the observed state of scheduled backups of NATS JetStream streams.
+type NATSBackupScheduleStatus struct {
+	State string `json:"state,omitempty"`
+	// LastScheduleTime is the scheduled time of the last backup which was created.
+	LastScheduleTime *kmetav1.Time `json:"lastScheduleTime,omitempty"`
+	// NextScheduleTime is the scheduled time of the next backup.
+	NextScheduleTime *kmetav1.Time `json:"nextScheduleTime,omitempty"`
+	// LastSuccessfulBackup is the name of the last completed backup.
+	LastSuccessfulBackup string `json:"lastSuccessfulBackup,omitempty"`
+	// LastSuccessfulTime is the completion time of the last completed backup.
+	LastSuccessfulTime *kmetav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// LastSuccessfulSize is the size of the snapshots of the last completed backup in bytes.
+	LastSuccessfulSize int64 `json:"lastSuccessfulSize,omitempty"`
+	// FailedBackups is the number of backups which failed and are not completed.
+	FailedBackups int32 `json:"failedBackups,omitempty"`
+	// LastFailure is the error of the last failed backup.
+	LastFailure string              `json:"lastFailure,omitempty"`
+	Conditions  []kmetav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NATSBackupScheduleList contains a list of NATSBackupSchedule.
+type NATSBackupScheduleList struct {
+	kmetav1.TypeMeta `json:",inline"`
+	kmetav1.ListMeta `json:"metadata,omitempty"`
+	Items            []NATSBackupSchedule `json:"items"`
+}
+
+// GetRetentionCount returns the number of completed backups which are kept.
+func (s *NATSBackupSchedule) GetRetentionCount() int {
+	if s.Spec.Retention.Count < 1 {
+		return DefaultBackupRetentionCount
+	}
+	return int(s.Spec.Retention.Count)
+}
+
+func (s *NATSBackupSchedule) IsInDeletion() bool {
+	return !s.DeletionTimestamp.IsZero()
+}
+
+func init() { //nolint:gochecknoinits //called in external function
+	SchemeBuilder.Register(&NATSBackupSchedule{}, &NATSBackupScheduleList{})
+}
@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (cs *NATSConsumerStatus) IsEqual(status NATSConsumerStatus) bool {
+	thisWithoutCond := cs.DeepCopy()
+	statusWithoutCond := status.DeepCopy()
+
+	// remove conditions, so that we don't compare them
+	thisWithoutCond.Conditions = []kmetav1.Condition{}
+	statusWithoutCond.Conditions = []kmetav1.Condition{}
+
+	return reflect.DeepEqual(thisWithoutCond, statusWithoutCond) &&
+		ConditionsEquals(cs.Conditions, status.Conditions)
+}
+
+func (cs *NATSConsumerStatus) UpdateConditionSynced(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionSynced),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&cs.Conditions, condition)
+}
+
+func (cs *NATSConsumerStatus) SetStateReady(reason ConditionReason, message string) {
+	cs.State = StateReady
+	cs.UpdateConditionSynced(kmetav1.ConditionTrue, reason, message)
+}
+
+func (cs *NATSConsumerStatus) SetStateProcessing(reason ConditionReason, message string) {
+	cs.State = StateProcessing
+	cs.UpdateConditionSynced(kmetav1.ConditionFalse, reason, message)
+}
+
+func (cs *NATSConsumerStatus) SetStateError(reason ConditionReason, message string) {
+	cs.State = StateError
+	cs.UpdateConditionSynced(kmetav1.ConditionFalse, reason, message)
+}
+
+func (cs *NATSConsumerStatus) SetStateDeleting() {
+	cs.State = StateDeleting
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:lll //this is annotation
+package v1alpha1
+
+import (
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AckPolicyNone     = "None"
+	AckPolicyAll      = "All"
+	AckPolicyExplicit = "Explicit"
+
+	DeliverPolicyAll            = "All"
+	DeliverPolicyLast           = "Last"
+	DeliverPolicyNew            = "New"
+	DeliverPolicyLastPerSubject = "LastPerSubject"
+)
+
+// NATSConsumer is the Schema for the NATSConsumer API.
+// A NATSConsumer declares a durable pull consumer on a stream which is declared by a NATSStream.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=natsconsumers
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kyma-nats}
+// +kubebuilder:printcolumn:name="Stream",type="string",JSONPath=".spec.streamRef.name",description="Name of the NATSStream the consumer is bound to"
+// +kubebuilder:printcolumn:name="Pending",type="integer",JSONPath=".status.numPending",description="Number of messages which are not delivered yet"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of the consumer"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age of the resource"
+type NATSConsumer struct {
+	kmetav1.TypeMeta   `json:",inline"`
+	kmetav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NATSConsumerSpec   `json:"spec,omitempty"`
+	Status NATSConsumerStatus `json:"status,omitempty"`
+}
+
+// NATSConsumerSpec defines the desired state of a durable NATS JetStream consumer.
+// +kubebuilder:validation:XValidation:rule="!has(self.backoff) || (has(self.maxDeliver) && self.maxDeliver > size(self.backoff))",message="maxDeliver must be greater than the number of backoff durations"
+type NATSConsumerSpec struct {
+	// StreamRef references the NATSStream in the same namespace the consumer is bound to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="streamRef is immutable"
+	StreamRef StreamReference `json:"streamRef"`
+
+	// Name is the durable name of the consumer in NATS JetStream.
+	// If not set, the name of the NATSConsumer resource is used.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable once it was set"
+	// +kubebuilder:validation:Pattern=`^[^.*>\s]+$`
+	Name string `json:"name,omitempty"`
+
+	// Description of the consumer.
+	Description string `json:"description,omitempty"`
+
+	// AckPolicy defines how messages have to be acknowledged.
+	// +kubebuilder:default:="Explicit"
+	// +kubebuilder:validation:Enum=None;All;Explicit
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="ackPolicy is immutable once it was set"
+	AckPolicy string `json:"ackPolicy,omitempty"`
+
+	// DeliverPolicy defines where in the stream the consumer starts to deliver messages.
+	// +kubebuilder:default:="All"
+	// +kubebuilder:validation:Enum=All;Last;New;LastPerSubject
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="deliverPolicy is immutable once it was set"
+	DeliverPolicy string `json:"deliverPolicy,omitempty"`
+
+	// FilterSubjects restricts the messages delivered by the consumer to the given subjects.
+	// If not set, all messages of the stream are delivered.
+	FilterSubjects []string `json:"filterSubjects,omitempty"`
+
+	// MaxDeliver defines how often a message is delivered before it is given up on.
+	// If not set, messages are redelivered until they are acknowledged.
+	// +kubebuilder:validation:Minimum:=1
+	MaxDeliver int `json:"maxDeliver,omitempty"`
+
+	// Backoff defines the delays between the redeliveries of a message which was not acknowledged.
+	Backoff []kmetav1.Duration `json:"backoff,omitempty"`
+}
+
+// StreamReference references a NATSStream.
+type StreamReference struct {
+	// Name of the NATSStream.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// NATSConsumerStatus defines the observed state of a durable NATS JetStream consumer.
+type NATSConsumerStatus struct {
+	State string `json:"state,omitempty"`
+	// NumPending is the number of messages in the stream which were not delivered to the consumer yet.
+	NumPending uint64 `json:"numPending,omitempty"`
+	// NumAckPending is the number of delivered messages which were not acknowledged yet.
+	NumAckPending int `json:"numAckPending,omitempty"`
+	// NumRedelivered is the number of messages which were delivered more than once.
+	NumRedelivered int                 `json:"numRedelivered,omitempty"`
+	Conditions     []kmetav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NATSConsumerList contains a list of NATSConsumer.
+type NATSConsumerList struct {
+	kmetav1.TypeMeta `json:",inline"`
+	kmetav1.ListMeta `json:"metadata,omitempty"`
+	Items            []NATSConsumer `json:"items"`
+}
+
+// ConsumerName returns the durable name of the consumer in NATS JetStream.
+func (c *NATSConsumer) ConsumerName() string {
+	if c.Spec.Name != "" {
+		return c.Spec.Name
+	}
+	return c.Name
+}
+
+func (c *NATSConsumer) IsInDeletion() bool {
+	return !c.DeletionTimestamp.IsZero()
+}
+
+func init() { //nolint:gochecknoinits //called in external function
+	SchemeBuilder.Register(&NATSConsumer{}, &NATSConsumerList{})
+}
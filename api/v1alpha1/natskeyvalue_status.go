@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (kvs *NATSKeyValueStatus) IsEqual(status NATSKeyValueStatus) bool {
+	thisWithoutCond := kvs.DeepCopy()
+	statusWithoutCond := status.DeepCopy()
+
+	// remove conditions, so that we don't compare them
+	thisWithoutCond.Conditions = []kmetav1.Condition{}
+	statusWithoutCond.Conditions = []kmetav1.Condition{}
+
+	return reflect.DeepEqual(thisWithoutCond, statusWithoutCond) &&
+		ConditionsEquals(kvs.Conditions, status.Conditions)
+}
+
+func (kvs *NATSKeyValueStatus) UpdateConditionSynced(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionSynced),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&kvs.Conditions, condition)
+}
+
+func (kvs *NATSKeyValueStatus) SetStateReady(reason ConditionReason, message string) {
+	kvs.State = StateReady
+	kvs.UpdateConditionSynced(kmetav1.ConditionTrue, reason, message)
+}
+
+func (kvs *NATSKeyValueStatus) SetStateProcessing(reason ConditionReason, message string) {
+	kvs.State = StateProcessing
+	kvs.UpdateConditionSynced(kmetav1.ConditionFalse, reason, message)
+}
+
+func (kvs *NATSKeyValueStatus) SetStateError(reason ConditionReason, message string) {
+	kvs.State = StateError
+	kvs.UpdateConditionSynced(kmetav1.ConditionFalse, reason, message)
+}
+
+func (kvs *NATSKeyValueStatus) SetStateDeleting() {
+	kvs.State = StateDeleting
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:lll //this is annotation
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NATSKeyValue is the Schema for the NATSKeyValue API.
+// A NATSKeyValue declares a NATS JetStream key-value bucket which is created, updated and deleted by the NATS manager.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=natskeyvalues
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kyma-nats}
+// +kubebuilder:printcolumn:name="Bucket",type="string",JSONPath=".spec.bucket",description="Name of the bucket in NATS JetStream"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of the bucket"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age of the resource"
+type NATSKeyValue struct {
+	kmetav1.TypeMeta   `json:",inline"`
+	kmetav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NATSKeyValueSpec   `json:"spec,omitempty"`
+	Status NATSKeyValueStatus `json:"status,omitempty"`
+}
+
+// NATSKeyValueSpec defines the desired state of a NATS JetStream key-value bucket.
+type NATSKeyValueSpec struct {
+	// Bucket is the name of the key-value bucket in NATS JetStream.
+	// If not set, the name of the NATSKeyValue resource is used.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="bucket is immutable once it was set"
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	Bucket string `json:"bucket,omitempty"`
+
+	// Description of the bucket.
+	Description string `json:"description,omitempty"`
+
+	// History defines how many historical values are kept per key.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=64
+	History int `json:"history,omitempty"`
+
+	// TTL defines how long values are kept. If not set, values do not expire.
+	TTL *kmetav1.Duration `json:"ttl,omitempty"`
+
+	// Replicas defines how many replicas of the bucket are kept in the NATS cluster.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=5
+	Replicas int `json:"replicas,omitempty"`
+
+	// MaxBytes defines how big the bucket may be. If not set, the bucket size is unlimited.
+	MaxBytes *resource.Quantity `json:"maxBytes,omitempty"`
+
+	// Storage defines the storage type of the bucket.
+	// +kubebuilder:default:="File"
+	// +kubebuilder:validation:Enum=File;Memory
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="storage is immutable once it was set"
+	Storage string `json:"storage,omitempty"`
+}
+
+// NATSKeyValueStatus defines the observed state of a NATS JetStream key-value bucket.
+type NATSKeyValueStatus struct {
+	State string `json:"state,omitempty"`
+	// Values is the number of stored values including the historical ones.
+	Values     uint64              `json:"values,omitempty"`
+	Bytes      uint64              `json:"bytes,omitempty"`
+	Conditions []kmetav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NATSKeyValueList contains a list of NATSKeyValue.
+type NATSKeyValueList struct {
+	kmetav1.TypeMeta `json:",inline"`
+	kmetav1.ListMeta `json:"metadata,omitempty"`
+	Items            []NATSKeyValue `json:"items"`
+}
+
+// BucketName returns the name of the key-value bucket in NATS JetStream.
+func (kv *NATSKeyValue) BucketName() string {
+	if kv.Spec.Bucket != "" {
+		return kv.Spec.Bucket
+	}
+	return kv.Name
+}
+
+func (kv *NATSKeyValue) IsInDeletion() bool {
+	return !kv.DeletionTimestamp.IsZero()
+}
+
+func init() { //nolint:gochecknoinits //called in external function
+	SchemeBuilder.Register(&NATSKeyValue{}, &NATSKeyValueList{})
+}
@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (oss *NATSObjectStoreStatus) IsEqual(status NATSObjectStoreStatus) bool {
+	thisWithoutCond := oss.DeepCopy()
+	statusWithoutCond := status.DeepCopy()
+
+	// remove conditions, so that we don't compare them
+	thisWithoutCond.Conditions = []kmetav1.Condition{}
+	statusWithoutCond.Conditions = []kmetav1.Condition{}
+
+	return reflect.DeepEqual(thisWithoutCond, statusWithoutCond) &&
+		ConditionsEquals(oss.Conditions, status.Conditions)
+}
+
+func (oss *NATSObjectStoreStatus) UpdateConditionSynced(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionSynced),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&oss.Conditions, condition)
+}
+
+func (oss *NATSObjectStoreStatus) SetStateReady(reason ConditionReason, message string) {
+	oss.State = StateReady
+	oss.UpdateConditionSynced(kmetav1.ConditionTrue, reason, message)
+}
+
+func (oss *NATSObjectStoreStatus) SetStateProcessing(reason ConditionReason, message string) {
+	oss.State = StateProcessing
+	oss.UpdateConditionSynced(kmetav1.ConditionFalse, reason, message)
+}
+
+func (oss *NATSObjectStoreStatus) SetStateError(reason ConditionReason, message string) {
+	oss.State = StateError
+	oss.UpdateConditionSynced(kmetav1.ConditionFalse, reason, message)
+}
+
+func (oss *NATSObjectStoreStatus) SetStateDeleting() {
+	oss.State = StateDeleting
+}
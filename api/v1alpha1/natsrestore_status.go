@@ -0,0 +1,50 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (rs *NATSRestoreStatus) IsEqual(status NATSRestoreStatus) bool {
+	thisWithoutCond := rs.DeepCopy()
+	statusWithoutCond := status.DeepCopy()
+
+	// remove conditions, so that we don't compare them
+	thisWithoutCond.Conditions = []kmetav1.Condition{}
+	statusWithoutCond.Conditions = []kmetav1.Condition{}
+
+	return reflect.DeepEqual(thisWithoutCond, statusWithoutCond) &&
+		ConditionsEquals(rs.Conditions, status.Conditions)
+}
+
+func (rs *NATSRestoreStatus) UpdateConditionCompleted(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionCompleted),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&rs.Conditions, condition)
+}
+
+func (rs *NATSRestoreStatus) SetStateReady(reason ConditionReason, message string) {
+	rs.State = StateReady
+	rs.UpdateConditionCompleted(kmetav1.ConditionTrue, reason, message)
+}
+
+func (rs *NATSRestoreStatus) SetStateProcessing(reason ConditionReason, message string) {
+	rs.State = StateProcessing
+	rs.UpdateConditionCompleted(kmetav1.ConditionFalse, reason, message)
+}
+
+func (rs *NATSRestoreStatus) SetStateError(reason ConditionReason, message string) {
+	rs.State = StateError
+	rs.UpdateConditionCompleted(kmetav1.ConditionFalse, reason, message)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:lll //this is annotation
+package v1alpha1
+
+import (
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NATSRestore is the Schema for the NATSRestore API.
+// A NATSRestore recreates NATS JetStream streams from the snapshots of a NATSBackup.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=natsrestores
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kyma-nats}
+// +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".spec.backupName",description="Name of the NATSBackup"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of the restore"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age of the resource"
+type NATSRestore struct {
+	kmetav1.TypeMeta   `json:",inline"`
+	kmetav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NATSRestoreSpec   `json:"spec,omitempty"`
+	Status NATSRestoreStatus `json:"status,omitempty"`
+}
+
+// NATSRestoreSpec defines the desired state of a restore of NATS JetStream streams.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type NATSRestoreSpec struct {
+	// BackupName is the name of the NATSBackup in the same namespace whose snapshots are restored.
+	// +kubebuilder:validation:MinLength=1
+	BackupName string `json:"backupName"`
+
+	// Streams are the names of the streams to restore. If not set, all streams of the backup are restored.
+	// The streams must not exist in NATS JetStream.
+	Streams []string `json:"streams,omitempty"`
+}
+
+// NATSRestoreStatus defines the observed state of a restore of NATS JetStream streams.
+type NATSRestoreStatus struct {
+	State string `json:"state,omitempty"`
+	// RestoredStreams are the streams which were restored already.
+	RestoredStreams []string `json:"restoredStreams,omitempty"`
+	// CompletionTime is the time when all streams were restored.
+	CompletionTime *kmetav1.Time       `json:"completionTime,omitempty"`
+	Conditions     []kmetav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NATSRestoreList contains a list of NATSRestore.
+type NATSRestoreList struct {
+	kmetav1.TypeMeta `json:",inline"`
+	kmetav1.ListMeta `json:"metadata,omitempty"`
+	Items            []NATSRestore `json:"items"`
+}
+
+// IsCompleted returns true if all streams were restored.
+func (r *NATSRestore) IsCompleted() bool {
+	return r.Status.CompletionTime != nil
+}
+
+func init() { //nolint:gochecknoinits //called in external function
+	SchemeBuilder.Register(&NATSRestore{}, &NATSRestoreList{})
+}
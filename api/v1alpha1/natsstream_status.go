@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (ss *NATSStreamStatus) IsEqual(status NATSStreamStatus) bool {
+	thisWithoutCond := ss.DeepCopy()
+	statusWithoutCond := status.DeepCopy()
+
+	// remove conditions, so that we don't compare them
+	thisWithoutCond.Conditions = []kmetav1.Condition{}
+	statusWithoutCond.Conditions = []kmetav1.Condition{}
+
+	return reflect.DeepEqual(thisWithoutCond, statusWithoutCond) &&
+		ConditionsEquals(ss.Conditions, status.Conditions)
+}
+
+func (ss *NATSStreamStatus) UpdateConditionSynced(
+	status kmetav1.ConditionStatus,
+	reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionSynced),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&ss.Conditions, condition)
+}
+
+func (ss *NATSStreamStatus) SetStateReady(reason ConditionReason, message string) {
+	ss.State = StateReady
+	ss.UpdateConditionSynced(kmetav1.ConditionTrue, reason, message)
+}
+
+func (ss *NATSStreamStatus) SetStateProcessing(reason ConditionReason, message string) {
+	ss.State = StateProcessing
+	ss.UpdateConditionSynced(kmetav1.ConditionFalse, reason, message)
+}
+
+func (ss *NATSStreamStatus) SetStateError(reason ConditionReason, message string) {
+	ss.State = StateError
+	ss.UpdateConditionSynced(kmetav1.ConditionFalse, reason, message)
+}
+
+func (ss *NATSStreamStatus) SetStateDeleting() {
+	ss.State = StateDeleting
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:lll //this is annotation
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RetentionPolicyLimits    = "Limits"
+	RetentionPolicyInterest  = "Interest"
+	RetentionPolicyWorkQueue = "WorkQueue"
+
+	StorageTypeFile   = "File"
+	StorageTypeMemory = "Memory"
+
+	DiscardPolicyOld = "Old"
+	DiscardPolicyNew = "New"
+)
+
+// NATSStream is the Schema for the NATSStream API.
+// A NATSStream declares a NATS JetStream stream which is created, updated and deleted by the NATS manager.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=natsstreams
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kyma-nats}
+// +kubebuilder:printcolumn:name="Stream",type="string",JSONPath=".spec.name",description="Name of the stream in NATS JetStream"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of the stream"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age of the resource"
+type NATSStream struct {
+	kmetav1.TypeMeta   `json:",inline"`
+	kmetav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NATSStreamSpec   `json:"spec,omitempty"`
+	Status NATSStreamStatus `json:"status,omitempty"`
+}
+
+// NATSStreamSpec defines the desired state of a NATS JetStream stream.
+// The fields mirror the corresponding fields of the JetStream stream configuration.
+// +kubebuilder:validation:XValidation:rule="has(self.name) == has(oldSelf.name)",message="name cannot be added or removed once the NATSStream was created"
+type NATSStreamSpec struct {
+	// Name of the stream in NATS JetStream.
+	// If not set, the name of the NATSStream resource is used.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable once it was set"
+	// +kubebuilder:validation:Pattern=`^[^.*>\s]+$`
+	Name string `json:"name,omitempty"`
+
+	// Description of the stream.
+	Description string `json:"description,omitempty"`
+
+	// Subjects defines the subjects the stream is listening on.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Subjects []string `json:"subjects"`
+
+	// Retention defines the retention policy of the stream.
+	// +kubebuilder:default:="Limits"
+	// +kubebuilder:validation:Enum=Limits;Interest;WorkQueue
+	Retention string `json:"retention,omitempty"`
+
+	// Replicas defines how many replicas of the stream are kept in the NATS cluster.
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=5
+	Replicas int `json:"replicas,omitempty"`
+
+	// MaxBytes defines how big the stream may be. If not set, the stream size is unlimited.
+	MaxBytes *resource.Quantity `json:"maxBytes,omitempty"`
+
+	// MaxMsgs defines how many messages the stream may hold. If not set, the number of messages is unlimited.
+	// +kubebuilder:validation:Minimum:=1
+	MaxMsgs int64 `json:"maxMsgs,omitempty"`
+
+	// MaxAge defines the maximum age of messages in the stream. If not set, messages do not expire.
+	MaxAge *kmetav1.Duration `json:"maxAge,omitempty"`
+
+	// Storage defines the storage type of the stream.
+	// +kubebuilder:default:="File"
+	// +kubebuilder:validation:Enum=File;Memory
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="storage is immutable once it was set"
+	Storage string `json:"storage,omitempty"`
+
+	// Discard defines which messages are discarded when the stream reached its limits.
+	// +kubebuilder:default:="Old"
+	// +kubebuilder:validation:Enum=Old;New
+	Discard string `json:"discard,omitempty"`
+}
+
+// NATSStreamStatus defines the observed state of a NATS JetStream stream.
+type NATSStreamStatus struct {
+	State      string              `json:"state,omitempty"`
+	Messages   uint64              `json:"messages,omitempty"`
+	Bytes      uint64              `json:"bytes,omitempty"`
+	Consumers  int                 `json:"consumers,omitempty"`
+	Conditions []kmetav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NATSStreamList contains a list of NATSStream.
+type NATSStreamList struct {
+	kmetav1.TypeMeta `json:",inline"`
+	kmetav1.ListMeta `json:"metadata,omitempty"`
+	Items            []NATSStream `json:"items"`
+}
+
+// StreamName returns the name of the stream in NATS JetStream.
+func (s *NATSStream) StreamName() string {
+	if s.Spec.Name != "" {
+		return s.Spec.Name
+	}
+	return s.Name
+}
+
+func (s *NATSStream) IsInDeletion() bool {
+	return !s.DeletionTimestamp.IsZero()
+}
+
+func init() { //nolint:gochecknoinits //called in external function
+	SchemeBuilder.Register(&NATSStream{}, &NATSStreamList{})
+}
@@ -25,87 +25,1222 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Auth) DeepCopyInto(out *Auth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Auth.
+func (in *Auth) DeepCopy() *Auth {
+	if in == nil {
+		return nil
+	}
+	out := new(Auth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetention) DeepCopyInto(out *BackupRetention) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetention.
+func (in *BackupRetention) DeepCopy() *BackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStorage) DeepCopyInto(out *BackupStorage) {
+	*out = *in
+	if in.Filesystem != nil {
+		in, out := &in.Filesystem, &out.Filesystem
+		*out = new(FilesystemBackupStorage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStorage.
+func (in *BackupStorage) DeepCopy() *BackupStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStream) DeepCopyInto(out *BackupStream) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStream.
+func (in *BackupStream) DeepCopy() *BackupStream {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cluster.
-func (in *Cluster) DeepCopy() *Cluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cluster.
+func (in *Cluster) DeepCopy() *Cluster {
+	if in == nil {
+		return nil
+	}
+	out := new(Cluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSecret) DeepCopyInto(out *CredentialsSecret) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSecret.
+func (in *CredentialsSecret) DeepCopy() *CredentialsSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionProtection) DeepCopyInto(out *DeletionProtection) {
+	*out = *in
+	if in.ProtectedStreams != nil {
+		in, out := &in.ProtectedStreams, &out.ProtectedStreams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoredStreams != nil {
+		in, out := &in.IgnoredStreams, &out.IgnoredStreams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionProtection.
+func (in *DeletionProtection) DeepCopy() *DeletionProtection {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionProtection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileStorage) DeepCopyInto(out *FileStorage) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	in.AutoGrow.DeepCopyInto(&out.AutoGrow)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileStorage.
+func (in *FileStorage) DeepCopy() *FileStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(FileStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileStorageAutoGrow) DeepCopyInto(out *FileStorageAutoGrow) {
+	*out = *in
+	out.MaxSize = in.MaxSize.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileStorageAutoGrow.
+func (in *FileStorageAutoGrow) DeepCopy() *FileStorageAutoGrow {
+	if in == nil {
+		return nil
+	}
+	out := new(FileStorageAutoGrow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemBackupStorage) DeepCopyInto(out *FilesystemBackupStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemBackupStorage.
+func (in *FilesystemBackupStorage) DeepCopy() *FilesystemBackupStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemBackupStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayStatus) DeepCopyInto(out *GatewayStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayStatus.
+func (in *GatewayStatus) DeepCopy() *GatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Gateways) DeepCopyInto(out *Gateways) {
+	*out = *in
+	if in.Remotes != nil {
+		in, out := &in.Remotes, &out.Remotes
+		*out = make([]RemoteGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Gateways.
+func (in *Gateways) DeepCopy() *Gateways {
+	if in == nil {
+		return nil
+	}
+	out := new(Gateways)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryEntry) DeepCopyInto(out *InventoryEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryEntry.
+func (in *InventoryEntry) DeepCopy() *InventoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JetStream) DeepCopyInto(out *JetStream) {
+	*out = *in
+	in.MemStorage.DeepCopyInto(&out.MemStorage)
+	in.FileStorage.DeepCopyInto(&out.FileStorage)
+	out.Encryption = in.Encryption
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JetStream.
+func (in *JetStream) DeepCopy() *JetStream {
+	if in == nil {
+		return nil
+	}
+	out := new(JetStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JetStreamEncryption) DeepCopyInto(out *JetStreamEncryption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JetStreamEncryption.
+func (in *JetStreamEncryption) DeepCopy() *JetStreamEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(JetStreamEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JetStreamStatus) DeepCopyInto(out *JetStreamStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JetStreamStatus.
+func (in *JetStreamStatus) DeepCopy() *JetStreamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JetStreamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeafNodeRemote) DeepCopyInto(out *LeafNodeRemote) {
+	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeafNodeRemote.
+func (in *LeafNodeRemote) DeepCopy() *LeafNodeRemote {
+	if in == nil {
+		return nil
+	}
+	out := new(LeafNodeRemote)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeafNodes) DeepCopyInto(out *LeafNodes) {
+	*out = *in
+	out.TLS = in.TLS
+	if in.Remotes != nil {
+		in, out := &in.Remotes, &out.Remotes
+		*out = make([]LeafNodeRemote, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeafNodes.
+func (in *LeafNodes) DeepCopy() *LeafNodes {
+	if in == nil {
+		return nil
+	}
+	out := new(LeafNodes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Logging) DeepCopyInto(out *Logging) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logging.
+func (in *Logging) DeepCopy() *Logging {
+	if in == nil {
+		return nil
+	}
+	out := new(Logging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemStorage) DeepCopyInto(out *MemStorage) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemStorage.
+func (in *MemStorage) DeepCopy() *MemStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(MemStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringTLS) DeepCopyInto(out *MonitoringTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringTLS.
+func (in *MonitoringTLS) DeepCopy() *MonitoringTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATS) DeepCopyInto(out *NATS) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATS.
+func (in *NATS) DeepCopy() *NATS {
+	if in == nil {
+		return nil
+	}
+	out := new(NATS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATS) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSAccount) DeepCopyInto(out *NATSAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSAccount.
+func (in *NATSAccount) DeepCopy() *NATSAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSAccountExport) DeepCopyInto(out *NATSAccountExport) {
+	*out = *in
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSAccountExport.
+func (in *NATSAccountExport) DeepCopy() *NATSAccountExport {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSAccountExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSAccountImport) DeepCopyInto(out *NATSAccountImport) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSAccountImport.
+func (in *NATSAccountImport) DeepCopy() *NATSAccountImport {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSAccountImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSAccountJetStream) DeepCopyInto(out *NATSAccountJetStream) {
+	*out = *in
+	if in.MaxMemory != nil {
+		in, out := &in.MaxMemory, &out.MaxMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxFile != nil {
+		in, out := &in.MaxFile, &out.MaxFile
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSAccountJetStream.
+func (in *NATSAccountJetStream) DeepCopy() *NATSAccountJetStream {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSAccountJetStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSAccountList) DeepCopyInto(out *NATSAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NATSAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSAccountList.
+func (in *NATSAccountList) DeepCopy() *NATSAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSAccountSpec) DeepCopyInto(out *NATSAccountSpec) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]NATSAccountUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JetStream != nil {
+		in, out := &in.JetStream, &out.JetStream
+		*out = new(NATSAccountJetStream)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exports != nil {
+		in, out := &in.Exports, &out.Exports
+		*out = make([]NATSAccountExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Imports != nil {
+		in, out := &in.Imports, &out.Imports
+		*out = make([]NATSAccountImport, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSAccountSpec.
+func (in *NATSAccountSpec) DeepCopy() *NATSAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSAccountStatus) DeepCopyInto(out *NATSAccountStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSAccountStatus.
+func (in *NATSAccountStatus) DeepCopy() *NATSAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSAccountUser) DeepCopyInto(out *NATSAccountUser) {
+	*out = *in
+	in.Permissions.DeepCopyInto(&out.Permissions)
+	in.CredentialsSecret.DeepCopyInto(&out.CredentialsSecret)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSAccountUser.
+func (in *NATSAccountUser) DeepCopy() *NATSAccountUser {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSAccountUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSBackup) DeepCopyInto(out *NATSBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSBackup.
+func (in *NATSBackup) DeepCopy() *NATSBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSBackupList) DeepCopyInto(out *NATSBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NATSBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSBackupList.
+func (in *NATSBackupList) DeepCopy() *NATSBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSBackupSchedule) DeepCopyInto(out *NATSBackupSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSBackupSchedule.
+func (in *NATSBackupSchedule) DeepCopy() *NATSBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSBackupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSBackupScheduleList) DeepCopyInto(out *NATSBackupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NATSBackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSBackupScheduleList.
+func (in *NATSBackupScheduleList) DeepCopy() *NATSBackupScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSBackupScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSBackupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSBackupScheduleSpec) DeepCopyInto(out *NATSBackupScheduleSpec) {
+	*out = *in
+	if in.Streams != nil {
+		in, out := &in.Streams, &out.Streams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Storage.DeepCopyInto(&out.Storage)
+	in.Retention.DeepCopyInto(&out.Retention)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSBackupScheduleSpec.
+func (in *NATSBackupScheduleSpec) DeepCopy() *NATSBackupScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSBackupScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSBackupScheduleStatus) DeepCopyInto(out *NATSBackupScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSBackupScheduleStatus.
+func (in *NATSBackupScheduleStatus) DeepCopy() *NATSBackupScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSBackupScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSBackupSpec) DeepCopyInto(out *NATSBackupSpec) {
+	*out = *in
+	if in.Streams != nil {
+		in, out := &in.Streams, &out.Streams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Storage.DeepCopyInto(&out.Storage)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSBackupSpec.
+func (in *NATSBackupSpec) DeepCopy() *NATSBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSBackupStatus) DeepCopyInto(out *NATSBackupStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Streams != nil {
+		in, out := &in.Streams, &out.Streams
+		*out = make([]BackupStream, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSBackupStatus.
+func (in *NATSBackupStatus) DeepCopy() *NATSBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSConsumer) DeepCopyInto(out *NATSConsumer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSConsumer.
+func (in *NATSConsumer) DeepCopy() *NATSConsumer {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSConsumer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSConsumer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSConsumerList) DeepCopyInto(out *NATSConsumerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NATSConsumer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSConsumerList.
+func (in *NATSConsumerList) DeepCopy() *NATSConsumerList {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSConsumerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSConsumerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSConsumerSpec) DeepCopyInto(out *NATSConsumerSpec) {
+	*out = *in
+	out.StreamRef = in.StreamRef
+	if in.FilterSubjects != nil {
+		in, out := &in.FilterSubjects, &out.FilterSubjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = make([]v1.Duration, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSConsumerSpec.
+func (in *NATSConsumerSpec) DeepCopy() *NATSConsumerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSConsumerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSConsumerStatus) DeepCopyInto(out *NATSConsumerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSConsumerStatus.
+func (in *NATSConsumerStatus) DeepCopy() *NATSConsumerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSConsumerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSKeyValue) DeepCopyInto(out *NATSKeyValue) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSKeyValue.
+func (in *NATSKeyValue) DeepCopy() *NATSKeyValue {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSKeyValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSKeyValue) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSKeyValueList) DeepCopyInto(out *NATSKeyValueList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NATSKeyValue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSKeyValueList.
+func (in *NATSKeyValueList) DeepCopy() *NATSKeyValueList {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSKeyValueList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSKeyValueList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSKeyValueSpec) DeepCopyInto(out *NATSKeyValueSpec) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSKeyValueSpec.
+func (in *NATSKeyValueSpec) DeepCopy() *NATSKeyValueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSKeyValueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSKeyValueStatus) DeepCopyInto(out *NATSKeyValueStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSKeyValueStatus.
+func (in *NATSKeyValueStatus) DeepCopy() *NATSKeyValueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSKeyValueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSList) DeepCopyInto(out *NATSList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NATS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSList.
+func (in *NATSList) DeepCopy() *NATSList {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSObjectStore) DeepCopyInto(out *NATSObjectStore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSObjectStore.
+func (in *NATSObjectStore) DeepCopy() *NATSObjectStore {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSObjectStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSObjectStore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSObjectStoreList) DeepCopyInto(out *NATSObjectStoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NATSObjectStore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSObjectStoreList.
+func (in *NATSObjectStoreList) DeepCopy() *NATSObjectStoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(Cluster)
+	out := new(NATSObjectStoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSObjectStoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileStorage) DeepCopyInto(out *FileStorage) {
+func (in *NATSObjectStoreSpec) DeepCopyInto(out *NATSObjectStoreSpec) {
 	*out = *in
-	out.Size = in.Size.DeepCopy()
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileStorage.
-func (in *FileStorage) DeepCopy() *FileStorage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSObjectStoreSpec.
+func (in *NATSObjectStoreSpec) DeepCopy() *NATSObjectStoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FileStorage)
+	out := new(NATSObjectStoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JetStream) DeepCopyInto(out *JetStream) {
+func (in *NATSObjectStoreStatus) DeepCopyInto(out *NATSObjectStoreStatus) {
 	*out = *in
-	in.MemStorage.DeepCopyInto(&out.MemStorage)
-	in.FileStorage.DeepCopyInto(&out.FileStorage)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JetStream.
-func (in *JetStream) DeepCopy() *JetStream {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSObjectStoreStatus.
+func (in *NATSObjectStoreStatus) DeepCopy() *NATSObjectStoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(JetStream)
+	out := new(NATSObjectStoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Logging) DeepCopyInto(out *Logging) {
+func (in *NATSPermission) DeepCopyInto(out *NATSPermission) {
 	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logging.
-func (in *Logging) DeepCopy() *Logging {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSPermission.
+func (in *NATSPermission) DeepCopy() *NATSPermission {
 	if in == nil {
 		return nil
 	}
-	out := new(Logging)
+	out := new(NATSPermission)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MemStorage) DeepCopyInto(out *MemStorage) {
+func (in *NATSPermissions) DeepCopyInto(out *NATSPermissions) {
 	*out = *in
-	out.Size = in.Size.DeepCopy()
+	in.Publish.DeepCopyInto(&out.Publish)
+	in.Subscribe.DeepCopyInto(&out.Subscribe)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemStorage.
-func (in *MemStorage) DeepCopy() *MemStorage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSPermissions.
+func (in *NATSPermissions) DeepCopy() *NATSPermissions {
 	if in == nil {
 		return nil
 	}
-	out := new(MemStorage)
+	out := new(NATSPermissions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NATS) DeepCopyInto(out *NATS) {
+func (in *NATSRestore) DeepCopyInto(out *NATSRestore) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -113,18 +1248,18 @@ func (in *NATS) DeepCopyInto(out *NATS) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATS.
-func (in *NATS) DeepCopy() *NATS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSRestore.
+func (in *NATSRestore) DeepCopy() *NATSRestore {
 	if in == nil {
 		return nil
 	}
-	out := new(NATS)
+	out := new(NATSRestore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NATS) DeepCopyObject() runtime.Object {
+func (in *NATSRestore) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -132,37 +1267,88 @@ func (in *NATS) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NATSList) DeepCopyInto(out *NATSList) {
+func (in *NATSRestoreList) DeepCopyInto(out *NATSRestoreList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NATS, len(*in))
+		*out = make([]NATSRestore, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSList.
-func (in *NATSList) DeepCopy() *NATSList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSRestoreList.
+func (in *NATSRestoreList) DeepCopy() *NATSRestoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(NATSList)
+	out := new(NATSRestoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NATSList) DeepCopyObject() runtime.Object {
+func (in *NATSRestoreList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSRestoreSpec) DeepCopyInto(out *NATSRestoreSpec) {
+	*out = *in
+	if in.Streams != nil {
+		in, out := &in.Streams, &out.Streams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSRestoreSpec.
+func (in *NATSRestoreSpec) DeepCopy() *NATSRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSRestoreStatus) DeepCopyInto(out *NATSRestoreStatus) {
+	*out = *in
+	if in.RestoredStreams != nil {
+		in, out := &in.RestoredStreams, &out.RestoredStreams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSRestoreStatus.
+func (in *NATSRestoreStatus) DeepCopy() *NATSRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NATSSpec) DeepCopyInto(out *NATSSpec) {
 	*out = *in
@@ -184,6 +1370,16 @@ func (in *NATSSpec) DeepCopyInto(out *NATSSpec) {
 			(*out)[key] = val
 		}
 	}
+	out.TLS = in.TLS
+	in.LeafNodes.DeepCopyInto(&out.LeafNodes)
+	in.Gateways.DeepCopyInto(&out.Gateways)
+	out.Auth = in.Auth
+	if in.DeletionGracePeriod != nil {
+		in, out := &in.DeletionGracePeriod, &out.DeletionGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	in.DeletionProtection.DeepCopyInto(&out.DeletionProtection)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSSpec.
@@ -206,6 +1402,36 @@ func (in *NATSStatus) DeepCopyInto(out *NATSStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]GatewayStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]ServerStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.JetStream != nil {
+		in, out := &in.JetStream, &out.JetStream
+		*out = new(JetStreamStatus)
+		**out = **in
+	}
+	if in.FileStorageSize != nil {
+		in, out := &in.FileStorageSize, &out.FileStorageSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]InventoryEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(PlanStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSStatus.
@@ -217,3 +1443,212 @@ func (in *NATSStatus) DeepCopy() *NATSStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSStream) DeepCopyInto(out *NATSStream) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSStream.
+func (in *NATSStream) DeepCopy() *NATSStream {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSStream) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSStreamList) DeepCopyInto(out *NATSStreamList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NATSStream, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSStreamList.
+func (in *NATSStreamList) DeepCopy() *NATSStreamList {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSStreamList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATSStreamList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSStreamSpec) DeepCopyInto(out *NATSStreamSpec) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSStreamSpec.
+func (in *NATSStreamSpec) DeepCopy() *NATSStreamSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSStreamSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSStreamStatus) DeepCopyInto(out *NATSStreamStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSStreamStatus.
+func (in *NATSStreamStatus) DeepCopy() *NATSStreamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSStreamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanStatus) DeepCopyInto(out *PlanStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanStatus.
+func (in *PlanStatus) DeepCopy() *PlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteGateway) DeepCopyInto(out *RemoteGateway) {
+	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteGateway.
+func (in *RemoteGateway) DeepCopy() *RemoteGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerStatus) DeepCopyInto(out *ServerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerStatus.
+func (in *ServerStatus) DeepCopy() *ServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamReference) DeepCopyInto(out *StreamReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamReference.
+func (in *StreamReference) DeepCopy() *StreamReference {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLS) DeepCopyInto(out *TLS) {
+	*out = *in
+	out.Client = in.Client
+	out.Cluster = in.Cluster
+	out.Monitoring = in.Monitoring
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
+func (in *TLS) DeepCopy() *TLS {
+	if in == nil {
+		return nil
+	}
+	out := new(TLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSListener) DeepCopyInto(out *TLSListener) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSListener.
+func (in *TLSListener) DeepCopy() *TLSListener {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSListener)
+	in.DeepCopyInto(out)
+	return out
+}
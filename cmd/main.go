@@ -21,7 +21,9 @@ import (
 	"os"
 
 	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmaccountctrl "github.com/kyma-project/nats-manager/internal/controller/account"
 	nmctrlcache "github.com/kyma-project/nats-manager/internal/controller/cache"
+	nmjsctrl "github.com/kyma-project/nats-manager/internal/controller/jetstream"
 	nmctrl "github.com/kyma-project/nats-manager/internal/controller/nats"
 	"github.com/kyma-project/nats-manager/pkg/env"
 	"github.com/kyma-project/nats-manager/pkg/k8s"
@@ -30,12 +32,14 @@ import (
 	"github.com/kyma-project/nats-manager/pkg/metrics"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	kcorev1 "k8s.io/api/core/v1"
 	kapiextclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kutilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	kscheme "k8s.io/client-go/kubernetes/scheme"
 	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	klogzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -127,6 +131,9 @@ func main() { //nolint:funlen // main function needs to initialize many objects
 		Metrics:       server.Options{BindAddress: metricsAddr},
 		WebhookServer: webhook.NewServer(webhook.Options{Port: metricsPort}),
 		NewCache:      nmctrlcache.New,
+		// the cache only contains the Secrets managed by the NATS manager, but the Secrets
+		// referenced in the NATS CR, e.g. for TLS, are created by the user.
+		Client: client.Options{Cache: &client.CacheOptions{DisableFor: []client.Object{&kcorev1.Secret{}}}},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -155,6 +162,21 @@ func main() { //nolint:funlen // main function needs to initialize many objects
 	collector := metrics.NewPrometheusCollector()
 	collector.RegisterMetrics()
 
+	// the NATS CR which is managed by this NATS manager.
+	allowedNATSCR := &nmapiv1alpha1.NATS{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      envConfigs.NATSCRName,
+			Namespace: envConfigs.NATSCRNamespace,
+		},
+	}
+
+	// in multi-instance mode, the NATS reconciler manages every NATS CR. The other reconcilers keep
+	// managing the resources of the NATS CR of Kyma eventing.
+	natsReconcilerAllowedCR := allowedNATSCR
+	if envConfigs.MultiInstanceEnabled {
+		natsReconcilerAllowedCR = nil
+	}
+
 	// create NATS reconciler instance
 	natsReconciler := nmctrl.NewReconciler(
 		mgr.GetClient(),
@@ -164,19 +186,125 @@ func main() { //nolint:funlen // main function needs to initialize many objects
 		sugaredLogger,
 		mgr.GetEventRecorderFor("nats-manager"),
 		natsManager,
-		&nmapiv1alpha1.NATS{
-			ObjectMeta: kmetav1.ObjectMeta{
-				Name:      envConfigs.NATSCRName,
-				Namespace: envConfigs.NATSCRNamespace,
-			},
-		},
+		natsReconcilerAllowedCR,
 		collector,
+		nmctrl.StatusCheckBackoff{
+			MinInterval: envConfigs.StatusCheckMinInterval,
+			MaxInterval: envConfigs.StatusCheckMaxInterval,
+		},
+		envConfigs.StorageWarningPercent,
 	)
 
 	if err = (natsReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NATS")
 		os.Exit(1)
 	}
+
+	// create NATSStream reconciler instance
+	streamReconciler := nmjsctrl.NewStreamReconciler(
+		mgr.GetClient(),
+		sugaredLogger,
+		mgr.GetEventRecorderFor("nats-manager"),
+		allowedNATSCR,
+	)
+
+	if err = streamReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NATSStream")
+		os.Exit(1)
+	}
+
+	// create NATSConsumer reconciler instance
+	consumerReconciler := nmjsctrl.NewConsumerReconciler(
+		mgr.GetClient(),
+		sugaredLogger,
+		mgr.GetEventRecorderFor("nats-manager"),
+		allowedNATSCR,
+	)
+
+	if err = consumerReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NATSConsumer")
+		os.Exit(1)
+	}
+
+	// create NATSKeyValue reconciler instance
+	keyValueReconciler := nmjsctrl.NewKeyValueReconciler(
+		mgr.GetClient(),
+		sugaredLogger,
+		mgr.GetEventRecorderFor("nats-manager"),
+		allowedNATSCR,
+	)
+
+	if err = keyValueReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NATSKeyValue")
+		os.Exit(1)
+	}
+
+	// create NATSObjectStore reconciler instance
+	objectStoreReconciler := nmjsctrl.NewObjectStoreReconciler(
+		mgr.GetClient(),
+		sugaredLogger,
+		mgr.GetEventRecorderFor("nats-manager"),
+		allowedNATSCR,
+	)
+
+	if err = objectStoreReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NATSObjectStore")
+		os.Exit(1)
+	}
+
+	// create NATSAccount reconciler instance
+	accountReconciler := nmaccountctrl.NewReconciler(
+		mgr.GetClient(),
+		sugaredLogger,
+		mgr.GetEventRecorderFor("nats-manager"),
+		allowedNATSCR,
+	)
+
+	if err = accountReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NATSAccount")
+		os.Exit(1)
+	}
+
+	// create NATSBackup reconciler instance
+	backupReconciler := nmjsctrl.NewBackupReconciler(
+		mgr.GetClient(),
+		sugaredLogger,
+		mgr.GetEventRecorderFor("nats-manager"),
+		allowedNATSCR,
+		envConfigs.BackupDir,
+	)
+
+	if err = backupReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NATSBackup")
+		os.Exit(1)
+	}
+
+	// create NATSRestore reconciler instance
+	restoreReconciler := nmjsctrl.NewRestoreReconciler(
+		mgr.GetClient(),
+		sugaredLogger,
+		mgr.GetEventRecorderFor("nats-manager"),
+		allowedNATSCR,
+		envConfigs.BackupDir,
+	)
+
+	if err = restoreReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NATSRestore")
+		os.Exit(1)
+	}
+
+	// create NATSBackupSchedule reconciler instance
+	backupScheduleReconciler := nmjsctrl.NewBackupScheduleReconciler(
+		mgr.GetClient(),
+		sugaredLogger,
+		mgr.GetEventRecorderFor("nats-manager"),
+		collector,
+	)
+
+	if err = backupScheduleReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NATSBackupSchedule")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -1,6 +1,14 @@
 export default [
   { text: 'Accessing the NATS Server Using CLI', link: './01-10-access-nats-server' },
   { text: 'NATS Custom Resource', link: './01-05-nats-custom-resource' },
+  { text: 'NATSStream Custom Resource', link: './01-06-natsstream-custom-resource' },
+  { text: 'NATSConsumer Custom Resource', link: './01-07-natsconsumer-custom-resource' },
+  { text: 'NATSKeyValue Custom Resource', link: './01-08-natskeyvalue-custom-resource' },
+  { text: 'NATSObjectStore Custom Resource', link: './01-09-natsobjectstore-custom-resource' },
+  { text: 'NATSAccount Custom Resource', link: './01-11-natsaccount-custom-resource' },
+  { text: 'NATSBackup Custom Resource', link: './01-12-natsbackup-custom-resource' },
+  { text: 'NATSRestore Custom Resource', link: './01-13-natsrestore-custom-resource' },
+  { text: 'NATSBackupSchedule Custom Resource', link: './01-14-natsbackupschedule-custom-resource' },
   { text: 'Troubleshooting', link: './troubleshooting/README.md', collapsed: true, items: [
     { text: 'General Diagnostics: NATS Module Readiness and Connectivity', link: './troubleshooting/03-05-nats-troubleshooting' },
     { text: 'Published Events Are Pending in the Stream', link: './troubleshooting/03-10-fix-pending-events' }
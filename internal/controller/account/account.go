@@ -0,0 +1,367 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/auth"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	"go.uber.org/zap"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	NATSAccountFinalizerName = "natsaccount.operator.kyma-project.io/finalizer"
+
+	// RequeueTimeForSync defines the time in seconds after which the credentials Secrets and the resolver
+	// configuration are synced again, as the Secrets are not watched.
+	RequeueTimeForSync = 60
+	// RequeueTimeForNATSNotReady defines the time in seconds after which the reconciliation
+	// is retried if the NATS cluster is not deployed yet.
+	RequeueTimeForNATSNotReady = 10
+
+	NATSNotReadyMsg          = "NATS cluster %s/%s is not ready"
+	AccountConflictMsg       = "Account %s is already declared by NATSAccount %s/%s."
+	AccountUnknownMsg        = "Account %s references the unknown account %s."
+	AccountReservedMsg       = "Account %s is reserved by the NATS manager."
+	AccountResolverUpdateMsg = "Updated the resolver configuration of NATS cluster %s/%s"
+	DefaultAccountMissingMsg = "The accounts are only applied once spec.auth.defaultAccount of NATS CR %s/%s " +
+		"names a NATSAccount with JetStream, because the clients without credentials would lose JetStream."
+)
+
+var ErrNATSNotReady = errors.New("NATS cluster is not ready")
+
+// Reconciler reconciles a NATSAccount object.
+type Reconciler struct {
+	client.Client
+	natsCR        *nmapiv1alpha1.NATS
+	recorder      record.EventRecorder
+	logger        *zap.SugaredLogger
+	natsClients   map[string]nmnats.Client
+	newNatsClient func(*nmnats.Config) nmnats.Client
+}
+
+func NewReconciler(
+	client client.Client,
+	logger *zap.SugaredLogger,
+	recorder record.EventRecorder,
+	natsCR *nmapiv1alpha1.NATS,
+) *Reconciler {
+	return &Reconciler{
+		Client:        client,
+		natsCR:        natsCR,
+		recorder:      recorder,
+		logger:        logger,
+		natsClients:   make(map[string]nmnats.Client),
+		newNatsClient: nmnats.NewNatsClient,
+	}
+}
+
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsaccounts,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsaccounts/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsaccounts/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=list
+//+kubebuilder:rbac:groups="",namespace=kyma-system,resources=secrets,verbs=get;create;update;delete
+
+func (r *Reconciler) Reconcile(ctx context.Context, req kcontrollerruntime.Request) (kcontrollerruntime.Result, error) {
+	currentAccount := &nmapiv1alpha1.NATSAccount{}
+	if err := r.Get(ctx, req.NamespacedName, currentAccount); err != nil {
+		return kcontrollerruntime.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Copy the object, so we don't modify the source object.
+	account := currentAccount.DeepCopy()
+
+	log := r.logger.With(
+		"kind", "NATSAccount",
+		"namespace", account.GetNamespace(),
+		"name", account.GetName(),
+		"account", account.AccountName(),
+	)
+
+	if account.IsInDeletion() {
+		return r.handleAccountDeletion(ctx, account, log)
+	}
+
+	if !controllerutil.ContainsFinalizer(account, NATSAccountFinalizerName) {
+		controllerutil.AddFinalizer(account, NATSAccountFinalizerName)
+		return kcontrollerruntime.Result{}, r.Update(ctx, account)
+	}
+
+	return r.handleAccountReconcile(ctx, account, log)
+}
+
+func (r *Reconciler) handleAccountReconcile(ctx context.Context, account *nmapiv1alpha1.NATSAccount,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	invalidAccounts, err := r.syncAccounts(ctx, log)
+	if errors.Is(err, ErrNATSNotReady) {
+		log.Infof("NATS cluster is not ready yet, retrying in %d seconds", RequeueTimeForNATSNotReady)
+		account.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonNATSNotReady,
+			fmt.Sprintf(NATSNotReadyMsg, r.natsCR.Namespace, r.natsCR.Name))
+		return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			r.syncAccountStatus(ctx, account, log)
+	}
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncAccountStatusWithErr(ctx, account, err, log)
+	}
+
+	if msg, isInvalid := invalidAccounts[account.UID]; isInvalid {
+		events.Warn(r.recorder, account, nmapiv1alpha1.ConditionReasonAccountInvalid, msg)
+		account.Status.SetStateError(nmapiv1alpha1.ConditionReasonAccountInvalid, msg)
+	} else {
+		account.Status.SetStateReady(nmapiv1alpha1.ConditionReasonInSync, "")
+	}
+	return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForSync * time.Second},
+		r.syncAccountStatus(ctx, account, log)
+}
+
+func (r *Reconciler) handleAccountDeletion(ctx context.Context, account *nmapiv1alpha1.NATSAccount,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	// skip reconciliation for deletion if the finalizer is not set.
+	if !controllerutil.ContainsFinalizer(account, NATSAccountFinalizerName) {
+		return kcontrollerruntime.Result{}, nil
+	}
+
+	account.Status.SetStateDeleting()
+	if err := r.deleteCredentials(ctx, account); err != nil {
+		return kcontrollerruntime.Result{}, r.syncAccountStatusWithErr(ctx, account, err, log)
+	}
+
+	// the accounts in deletion are not synced to NATS anymore. If NATS is not ready, the account is removed
+	// from NATS once the other accounts are synced.
+	if _, err := r.syncAccounts(ctx, log); err != nil && !errors.Is(err, ErrNATSNotReady) {
+		return kcontrollerruntime.Result{}, r.syncAccountStatusWithErr(ctx, account, err, log)
+	}
+	log.Info("Deleted account")
+
+	controllerutil.RemoveFinalizer(account, NATSAccountFinalizerName)
+	return kcontrollerruntime.Result{}, r.Update(ctx, account)
+}
+
+// syncAccounts syncs all NATSAccounts to the NATS cluster. In the memory authentication mode, they are rendered
+// into the resolver configuration. In the JWT authentication mode, their JWTs are pushed to the resolver.
+// It returns the reasons why accounts are invalid by the UID of their NATSAccount, or ErrNATSNotReady
+// if the accounts cannot be synced to the NATS cluster yet.
+func (r *Reconciler) syncAccounts(ctx context.Context, log *zap.SugaredLogger) (map[ktypes.UID]string, error) {
+	nats, err := r.getNATS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if nats == nil || nats.IsInDeletion() {
+		return nil, ErrNATSNotReady
+	}
+
+	accountList := &nmapiv1alpha1.NATSAccountList{}
+	if err = r.List(ctx, accountList); err != nil {
+		return nil, err
+	}
+
+	if nats.IsJWTAuthEnabled() {
+		accounts, invalidAccounts := selectAccounts(accountList.Items, jwtAccountRules)
+		return invalidAccounts, r.syncAccountJWTs(ctx, nats, accounts, invalidAccounts, log)
+	}
+	accounts, invalidAccounts := selectAccounts(accountList.Items, memoryAccountRules)
+	accounts = withDefaultAccount(nats, accounts, invalidAccounts)
+	return invalidAccounts, r.syncResolverConfig(ctx, nats, accounts, invalidAccounts, log)
+}
+
+// withDefaultAccount returns the accounts if the default account of the NATS CR is one of them and has JetStream.
+// Otherwise, none of the accounts are rendered, because the clients without credentials would lose JetStream
+// in the global account.
+func withDefaultAccount(nats *nmapiv1alpha1.NATS, accounts []nmapiv1alpha1.NATSAccount,
+	invalidAccounts map[ktypes.UID]string,
+) []nmapiv1alpha1.NATSAccount {
+	for i := range accounts {
+		if accounts[i].AccountName() == nats.Spec.Auth.DefaultAccount && accounts[i].Spec.JetStream != nil {
+			return accounts
+		}
+	}
+	for i := range accounts {
+		invalidAccounts[accounts[i].UID] = fmt.Sprintf(DefaultAccountMissingMsg, nats.Namespace, nats.Name)
+	}
+	return nil
+}
+
+// accountRules define which accounts can be declared and referenced in an authentication mode.
+type accountRules struct {
+	// reserved are the accounts which cannot be declared.
+	reserved []string
+	// known are the accounts which can be referenced without being declared.
+	known []string
+	// exportsReferenceAccounts is set if the accounts which may import an export must be declared.
+	exportsReferenceAccounts bool
+}
+
+var (
+	// memoryAccountRules apply to the resolver configuration, which NATS rejects if it references unknown accounts.
+	memoryAccountRules = accountRules{known: []string{systemAccount}, exportsReferenceAccounts: true}
+	// jwtAccountRules reserve the accounts generated by the NATS manager. Restricted exports are granted with
+	// activation tokens, which do not reference the importing accounts.
+	jwtAccountRules = accountRules{reserved: []string{auth.SystemAccountName, auth.ManagerAccountName}}
+)
+
+// selectAccounts returns the accounts which can be synced to NATS. The oldest NATSAccount wins if several
+// NATSAccounts declare the same account, and accounts which reference undeclared accounts are left out.
+// It returns the reasons why accounts are invalid by the UID of their NATSAccount.
+func selectAccounts(items []nmapiv1alpha1.NATSAccount,
+	rules accountRules,
+) ([]nmapiv1alpha1.NATSAccount, map[ktypes.UID]string) {
+	slices.SortFunc(items, func(a, b nmapiv1alpha1.NATSAccount) int {
+		if byAge := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); byAge != 0 {
+			return byAge
+		}
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	invalidAccounts := map[ktypes.UID]string{}
+	declaredBy := map[string]*nmapiv1alpha1.NATSAccount{}
+	var accounts []nmapiv1alpha1.NATSAccount
+	for i := range items {
+		account := &items[i]
+		if account.IsInDeletion() {
+			continue
+		}
+		if slices.Contains(rules.reserved, account.AccountName()) {
+			invalidAccounts[account.UID] = fmt.Sprintf(AccountReservedMsg, account.AccountName())
+			continue
+		}
+		if other, isDeclared := declaredBy[account.AccountName()]; isDeclared {
+			invalidAccounts[account.UID] = fmt.Sprintf(AccountConflictMsg,
+				account.AccountName(), other.Namespace, other.Name)
+			continue
+		}
+		declaredBy[account.AccountName()] = account
+		accounts = append(accounts, *account)
+	}
+	return withoutUnknownReferences(accounts, rules, invalidAccounts), invalidAccounts
+}
+
+// withoutUnknownReferences removes the accounts which import from or export to accounts which are not rendered,
+// until all references can be resolved by NATS.
+func withoutUnknownReferences(accounts []nmapiv1alpha1.NATSAccount, rules accountRules,
+	invalidAccounts map[ktypes.UID]string,
+) []nmapiv1alpha1.NATSAccount {
+	for {
+		known := map[string]bool{}
+		for _, name := range rules.known {
+			known[name] = true
+		}
+		for i := range accounts {
+			known[accounts[i].AccountName()] = true
+		}
+
+		valid := accounts[:0:0]
+		for i := range accounts {
+			if unknown := unknownReference(&accounts[i], known, rules.exportsReferenceAccounts); unknown != "" {
+				invalidAccounts[accounts[i].UID] = fmt.Sprintf(AccountUnknownMsg, accounts[i].AccountName(), unknown)
+				continue
+			}
+			valid = append(valid, accounts[i])
+		}
+		if len(valid) == len(accounts) {
+			return valid
+		}
+		accounts = valid
+	}
+}
+
+func unknownReference(account *nmapiv1alpha1.NATSAccount, known map[string]bool, checkExports bool) string {
+	for _, imp := range account.Spec.Imports {
+		if !known[imp.Account] {
+			return imp.Account
+		}
+	}
+	if !checkExports {
+		return ""
+	}
+	for _, export := range account.Spec.Exports {
+		for _, name := range export.Accounts {
+			if !known[name] {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// getNATS returns the NATS CR which hosts the accounts, or nil if it does not exist.
+func (r *Reconciler) getNATS(ctx context.Context) (*nmapiv1alpha1.NATS, error) {
+	nats := &nmapiv1alpha1.NATS{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: r.natsCR.Name, Namespace: r.natsCR.Namespace}, nats)
+	if kapierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nats, nil
+}
+
+// getSecret returns the Secret with the given name in the namespace of the given NATS CR,
+// or nil if it does not exist.
+func (r *Reconciler) getSecret(ctx context.Context, nats *nmapiv1alpha1.NATS, name string) (*kcorev1.Secret, error) {
+	secret := &kcorev1.Secret{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: name, Namespace: nats.Namespace}, secret)
+	if kapierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// syncAccountStatusWithErr sets the error state in the status and syncs it.
+// Returns the original error, so the controller triggers another reconciliation.
+func (r *Reconciler) syncAccountStatusWithErr(ctx context.Context, account *nmapiv1alpha1.NATSAccount,
+	err error, log *zap.SugaredLogger,
+) error {
+	account.Status.SetStateError(nmapiv1alpha1.ConditionReasonProcessingError, err.Error())
+	return errors.Join(err, r.syncAccountStatus(ctx, account, log))
+}
+
+// syncAccountStatus updates the status of the NATSAccount if it was modified.
+func (r *Reconciler) syncAccountStatus(ctx context.Context, account *nmapiv1alpha1.NATSAccount,
+	log *zap.SugaredLogger,
+) error {
+	// fetch the latest object, to avoid k8s conflict errors.
+	actualAccount := &nmapiv1alpha1.NATSAccount{}
+	if err := r.Get(ctx, ktypes.NamespacedName{Name: account.Name, Namespace: account.Namespace},
+		actualAccount); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if actualAccount.Status.IsEqual(account.Status) {
+		return nil
+	}
+
+	desiredAccount := actualAccount.DeepCopy()
+	desiredAccount.Status = account.Status
+	if err := r.Status().Update(ctx, desiredAccount); err != nil {
+		return err
+	}
+
+	log.Debugw("Updated NATSAccount status",
+		"oldStatus", actualAccount.Status, "newStatus", desiredAccount.Status)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr kcontrollerruntime.Manager) error {
+	return kcontrollerruntime.NewControllerManagedBy(mgr).
+		For(&nmapiv1alpha1.NATSAccount{}).
+		Complete(r)
+}
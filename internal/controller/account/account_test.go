@@ -0,0 +1,326 @@
+package account
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmlabels "github.com/kyma-project/nats-manager/pkg/labels"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	natsName      = "eventing-nats"
+	natsNamespace = "kyma-system"
+)
+
+// newTestReconciler returns a Reconciler with a fake client which contains the given objects.
+func newTestReconciler(t *testing.T, objs ...client.Object) (*Reconciler, client.Client) {
+	t.Helper()
+
+	sugaredLogger, err := testutils.NewSugaredLogger()
+	require.NoError(t, err)
+
+	newScheme := runtime.NewScheme()
+	require.NoError(t, nmapiv1alpha1.AddToScheme(newScheme))
+	require.NoError(t, kcorev1.AddToScheme(newScheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).
+		WithObjects(objs...).WithStatusSubresource(&nmapiv1alpha1.NATSAccount{}).Build()
+
+	natsCR := testutils.NewNATSCR(testutils.WithNATSCRName(natsName), testutils.WithNATSCRNamespace(natsNamespace))
+	return NewReconciler(fakeClient, sugaredLogger, record.NewFakeRecorder(5), natsCR), fakeClient
+}
+
+// newNATS returns the NATS CR which hosts the accounts, with orders as its default account.
+func newNATS() *nmapiv1alpha1.NATS {
+	return testutils.NewNATSCR(testutils.WithNATSCRName(natsName), testutils.WithNATSCRNamespace(natsNamespace),
+		testutils.WithNATSDefaultAccount("orders"))
+}
+
+func newAccountsSecret() *kcorev1.Secret {
+	return &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: natsName + "-secret", Namespace: natsNamespace},
+		Data: map[string][]byte{
+			ResolverConfigKey: []byte(`accounts: { "$SYS": { users: [ { user: "admin", password: "admin-pw" } ] } }`),
+		},
+	}
+}
+
+func newAccount(name, namespace string, spec nmapiv1alpha1.NATSAccountSpec) *nmapiv1alpha1.NATSAccount {
+	return testutils.NewNATSAccountCR(
+		testutils.WithNATSAccountName(name),
+		testutils.WithNATSAccountNamespace(namespace),
+		testutils.WithNATSAccountFinalizer(NATSAccountFinalizerName),
+		testutils.WithNATSAccountSpec(spec),
+	)
+}
+
+func reconcileAccount(ctx context.Context, t *testing.T, reconciler *Reconciler,
+	account *nmapiv1alpha1.NATSAccount,
+) kcontrollerruntime.Result {
+	t.Helper()
+	result, err := reconciler.Reconcile(ctx, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: account.Name, Namespace: account.Namespace},
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func getAccount(ctx context.Context, t *testing.T, c client.Client,
+	account *nmapiv1alpha1.NATSAccount,
+) *nmapiv1alpha1.NATSAccount {
+	t.Helper()
+	got := &nmapiv1alpha1.NATSAccount{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(account), got))
+	return got
+}
+
+func getResolverConfig(ctx context.Context, t *testing.T, c client.Client) string {
+	t.Helper()
+	secret := &kcorev1.Secret{}
+	require.NoError(t, c.Get(ctx, ktypes.NamespacedName{Name: natsName + "-secret", Namespace: natsNamespace}, secret))
+	return string(secret.Data[ResolverConfigKey])
+}
+
+func Test_Reconcile_WritesCredentialsAndResolverConfig(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	givenNATS := newNATS()
+	givenAccount := newAccount("orders", "shop", nmapiv1alpha1.NATSAccountSpec{
+		Users: []nmapiv1alpha1.NATSAccountUser{
+			{Name: "publisher"},
+			{Name: "reader", CredentialsSecret: nmapiv1alpha1.CredentialsSecret{
+				Name: "reader-creds", Namespaces: []string{"billing", "audit"},
+			}},
+		},
+		JetStream: &nmapiv1alpha1.NATSAccountJetStream{},
+	})
+	reconciler, fakeClient := newTestReconciler(t, givenNATS, newAccountsSecret(), givenAccount)
+
+	// when
+	result := reconcileAccount(ctx, t, reconciler, givenAccount)
+
+	// then
+	require.Equal(t, kcontrollerruntime.Result{RequeueAfter: RequeueTimeForSync * time.Second}, result)
+	require.Equal(t, nmapiv1alpha1.StateReady, getAccount(ctx, t, fakeClient, givenAccount).Status.State)
+
+	publisherSecret := &kcorev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx,
+		ktypes.NamespacedName{Name: "orders-publisher", Namespace: "shop"}, publisherSecret))
+	require.Equal(t, "orders.publisher", string(publisherSecret.Data[UsernameKey]))
+	require.Equal(t, "nats://eventing-nats.kyma-system.svc.cluster.local:4222", string(publisherSecret.Data[URLKey]))
+	require.Equal(t, string(givenAccount.UID), publisherSecret.Labels[nmlabels.KeyNATSAccount])
+	password := string(publisherSecret.Data[PasswordKey])
+	require.Len(t, password, passwordLength)
+
+	billingSecret, auditSecret := &kcorev1.Secret{}, &kcorev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx,
+		ktypes.NamespacedName{Name: "reader-creds", Namespace: "billing"}, billingSecret))
+	require.NoError(t, fakeClient.Get(ctx,
+		ktypes.NamespacedName{Name: "reader-creds", Namespace: "audit"}, auditSecret))
+	require.Equal(t, billingSecret.Data, auditSecret.Data)
+
+	resolverConfig := getResolverConfig(ctx, t, fakeClient)
+	require.Contains(t, resolverConfig, `"password": "admin-pw"`)
+	require.Contains(t, resolverConfig, `"password": "`+password+`"`)
+	require.Contains(t, resolverConfig, `"user": "orders.reader"`)
+	require.Contains(t, resolverConfig, `"user": "anonymous"`)
+	require.NotContains(t, resolverConfig, "authorization")
+
+	// when the account is reconciled again, the passwords are kept.
+	reconcileAccount(ctx, t, reconciler, givenAccount)
+
+	// then
+	require.Equal(t, resolverConfig, getResolverConfig(ctx, t, fakeClient))
+}
+
+func Test_Reconcile_InvalidAccounts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	older := kmetav1.NewTime(time.Now().Add(-time.Hour))
+
+	testCases := []struct {
+		name              string
+		givenAccount      *nmapiv1alpha1.NATSAccount
+		givenObjects      []client.Object
+		wantState         string
+		wantReason        nmapiv1alpha1.ConditionReason
+		wantConfigContain string
+		wantConfigMiss    string
+	}{
+		{
+			name: "should be ready if the referenced accounts are declared",
+			givenAccount: newAccount("billing", "shop", nmapiv1alpha1.NATSAccountSpec{
+				Users:   []nmapiv1alpha1.NATSAccountUser{{Name: "reader"}},
+				Imports: []nmapiv1alpha1.NATSAccountImport{{Account: "orders", Stream: "orders.created"}},
+			}),
+			givenObjects: []client.Object{
+				newAccount("orders", "shop", nmapiv1alpha1.NATSAccountSpec{
+					JetStream: &nmapiv1alpha1.NATSAccountJetStream{},
+					Exports:   []nmapiv1alpha1.NATSAccountExport{{Stream: "orders.created"}},
+				}),
+			},
+			wantState:         nmapiv1alpha1.StateReady,
+			wantReason:        nmapiv1alpha1.ConditionReasonInSync,
+			wantConfigContain: `"user": "billing.reader"`,
+		},
+		{
+			name: "should fail if an imported account is not declared",
+			givenAccount: newAccount("billing", "shop", nmapiv1alpha1.NATSAccountSpec{
+				Users:   []nmapiv1alpha1.NATSAccountUser{{Name: "reader"}},
+				Imports: []nmapiv1alpha1.NATSAccountImport{{Account: "orders", Stream: "orders.created"}},
+			}),
+			wantState:      nmapiv1alpha1.StateError,
+			wantReason:     nmapiv1alpha1.ConditionReasonAccountInvalid,
+			wantConfigMiss: `"billing"`,
+		},
+		{
+			name: "should fail if the account is already declared by an older NATSAccount",
+			givenAccount: func() *nmapiv1alpha1.NATSAccount {
+				account := newAccount("orders-copy", "shop", nmapiv1alpha1.NATSAccountSpec{
+					Account: "orders",
+					Users:   []nmapiv1alpha1.NATSAccountUser{{Name: "copy"}},
+				})
+				account.CreationTimestamp = kmetav1.Now()
+				return account
+			}(),
+			givenObjects: []client.Object{
+				func() client.Object {
+					account := newAccount("orders", "other", nmapiv1alpha1.NATSAccountSpec{
+						Users:     []nmapiv1alpha1.NATSAccountUser{{Name: "original"}},
+						JetStream: &nmapiv1alpha1.NATSAccountJetStream{},
+					})
+					account.CreationTimestamp = older
+					return account
+				}(),
+			},
+			wantState:         nmapiv1alpha1.StateError,
+			wantReason:        nmapiv1alpha1.ConditionReasonAccountInvalid,
+			wantConfigContain: `"user": "orders.original"`,
+			wantConfigMiss:    `"user": "orders.copy"`,
+		},
+		{
+			name: "should fail if a credentials Secret is not managed by the NATS manager",
+			givenAccount: newAccount("orders", "shop", nmapiv1alpha1.NATSAccountSpec{
+				Users:     []nmapiv1alpha1.NATSAccountUser{{Name: "publisher"}, {Name: "reader"}},
+				JetStream: &nmapiv1alpha1.NATSAccountJetStream{},
+			}),
+			givenObjects: []client.Object{
+				&kcorev1.Secret{ObjectMeta: kmetav1.ObjectMeta{Name: "orders-reader", Namespace: "shop"}},
+			},
+			wantState:         nmapiv1alpha1.StateError,
+			wantReason:        nmapiv1alpha1.ConditionReasonAccountInvalid,
+			wantConfigContain: `"user": "orders.publisher"`,
+			wantConfigMiss:    `"user": "orders.reader"`,
+		},
+		{
+			name: "should fail if the default account is not declared",
+			givenAccount: newAccount("billing", "shop", nmapiv1alpha1.NATSAccountSpec{
+				Users:     []nmapiv1alpha1.NATSAccountUser{{Name: "reader"}},
+				JetStream: &nmapiv1alpha1.NATSAccountJetStream{},
+			}),
+			wantState:         nmapiv1alpha1.StateError,
+			wantReason:        nmapiv1alpha1.ConditionReasonAccountInvalid,
+			wantConfigContain: `authorization: {"users": [{"user": "anonymous"}]}`,
+			wantConfigMiss:    `"billing"`,
+		},
+		{
+			name: "should fail if the default account has no JetStream",
+			givenAccount: newAccount("orders", "shop", nmapiv1alpha1.NATSAccountSpec{
+				Users: []nmapiv1alpha1.NATSAccountUser{{Name: "publisher"}},
+			}),
+			wantState:         nmapiv1alpha1.StateError,
+			wantReason:        nmapiv1alpha1.ConditionReasonAccountInvalid,
+			wantConfigContain: `authorization: {"users": [{"user": "anonymous"}]}`,
+			wantConfigMiss:    `"orders"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := newNATS()
+			objs := append([]client.Object{givenNATS, newAccountsSecret(), tc.givenAccount}, tc.givenObjects...)
+			reconciler, fakeClient := newTestReconciler(t, objs...)
+
+			// when
+			reconcileAccount(ctx, t, reconciler, tc.givenAccount)
+
+			// then
+			gotAccount := getAccount(ctx, t, fakeClient, tc.givenAccount)
+			require.Equal(t, tc.wantState, gotAccount.Status.State)
+			gotCondition := meta.FindStatusCondition(gotAccount.Status.Conditions, string(nmapiv1alpha1.ConditionSynced))
+			require.NotNil(t, gotCondition)
+			require.Equal(t, string(tc.wantReason), gotCondition.Reason)
+
+			resolverConfig := getResolverConfig(ctx, t, fakeClient)
+			if tc.wantConfigContain != "" {
+				require.Contains(t, resolverConfig, tc.wantConfigContain)
+			}
+			if tc.wantConfigMiss != "" {
+				require.NotContains(t, resolverConfig, tc.wantConfigMiss)
+			}
+		})
+	}
+}
+
+func Test_Reconcile_NATSNotReady(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	givenAccount := newAccount("orders", "shop", nmapiv1alpha1.NATSAccountSpec{})
+	reconciler, fakeClient := newTestReconciler(t, givenAccount)
+
+	// when
+	result := reconcileAccount(ctx, t, reconciler, givenAccount)
+
+	// then
+	require.Equal(t, kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second}, result)
+	require.Equal(t, nmapiv1alpha1.StateProcessing, getAccount(ctx, t, fakeClient, givenAccount).Status.State)
+}
+
+func Test_handleAccountDeletion(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	givenNATS := newNATS()
+	givenAccount := newAccount("orders", "shop", nmapiv1alpha1.NATSAccountSpec{
+		Users:     []nmapiv1alpha1.NATSAccountUser{{Name: "publisher"}},
+		JetStream: &nmapiv1alpha1.NATSAccountJetStream{},
+	})
+	reconciler, fakeClient := newTestReconciler(t, givenNATS, newAccountsSecret(), givenAccount)
+	reconcileAccount(ctx, t, reconciler, givenAccount)
+	require.Contains(t, getResolverConfig(ctx, t, fakeClient), `"user": "orders.publisher"`)
+
+	// when
+	require.NoError(t, fakeClient.Delete(ctx, givenAccount))
+	reconcileAccount(ctx, t, reconciler, givenAccount)
+
+	// then
+	require.NotContains(t, getResolverConfig(ctx, t, fakeClient), `"user": "orders.publisher"`)
+	secrets := &kcorev1.SecretList{}
+	require.NoError(t, fakeClient.List(ctx, secrets,
+		client.MatchingLabels{nmlabels.KeyNATSAccount: string(givenAccount.UID)}))
+	require.Empty(t, secrets.Items)
+	err := fakeClient.Get(ctx, client.ObjectKeyFromObject(givenAccount), &nmapiv1alpha1.NATSAccount{})
+	require.True(t, kapierrors.IsNotFound(err))
+}
@@ -0,0 +1,197 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmctrlurl "github.com/kyma-project/nats-manager/internal/controller/nats/url"
+	nmlabels "github.com/kyma-project/nats-manager/pkg/labels"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	UsernameKey = "username"
+	PasswordKey = "password"
+	URLKey      = "url"
+
+	passwordLength  = 32
+	passwordCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+var ErrSecretNotManaged = errors.New("the Secret exists and is not managed by the NATS manager")
+
+// userCredentials returns the data of the credentials Secrets of the given user. The Secrets which already exist
+// are passed, so that the credentials can be kept.
+type userCredentials func(user nmapiv1alpha1.NATSAccountUser, secrets []kcorev1.Secret) (map[string][]byte, error)
+
+// syncCredentials writes the credentials of the users of the given account to their Secrets and deletes the
+// Secrets which are not declared anymore. It returns the written credentials by username. Users whose Secrets
+// cannot be written are left out and reported with ErrSecretNotManaged.
+func (r *Reconciler) syncCredentials(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	account *nmapiv1alpha1.NATSAccount, credentials userCredentials,
+) (map[string]map[string][]byte, error) {
+	secrets, err := r.listCredentialsSecrets(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	written := map[string]map[string][]byte{}
+	declared := map[client.ObjectKey]bool{}
+	var notManagedErr error
+	for _, user := range account.Spec.Users {
+		var data map[string][]byte
+		data, err = credentials(user, secrets)
+		if err != nil {
+			return nil, err
+		}
+		data[UsernameKey] = []byte(account.Username(user))
+		data[URLKey] = []byte(nmctrlurl.ForNATS(nats))
+
+		isWritten := true
+		for _, namespace := range account.CredentialsSecretNamespaces(user) {
+			key := client.ObjectKey{Name: account.CredentialsSecretName(user), Namespace: namespace}
+			declared[key] = true
+			err = r.writeCredentialsSecret(ctx, account, key, secrets, data)
+			if errors.Is(err, ErrSecretNotManaged) {
+				// the user is left out, but the other users of the account are synced.
+				notManagedErr = errors.Join(notManagedErr, err)
+				isWritten = false
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if isWritten {
+			written[account.Username(user)] = data
+		}
+	}
+
+	for i := range secrets {
+		if declared[client.ObjectKeyFromObject(&secrets[i])] {
+			continue
+		}
+		if err = r.Delete(ctx, &secrets[i]); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+	}
+	return written, notManagedErr
+}
+
+// passwordCredentials returns the credentials of users which connect with a password. The password of a user
+// is kept as long as one of its Secrets exists.
+func passwordCredentials(account *nmapiv1alpha1.NATSAccount) userCredentials {
+	return func(user nmapiv1alpha1.NATSAccountUser, secrets []kcorev1.Secret) (map[string][]byte, error) {
+		password, err := credentialsPassword(secrets, account.Username(user))
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{PasswordKey: []byte(password)}, nil
+	}
+}
+
+// deleteCredentials deletes all Secrets with the credentials of the users of the given account.
+func (r *Reconciler) deleteCredentials(ctx context.Context, account *nmapiv1alpha1.NATSAccount) error {
+	secrets, err := r.listCredentialsSecrets(ctx, account)
+	if err != nil {
+		return err
+	}
+	for i := range secrets {
+		if err = r.Delete(ctx, &secrets[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listCredentialsSecrets returns the Secrets with the credentials of the users of the given account in all namespaces.
+func (r *Reconciler) listCredentialsSecrets(ctx context.Context,
+	account *nmapiv1alpha1.NATSAccount,
+) ([]kcorev1.Secret, error) {
+	secrets := &kcorev1.SecretList{}
+	if err := r.List(ctx, secrets,
+		client.MatchingLabels{nmlabels.KeyNATSAccount: string(account.UID)}); err != nil {
+		return nil, err
+	}
+	return secrets.Items, nil
+}
+
+// writeCredentialsSecret creates or updates the Secret with the given key. Secrets which were not created by the
+// NATS manager are not overwritten.
+func (r *Reconciler) writeCredentialsSecret(ctx context.Context, account *nmapiv1alpha1.NATSAccount,
+	key client.ObjectKey, secrets []kcorev1.Secret, data map[string][]byte,
+) error {
+	index := slices.IndexFunc(secrets, func(secret kcorev1.Secret) bool {
+		return client.ObjectKeyFromObject(&secret) == key
+	})
+	if index < 0 {
+		secret := &kcorev1.Secret{
+			ObjectMeta: kmetav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					nmlabels.KeyManagedBy:   nmlabels.ValueNATSManager,
+					nmlabels.KeyNATSAccount: string(account.UID),
+				},
+			},
+			Type: kcorev1.SecretTypeOpaque,
+			Data: data,
+		}
+		err := r.Create(ctx, secret)
+		if kapierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("%w: %s", ErrSecretNotManaged, key)
+		}
+		return err
+	}
+
+	secret := &secrets[index]
+	if equalData(secret.Data, data) {
+		return nil
+	}
+	secret.Data = data
+	return r.Update(ctx, secret)
+}
+
+// credentialsPassword returns the password of the given user from its Secrets, or a new password
+// if the user has no Secret yet.
+func credentialsPassword(secrets []kcorev1.Secret, username string) (string, error) {
+	for _, secret := range secrets {
+		if string(secret.Data[UsernameKey]) == username && len(secret.Data[PasswordKey]) > 0 {
+			return string(secret.Data[PasswordKey]), nil
+		}
+	}
+	return newPassword()
+}
+
+func newPassword() (string, error) {
+	password := make([]byte, passwordLength)
+	for i := range password {
+		index, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordCharset))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = passwordCharset[index.Int64()]
+	}
+	return string(password), nil
+}
+
+func equalData(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if !bytes.Equal(value, b[key]) {
+			return false
+		}
+	}
+	return true
+}
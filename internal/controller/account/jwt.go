@@ -0,0 +1,345 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmctrlclientconfig "github.com/kyma-project/nats-manager/internal/controller/nats/clientconfig"
+	"github.com/kyma-project/nats-manager/pkg/auth"
+	nmlabels "github.com/kyma-project/nats-manager/pkg/labels"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	"go.uber.org/zap"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// AccountKeysSecretSuffix is appended to the name of the NATS CR to name the Secret with the NKeys of the
+	// accounts and the JWTs which were pushed to the resolver in the JWT authentication mode.
+	AccountKeysSecretSuffix = "-account-keys"
+
+	seedKeySuffix = ".seed"
+	jwtKeySuffix  = ".jwt"
+
+	AccountPushMsg       = "Pushed the JWT of account %s to NATS cluster %s/%s"
+	AccountDeleteMsg     = "Deleted the JWTs of accounts %s from NATS cluster %s/%s"
+	AccountJWTInvalidMsg = "The JWT of account %s cannot be issued: %s"
+)
+
+// syncAccountJWTs issues the JWTs of the given accounts and the credentials of their users, and pushes the JWTs
+// which changed to the full resolver of the NATS cluster. The NKeys of the accounts and the pushed JWTs are kept
+// in the account keys Secret, so that the JWTs are only pushed again if their claims change, and the accounts
+// which are not declared anymore are deleted from the resolver.
+// Accounts whose JWT cannot be issued or whose credentials cannot be written are added to the invalid accounts.
+func (r *Reconciler) syncAccountJWTs(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	accounts []nmapiv1alpha1.NATSAccount, invalidAccounts map[ktypes.UID]string, log *zap.SugaredLogger,
+) error {
+	operatorSecret, err := r.getSecret(ctx, nats, nats.OperatorSecretName())
+	if err != nil {
+		return err
+	}
+	if operatorSecret == nil {
+		return ErrNATSNotReady
+	}
+	operatorSeed := operatorSecret.Data[auth.OperatorSeedKey]
+
+	keysSecret, err := r.getSecret(ctx, nats, nats.Name+AccountKeysSecretSuffix)
+	if err != nil {
+		return err
+	}
+	keys := map[string][]byte{}
+	if keysSecret != nil {
+		maps.Copy(keys, keysSecret.Data)
+	}
+
+	// the NKeys of all accounts are needed to issue the imports, new NKeys are stored before any JWT is pushed.
+	seeds := map[string][]byte{}
+	publicKeys := map[string]string{}
+	for i := range accounts {
+		name := accounts[i].AccountName()
+		if len(keys[name+seedKeySuffix]) == 0 {
+			if keys[name+seedKeySuffix], err = auth.NewAccountSeed(); err != nil {
+				return err
+			}
+		}
+		seeds[name] = keys[name+seedKeySuffix]
+		if publicKeys[name], err = auth.PublicKey(seeds[name]); err != nil {
+			return err
+		}
+	}
+	if keysSecret, err = r.writeAccountKeysSecret(ctx, nats, keysSecret, keys); err != nil {
+		return err
+	}
+
+	desiredKeys := map[string][]byte{}
+	var changedAccounts []string
+	var changedJWTs []string
+	var issuedAccounts []nmapiv1alpha1.NATSAccount
+	for i := range accounts {
+		account := &accounts[i]
+		name := account.AccountName()
+		accountJWT, issueErr := issueAccountJWT(operatorSeed, account, accounts, seeds, publicKeys)
+		if issueErr != nil {
+			invalidAccounts[account.UID] = fmt.Sprintf(AccountJWTInvalidMsg, name, issueErr)
+			continue
+		}
+		if previousJWT := string(keys[name+jwtKeySuffix]); auth.SameClaims(previousJWT, accountJWT) {
+			accountJWT = previousJWT
+		} else {
+			changedAccounts = append(changedAccounts, name)
+			changedJWTs = append(changedJWTs, accountJWT)
+		}
+		desiredKeys[name+seedKeySuffix] = seeds[name]
+		desiredKeys[name+jwtKeySuffix] = []byte(accountJWT)
+		issuedAccounts = append(issuedAccounts, *account)
+	}
+
+	var deletedAccounts []string
+	var deletedPublicKeys []string
+	for key, seed := range keys {
+		name, isSeed := strings.CutSuffix(key, seedKeySuffix)
+		if !isSeed || desiredKeys[key] != nil {
+			continue
+		}
+		publicKey, keyErr := auth.PublicKey(seed)
+		if keyErr != nil {
+			return keyErr
+		}
+		deletedAccounts = append(deletedAccounts, name)
+		deletedPublicKeys = append(deletedPublicKeys, publicKey)
+	}
+	slices.Sort(deletedAccounts)
+
+	if len(changedJWTs) > 0 || len(deletedPublicKeys) > 0 {
+		if err = r.pushAccountJWTs(ctx, nats, operatorSeed, changedJWTs, deletedPublicKeys); err != nil {
+			return err
+		}
+		for _, name := range changedAccounts {
+			log.Infof(AccountPushMsg, name, nats.Namespace, nats.Name)
+		}
+		if len(deletedAccounts) > 0 {
+			log.Infof(AccountDeleteMsg, strings.Join(deletedAccounts, ", "), nats.Namespace, nats.Name)
+		}
+	}
+	if _, err = r.writeAccountKeysSecret(ctx, nats, keysSecret, desiredKeys); err != nil {
+		return err
+	}
+
+	// the credentials are written once the accounts of the users are known to the resolver.
+	for i := range issuedAccounts {
+		account := &issuedAccounts[i]
+		_, syncErr := r.syncCredentials(ctx, nats, account, jwtCredentials(account, seeds[account.AccountName()]))
+		if errors.Is(syncErr, ErrSecretNotManaged) {
+			invalidAccounts[account.UID] = syncErr.Error()
+		} else if syncErr != nil {
+			return syncErr
+		}
+	}
+	return nil
+}
+
+// pushAccountJWTs pushes the given account JWTs to the resolver and deletes the accounts with the given
+// public keys from it.
+func (r *Reconciler) pushAccountJWTs(ctx context.Context, nats *nmapiv1alpha1.NATS, operatorSeed []byte,
+	accountJWTs, deletedPublicKeys []string,
+) error {
+	natsClient, err := r.connect(ctx, nats)
+	if err != nil {
+		return err
+	}
+	for _, accountJWT := range accountJWTs {
+		if err = natsClient.UpdateAccountClaims(accountJWT); err != nil {
+			return err
+		}
+	}
+	if len(deletedPublicKeys) == 0 {
+		return nil
+	}
+	deleteRequest, err := auth.IssueDeleteRequest(operatorSeed, deletedPublicKeys)
+	if err != nil {
+		return err
+	}
+	return natsClient.DeleteAccountClaims(deleteRequest)
+}
+
+// connect returns a NATS client which is connected as the user of the system account.
+// Returns ErrNATSNotReady if the NATS cluster cannot serve requests yet.
+func (r *Reconciler) connect(ctx context.Context, nats *nmapiv1alpha1.NATS) (nmnats.Client, error) {
+	if nats.Status.State != nmapiv1alpha1.StateReady && nats.Status.State != nmapiv1alpha1.StateWarning {
+		return nil, ErrNATSNotReady
+	}
+
+	crKey := nats.Namespace + "/" + nats.Name
+	if r.natsClients[crKey] == nil {
+		config, err := nmctrlclientconfig.NewSystem(ctx, r.Client, nats)
+		if err != nil {
+			return nil, errors.Join(ErrNATSNotReady, err)
+		}
+		r.natsClients[crKey] = r.newNatsClient(config)
+	}
+	if err := r.natsClients[crKey].Init(); err != nil {
+		return nil, errors.Join(ErrNATSNotReady, err)
+	}
+	return r.natsClients[crKey], nil
+}
+
+// writeAccountKeysSecret creates or updates the account keys Secret with the given data. The Secret is owned
+// by the NATS CR, so it is deleted together with the NATS cluster. It returns the written Secret.
+func (r *Reconciler) writeAccountKeysSecret(ctx context.Context, nats *nmapiv1alpha1.NATS, secret *kcorev1.Secret,
+	data map[string][]byte,
+) (*kcorev1.Secret, error) {
+	if secret == nil {
+		secret = &kcorev1.Secret{
+			ObjectMeta: kmetav1.ObjectMeta{
+				Name:      nats.Name + AccountKeysSecretSuffix,
+				Namespace: nats.Namespace,
+				Labels:    map[string]string{nmlabels.KeyManagedBy: nmlabels.ValueNATSManager},
+			},
+			Type: kcorev1.SecretTypeOpaque,
+			Data: data,
+		}
+		if err := controllerutil.SetOwnerReference(nats, secret, r.Scheme()); err != nil {
+			return nil, err
+		}
+		return secret, r.Create(ctx, secret)
+	}
+
+	if equalData(secret.Data, data) {
+		return secret, nil
+	}
+	secret.Data = data
+	return secret, r.Update(ctx, secret)
+}
+
+// issueAccountJWT issues the JWT of the given account. The imports of exports which are restricted to
+// certain accounts are issued with an activation token of the exporting account.
+func issueAccountJWT(operatorSeed []byte, account *nmapiv1alpha1.NATSAccount,
+	accounts []nmapiv1alpha1.NATSAccount, seeds map[string][]byte, publicKeys map[string]string,
+) (string, error) {
+	name := account.AccountName()
+	claims := auth.Account{Name: name}
+
+	if jetStream := account.Spec.JetStream; jetStream != nil {
+		claims.JetStream = &auth.JetStreamLimits{
+			MaxStreams:   int64(jetStream.MaxStreams),
+			MaxConsumers: int64(jetStream.MaxConsumers),
+		}
+		if jetStream.MaxMemory != nil {
+			claims.JetStream.MaxMemory = jetStream.MaxMemory.Value()
+		}
+		if jetStream.MaxFile != nil {
+			claims.JetStream.MaxFile = jetStream.MaxFile.Value()
+		}
+	}
+
+	for _, export := range account.Spec.Exports {
+		claims.Exports = append(claims.Exports, auth.Export{
+			Subject:       export.Stream + export.Service,
+			Service:       export.Service != "",
+			TokenRequired: len(export.Accounts) > 0,
+		})
+	}
+
+	for _, imp := range account.Spec.Imports {
+		claimsImport := auth.Import{
+			Account:      publicKeys[imp.Account],
+			Subject:      imp.Stream + imp.Service,
+			Service:      imp.Service != "",
+			LocalSubject: imp.To,
+		}
+		if imp.Prefix != "" {
+			claimsImport.LocalSubject = imp.Prefix + "." + imp.Stream
+		}
+		if isRestrictedTo(accounts, imp.Account, claimsImport.Service, name) {
+			token, err := auth.IssueActivation(seeds[imp.Account], publicKeys[name],
+				claimsImport.Subject, claimsImport.Service)
+			if err != nil {
+				return "", err
+			}
+			claimsImport.Token = token
+		}
+		claims.Imports = append(claims.Imports, claimsImport)
+	}
+	return auth.IssueAccount(operatorSeed, publicKeys[name], claims)
+}
+
+// isRestrictedTo checks if the given exporting account has a restricted export of the given type
+// which the given importing account may import.
+func isRestrictedTo(accounts []nmapiv1alpha1.NATSAccount, exporter string, service bool, importer string) bool {
+	for i := range accounts {
+		if accounts[i].AccountName() != exporter {
+			continue
+		}
+		for _, export := range accounts[i].Spec.Exports {
+			if (export.Service != "") == service && slices.Contains(export.Accounts, importer) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jwtCredentials returns the credentials of users which connect with a JWT issued by the account with the
+// given seed. The NKey of a user is kept as long as one of its Secrets exists, and its JWT as long as
+// its claims do not change.
+func jwtCredentials(account *nmapiv1alpha1.NATSAccount, accountSeed []byte) userCredentials {
+	return func(user nmapiv1alpha1.NATSAccountUser, secrets []kcorev1.Secret) (map[string][]byte, error) {
+		username := account.Username(user)
+		currentJWT, userSeed := credentialsFile(secrets, username)
+		if userSeed == nil {
+			var err error
+			if userSeed, err = auth.NewUserSeed(); err != nil {
+				return nil, err
+			}
+		}
+		userPublicKey, err := auth.PublicKey(userSeed)
+		if err != nil {
+			return nil, err
+		}
+
+		userJWT, err := auth.IssueUser(accountSeed, userPublicKey, auth.User{
+			Name: username,
+			Publish: auth.Permission{
+				Allow: user.Permissions.Publish.Allow,
+				Deny:  user.Permissions.Publish.Deny,
+			},
+			Subscribe: auth.Permission{
+				Allow: user.Permissions.Subscribe.Allow,
+				Deny:  user.Permissions.Subscribe.Deny,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if auth.SameClaims(currentJWT, userJWT) {
+			userJWT = currentJWT
+		}
+
+		credentials, err := auth.FormatCredentials(userJWT, userSeed)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{auth.CredentialsKey: credentials}, nil
+	}
+}
+
+// credentialsFile returns the JWT and the seed of the given user from its Secrets,
+// or empty values if the user has no valid credentials file yet.
+func credentialsFile(secrets []kcorev1.Secret, username string) (string, []byte) {
+	for _, secret := range secrets {
+		if string(secret.Data[UsernameKey]) != username {
+			continue
+		}
+		if userJWT, userSeed, err := auth.ParseCredentials(secret.Data[auth.CredentialsKey]); err == nil {
+			return userJWT, userSeed
+		}
+	}
+	return "", nil
+}
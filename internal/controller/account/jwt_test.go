@@ -0,0 +1,185 @@
+package account
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/auth"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	nmnatsmocks "github.com/kyma-project/nats-manager/pkg/nats/mocks"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/nats-io/jwt/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newJWTNATS(t *testing.T, state string) (*nmapiv1alpha1.NATS, *kcorev1.Secret) {
+	t.Helper()
+	nats := testutils.NewNATSCR(
+		testutils.WithNATSCRName(natsName),
+		testutils.WithNATSCRNamespace(natsNamespace),
+		testutils.WithNATSAuthMode(nmapiv1alpha1.AuthModeJWT),
+		testutils.WithNATSStateReady(),
+	)
+	nats.Status.State = state
+	data, err := auth.NewOperatorSecretData(natsName)
+	require.NoError(t, err)
+	operatorSecret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: natsName + "-operator", Namespace: natsNamespace},
+		Data:       data,
+	}
+	return nats, operatorSecret
+}
+
+func getAccountKeys(ctx context.Context, t *testing.T, c client.Client) map[string][]byte {
+	t.Helper()
+	secret := &kcorev1.Secret{}
+	require.NoError(t, c.Get(ctx,
+		ktypes.NamespacedName{Name: natsName + AccountKeysSecretSuffix, Namespace: natsNamespace}, secret))
+	return secret.Data
+}
+
+func Test_Reconcile_JWT(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	givenNATS, givenOperatorSecret := newJWTNATS(t, nmapiv1alpha1.StateReady)
+	givenOrders := newAccount("orders", "shop", nmapiv1alpha1.NATSAccountSpec{
+		Exports: []nmapiv1alpha1.NATSAccountExport{{Stream: "orders.>", Accounts: []string{"billing"}}},
+	})
+	givenBilling := newAccount("billing", "shop", nmapiv1alpha1.NATSAccountSpec{
+		Users:     []nmapiv1alpha1.NATSAccountUser{{Name: "reader"}},
+		JetStream: &nmapiv1alpha1.NATSAccountJetStream{MaxStreams: 10},
+		Imports: []nmapiv1alpha1.NATSAccountImport{
+			{Account: "orders", Stream: "orders.>", Prefix: "billing"},
+		},
+	})
+	reconciler, fakeClient := newTestReconciler(t, givenNATS, givenOperatorSecret, givenOrders, givenBilling)
+
+	pushedJWTs := map[string]string{}
+	natsClient := &nmnatsmocks.Client{}
+	natsClient.On("Init").Return(nil)
+	natsClient.On("UpdateAccountClaims", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		claims, err := jwt.DecodeAccountClaims(args.String(0))
+		require.NoError(t, err)
+		pushedJWTs[claims.Name] = args.String(0)
+	})
+	natsClient.On("DeleteAccountClaims", mock.Anything).Return(nil)
+	reconciler.newNatsClient = func(config *nmnats.Config) nmnats.Client {
+		require.Equal(t, givenOperatorSecret.Data[auth.SystemUserCredentialsKey], config.Credentials)
+		return natsClient
+	}
+
+	// when
+	result := reconcileAccount(ctx, t, reconciler, givenBilling)
+
+	// then
+	require.Equal(t, kcontrollerruntime.Result{RequeueAfter: RequeueTimeForSync * time.Second}, result)
+	require.Equal(t, nmapiv1alpha1.StateReady, getAccount(ctx, t, fakeClient, givenBilling).Status.State)
+	require.Len(t, pushedJWTs, 2)
+
+	keys := getAccountKeys(ctx, t, fakeClient)
+	ordersKey, err := auth.PublicKey(keys["orders.seed"])
+	require.NoError(t, err)
+	billingKey, err := auth.PublicKey(keys["billing.seed"])
+	require.NoError(t, err)
+	require.Equal(t, pushedJWTs["billing"], string(keys["billing.jwt"]))
+
+	billingClaims, err := jwt.DecodeAccountClaims(pushedJWTs["billing"])
+	require.NoError(t, err)
+	operatorKey, err := auth.PublicKey(givenOperatorSecret.Data[auth.OperatorSeedKey])
+	require.NoError(t, err)
+	require.Equal(t, operatorKey, billingClaims.Issuer)
+	require.Equal(t, int64(10), billingClaims.Limits.Streams)
+	require.Len(t, billingClaims.Imports, 1)
+	require.Equal(t, ordersKey, billingClaims.Imports[0].Account)
+	require.Equal(t, jwt.RenamingSubject("billing.orders.>"), billingClaims.Imports[0].LocalSubject)
+	require.NotEmpty(t, billingClaims.Imports[0].Token)
+
+	readerSecret := &kcorev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx,
+		ktypes.NamespacedName{Name: "billing-reader", Namespace: "shop"}, readerSecret))
+	require.Equal(t, "billing.reader", string(readerSecret.Data[UsernameKey]))
+	userJWT, _, err := auth.ParseCredentials(readerSecret.Data[auth.CredentialsKey])
+	require.NoError(t, err)
+	userClaims, err := jwt.DecodeUserClaims(userJWT)
+	require.NoError(t, err)
+	require.Equal(t, billingKey, userClaims.Issuer)
+
+	// when the account is reconciled again, nothing is pushed and the credentials are kept.
+	reconcileAccount(ctx, t, reconciler, givenBilling)
+
+	// then
+	natsClient.AssertNumberOfCalls(t, "UpdateAccountClaims", 2)
+	gotReaderSecret := &kcorev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(readerSecret), gotReaderSecret))
+	require.Equal(t, readerSecret.Data, gotReaderSecret.Data)
+
+	// when the importing account is deleted.
+	require.NoError(t, fakeClient.Delete(ctx, givenBilling))
+	reconcileAccount(ctx, t, reconciler, givenBilling)
+
+	// then
+	natsClient.AssertNumberOfCalls(t, "DeleteAccountClaims", 1)
+	keys = getAccountKeys(ctx, t, fakeClient)
+	require.NotContains(t, keys, "billing.seed")
+	require.Contains(t, keys, "orders.seed")
+}
+
+func Test_Reconcile_JWT_InvalidAccounts(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		givenAccount *nmapiv1alpha1.NATSAccount
+		givenState   string
+		wantState    string
+		wantReason   nmapiv1alpha1.ConditionReason
+	}{
+		{
+			name:         "should fail if the account is reserved by the NATS manager",
+			givenAccount: newAccount("sys", "shop", nmapiv1alpha1.NATSAccountSpec{Account: auth.SystemAccountName}),
+			givenState:   nmapiv1alpha1.StateReady,
+			wantState:    nmapiv1alpha1.StateError,
+			wantReason:   nmapiv1alpha1.ConditionReasonAccountInvalid,
+		},
+		{
+			name:         "should wait if the JWT cannot be pushed to NATS yet",
+			givenAccount: newAccount("orders", "shop", nmapiv1alpha1.NATSAccountSpec{}),
+			givenState:   nmapiv1alpha1.StateProcessing,
+			wantState:    nmapiv1alpha1.StateProcessing,
+			wantReason:   nmapiv1alpha1.ConditionReasonNATSNotReady,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			ctx := context.Background()
+			givenNATS, givenOperatorSecret := newJWTNATS(t, tc.givenState)
+			reconciler, fakeClient := newTestReconciler(t, givenNATS, givenOperatorSecret, tc.givenAccount)
+			reconciler.newNatsClient = func(*nmnats.Config) nmnats.Client {
+				require.Fail(t, "no JWT should be pushed")
+				return nil
+			}
+
+			// when
+			reconcileAccount(ctx, t, reconciler, tc.givenAccount)
+
+			// then
+			gotAccount := getAccount(ctx, t, fakeClient, tc.givenAccount)
+			require.Equal(t, tc.wantState, gotAccount.Status.State)
+			require.Equal(t, string(tc.wantReason), gotAccount.Status.Conditions[0].Reason)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmctrlclientconfig "github.com/kyma-project/nats-manager/internal/controller/nats/clientconfig"
+	"go.uber.org/zap"
+	ktypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ResolverConfigKey is the key of the resolver configuration in the accounts Secret of the NATS cluster.
+	ResolverConfigKey = nmctrlclientconfig.ResolverConfigKey
+
+	systemAccount = "$SYS"
+	// anonymousUser is the user of the clients which connect without credentials. It belongs to the global
+	// account as long as the system account is the only other account, and to the default account otherwise.
+	anonymousUser = "anonymous"
+)
+
+// accountConfig is the configuration of an account in the NATS server configuration.
+type accountConfig struct {
+	Users     []userConfig   `json:"users,omitempty"`
+	JetStream any            `json:"jetstream,omitempty"`
+	Exports   []exportConfig `json:"exports,omitempty"`
+	Imports   []importConfig `json:"imports,omitempty"`
+}
+
+type userConfig struct {
+	User        string             `json:"user"`
+	Password    string             `json:"password,omitempty"`
+	Permissions *permissionsConfig `json:"permissions,omitempty"`
+}
+
+type permissionsConfig struct {
+	Publish   *permissionConfig `json:"publish,omitempty"`
+	Subscribe *permissionConfig `json:"subscribe,omitempty"`
+}
+
+type permissionConfig struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+type jetStreamConfig struct {
+	MaxMemory    int64 `json:"max_mem,omitempty"`
+	MaxFile      int64 `json:"max_file,omitempty"`
+	MaxStreams   int   `json:"max_streams,omitempty"`
+	MaxConsumers int   `json:"max_consumers,omitempty"`
+}
+
+type exportConfig struct {
+	Stream   string   `json:"stream,omitempty"`
+	Service  string   `json:"service,omitempty"`
+	Accounts []string `json:"accounts,omitempty"`
+}
+
+type importConfig struct {
+	Stream  *importSource `json:"stream,omitempty"`
+	Service *importSource `json:"service,omitempty"`
+	Prefix  string        `json:"prefix,omitempty"`
+	To      string        `json:"to,omitempty"`
+}
+
+type importSource struct {
+	Account string `json:"account"`
+	Subject string `json:"subject"`
+}
+
+// syncResolverConfig renders the given accounts into the resolver configuration in the accounts Secret of the
+// NATS cluster. The config reloader of the NATS servers applies the configuration once the Secret is updated.
+// NATS rejects the whole configuration if an account is invalid, so only valid accounts are passed.
+// Accounts whose credentials cannot be written are added to the invalid accounts.
+func (r *Reconciler) syncResolverConfig(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	accounts []nmapiv1alpha1.NATSAccount, invalidAccounts map[ktypes.UID]string, log *zap.SugaredLogger,
+) error {
+	accountsSecret, err := r.getSecret(ctx, nats, nats.Name+nmapiv1alpha1.AccountsSecretSuffix)
+	if err != nil {
+		return err
+	}
+	if accountsSecret == nil {
+		return ErrNATSNotReady
+	}
+
+	passwords := map[string]string{}
+	for i := range accounts {
+		credentials, syncErr := r.syncCredentials(ctx, nats, &accounts[i], passwordCredentials(&accounts[i]))
+		if errors.Is(syncErr, ErrSecretNotManaged) {
+			// the other users of the account keep their access.
+			invalidAccounts[accounts[i].UID] = syncErr.Error()
+		} else if syncErr != nil {
+			return syncErr
+		}
+		for username, data := range credentials {
+			passwords[username] = string(data[PasswordKey])
+		}
+	}
+
+	resolverConfig, err := renderResolverConfig(nmctrlclientconfig.AdminPassword(accountsSecret.Data[ResolverConfigKey]),
+		accounts, passwords, nats.Spec.Auth.DefaultAccount)
+	if err != nil {
+		return err
+	}
+	if string(accountsSecret.Data[ResolverConfigKey]) == string(resolverConfig) {
+		return nil
+	}
+
+	if accountsSecret.Data == nil {
+		accountsSecret.Data = map[string][]byte{}
+	}
+	accountsSecret.Data[ResolverConfigKey] = resolverConfig
+	if err = r.Update(ctx, accountsSecret); err != nil {
+		return err
+	}
+	log.Infof(AccountResolverUpdateMsg, nats.Namespace, nats.Name)
+	return nil
+}
+
+// renderResolverConfig renders the system account and the given accounts with the passwords of their users
+// into the resolver configuration of the NATS servers. Users without a password are left out.
+// Clients without credentials connect as the anonymous user, which belongs to the global account without
+// other accounts, and to the given default account otherwise, as NATS disables JetStream for the global account
+// once other accounts are configured.
+// The accounts are rendered as JSON, which is valid in the NATS configuration format.
+// As the keys are sorted, the result only changes if an account changes.
+func renderResolverConfig(adminPassword string, accounts []nmapiv1alpha1.NATSAccount,
+	passwords map[string]string, defaultAccount string,
+) ([]byte, error) {
+	configs := map[string]accountConfig{
+		systemAccount: {Users: []userConfig{{User: nmctrlclientconfig.AdminUser, Password: adminPassword}}},
+	}
+	for i := range accounts {
+		configs[accounts[i].AccountName()] = toAccountConfig(&accounts[i], passwords)
+	}
+	config, hasDefaultAccount := configs[defaultAccount]
+	inDefaultAccount := len(accounts) > 0 && hasDefaultAccount
+	if inDefaultAccount {
+		config.Users = append(config.Users, userConfig{User: anonymousUser})
+		configs[defaultAccount] = config
+	}
+
+	buffer := &bytes.Buffer{}
+	buffer.WriteString("accounts: ")
+	encoder := json.NewEncoder(buffer)
+	// subjects may contain the wildcard '>'.
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(configs); err != nil {
+		return nil, err
+	}
+	buffer.WriteString(`system_account: "` + systemAccount + `"` + "\n")
+	if !inDefaultAccount {
+		buffer.WriteString(`authorization: {"users": [{"user": "` + anonymousUser + `"}]}` + "\n")
+	}
+	buffer.WriteString(`no_auth_user: "` + anonymousUser + `"` + "\n")
+	return buffer.Bytes(), nil
+}
+
+func toAccountConfig(account *nmapiv1alpha1.NATSAccount, passwords map[string]string) accountConfig {
+	config := accountConfig{}
+	for _, user := range account.Spec.Users {
+		username := account.Username(user)
+		if passwords[username] == "" {
+			continue
+		}
+		config.Users = append(config.Users, userConfig{
+			User:        username,
+			Password:    passwords[username],
+			Permissions: toPermissionsConfig(user.Permissions),
+		})
+	}
+
+	if jetStream := account.Spec.JetStream; jetStream != nil {
+		limits := jetStreamConfig{MaxStreams: jetStream.MaxStreams, MaxConsumers: jetStream.MaxConsumers}
+		if jetStream.MaxMemory != nil {
+			limits.MaxMemory = jetStream.MaxMemory.Value()
+		}
+		if jetStream.MaxFile != nil {
+			limits.MaxFile = jetStream.MaxFile.Value()
+		}
+		config.JetStream = limits
+		if limits == (jetStreamConfig{}) {
+			config.JetStream = "enabled"
+		}
+	}
+
+	for _, export := range account.Spec.Exports {
+		config.Exports = append(config.Exports, exportConfig{
+			Stream:   export.Stream,
+			Service:  export.Service,
+			Accounts: export.Accounts,
+		})
+	}
+
+	for _, imp := range account.Spec.Imports {
+		source := &importSource{Account: imp.Account, Subject: imp.Stream}
+		if imp.Stream != "" {
+			config.Imports = append(config.Imports, importConfig{Stream: source, Prefix: imp.Prefix})
+			continue
+		}
+		source.Subject = imp.Service
+		config.Imports = append(config.Imports, importConfig{Service: source, To: imp.To})
+	}
+	return config
+}
+
+func toPermissionsConfig(permissions nmapiv1alpha1.NATSPermissions) *permissionsConfig {
+	config := &permissionsConfig{
+		Publish:   toPermissionConfig(permissions.Publish),
+		Subscribe: toPermissionConfig(permissions.Subscribe),
+	}
+	if config.Publish == nil && config.Subscribe == nil {
+		return nil
+	}
+	return config
+}
+
+func toPermissionConfig(permission nmapiv1alpha1.NATSPermission) *permissionConfig {
+	if len(permission.Allow) == 0 && len(permission.Deny) == 0 {
+		return nil
+	}
+	return &permissionConfig{Allow: permission.Allow, Deny: permission.Deny}
+}
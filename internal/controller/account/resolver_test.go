@@ -0,0 +1,153 @@
+package account
+
+import (
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmctrlclientconfig "github.com/kyma-project/nats-manager/internal/controller/nats/clientconfig"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func Test_renderResolverConfig(t *testing.T) {
+	t.Parallel()
+
+	// given
+	maxFile := resource.MustParse("1Gi")
+	orders := testutils.NewNATSAccountCR(
+		testutils.WithNATSAccountName("orders"),
+		testutils.WithNATSAccountSpec(nmapiv1alpha1.NATSAccountSpec{
+			Users: []nmapiv1alpha1.NATSAccountUser{
+				{
+					Name: "publisher",
+					Permissions: nmapiv1alpha1.NATSPermissions{
+						Publish:   nmapiv1alpha1.NATSPermission{Allow: []string{"orders.>"}},
+						Subscribe: nmapiv1alpha1.NATSPermission{Deny: []string{">"}},
+					},
+				},
+				{Name: "pending"},
+			},
+			JetStream: &nmapiv1alpha1.NATSAccountJetStream{MaxFile: &maxFile, MaxStreams: 10},
+			Exports:   []nmapiv1alpha1.NATSAccountExport{{Stream: "orders.created", Accounts: []string{"billing"}}},
+		}),
+	)
+	billing := testutils.NewNATSAccountCR(
+		testutils.WithNATSAccountName("billing-account"),
+		testutils.WithNATSAccountSpec(nmapiv1alpha1.NATSAccountSpec{
+			Account:   "billing",
+			Users:     []nmapiv1alpha1.NATSAccountUser{{Name: "reader"}},
+			JetStream: &nmapiv1alpha1.NATSAccountJetStream{},
+			Imports: []nmapiv1alpha1.NATSAccountImport{
+				{Account: "orders", Stream: "orders.created", Prefix: "billing"},
+				{Account: "orders", Service: "orders.lookup", To: "lookup"},
+			},
+		}),
+	)
+	passwords := map[string]string{"orders.publisher": "p1", "billing.reader": "p2"}
+
+	// when
+	got, err := renderResolverConfig("admin-password",
+		[]nmapiv1alpha1.NATSAccount{*orders, *billing}, passwords, "billing")
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, `accounts: {
+  "$SYS": {
+    "users": [
+      {
+        "user": "admin",
+        "password": "admin-password"
+      }
+    ]
+  },
+  "billing": {
+    "users": [
+      {
+        "user": "billing.reader",
+        "password": "p2"
+      },
+      {
+        "user": "anonymous"
+      }
+    ],
+    "jetstream": "enabled",
+    "imports": [
+      {
+        "stream": {
+          "account": "orders",
+          "subject": "orders.created"
+        },
+        "prefix": "billing"
+      },
+      {
+        "service": {
+          "account": "orders",
+          "subject": "orders.lookup"
+        },
+        "to": "lookup"
+      }
+    ]
+  },
+  "orders": {
+    "users": [
+      {
+        "user": "orders.publisher",
+        "password": "p1",
+        "permissions": {
+          "publish": {
+            "allow": [
+              "orders.>"
+            ]
+          },
+          "subscribe": {
+            "deny": [
+              ">"
+            ]
+          }
+        }
+      }
+    ],
+    "jetstream": {
+      "max_file": 1073741824,
+      "max_streams": 10
+    },
+    "exports": [
+      {
+        "stream": "orders.created",
+        "accounts": [
+          "billing"
+        ]
+      }
+    ]
+  }
+}
+system_account: "$SYS"
+no_auth_user: "anonymous"
+`, string(got))
+	require.Equal(t, "admin-password", nmctrlclientconfig.AdminPassword(got))
+}
+
+func Test_renderResolverConfig_WithoutAccounts(t *testing.T) {
+	t.Parallel()
+
+	// when
+	got, err := renderResolverConfig("admin-password", nil, nil, "billing")
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, `accounts: {
+  "$SYS": {
+    "users": [
+      {
+        "user": "admin",
+        "password": "admin-password"
+      }
+    ]
+  }
+}
+system_account: "$SYS"
+authorization: {"users": [{"user": "anonymous"}]}
+no_auth_user: "anonymous"
+`, string(got))
+}
@@ -0,0 +1,425 @@
+package jetstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/backup"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	NATSBackupFinalizerName = "natsbackup.operator.kyma-project.io/finalizer"
+
+	// SnapshotKeySuffix and SnapshotInfoKeySuffix are the suffixes of the keys of the snapshot data and
+	// of the configuration and state of a stream in the storage backend.
+	SnapshotKeySuffix     = ".snapshot"
+	SnapshotInfoKeySuffix = ".json"
+
+	BackupStartedMsg    = "Backing up %d streams"
+	BackupCompletedMsg  = "Backed up %d streams with %d bytes"
+	BackupDeletedMsg    = "Deleted the snapshots of the backup in %s"
+	SnapshotsMissingMsg = "The snapshots of streams %s are missing in the storage backend"
+)
+
+// ErrOtherAccountStreams is returned if a backup of all streams would leave out the streams of other accounts.
+var ErrOtherAccountStreams = errors.New("the streams of other accounts than the one of the NATS manager " +
+	"cannot be backed up, set spec.streams to back up the streams of the NATS manager's account only")
+
+// BackupReconciler reconciles a NATSBackup object.
+type BackupReconciler struct {
+	*natsConnector
+	recorder   record.EventRecorder
+	logger     *zap.SugaredLogger
+	newBackend func(storage nmapiv1alpha1.BackupStorage) (backup.Backend, error)
+}
+
+func NewBackupReconciler(
+	client client.Client,
+	logger *zap.SugaredLogger,
+	recorder record.EventRecorder,
+	natsCR *nmapiv1alpha1.NATS,
+	backupDir string,
+) *BackupReconciler {
+	return &BackupReconciler{
+		natsConnector: newNATSConnector(client, natsCR),
+		recorder:      recorder,
+		logger:        logger,
+		newBackend: func(storage nmapiv1alpha1.BackupStorage) (backup.Backend, error) {
+			return backup.NewBackend(storage, backupDir)
+		},
+	}
+}
+
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsbackups,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsbackups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsbackups/finalizers,verbs=update
+
+func (r *BackupReconciler) Reconcile(ctx context.Context,
+	req kcontrollerruntime.Request,
+) (kcontrollerruntime.Result, error) {
+	currentBackup := &nmapiv1alpha1.NATSBackup{}
+	if err := r.Get(ctx, req.NamespacedName, currentBackup); err != nil {
+		return kcontrollerruntime.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Copy the object, so we don't modify the source object.
+	natsBackup := currentBackup.DeepCopy()
+
+	log := r.logger.With(
+		"kind", "NATSBackup",
+		"namespace", natsBackup.GetNamespace(),
+		"name", natsBackup.GetName(),
+	)
+
+	if natsBackup.IsInDeletion() {
+		return r.handleBackupDeletion(ctx, natsBackup, log)
+	}
+
+	if !controllerutil.ContainsFinalizer(natsBackup, NATSBackupFinalizerName) {
+		controllerutil.AddFinalizer(natsBackup, NATSBackupFinalizerName)
+		return kcontrollerruntime.Result{}, r.Update(ctx, natsBackup)
+	}
+
+	// a backup is taken once. Afterward, it is checked that its snapshots still exist, for example,
+	// after the NATS manager was restarted with a backup directory which is not persistent.
+	if natsBackup.IsCompleted() {
+		return r.verifyBackup(ctx, natsBackup, log)
+	}
+
+	return r.handleBackup(ctx, natsBackup, log)
+}
+
+// handleBackup takes a snapshot of every stream of the backup and stores it in the storage backend.
+// If any snapshot fails, the stored snapshots are deleted and the backup is retried.
+func (r *BackupReconciler) handleBackup(ctx context.Context,
+	natsBackup *nmapiv1alpha1.NATSBackup, log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	natsCluster, err := r.getNATS(ctx)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	natsClient, err := r.connect(ctx, natsCluster)
+	if errors.Is(err, ErrNATSNotReady) {
+		log.Infof("NATS cluster is not ready, retrying in %d seconds", RequeueTimeForNATSNotReady)
+		natsBackup.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonNATSNotReady,
+			fmt.Sprintf(NATSNotReadyMsg, r.natsCR.Namespace, r.natsCR.Name))
+		return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			r.syncBackupStatus(ctx, natsBackup, log)
+	}
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncBackupStatusWithErr(ctx, natsBackup, err, log)
+	}
+
+	backend, err := r.newBackend(natsBackup.Spec.Storage)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncBackupStatusWithErr(ctx, natsBackup, err, log)
+	}
+
+	streamNames, err := r.backupStreamNames(ctx, natsCluster, natsClient, natsBackup)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncBackupStatusWithErr(ctx, natsBackup, err, log)
+	}
+
+	now := kmetav1.Now()
+	natsBackup.Status.StartTime = &now
+	natsBackup.Status.Location = natsBackup.StorageLocation()
+	natsBackup.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonBackingUp,
+		fmt.Sprintf(BackupStartedMsg, len(streamNames)))
+	if err = r.syncBackupStatus(ctx, natsBackup, log); err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	natsBackup.Status.Streams = nil
+	natsBackup.Status.Size = 0
+	for _, streamName := range streamNames {
+		stream, err := snapshotStream(ctx, natsClient, backend, natsBackup.StorageLocation(), streamName)
+		if err != nil {
+			err = fmt.Errorf("failed to back up stream %s: %w", streamName, err)
+			// the snapshots of an incomplete backup are not kept.
+			return kcontrollerruntime.Result{}, r.syncBackupStatusWithErr(ctx, natsBackup,
+				errors.Join(err, backend.Delete(ctx, natsBackup.StorageLocation())), log)
+		}
+		natsBackup.Status.Streams = append(natsBackup.Status.Streams, *stream)
+		natsBackup.Status.Size += stream.Size
+	}
+
+	completionTime := kmetav1.Now()
+	natsBackup.Status.CompletionTime = &completionTime
+	msg := fmt.Sprintf(BackupCompletedMsg, len(streamNames), natsBackup.Status.Size)
+	natsBackup.Status.SetStateReady(nmapiv1alpha1.ConditionReasonBackedUp, msg)
+	events.Normal(r.recorder, natsBackup, nmapiv1alpha1.ConditionReasonBackedUp, msg)
+	log.Infow("Backed up streams", "streams", len(streamNames), "size", natsBackup.Status.Size)
+
+	return kcontrollerruntime.Result{}, r.syncBackupStatus(ctx, natsBackup, log)
+}
+
+// verifyBackup sets the error state if snapshots of the completed backup are missing in the storage backend,
+// so that the backup is not restored. The backup is ready again once the snapshots are back.
+func (r *BackupReconciler) verifyBackup(ctx context.Context,
+	natsBackup *nmapiv1alpha1.NATSBackup, log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	backend, err := r.newBackend(natsBackup.Spec.Storage)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncBackupStatusWithErr(ctx, natsBackup, err, log)
+	}
+	missingStreams, err := missingSnapshots(ctx, backend, natsBackup)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncBackupStatusWithErr(ctx, natsBackup, err, log)
+	}
+
+	if len(missingStreams) > 0 {
+		msg := fmt.Sprintf(SnapshotsMissingMsg, strings.Join(missingStreams, ", "))
+		natsBackup.Status.SetStateError(nmapiv1alpha1.ConditionReasonSnapshotsMissing, msg)
+		events.Warn(r.recorder, natsBackup, nmapiv1alpha1.ConditionReasonSnapshotsMissing, msg)
+		log.Warnw("Snapshots of the backup are missing", "streams", missingStreams)
+		return kcontrollerruntime.Result{}, r.syncBackupStatus(ctx, natsBackup, log)
+	}
+
+	natsBackup.Status.SetStateReady(nmapiv1alpha1.ConditionReasonBackedUp,
+		fmt.Sprintf(BackupCompletedMsg, len(natsBackup.Status.Streams), natsBackup.Status.Size))
+	return kcontrollerruntime.Result{}, r.syncBackupStatus(ctx, natsBackup, log)
+}
+
+// missingSnapshots returns the names of the streams of the backup whose snapshot data or snapshot info
+// does not exist in the storage backend.
+func missingSnapshots(ctx context.Context, backend backup.Backend, natsBackup *nmapiv1alpha1.NATSBackup,
+) ([]string, error) {
+	var streamNames []string
+	for _, stream := range natsBackup.Status.Streams {
+		for _, key := range []string{
+			snapshotKey(natsBackup.Status.Location, stream.Name),
+			snapshotInfoKey(natsBackup.Status.Location, stream.Name),
+		} {
+			reader, err := backend.Reader(ctx, key)
+			if errors.Is(err, backup.ErrNotFound) {
+				streamNames = append(streamNames, stream.Name)
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if err = reader.Close(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return streamNames, nil
+}
+
+// backupStreamNames returns the names of the streams of the backup, which are all streams if none are set.
+// The NATS manager can only snapshot the streams of its own account. So, a backup of all streams fails
+// if other accounts have streams, instead of leaving them out.
+func (r *BackupReconciler) backupStreamNames(ctx context.Context, natsCluster *nmapiv1alpha1.NATS,
+	natsClient nmnats.Client, natsBackup *nmapiv1alpha1.NATSBackup,
+) ([]string, error) {
+	if len(natsBackup.Spec.Streams) > 0 {
+		return natsBackup.Spec.Streams, nil
+	}
+	otherStreams, err := r.otherAccountStreams(ctx, natsCluster, natsClient)
+	if err != nil {
+		return nil, err
+	}
+	if len(otherStreams) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrOtherAccountStreams, strings.Join(otherStreams, ", "))
+	}
+	streams, err := natsClient.GetStreams()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(streams))
+	for _, stream := range streams {
+		names = append(names, stream.Config.Name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// otherAccountStreams returns the streams of all accounts besides the one of the given NATS client
+// as <account>/<stream>. They are read with the user of the system account from every NATS server.
+func (r *BackupReconciler) otherAccountStreams(ctx context.Context, natsCluster *nmapiv1alpha1.NATS,
+	natsClient nmnats.Client,
+) ([]string, error) {
+	accountName, err := natsClient.AccountName()
+	if err != nil {
+		return nil, err
+	}
+	systemClient, err := r.connectSystem(ctx, natsCluster)
+	if err != nil {
+		return nil, err
+	}
+	defer systemClient.Close()
+	accounts, err := systemClient.ClusterStreams(natsCluster.Spec.Cluster.Size)
+	if err != nil {
+		return nil, err
+	}
+	var streams []string
+	for _, account := range accounts {
+		if account.Name == accountName {
+			continue
+		}
+		for _, stream := range account.Streams {
+			streams = append(streams, account.Name+"/"+stream.Name)
+		}
+	}
+	slices.Sort(streams)
+	return streams, nil
+}
+
+// snapshotStream stores the snapshot data and the snapshot info of the given stream below the given location.
+func snapshotStream(ctx context.Context, natsClient nmnats.Client, backend backup.Backend,
+	location, streamName string,
+) (*nmapiv1alpha1.BackupStream, error) {
+	writer, err := backend.Writer(ctx, snapshotKey(location, streamName))
+	if err != nil {
+		return nil, err
+	}
+	countingWriter := &countingWriter{Writer: writer}
+	info, err := natsClient.SnapshotStream(streamName, countingWriter)
+	if err = errors.Join(err, writer.Close()); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	infoWriter, err := backend.Writer(ctx, snapshotInfoKey(location, streamName))
+	if err != nil {
+		return nil, err
+	}
+	_, err = infoWriter.Write(data)
+	if err = errors.Join(err, infoWriter.Close()); err != nil {
+		return nil, err
+	}
+
+	return &nmapiv1alpha1.BackupStream{
+		Name:     streamName,
+		Messages: info.State.Msgs,
+		Bytes:    info.State.Bytes,
+		Size:     countingWriter.size,
+	}, nil
+}
+
+// readSnapshotInfo returns the configuration and state of the given stream at the time of its snapshot.
+func readSnapshotInfo(ctx context.Context, backend backup.Backend, location, streamName string,
+) (*nats.StreamInfo, error) {
+	reader, err := backend.Reader(ctx, snapshotInfoKey(location, streamName))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	info := &nats.StreamInfo{}
+	if err = json.NewDecoder(reader).Decode(info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func snapshotKey(location, streamName string) string {
+	return path.Join(location, streamName+SnapshotKeySuffix)
+}
+
+func snapshotInfoKey(location, streamName string) string {
+	return path.Join(location, streamName+SnapshotInfoKeySuffix)
+}
+
+// countingWriter counts the bytes which are written.
+type countingWriter struct {
+	io.Writer
+	size int64
+}
+
+func (w *countingWriter) Write(data []byte) (int, error) {
+	n, err := w.Writer.Write(data)
+	w.size += int64(n)
+	return n, err
+}
+
+func (r *BackupReconciler) handleBackupDeletion(ctx context.Context,
+	natsBackup *nmapiv1alpha1.NATSBackup, log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	// skip reconciliation for deletion if the finalizer is not set.
+	if !controllerutil.ContainsFinalizer(natsBackup, NATSBackupFinalizerName) {
+		return kcontrollerruntime.Result{}, nil
+	}
+
+	// if the backup never started, no snapshots were stored.
+	if natsBackup.Status.Location != "" {
+		natsBackup.Status.SetStateDeleting()
+		backend, err := r.newBackend(natsBackup.Spec.Storage)
+		if err != nil {
+			return kcontrollerruntime.Result{}, r.syncBackupStatusWithErr(ctx, natsBackup, err, log)
+		}
+		if err = backend.Delete(ctx, natsBackup.Status.Location); err != nil {
+			return kcontrollerruntime.Result{}, r.syncBackupStatusWithErr(ctx, natsBackup, err, log)
+		}
+		events.Normal(r.recorder, natsBackup, nmapiv1alpha1.ConditionReasonDeleting,
+			BackupDeletedMsg, natsBackup.Status.Location)
+		log.Info("Deleted the snapshots of the backup")
+	}
+
+	controllerutil.RemoveFinalizer(natsBackup, NATSBackupFinalizerName)
+	return kcontrollerruntime.Result{}, r.Update(ctx, natsBackup)
+}
+
+// syncBackupStatusWithErr sets the error state in the status and syncs it.
+// Returns the original error, so the controller triggers another reconciliation.
+func (r *BackupReconciler) syncBackupStatusWithErr(ctx context.Context,
+	natsBackup *nmapiv1alpha1.NATSBackup, err error, log *zap.SugaredLogger,
+) error {
+	natsBackup.Status.SetStateError(nmapiv1alpha1.ConditionReasonProcessingError, err.Error())
+	return errors.Join(err, r.syncBackupStatus(ctx, natsBackup, log))
+}
+
+// syncBackupStatus updates the status of the NATSBackup if it was modified.
+func (r *BackupReconciler) syncBackupStatus(ctx context.Context, natsBackup *nmapiv1alpha1.NATSBackup,
+	log *zap.SugaredLogger,
+) error {
+	// fetch the latest object, to avoid k8s conflict errors.
+	actualBackup := &nmapiv1alpha1.NATSBackup{}
+	namespacedName := ktypes.NamespacedName{Name: natsBackup.Name, Namespace: natsBackup.Namespace}
+	if err := r.Get(ctx, namespacedName, actualBackup); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if actualBackup.Status.IsEqual(natsBackup.Status) {
+		return nil
+	}
+
+	desiredBackup := actualBackup.DeepCopy()
+	desiredBackup.Status = natsBackup.Status
+	if err := r.Status().Update(ctx, desiredBackup); err != nil {
+		return err
+	}
+
+	log.Debugw("Updated NATSBackup status",
+		"oldStatus", actualBackup.Status, "newStatus", desiredBackup.Status)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackupReconciler) SetupWithManager(mgr kcontrollerruntime.Manager) error {
+	return kcontrollerruntime.NewControllerManagedBy(mgr).
+		For(&nmapiv1alpha1.NATSBackup{}).
+		Complete(r)
+}
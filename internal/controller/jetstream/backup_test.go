@@ -0,0 +1,267 @@
+package jetstream
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	"github.com/kyma-project/nats-manager/testutils"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func Test_BackupReconcile_SnapshotsStreams(t *testing.T) {
+	t.Parallel()
+
+	// given
+	backupDir := t.TempDir()
+	givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+	givenBackup := testutils.NewNATSBackupCR(
+		testutils.WithNATSBackupName("nightly"),
+		testutils.WithNATSBackupNamespace("kyma-system"),
+		testutils.WithNATSBackupFinalizer(NATSBackupFinalizerName),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, newAccountsSecret(givenNATS), givenBackup)
+	testEnv.NatsClient.On("Init").Return(nil)
+	testEnv.NatsClient.On("AccountName").Return("$G", nil)
+	testEnv.NatsClient.On("ClusterStreams", givenNATS.Spec.Cluster.Size).Return([]*monitor.AccountDetail{
+		{Name: "$G", Streams: []*monitor.StreamDetail{{Name: "orders"}, {Name: "events"}}},
+	}, nil)
+	testEnv.NatsClient.On("Close").Return()
+	testEnv.NatsClient.On("GetStreams").Return([]*natsgo.StreamInfo{
+		{Config: natsgo.StreamConfig{Name: "orders"}},
+		{Config: natsgo.StreamConfig{Name: "events"}},
+	}, nil)
+	for _, streamName := range []string{"events", "orders"} {
+		testEnv.NatsClient.On("SnapshotStream", streamName, mock.Anything).Run(func(args mock.Arguments) {
+			_, err := args.Get(1).(io.Writer).Write([]byte(streamName + "-data"))
+			require.NoError(t, err)
+		}).Return(&natsgo.StreamInfo{
+			Config: natsgo.StreamConfig{Name: streamName},
+			State:  natsgo.StreamState{Msgs: 3, Bytes: 300},
+		}, nil)
+	}
+	reconciler := testEnv.NewBackupReconciler(backupDir)
+
+	// when
+	_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenBackup.Name, Namespace: givenBackup.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	gotBackup := &nmapiv1alpha1.NATSBackup{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenBackup.Name, Namespace: givenBackup.Namespace}, gotBackup))
+	require.Equal(t, nmapiv1alpha1.StateReady, gotBackup.Status.State)
+	require.Equal(t, "kyma-system/nightly", gotBackup.Status.Location)
+	require.NotNil(t, gotBackup.Status.CompletionTime)
+	require.Equal(t, []nmapiv1alpha1.BackupStream{
+		{Name: "events", Messages: 3, Bytes: 300, Size: 11},
+		{Name: "orders", Messages: 3, Bytes: 300, Size: 11},
+	}, gotBackup.Status.Streams)
+	require.Equal(t, int64(22), gotBackup.Status.Size)
+
+	data, err := os.ReadFile(filepath.Join(backupDir, "kyma-system", "nightly", "orders.snapshot"))
+	require.NoError(t, err)
+	require.Equal(t, "orders-data", string(data))
+	require.FileExists(t, filepath.Join(backupDir, "kyma-system", "nightly", "orders.json"))
+
+	require.Equal(t, []string{"Normal BackedUp Backed up 2 streams with 22 bytes"}, testEnv.GetK8sEvents())
+	testEnv.NatsClient.AssertExpectations(t)
+}
+
+func Test_BackupReconcile_DeletesSnapshotsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	backupDir := t.TempDir()
+	givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+	givenBackup := testutils.NewNATSBackupCR(
+		testutils.WithNATSBackupName("nightly"),
+		testutils.WithNATSBackupNamespace("kyma-system"),
+		testutils.WithNATSBackupFinalizer(NATSBackupFinalizerName),
+		testutils.WithNATSBackupStreams("events", "orders"),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, givenBackup)
+	testEnv.NatsClient.On("Init").Return(nil)
+	testEnv.NatsClient.On("SnapshotStream", "events", mock.Anything).Return(&natsgo.StreamInfo{}, nil)
+	testEnv.NatsClient.On("SnapshotStream", "orders", mock.Anything).Return(nil, natsgo.ErrStreamNotFound)
+	reconciler := testEnv.NewBackupReconciler(backupDir)
+
+	// when
+	_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenBackup.Name, Namespace: givenBackup.Namespace},
+	})
+
+	// then
+	require.ErrorIs(t, err, natsgo.ErrStreamNotFound)
+	gotBackup := &nmapiv1alpha1.NATSBackup{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenBackup.Name, Namespace: givenBackup.Namespace}, gotBackup))
+	require.Equal(t, nmapiv1alpha1.StateError, gotBackup.Status.State)
+	require.Nil(t, gotBackup.Status.CompletionTime)
+	require.NoDirExists(t, filepath.Join(backupDir, "kyma-system", "nightly"))
+	testEnv.NatsClient.AssertExpectations(t)
+}
+
+func Test_BackupReconcile_FailsWithStreamsOfOtherAccounts(t *testing.T) {
+	t.Parallel()
+
+	// given
+	backupDir := t.TempDir()
+	givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+	givenBackup := testutils.NewNATSBackupCR(
+		testutils.WithNATSBackupName("nightly"),
+		testutils.WithNATSBackupNamespace("kyma-system"),
+		testutils.WithNATSBackupFinalizer(NATSBackupFinalizerName),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, newAccountsSecret(givenNATS), givenBackup)
+	testEnv.NatsClient.On("Init").Return(nil)
+	testEnv.NatsClient.On("AccountName").Return("$G", nil)
+	testEnv.NatsClient.On("ClusterStreams", givenNATS.Spec.Cluster.Size).Return([]*monitor.AccountDetail{
+		{Name: "$G", Streams: []*monitor.StreamDetail{{Name: "events"}}},
+		{Name: "orders", Streams: []*monitor.StreamDetail{{Name: "payments"}, {Name: "invoices"}}},
+	}, nil)
+	testEnv.NatsClient.On("Close").Return()
+	reconciler := testEnv.NewBackupReconciler(backupDir)
+
+	// when
+	_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenBackup.Name, Namespace: givenBackup.Namespace},
+	})
+
+	// then
+	require.ErrorIs(t, err, ErrOtherAccountStreams)
+	require.ErrorContains(t, err, "orders/invoices, orders/payments")
+	gotBackup := &nmapiv1alpha1.NATSBackup{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenBackup.Name, Namespace: givenBackup.Namespace}, gotBackup))
+	require.Equal(t, nmapiv1alpha1.StateError, gotBackup.Status.State)
+	testEnv.NatsClient.AssertNotCalled(t, "SnapshotStream", mock.Anything, mock.Anything)
+}
+
+func Test_BackupReconcile_VerifiesSnapshots(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		givenMissingKeys []string
+		wantState        string
+		wantReason       nmapiv1alpha1.ConditionReason
+		wantMessage      string
+		wantEvents       []string
+	}{
+		{
+			name:        "should keep the backup ready if all snapshots exist",
+			wantState:   nmapiv1alpha1.StateReady,
+			wantReason:  nmapiv1alpha1.ConditionReasonBackedUp,
+			wantMessage: "Backed up 2 streams with 22 bytes",
+			wantEvents:  []string{},
+		},
+		{
+			name:             "should set the error state if snapshots are missing",
+			givenMissingKeys: []string{"events.snapshot", "orders.json"},
+			wantState:        nmapiv1alpha1.StateError,
+			wantReason:       nmapiv1alpha1.ConditionReasonSnapshotsMissing,
+			wantMessage:      "The snapshots of streams events, orders are missing in the storage backend",
+			wantEvents: []string{
+				"Warning SnapshotsMissing The snapshots of streams events, orders are missing in the storage backend",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			backupDir := t.TempDir()
+			givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+			givenBackup := newCompletedBackup(t, backupDir, "events", "orders")
+			givenBackup.Status.Size = 22
+			controllerutil.AddFinalizer(givenBackup, NATSBackupFinalizerName)
+			for _, key := range tc.givenMissingKeys {
+				require.NoError(t, os.Remove(filepath.Join(backupDir, givenBackup.Status.Location, key)))
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, givenBackup)
+			reconciler := testEnv.NewBackupReconciler(backupDir)
+
+			// when
+			_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+				NamespacedName: ktypes.NamespacedName{Name: givenBackup.Name, Namespace: givenBackup.Namespace},
+			})
+
+			// then
+			require.NoError(t, err)
+			gotBackup := &nmapiv1alpha1.NATSBackup{}
+			require.NoError(t, testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: givenBackup.Name, Namespace: givenBackup.Namespace}, gotBackup))
+			require.Equal(t, tc.wantState, gotBackup.Status.State)
+			gotCondition := gotBackup.Status.Conditions[0]
+			require.Equal(t, string(tc.wantReason), gotCondition.Reason)
+			require.Equal(t, tc.wantMessage, gotCondition.Message)
+			require.Equal(t, tc.wantEvents, testEnv.GetK8sEvents())
+			testEnv.NatsClient.AssertNotCalled(t, "SnapshotStream", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func Test_handleBackupDeletion(t *testing.T) {
+	t.Parallel()
+
+	// given
+	backupDir := t.TempDir()
+	location := filepath.Join(backupDir, "kyma-system", "nightly")
+	require.NoError(t, os.MkdirAll(location, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(location, "orders.snapshot"), []byte("data"), 0o600))
+
+	givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+	givenBackup := testutils.NewNATSBackupCR(
+		testutils.WithNATSBackupName("nightly"),
+		testutils.WithNATSBackupNamespace("kyma-system"),
+		testutils.WithNATSBackupFinalizer(NATSBackupFinalizerName),
+		testutils.WithNATSBackupDeletionTimestamp(),
+		testutils.WithNATSBackupStatus(nmapiv1alpha1.NATSBackupStatus{Location: "kyma-system/nightly"}),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, givenBackup)
+	reconciler := testEnv.NewBackupReconciler(backupDir)
+
+	// when
+	_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenBackup.Name, Namespace: givenBackup.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	err = testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenBackup.Name, Namespace: givenBackup.Namespace},
+		&nmapiv1alpha1.NATSBackup{})
+	require.True(t, kapierrors.IsNotFound(err))
+	require.NoDirExists(t, location)
+	require.Equal(t, []string{"Normal Deleting Deleted the snapshots of the backup in kyma-system/nightly"},
+		testEnv.GetK8sEvents())
+}
+
+// newAccountsSecret returns the Secret with the accounts of the given NATS CR, which holds the admin
+// of the system account.
+func newAccountsSecret(nats *nmapiv1alpha1.NATS) *kcorev1.Secret {
+	return &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name: nats.Name + nmapiv1alpha1.AccountsSecretSuffix, Namespace: nats.Namespace,
+		},
+		Data: map[string][]byte{
+			"resolver.conf": []byte(`accounts: { "$SYS": { users: [ { user: "admin", password: "admin-pw" } ] } }`),
+		},
+	}
+}
@@ -0,0 +1,367 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/backup"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	"github.com/kyma-project/nats-manager/pkg/metrics"
+	"go.uber.org/zap"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	NATSBackupScheduleFinalizerName = "natsbackupschedule.operator.kyma-project.io/finalizer"
+
+	BackupScheduledMsg = "Next backup at %s"
+	BackupSuspendedMsg = "Backups are suspended"
+	BackupCreatedMsg   = "Created backup %s"
+	BackupExpiredMsg   = "Deleted expired backup %s"
+	BackupFailedMsg    = "Backup %s failed: %s"
+)
+
+// BackupScheduleReconciler reconciles a NATSBackupSchedule object.
+type BackupScheduleReconciler struct {
+	client.Client
+	recorder  record.EventRecorder
+	logger    *zap.SugaredLogger
+	collector metrics.Collector
+	now       func() time.Time
+}
+
+func NewBackupScheduleReconciler(
+	client client.Client,
+	logger *zap.SugaredLogger,
+	recorder record.EventRecorder,
+	collector metrics.Collector,
+) *BackupScheduleReconciler {
+	return &BackupScheduleReconciler{
+		Client:    client,
+		recorder:  recorder,
+		logger:    logger,
+		collector: collector,
+		now:       time.Now,
+	}
+}
+
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsbackupschedules,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsbackupschedules/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsbackupschedules/finalizers,verbs=update
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsbackups,verbs=create;delete
+
+func (r *BackupScheduleReconciler) Reconcile(ctx context.Context,
+	req kcontrollerruntime.Request,
+) (kcontrollerruntime.Result, error) {
+	currentSchedule := &nmapiv1alpha1.NATSBackupSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, currentSchedule); err != nil {
+		return kcontrollerruntime.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Copy the object, so we don't modify the source object.
+	backupSchedule := currentSchedule.DeepCopy()
+
+	log := r.logger.With(
+		"kind", "NATSBackupSchedule",
+		"namespace", backupSchedule.GetNamespace(),
+		"name", backupSchedule.GetName(),
+	)
+
+	if backupSchedule.IsInDeletion() {
+		return r.handleBackupScheduleDeletion(ctx, backupSchedule, log)
+	}
+
+	if !controllerutil.ContainsFinalizer(backupSchedule, NATSBackupScheduleFinalizerName) {
+		controllerutil.AddFinalizer(backupSchedule, NATSBackupScheduleFinalizerName)
+		return kcontrollerruntime.Result{}, r.Update(ctx, backupSchedule)
+	}
+
+	return r.handleBackupSchedule(ctx, backupSchedule, log)
+}
+
+// handleBackupSchedule deletes the expired backups of the schedule, records the results of its backups,
+// and creates a backup if a scheduled time passed.
+func (r *BackupScheduleReconciler) handleBackupSchedule(ctx context.Context,
+	backupSchedule *nmapiv1alpha1.NATSBackupSchedule, log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	// an invalid schedule is not retried, because the NATSBackupSchedule is reconciled again once it is fixed.
+	schedule, err := backup.ParseSchedule(backupSchedule.Spec.Schedule)
+	if err != nil {
+		backupSchedule.Status.NextScheduleTime = nil
+		backupSchedule.Status.SetStateError(nmapiv1alpha1.ConditionReasonScheduleInvalid, err.Error())
+		return kcontrollerruntime.Result{}, r.syncBackupScheduleStatus(ctx, backupSchedule, log)
+	}
+
+	backups, err := r.backupsOfSchedule(ctx, backupSchedule)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncBackupScheduleStatusWithErr(ctx, backupSchedule, err, log)
+	}
+
+	now := r.now().UTC()
+	if backups, err = r.deleteExpiredBackups(ctx, backupSchedule, backups, now, log); err != nil {
+		return kcontrollerruntime.Result{}, r.syncBackupScheduleStatusWithErr(ctx, backupSchedule, err, log)
+	}
+	r.updateBackupResults(backupSchedule, backups)
+
+	if backupSchedule.Spec.Suspend {
+		backupSchedule.Status.NextScheduleTime = nil
+		r.setBackupScheduleState(backupSchedule, backups, nmapiv1alpha1.ConditionReasonSuspended, BackupSuspendedMsg)
+		return kcontrollerruntime.Result{}, r.syncBackupScheduleStatus(ctx, backupSchedule, log)
+	}
+
+	if scheduledTime := lastMissedScheduleTime(schedule, backupSchedule, now); !scheduledTime.IsZero() {
+		natsBackup, err := r.createBackup(ctx, backupSchedule, scheduledTime)
+		if err != nil {
+			return kcontrollerruntime.Result{}, r.syncBackupScheduleStatusWithErr(ctx, backupSchedule, err, log)
+		}
+		backupSchedule.Status.LastScheduleTime = &kmetav1.Time{Time: scheduledTime}
+		events.Normal(r.recorder, backupSchedule, nmapiv1alpha1.ConditionReasonScheduled,
+			BackupCreatedMsg, natsBackup.Name)
+		log.Infow("Created scheduled backup", "backup", natsBackup.Name)
+	}
+
+	result := kcontrollerruntime.Result{}
+	backupSchedule.Status.NextScheduleTime = nil
+	msg := fmt.Sprintf(BackupScheduledMsg, "never")
+	if nextTime := schedule.Next(now); !nextTime.IsZero() {
+		backupSchedule.Status.NextScheduleTime = &kmetav1.Time{Time: nextTime}
+		msg = fmt.Sprintf(BackupScheduledMsg, nextTime.Format(time.RFC3339))
+		result.RequeueAfter = nextTime.Sub(now)
+	}
+	r.setBackupScheduleState(backupSchedule, backups, nmapiv1alpha1.ConditionReasonScheduled, msg)
+
+	return result, r.syncBackupScheduleStatus(ctx, backupSchedule, log)
+}
+
+// backupsOfSchedule returns the NATSBackups which were created by the given schedule, the newest first.
+func (r *BackupScheduleReconciler) backupsOfSchedule(ctx context.Context,
+	backupSchedule *nmapiv1alpha1.NATSBackupSchedule,
+) ([]nmapiv1alpha1.NATSBackup, error) {
+	backupList := &nmapiv1alpha1.NATSBackupList{}
+	if err := r.List(ctx, backupList, client.InNamespace(backupSchedule.Namespace)); err != nil {
+		return nil, err
+	}
+
+	backups := []nmapiv1alpha1.NATSBackup{}
+	for _, natsBackup := range backupList.Items {
+		if kmetav1.IsControlledBy(&natsBackup, backupSchedule) {
+			backups = append(backups, natsBackup)
+		}
+	}
+	slices.SortFunc(backups, func(a, b nmapiv1alpha1.NATSBackup) int {
+		if c := b.CreationTimestamp.Compare(a.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(b.Name, a.Name)
+	})
+	return backups, nil
+}
+
+// deleteExpiredBackups deletes the completed backups beyond the retention count, the backups which are older
+// than the maximum age, and the incomplete backups which are older than the last completed backup.
+// The last completed backup is kept, so that a schedule whose backups fail does not lose all backups.
+// Returns the remaining backups.
+func (r *BackupScheduleReconciler) deleteExpiredBackups(ctx context.Context,
+	backupSchedule *nmapiv1alpha1.NATSBackupSchedule, backups []nmapiv1alpha1.NATSBackup, now time.Time,
+	log *zap.SugaredLogger,
+) ([]nmapiv1alpha1.NATSBackup, error) {
+	maxAge := backupSchedule.Spec.Retention.MaxAge
+	remaining := []nmapiv1alpha1.NATSBackup{}
+	completed := 0
+	for _, natsBackup := range backups {
+		expired := false
+		switch {
+		case natsBackup.IsInDeletion():
+		case natsBackup.IsCompleted():
+			completed++
+			expired = completed > backupSchedule.GetRetentionCount() ||
+				(completed > 1 && maxAge != nil && now.Sub(natsBackup.CreationTimestamp.Time) > maxAge.Duration)
+		default:
+			expired = completed > 0 ||
+				(maxAge != nil && now.Sub(natsBackup.CreationTimestamp.Time) > maxAge.Duration)
+		}
+
+		if !expired {
+			remaining = append(remaining, natsBackup)
+			continue
+		}
+		if err := r.Delete(ctx, &natsBackup); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		events.Normal(r.recorder, backupSchedule, nmapiv1alpha1.ConditionReasonBackupExpired,
+			BackupExpiredMsg, natsBackup.Name)
+		log.Infow("Deleted expired backup", "backup", natsBackup.Name)
+	}
+	return remaining, nil
+}
+
+// updateBackupResults records the last completed backup and the failed backups of the schedule.
+func (r *BackupScheduleReconciler) updateBackupResults(backupSchedule *nmapiv1alpha1.NATSBackupSchedule,
+	backups []nmapiv1alpha1.NATSBackup,
+) {
+	status := &backupSchedule.Status
+	status.FailedBackups = 0
+	status.LastFailure = ""
+	for _, natsBackup := range backups {
+		if natsBackup.Status.State != nmapiv1alpha1.StateError {
+			continue
+		}
+		if status.FailedBackups == 0 {
+			status.LastFailure = fmt.Sprintf(BackupFailedMsg, natsBackup.Name, backupFailure(&natsBackup))
+		}
+		status.FailedBackups++
+	}
+
+	for _, natsBackup := range backups {
+		if !natsBackup.IsCompleted() {
+			continue
+		}
+		status.LastSuccessfulBackup = natsBackup.Name
+		status.LastSuccessfulTime = natsBackup.Status.CompletionTime
+		status.LastSuccessfulSize = natsBackup.Status.Size
+		break
+	}
+
+	if status.LastSuccessfulTime != nil {
+		r.collector.RecordBackupMetric(backupSchedule.Namespace, backupSchedule.Name,
+			status.LastSuccessfulTime.Time, status.LastSuccessfulSize)
+	}
+}
+
+// setBackupScheduleState sets the Warning state if the newest backup failed, otherwise the Ready state.
+func (r *BackupScheduleReconciler) setBackupScheduleState(backupSchedule *nmapiv1alpha1.NATSBackupSchedule,
+	backups []nmapiv1alpha1.NATSBackup, reason nmapiv1alpha1.ConditionReason, msg string,
+) {
+	if len(backups) > 0 && backups[0].Status.State == nmapiv1alpha1.StateError {
+		backupSchedule.Status.SetStateWarning(nmapiv1alpha1.ConditionReasonBackupFailed,
+			backupSchedule.Status.LastFailure)
+		return
+	}
+	backupSchedule.Status.SetStateReady(reason, msg)
+}
+
+// backupFailure returns the error message of a failed backup.
+func backupFailure(natsBackup *nmapiv1alpha1.NATSBackup) string {
+	condition := meta.FindStatusCondition(natsBackup.Status.Conditions, string(nmapiv1alpha1.ConditionCompleted))
+	if condition == nil {
+		return ""
+	}
+	return condition.Message
+}
+
+// lastMissedScheduleTime returns the latest scheduled time after the last backup of the schedule which has
+// passed, or the zero time if none has passed. Older missed times are skipped, so that only one backup is
+// created after an outage.
+func lastMissedScheduleTime(schedule *backup.Schedule, backupSchedule *nmapiv1alpha1.NATSBackupSchedule,
+	now time.Time,
+) time.Time {
+	last := backupSchedule.CreationTimestamp.Time
+	if backupSchedule.Status.LastScheduleTime != nil {
+		last = backupSchedule.Status.LastScheduleTime.Time
+	}
+	if last.IsZero() {
+		return time.Time{}
+	}
+
+	missed := time.Time{}
+	for next := schedule.Next(last.UTC()); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		missed = next
+	}
+	return missed
+}
+
+// createBackup creates the NATSBackup of the given scheduled time. The name is derived from the scheduled time,
+// so that a backup is not created twice if the status update fails.
+func (r *BackupScheduleReconciler) createBackup(ctx context.Context,
+	backupSchedule *nmapiv1alpha1.NATSBackupSchedule, scheduledTime time.Time,
+) (*nmapiv1alpha1.NATSBackup, error) {
+	natsBackup := &nmapiv1alpha1.NATSBackup{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", backupSchedule.Name, scheduledTime.Unix()),
+			Namespace: backupSchedule.Namespace,
+		},
+		Spec: nmapiv1alpha1.NATSBackupSpec{
+			Streams: backupSchedule.Spec.Streams,
+			Storage: backupSchedule.Spec.Storage,
+		},
+	}
+	if err := controllerutil.SetControllerReference(backupSchedule, natsBackup, r.Scheme()); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, natsBackup); err != nil && !kapierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	return natsBackup, nil
+}
+
+func (r *BackupScheduleReconciler) handleBackupScheduleDeletion(ctx context.Context,
+	backupSchedule *nmapiv1alpha1.NATSBackupSchedule, log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	// skip reconciliation for deletion if the finalizer is not set.
+	if !controllerutil.ContainsFinalizer(backupSchedule, NATSBackupScheduleFinalizerName) {
+		return kcontrollerruntime.Result{}, nil
+	}
+
+	// the backups of the schedule are deleted by the garbage collector.
+	r.collector.ResetBackupMetric(backupSchedule.Namespace, backupSchedule.Name)
+	log.Info("Deleted the backup schedule")
+
+	controllerutil.RemoveFinalizer(backupSchedule, NATSBackupScheduleFinalizerName)
+	return kcontrollerruntime.Result{}, r.Update(ctx, backupSchedule)
+}
+
+// syncBackupScheduleStatusWithErr sets the error state in the status and syncs it.
+// Returns the original error, so the controller triggers another reconciliation.
+func (r *BackupScheduleReconciler) syncBackupScheduleStatusWithErr(ctx context.Context,
+	backupSchedule *nmapiv1alpha1.NATSBackupSchedule, err error, log *zap.SugaredLogger,
+) error {
+	backupSchedule.Status.SetStateError(nmapiv1alpha1.ConditionReasonProcessingError, err.Error())
+	return errors.Join(err, r.syncBackupScheduleStatus(ctx, backupSchedule, log))
+}
+
+// syncBackupScheduleStatus updates the status of the NATSBackupSchedule if it was modified.
+func (r *BackupScheduleReconciler) syncBackupScheduleStatus(ctx context.Context,
+	backupSchedule *nmapiv1alpha1.NATSBackupSchedule, log *zap.SugaredLogger,
+) error {
+	// fetch the latest object, to avoid k8s conflict errors.
+	actualSchedule := &nmapiv1alpha1.NATSBackupSchedule{}
+	namespacedName := ktypes.NamespacedName{Name: backupSchedule.Name, Namespace: backupSchedule.Namespace}
+	if err := r.Get(ctx, namespacedName, actualSchedule); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if actualSchedule.Status.IsEqual(backupSchedule.Status) {
+		return nil
+	}
+
+	desiredSchedule := actualSchedule.DeepCopy()
+	desiredSchedule.Status = backupSchedule.Status
+	if err := r.Status().Update(ctx, desiredSchedule); err != nil {
+		return err
+	}
+
+	log.Debugw("Updated NATSBackupSchedule status",
+		"oldStatus", actualSchedule.Status, "newStatus", desiredSchedule.Status)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager. The schedules are reconciled again when their
+// backups change, so that the results of the backups are recorded.
+func (r *BackupScheduleReconciler) SetupWithManager(mgr kcontrollerruntime.Manager) error {
+	return kcontrollerruntime.NewControllerManagedBy(mgr).
+		For(&nmapiv1alpha1.NATSBackupSchedule{}).
+		Owns(&nmapiv1alpha1.NATSBackup{}).
+		Complete(r)
+}
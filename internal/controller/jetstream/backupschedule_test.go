@@ -0,0 +1,194 @@
+package jetstream
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/metrics"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_BackupScheduleReconcile_CreatesBackup(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNow := time.Date(2026, time.March, 10, 1, 30, 0, 0, time.UTC)
+	givenSchedule := testutils.NewNATSBackupScheduleCR(
+		testutils.WithNATSBackupScheduleName("hourly"),
+		testutils.WithNATSBackupScheduleFinalizer(NATSBackupScheduleFinalizerName),
+		testutils.WithNATSBackupScheduleSchedule("0 * * * *"),
+		testutils.WithNATSBackupScheduleCreationTimestamp(givenNow.Add(-3*time.Hour)),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, testutils.NewNATSCR(), givenSchedule)
+	reconciler := testEnv.NewBackupScheduleReconciler(metrics.NewPrometheusCollector(), givenNow)
+
+	// when
+	result, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenSchedule.Name, Namespace: givenSchedule.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, kcontrollerruntime.Result{RequeueAfter: 30 * time.Minute}, result)
+
+	// only the backup of the last missed time is created.
+	wantScheduledTime := time.Date(2026, time.March, 10, 1, 0, 0, 0, time.UTC)
+	wantBackupName := fmt.Sprintf("hourly-%d", wantScheduledTime.Unix())
+	gotBackup := &nmapiv1alpha1.NATSBackup{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: wantBackupName, Namespace: givenSchedule.Namespace}, gotBackup))
+	require.True(t, kmetav1.IsControlledBy(gotBackup, givenSchedule))
+	require.Equal(t, givenSchedule.Spec.Storage, gotBackup.Spec.Storage)
+
+	gotSchedule := &nmapiv1alpha1.NATSBackupSchedule{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenSchedule.Name, Namespace: givenSchedule.Namespace}, gotSchedule))
+	require.Equal(t, nmapiv1alpha1.StateReady, gotSchedule.Status.State)
+	require.True(t, wantScheduledTime.Equal(gotSchedule.Status.LastScheduleTime.Time))
+	require.True(t, wantScheduledTime.Add(time.Hour).Equal(gotSchedule.Status.NextScheduleTime.Time))
+	require.Equal(t, []string{"Normal Scheduled Created backup " + wantBackupName}, testEnv.GetK8sEvents())
+}
+
+func Test_BackupScheduleReconcile_DeletesExpiredBackups(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNow := time.Date(2026, time.March, 10, 1, 30, 0, 0, time.UTC)
+	givenSchedule := testutils.NewNATSBackupScheduleCR(
+		testutils.WithNATSBackupScheduleName("daily"),
+		testutils.WithNATSBackupScheduleFinalizer(NATSBackupScheduleFinalizerName),
+		testutils.WithNATSBackupScheduleSchedule("0 0 * * *"),
+		testutils.WithNATSBackupScheduleRetention(nmapiv1alpha1.BackupRetention{
+			Count:  2,
+			MaxAge: &kmetav1.Duration{Duration: 48 * time.Hour},
+		}),
+		testutils.WithNATSBackupScheduleStatus(nmapiv1alpha1.NATSBackupScheduleStatus{
+			LastScheduleTime: &kmetav1.Time{Time: time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)},
+		}),
+	)
+	newBackup := func(name string, age time.Duration, status nmapiv1alpha1.NATSBackupStatus) client.Object {
+		return testutils.NewNATSBackupCR(
+			testutils.WithNATSBackupName(name),
+			testutils.WithNATSBackupOwner(givenSchedule),
+			testutils.WithNATSBackupCreationTimestamp(givenNow.Add(-age)),
+			testutils.WithNATSBackupStatus(status),
+		)
+	}
+	completed := func(size int64) nmapiv1alpha1.NATSBackupStatus {
+		completionTime := kmetav1.NewTime(givenNow.Add(-time.Hour))
+		return nmapiv1alpha1.NATSBackupStatus{State: nmapiv1alpha1.StateReady, CompletionTime: &completionTime, Size: size}
+	}
+	failed := nmapiv1alpha1.NATSBackupStatus{State: nmapiv1alpha1.StateError}
+	failed.SetStateError(nmapiv1alpha1.ConditionReasonProcessingError, "stream not found")
+
+	givenBackups := []client.Object{
+		newBackup("daily-1", 90*time.Minute, failed),
+		newBackup("daily-2", 25*time.Hour, completed(100)),
+		newBackup("daily-3", 30*time.Hour, failed),
+		newBackup("daily-4", 49*time.Hour, completed(200)),
+		newBackup("daily-5", 73*time.Hour, completed(300)),
+	}
+	testEnv := NewMockedUnitTestEnvironment(t, testutils.NewNATSCR(), append(givenBackups, givenSchedule)...)
+	collector := metrics.NewPrometheusCollector()
+	reconciler := testEnv.NewBackupScheduleReconciler(collector, givenNow)
+
+	// when
+	_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenSchedule.Name, Namespace: givenSchedule.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	gotBackups := &nmapiv1alpha1.NATSBackupList{}
+	require.NoError(t, testEnv.Client.List(testEnv.Context, gotBackups, client.InNamespace(givenSchedule.Namespace)))
+	gotBackupNames := []string{}
+	for _, gotBackup := range gotBackups.Items {
+		gotBackupNames = append(gotBackupNames, gotBackup.Name)
+	}
+	// the failed backup which is older than the last completed backup, the completed backup beyond the retention
+	// count, and the completed backup which is older than the maximum age are deleted.
+	require.ElementsMatch(t, []string{"daily-1", "daily-2"}, gotBackupNames)
+
+	gotSchedule := &nmapiv1alpha1.NATSBackupSchedule{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenSchedule.Name, Namespace: givenSchedule.Namespace}, gotSchedule))
+	require.Equal(t, nmapiv1alpha1.StateWarning, gotSchedule.Status.State)
+	require.Equal(t, "daily-2", gotSchedule.Status.LastSuccessfulBackup)
+	require.Equal(t, int64(100), gotSchedule.Status.LastSuccessfulSize)
+	require.Equal(t, int32(1), gotSchedule.Status.FailedBackups)
+	require.Equal(t, "Backup daily-1 failed: stream not found", gotSchedule.Status.LastFailure)
+
+	gauge, err := collector.GetBackupSizeMetric(givenSchedule.Namespace, givenSchedule.Name)
+	require.NoError(t, err)
+	require.InDelta(t, 100, testutil.ToFloat64(gauge), 0)
+	require.ElementsMatch(t, []string{
+		"Normal BackupExpired Deleted expired backup daily-3",
+		"Normal BackupExpired Deleted expired backup daily-4",
+		"Normal BackupExpired Deleted expired backup daily-5",
+	}, testEnv.GetK8sEvents())
+}
+
+func Test_BackupScheduleReconcile_InvalidSchedule(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenSchedule := testutils.NewNATSBackupScheduleCR(
+		testutils.WithNATSBackupScheduleFinalizer(NATSBackupScheduleFinalizerName),
+		testutils.WithNATSBackupScheduleSchedule("0 25 * * *"),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, testutils.NewNATSCR(), givenSchedule)
+	reconciler := testEnv.NewBackupScheduleReconciler(metrics.NewPrometheusCollector(), time.Now())
+
+	// when
+	result, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenSchedule.Name, Namespace: givenSchedule.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, kcontrollerruntime.Result{}, result)
+	gotSchedule := &nmapiv1alpha1.NATSBackupSchedule{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenSchedule.Name, Namespace: givenSchedule.Namespace}, gotSchedule))
+	require.Equal(t, nmapiv1alpha1.StateError, gotSchedule.Status.State)
+	require.Nil(t, gotSchedule.Status.NextScheduleTime)
+}
+
+func Test_BackupScheduleReconcile_Suspended(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNow := time.Date(2026, time.March, 10, 1, 30, 0, 0, time.UTC)
+	givenSchedule := testutils.NewNATSBackupScheduleCR(
+		testutils.WithNATSBackupScheduleFinalizer(NATSBackupScheduleFinalizerName),
+		testutils.WithNATSBackupScheduleSchedule("0 * * * *"),
+		testutils.WithNATSBackupScheduleCreationTimestamp(givenNow.Add(-3*time.Hour)),
+		testutils.WithNATSBackupScheduleSuspend(),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, testutils.NewNATSCR(), givenSchedule)
+	reconciler := testEnv.NewBackupScheduleReconciler(metrics.NewPrometheusCollector(), givenNow)
+
+	// when
+	_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenSchedule.Name, Namespace: givenSchedule.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	gotBackups := &nmapiv1alpha1.NATSBackupList{}
+	require.NoError(t, testEnv.Client.List(testEnv.Context, gotBackups, client.InNamespace(givenSchedule.Namespace)))
+	require.Empty(t, gotBackups.Items)
+	gotSchedule := &nmapiv1alpha1.NATSBackupSchedule{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenSchedule.Name, Namespace: givenSchedule.Namespace}, gotSchedule))
+	require.Equal(t, nmapiv1alpha1.StateReady, gotSchedule.Status.State)
+	require.Nil(t, gotSchedule.Status.NextScheduleTime)
+}
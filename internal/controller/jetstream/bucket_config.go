@@ -0,0 +1,143 @@
+package jetstream
+
+import (
+	"errors"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	"github.com/nats-io/nats.go"
+)
+
+// toKeyValueConfig converts the spec of a NATSKeyValue to a JetStream key-value configuration.
+func toKeyValueConfig(kv *nmapiv1alpha1.NATSKeyValue) *nats.KeyValueConfig {
+	config := &nats.KeyValueConfig{
+		Bucket:      kv.BucketName(),
+		Description: kv.Spec.Description,
+		History:     uint8(min(max(kv.Spec.History, 1), nats.KeyValueMaxHistory)), //nolint:gosec // bounded above
+		Replicas:    max(kv.Spec.Replicas, 1),
+		MaxBytes:    unlimited,
+		Storage:     toStorageType(kv.Spec.Storage),
+	}
+	if kv.Spec.TTL != nil {
+		config.TTL = kv.Spec.TTL.Duration
+	}
+	if kv.Spec.MaxBytes != nil {
+		config.MaxBytes = kv.Spec.MaxBytes.Value()
+	}
+	return config
+}
+
+// keyValueStreamConfig returns the configuration of the stream which backs the key-value bucket.
+// Only the fields managed by the NATSKeyValue are set.
+func keyValueStreamConfig(config *nats.KeyValueConfig) *nats.StreamConfig {
+	return &nats.StreamConfig{
+		Name:              nmnats.KeyValueStreamName(config.Bucket),
+		Description:       config.Description,
+		MaxMsgsPerSubject: int64(config.History),
+		MaxAge:            config.TTL,
+		Replicas:          config.Replicas,
+		MaxBytes:          config.MaxBytes,
+		Storage:           config.Storage,
+	}
+}
+
+// toObjectStoreConfig converts the spec of a NATSObjectStore to a JetStream object store configuration.
+func toObjectStoreConfig(objectStore *nmapiv1alpha1.NATSObjectStore) *nats.ObjectStoreConfig {
+	config := &nats.ObjectStoreConfig{
+		Bucket:      objectStore.BucketName(),
+		Description: objectStore.Spec.Description,
+		Replicas:    max(objectStore.Spec.Replicas, 1),
+		MaxBytes:    unlimited,
+		Storage:     toStorageType(objectStore.Spec.Storage),
+	}
+	if objectStore.Spec.TTL != nil {
+		config.TTL = objectStore.Spec.TTL.Duration
+	}
+	if objectStore.Spec.MaxBytes != nil {
+		config.MaxBytes = objectStore.Spec.MaxBytes.Value()
+	}
+	return config
+}
+
+// objectStoreStreamConfig returns the configuration of the stream which backs the object store bucket.
+// Only the fields managed by the NATSObjectStore are set.
+func objectStoreStreamConfig(config *nats.ObjectStoreConfig) *nats.StreamConfig {
+	return &nats.StreamConfig{
+		Name:        nmnats.ObjectStoreStreamName(config.Bucket),
+		Description: config.Description,
+		MaxAge:      config.TTL,
+		Replicas:    config.Replicas,
+		MaxBytes:    config.MaxBytes,
+		Storage:     config.Storage,
+	}
+}
+
+// bucketConfigDrift returns the names of the spec fields for which the actual configuration of the stream
+// backing a bucket differs from the desired one. The history is only compared for key-value buckets.
+func bucketConfigDrift(desired, actual *nats.StreamConfig) []string {
+	var drift []string
+	if desired.Description != actual.Description {
+		drift = append(drift, "description")
+	}
+	if desired.MaxMsgsPerSubject != 0 && desired.MaxMsgsPerSubject != actual.MaxMsgsPerSubject {
+		drift = append(drift, "history")
+	}
+	if desired.MaxAge != actual.MaxAge {
+		drift = append(drift, "ttl")
+	}
+	if desired.Replicas != max(actual.Replicas, 1) {
+		drift = append(drift, "replicas")
+	}
+	if desired.MaxBytes != actual.MaxBytes {
+		drift = append(drift, "maxBytes")
+	}
+	if desired.Storage != actual.Storage {
+		drift = append(drift, "storage")
+	}
+	return drift
+}
+
+// mergeBucketConfig returns a copy of the actual configuration of the stream backing a bucket
+// with all fields managed by the bucket resource set to the desired values.
+func mergeBucketConfig(desired, actual *nats.StreamConfig) *nats.StreamConfig {
+	merged := *actual
+	merged.Description = desired.Description
+	if desired.MaxMsgsPerSubject != 0 {
+		merged.MaxMsgsPerSubject = desired.MaxMsgsPerSubject
+	}
+	merged.MaxAge = desired.MaxAge
+	merged.Replicas = desired.Replicas
+	merged.MaxBytes = desired.MaxBytes
+	merged.Storage = desired.Storage
+	// the duplicate window must not exceed the TTL.
+	if merged.MaxAge > 0 && merged.Duplicates > merged.MaxAge {
+		merged.Duplicates = merged.MaxAge
+	}
+	return &merged
+}
+
+// reconcileBackingStream makes sure the stream backing a bucket matches the desired configuration.
+// The given create function is called if the bucket does not exist yet.
+// Returns the info of the stream and the names of the corrected fields.
+func reconcileBackingStream(natsClient nmnats.Client, desired *nats.StreamConfig,
+	create func() error,
+) (*nats.StreamInfo, bool, []string, error) {
+	info, err := natsClient.StreamInfo(desired.Name)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		if err = create(); err != nil {
+			return nil, false, nil, err
+		}
+		info, err = natsClient.StreamInfo(desired.Name)
+		return info, true, nil, err
+	}
+	if err != nil {
+		return nil, false, nil, err
+	}
+
+	drift := bucketConfigDrift(desired, &info.Config)
+	if len(drift) == 0 {
+		return info, false, nil, nil
+	}
+	info, err = natsClient.UpdateStream(mergeBucketConfig(desired, &info.Config))
+	return info, false, drift, err
+}
@@ -0,0 +1,98 @@
+package jetstream
+
+import (
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_bucketConfigDrift(t *testing.T) {
+	t.Parallel()
+
+	desired := &natsgo.StreamConfig{
+		Name:     "OBJ_artifacts",
+		Replicas: 1,
+		MaxBytes: unlimited,
+		Storage:  natsgo.FileStorage,
+	}
+
+	testCases := []struct {
+		name      string
+		givenFunc func(*natsgo.StreamConfig)
+		wantDrift []string
+	}{
+		{
+			name:      "should not report drift of fields managed by NATS",
+			givenFunc: func(actual *natsgo.StreamConfig) { actual.Subjects = []string{"$O.artifacts.>"} },
+		},
+		{
+			name: "should ignore the history of object store buckets",
+			givenFunc: func(actual *natsgo.StreamConfig) {
+				actual.MaxMsgsPerSubject = 3
+			},
+		},
+		{
+			name: "should report all drifted fields",
+			givenFunc: func(actual *natsgo.StreamConfig) {
+				actual.Description = "changed"
+				actual.MaxAge = time.Hour
+				actual.Replicas = 3
+				actual.MaxBytes = 1024
+				actual.Storage = natsgo.MemoryStorage
+			},
+			wantDrift: []string{"description", "ttl", "replicas", "maxBytes", "storage"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := *desired
+			tc.givenFunc(&actual)
+			require.Equal(t, tc.wantDrift, bucketConfigDrift(desired, &actual))
+		})
+	}
+}
+
+func Test_mergeBucketConfig(t *testing.T) {
+	t.Parallel()
+
+	// given
+	desired := &natsgo.StreamConfig{
+		Name:              "KV_sessions",
+		MaxMsgsPerSubject: 5,
+		MaxAge:            time.Minute,
+		Replicas:          3,
+		MaxBytes:          unlimited,
+		Storage:           natsgo.FileStorage,
+	}
+	actual := &natsgo.StreamConfig{
+		Name:              "KV_sessions",
+		Subjects:          []string{"$KV.sessions.>"},
+		MaxMsgsPerSubject: 1,
+		Replicas:          1,
+		MaxBytes:          unlimited,
+		Storage:           natsgo.FileStorage,
+		Duplicates:        2 * time.Minute,
+		AllowRollup:       true,
+	}
+
+	// when
+	merged := mergeBucketConfig(desired, actual)
+
+	// then
+	require.Equal(t, &natsgo.StreamConfig{
+		Name:              "KV_sessions",
+		Subjects:          []string{"$KV.sessions.>"},
+		MaxMsgsPerSubject: 5,
+		MaxAge:            time.Minute,
+		Replicas:          3,
+		MaxBytes:          unlimited,
+		Storage:           natsgo.FileStorage,
+		Duplicates:        time.Minute,
+		AllowRollup:       true,
+	}, merged)
+}
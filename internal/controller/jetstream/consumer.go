@@ -0,0 +1,281 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	NATSConsumerFinalizerName = "natsconsumer.operator.kyma-project.io/finalizer"
+
+	ConsumerCreatedMsg        = "Created consumer %s on stream %s"
+	ConsumerDriftCorrectedMsg = "Corrected drift of consumer %s in: %s"
+	ConsumerDeletedMsg        = "Deleted consumer %s from stream %s"
+	StreamNotReadyMsg         = "NATSStream %s is not ready"
+)
+
+// ConsumerReconciler reconciles a NATSConsumer object.
+type ConsumerReconciler struct {
+	*natsConnector
+	recorder record.EventRecorder
+	logger   *zap.SugaredLogger
+}
+
+func NewConsumerReconciler(
+	client client.Client,
+	logger *zap.SugaredLogger,
+	recorder record.EventRecorder,
+	natsCR *nmapiv1alpha1.NATS,
+) *ConsumerReconciler {
+	return &ConsumerReconciler{
+		natsConnector: newNATSConnector(client, natsCR),
+		recorder:      recorder,
+		logger:        logger,
+	}
+}
+
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsconsumers,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsconsumers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsconsumers/finalizers,verbs=update
+
+func (r *ConsumerReconciler) Reconcile(ctx context.Context,
+	req kcontrollerruntime.Request,
+) (kcontrollerruntime.Result, error) {
+	currentConsumer := &nmapiv1alpha1.NATSConsumer{}
+	if err := r.Get(ctx, req.NamespacedName, currentConsumer); err != nil {
+		return kcontrollerruntime.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Copy the object, so we don't modify the source object.
+	consumer := currentConsumer.DeepCopy()
+
+	log := r.logger.With(
+		"kind", "NATSConsumer",
+		"namespace", consumer.GetNamespace(),
+		"name", consumer.GetName(),
+		"consumer", consumer.ConsumerName(),
+	)
+
+	if consumer.IsInDeletion() {
+		return r.handleConsumerDeletion(ctx, consumer, log)
+	}
+
+	if !controllerutil.ContainsFinalizer(consumer, NATSConsumerFinalizerName) {
+		controllerutil.AddFinalizer(consumer, NATSConsumerFinalizerName)
+		return kcontrollerruntime.Result{}, r.Update(ctx, consumer)
+	}
+
+	return r.handleConsumerReconcile(ctx, consumer, log)
+}
+
+func (r *ConsumerReconciler) handleConsumerReconcile(ctx context.Context, consumer *nmapiv1alpha1.NATSConsumer,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	// the consumer can only be created once the stream exists.
+	stream, err := r.getStream(ctx, consumer)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+	if stream == nil || stream.Status.State != nmapiv1alpha1.StateReady {
+		log.Infof("NATSStream is not ready, retrying in %d seconds", RequeueTimeForNATSNotReady)
+		consumer.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonStreamNotReady,
+			fmt.Sprintf(StreamNotReadyMsg, consumer.Spec.StreamRef.Name))
+		return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			r.syncConsumerStatus(ctx, consumer, log)
+	}
+
+	natsCluster, err := r.getNATS(ctx)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	natsClient, err := r.connect(ctx, natsCluster)
+	if errors.Is(err, ErrNATSNotReady) {
+		log.Infof("NATS cluster is not ready, retrying in %d seconds", RequeueTimeForNATSNotReady)
+		consumer.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonNATSNotReady,
+			fmt.Sprintf(NATSNotReadyMsg, r.natsCR.Namespace, r.natsCR.Name))
+		return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			r.syncConsumerStatus(ctx, consumer, log)
+	}
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncConsumerStatusWithErr(ctx, consumer, err, log)
+	}
+
+	streamName := stream.StreamName()
+	desired := toConsumerConfig(consumer)
+	info, err := natsClient.ConsumerInfo(streamName, desired.Durable)
+	switch {
+	case errors.Is(err, nats.ErrConsumerNotFound):
+		if info, err = natsClient.CreateConsumer(streamName, desired); err != nil {
+			return kcontrollerruntime.Result{}, r.syncConsumerStatusWithErr(ctx, consumer, err, log)
+		}
+		events.Normal(r.recorder, consumer, nmapiv1alpha1.ConditionReasonCreated,
+			ConsumerCreatedMsg, desired.Durable, streamName)
+		consumer.Status.SetStateReady(nmapiv1alpha1.ConditionReasonCreated, "")
+	case err != nil:
+		return kcontrollerruntime.Result{}, r.syncConsumerStatusWithErr(ctx, consumer, err, log)
+	default:
+		drift := consumerConfigDrift(desired, &info.Config)
+		if len(drift) == 0 {
+			consumer.Status.SetStateReady(nmapiv1alpha1.ConditionReasonInSync, "")
+			break
+		}
+		log.Infow("Consumer configuration drifted from the spec", "fields", drift)
+		if info, err = natsClient.UpdateConsumer(streamName, mergeConsumerConfig(desired, &info.Config)); err != nil {
+			return kcontrollerruntime.Result{}, r.syncConsumerStatusWithErr(ctx, consumer, err, log)
+		}
+		msg := fmt.Sprintf(ConsumerDriftCorrectedMsg, desired.Durable, strings.Join(drift, ", "))
+		events.Warn(r.recorder, consumer, nmapiv1alpha1.ConditionReasonDriftCorrected, msg)
+		consumer.Status.SetStateReady(nmapiv1alpha1.ConditionReasonDriftCorrected, msg)
+	}
+
+	consumer.Status.NumPending = info.NumPending
+	consumer.Status.NumAckPending = info.NumAckPending
+	consumer.Status.NumRedelivered = info.NumRedelivered
+
+	return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+		r.syncConsumerStatus(ctx, consumer, log)
+}
+
+func (r *ConsumerReconciler) handleConsumerDeletion(ctx context.Context, consumer *nmapiv1alpha1.NATSConsumer,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	// skip reconciliation for deletion if the finalizer is not set.
+	if !controllerutil.ContainsFinalizer(consumer, NATSConsumerFinalizerName) {
+		return kcontrollerruntime.Result{}, nil
+	}
+
+	stream, err := r.getStream(ctx, consumer)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+	natsCluster, err := r.getNATS(ctx)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	// consumers are deleted together with their stream.
+	if stream == nil || stream.IsInDeletion() || natsCluster == nil || natsCluster.IsInDeletion() {
+		return kcontrollerruntime.Result{}, r.removeConsumerFinalizer(ctx, consumer)
+	}
+
+	consumer.Status.SetStateDeleting()
+	natsClient, err := r.connect(ctx, natsCluster)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncConsumerStatusWithErr(ctx, consumer, err, log)
+	}
+
+	streamName := stream.StreamName()
+	err = natsClient.DeleteConsumer(streamName, consumer.ConsumerName())
+	if err != nil && !errors.Is(err, nats.ErrConsumerNotFound) && !errors.Is(err, nats.ErrStreamNotFound) {
+		return kcontrollerruntime.Result{}, r.syncConsumerStatusWithErr(ctx, consumer, err, log)
+	}
+	events.Normal(r.recorder, consumer, nmapiv1alpha1.ConditionReasonDeleting,
+		ConsumerDeletedMsg, consumer.ConsumerName(), streamName)
+	log.Info("Deleted consumer")
+
+	return kcontrollerruntime.Result{}, r.removeConsumerFinalizer(ctx, consumer)
+}
+
+// getStream returns the NATSStream the consumer is bound to.
+// Returns nil if the NATSStream does not exist.
+func (r *ConsumerReconciler) getStream(ctx context.Context,
+	consumer *nmapiv1alpha1.NATSConsumer,
+) (*nmapiv1alpha1.NATSStream, error) {
+	stream := &nmapiv1alpha1.NATSStream{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: consumer.Spec.StreamRef.Name, Namespace: consumer.Namespace}, stream)
+	if kapierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (r *ConsumerReconciler) removeConsumerFinalizer(ctx context.Context, consumer *nmapiv1alpha1.NATSConsumer) error {
+	controllerutil.RemoveFinalizer(consumer, NATSConsumerFinalizerName)
+	return r.Update(ctx, consumer)
+}
+
+// syncConsumerStatusWithErr sets the error state in the status and syncs it.
+// Returns the original error, so the controller triggers another reconciliation.
+func (r *ConsumerReconciler) syncConsumerStatusWithErr(ctx context.Context, consumer *nmapiv1alpha1.NATSConsumer,
+	err error, log *zap.SugaredLogger,
+) error {
+	consumer.Status.SetStateError(nmapiv1alpha1.ConditionReasonProcessingError, err.Error())
+	return errors.Join(err, r.syncConsumerStatus(ctx, consumer, log))
+}
+
+// syncConsumerStatus updates the status of the NATSConsumer if it was modified.
+func (r *ConsumerReconciler) syncConsumerStatus(ctx context.Context, consumer *nmapiv1alpha1.NATSConsumer,
+	log *zap.SugaredLogger,
+) error {
+	// fetch the latest object, to avoid k8s conflict errors.
+	actualConsumer := &nmapiv1alpha1.NATSConsumer{}
+	namespacedName := ktypes.NamespacedName{Name: consumer.Name, Namespace: consumer.Namespace}
+	if err := r.Get(ctx, namespacedName, actualConsumer); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if actualConsumer.Status.IsEqual(consumer.Status) {
+		return nil
+	}
+
+	desiredConsumer := actualConsumer.DeepCopy()
+	desiredConsumer.Status = consumer.Status
+	if err := r.Status().Update(ctx, desiredConsumer); err != nil {
+		return err
+	}
+
+	log.Debugw("Updated NATSConsumer status",
+		"oldStatus", actualConsumer.Status, "newStatus", desiredConsumer.Status)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ConsumerReconciler) SetupWithManager(mgr kcontrollerruntime.Manager) error {
+	return kcontrollerruntime.NewControllerManagedBy(mgr).
+		For(&nmapiv1alpha1.NATSConsumer{}).
+		Watches(
+			&nmapiv1alpha1.NATSStream{}, // watch for the streams the consumers are bound to.
+			handler.EnqueueRequestsFromMapFunc(r.consumersOfStream),
+		).
+		Complete(r)
+}
+
+// consumersOfStream returns reconcile requests for all NATSConsumers which are bound to the given NATSStream.
+func (r *ConsumerReconciler) consumersOfStream(ctx context.Context, obj client.Object) []reconcile.Request {
+	consumers := &nmapiv1alpha1.NATSConsumerList{}
+	if err := r.List(ctx, consumers, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.logger.Errorw("Failed to list NATSConsumers", "error", err)
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, consumer := range consumers.Items {
+		if consumer.Spec.StreamRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: ktypes.NamespacedName{
+				Name:      consumer.Name,
+				Namespace: consumer.Namespace,
+			}})
+		}
+	}
+	return requests
+}
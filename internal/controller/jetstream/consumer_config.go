@@ -0,0 +1,103 @@
+package jetstream
+
+import (
+	"slices"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/nats-io/nats.go"
+)
+
+// toConsumerConfig converts the spec of a NATSConsumer to a JetStream consumer configuration.
+func toConsumerConfig(consumer *nmapiv1alpha1.NATSConsumer) *nats.ConsumerConfig {
+	config := &nats.ConsumerConfig{
+		Durable:       consumer.ConsumerName(),
+		Description:   consumer.Spec.Description,
+		AckPolicy:     toAckPolicy(consumer.Spec.AckPolicy),
+		DeliverPolicy: toDeliverPolicy(consumer.Spec.DeliverPolicy),
+		MaxDeliver:    unlimited,
+	}
+	// NATS keeps a single filter subject in FilterSubject.
+	if len(consumer.Spec.FilterSubjects) == 1 {
+		config.FilterSubject = consumer.Spec.FilterSubjects[0]
+	} else {
+		config.FilterSubjects = consumer.Spec.FilterSubjects
+	}
+	if consumer.Spec.MaxDeliver > 0 {
+		config.MaxDeliver = consumer.Spec.MaxDeliver
+	}
+	for _, backoff := range consumer.Spec.Backoff {
+		config.BackOff = append(config.BackOff, backoff.Duration)
+	}
+	return config
+}
+
+func toAckPolicy(ackPolicy string) nats.AckPolicy {
+	switch ackPolicy {
+	case nmapiv1alpha1.AckPolicyNone:
+		return nats.AckNonePolicy
+	case nmapiv1alpha1.AckPolicyAll:
+		return nats.AckAllPolicy
+	default:
+		return nats.AckExplicitPolicy
+	}
+}
+
+func toDeliverPolicy(deliverPolicy string) nats.DeliverPolicy {
+	switch deliverPolicy {
+	case nmapiv1alpha1.DeliverPolicyLast:
+		return nats.DeliverLastPolicy
+	case nmapiv1alpha1.DeliverPolicyNew:
+		return nats.DeliverNewPolicy
+	case nmapiv1alpha1.DeliverPolicyLastPerSubject:
+		return nats.DeliverLastPerSubjectPolicy
+	default:
+		return nats.DeliverAllPolicy
+	}
+}
+
+// consumerConfigDrift returns the names of the spec fields for which the actual consumer configuration
+// differs from the desired one. Fields which are not managed by the NATSConsumer are ignored.
+func consumerConfigDrift(desired, actual *nats.ConsumerConfig) []string {
+	var drift []string
+	if desired.Description != actual.Description {
+		drift = append(drift, "description")
+	}
+	if desired.AckPolicy != actual.AckPolicy {
+		drift = append(drift, "ackPolicy")
+	}
+	if desired.DeliverPolicy != actual.DeliverPolicy {
+		drift = append(drift, "deliverPolicy")
+	}
+	if !sameElements(filterSubjects(desired), filterSubjects(actual)) {
+		drift = append(drift, "filterSubjects")
+	}
+	if desired.MaxDeliver != actual.MaxDeliver {
+		drift = append(drift, "maxDeliver")
+	}
+	if !slices.Equal(desired.BackOff, actual.BackOff) {
+		drift = append(drift, "backoff")
+	}
+	return drift
+}
+
+// mergeConsumerConfig returns a copy of the actual consumer configuration with all fields managed
+// by the NATSConsumer set to the desired values. Fields set by NATS itself are kept untouched.
+func mergeConsumerConfig(desired, actual *nats.ConsumerConfig) *nats.ConsumerConfig {
+	merged := *actual
+	merged.Description = desired.Description
+	merged.AckPolicy = desired.AckPolicy
+	merged.DeliverPolicy = desired.DeliverPolicy
+	merged.FilterSubject = desired.FilterSubject
+	merged.FilterSubjects = desired.FilterSubjects
+	merged.MaxDeliver = desired.MaxDeliver
+	merged.BackOff = slices.Clone(desired.BackOff)
+	return &merged
+}
+
+// filterSubjects returns all filter subjects of the consumer configuration.
+func filterSubjects(config *nats.ConsumerConfig) []string {
+	if config.FilterSubject != "" {
+		return []string{config.FilterSubject}
+	}
+	return config.FilterSubjects
+}
@@ -0,0 +1,129 @@
+package jetstream
+
+import (
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/testutils"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_toConsumerConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		givenSpec  nmapiv1alpha1.NATSConsumerSpec
+		wantConfig *natsgo.ConsumerConfig
+	}{
+		{
+			name:      "should use unlimited deliveries and defaults if not set",
+			givenSpec: nmapiv1alpha1.NATSConsumerSpec{},
+			wantConfig: &natsgo.ConsumerConfig{
+				Durable:       "billing",
+				AckPolicy:     natsgo.AckExplicitPolicy,
+				DeliverPolicy: natsgo.DeliverAllPolicy,
+				MaxDeliver:    unlimited,
+			},
+		},
+		{
+			name: "should convert all fields",
+			givenSpec: nmapiv1alpha1.NATSConsumerSpec{
+				Name:           "billing-v2",
+				Description:    "billing",
+				AckPolicy:      nmapiv1alpha1.AckPolicyAll,
+				DeliverPolicy:  nmapiv1alpha1.DeliverPolicyNew,
+				FilterSubjects: []string{"orders.created", "orders.cancelled"},
+				MaxDeliver:     5,
+				Backoff:        []kmetav1.Duration{{Duration: time.Second}},
+			},
+			wantConfig: &natsgo.ConsumerConfig{
+				Durable:        "billing-v2",
+				Description:    "billing",
+				AckPolicy:      natsgo.AckAllPolicy,
+				DeliverPolicy:  natsgo.DeliverNewPolicy,
+				FilterSubjects: []string{"orders.created", "orders.cancelled"},
+				MaxDeliver:     5,
+				BackOff:        []time.Duration{time.Second},
+			},
+		},
+		{
+			name: "should use the single filter subject field for one subject",
+			givenSpec: nmapiv1alpha1.NATSConsumerSpec{
+				FilterSubjects: []string{"orders.created"},
+			},
+			wantConfig: &natsgo.ConsumerConfig{
+				Durable:       "billing",
+				AckPolicy:     natsgo.AckExplicitPolicy,
+				DeliverPolicy: natsgo.DeliverAllPolicy,
+				FilterSubject: "orders.created",
+				MaxDeliver:    unlimited,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			consumer := testutils.NewNATSConsumerCR(
+				testutils.WithNATSConsumerName("billing"),
+				testutils.WithNATSConsumerSpec(tc.givenSpec),
+			)
+
+			// when, then
+			require.Equal(t, tc.wantConfig, toConsumerConfig(consumer))
+		})
+	}
+}
+
+func Test_consumerConfigDrift(t *testing.T) {
+	t.Parallel()
+
+	desired := &natsgo.ConsumerConfig{
+		Durable:        "billing",
+		AckPolicy:      natsgo.AckExplicitPolicy,
+		FilterSubjects: []string{"a", "b"},
+		MaxDeliver:     unlimited,
+	}
+
+	testCases := []struct {
+		name        string
+		givenActual func() *natsgo.ConsumerConfig
+		wantDrift   []string
+	}{
+		{
+			name: "should ignore the order of filter subjects and fields not managed by the NATSConsumer",
+			givenActual: func() *natsgo.ConsumerConfig {
+				actual := *desired
+				actual.FilterSubjects = []string{"b", "a"}
+				actual.AckWait = time.Minute
+				return &actual
+			},
+			wantDrift: nil,
+		},
+		{
+			name: "should report all drifted fields",
+			givenActual: func() *natsgo.ConsumerConfig {
+				actual := *desired
+				actual.FilterSubjects = nil
+				actual.FilterSubject = "a"
+				actual.MaxDeliver = 3
+				actual.BackOff = []time.Duration{time.Second}
+				return &actual
+			},
+			wantDrift: []string{"filterSubjects", "maxDeliver", "backoff"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.wantDrift, consumerConfigDrift(desired, tc.givenActual()))
+		})
+	}
+}
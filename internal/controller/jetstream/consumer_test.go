@@ -0,0 +1,343 @@
+package jetstream
+
+import (
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/testutils"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func Test_ConsumerReconcile(t *testing.T) {
+	t.Parallel()
+
+	const (
+		namespace    = "kyma-system"
+		streamName   = "orders"
+		consumerName = "billing"
+	)
+	desiredConfig := natsgo.ConsumerConfig{
+		Durable:       consumerName,
+		AckPolicy:     natsgo.AckExplicitPolicy,
+		DeliverPolicy: natsgo.DeliverAllPolicy,
+		FilterSubject: "orders.created",
+		MaxDeliver:    3,
+		BackOff:       []time.Duration{time.Second, time.Minute},
+	}
+	driftedConfig := desiredConfig
+	driftedConfig.MaxDeliver = 10
+	driftedConfig.BackOff = nil
+	driftedConfig.AckWait = time.Second
+	correctedConfig := desiredConfig
+	correctedConfig.AckWait = time.Second
+
+	testCases := []struct {
+		name                string
+		givenStream         *nmapiv1alpha1.NATSStream
+		givenMocks          func(*MockedUnitTestEnvironment)
+		wantResult          kcontrollerruntime.Result
+		wantErr             error
+		wantState           string
+		wantConditionStatus kmetav1.ConditionStatus
+		wantConditionReason nmapiv1alpha1.ConditionReason
+		wantNumPending      uint64
+		wantK8sEvents       []string
+	}{
+		{
+			name:                "should wait if the stream does not exist",
+			givenStream:         nil,
+			givenMocks:          func(*MockedUnitTestEnvironment) {},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			wantState:           nmapiv1alpha1.StateProcessing,
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonStreamNotReady,
+			wantK8sEvents:       []string{},
+		},
+		{
+			name: "should wait if the stream is not ready",
+			givenStream: testutils.NewNATSStreamCR(
+				testutils.WithNATSStreamName(streamName),
+				testutils.WithNATSStreamNamespace(namespace),
+				testutils.WithNATSStreamState(nmapiv1alpha1.StateProcessing),
+			),
+			givenMocks:          func(*MockedUnitTestEnvironment) {},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			wantState:           nmapiv1alpha1.StateProcessing,
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonStreamNotReady,
+			wantK8sEvents:       []string{},
+		},
+		{
+			name: "should create the consumer if it does not exist",
+			givenStream: testutils.NewNATSStreamCR(
+				testutils.WithNATSStreamName(streamName),
+				testutils.WithNATSStreamNamespace(namespace),
+				testutils.WithNATSStreamState(nmapiv1alpha1.StateReady),
+			),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("ConsumerInfo", streamName, consumerName).
+					Return(nil, natsgo.ErrConsumerNotFound)
+				testEnv.NatsClient.On("CreateConsumer", streamName, &desiredConfig).
+					Return(&natsgo.ConsumerInfo{Config: desiredConfig}, nil)
+			},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+			wantState:           nmapiv1alpha1.StateReady,
+			wantConditionStatus: kmetav1.ConditionTrue,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonCreated,
+			wantK8sEvents:       []string{"Normal Created Created consumer billing on stream orders"},
+		},
+		{
+			name: "should report the consumer info if the consumer is in sync",
+			givenStream: testutils.NewNATSStreamCR(
+				testutils.WithNATSStreamName(streamName),
+				testutils.WithNATSStreamNamespace(namespace),
+				testutils.WithNATSStreamState(nmapiv1alpha1.StateReady),
+			),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("ConsumerInfo", streamName, consumerName).Return(&natsgo.ConsumerInfo{
+					Config:         desiredConfig,
+					NumPending:     7,
+					NumAckPending:  2,
+					NumRedelivered: 1,
+				}, nil)
+			},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+			wantState:           nmapiv1alpha1.StateReady,
+			wantConditionStatus: kmetav1.ConditionTrue,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonInSync,
+			wantNumPending:      7,
+			wantK8sEvents:       []string{},
+		},
+		{
+			name: "should correct the drifted fields",
+			givenStream: testutils.NewNATSStreamCR(
+				testutils.WithNATSStreamName(streamName),
+				testutils.WithNATSStreamNamespace(namespace),
+				testutils.WithNATSStreamState(nmapiv1alpha1.StateReady),
+			),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("ConsumerInfo", streamName, consumerName).
+					Return(&natsgo.ConsumerInfo{Config: driftedConfig}, nil)
+				testEnv.NatsClient.On("UpdateConsumer", streamName, &correctedConfig).
+					Return(&natsgo.ConsumerInfo{Config: correctedConfig}, nil)
+			},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+			wantState:           nmapiv1alpha1.StateReady,
+			wantConditionStatus: kmetav1.ConditionTrue,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonDriftCorrected,
+			wantK8sEvents: []string{
+				"Warning DriftCorrected Corrected drift of consumer billing in: maxDeliver, backoff",
+			},
+		},
+		{
+			name: "should report an error if the consumer cannot be created",
+			givenStream: testutils.NewNATSStreamCR(
+				testutils.WithNATSStreamName(streamName),
+				testutils.WithNATSStreamNamespace(namespace),
+				testutils.WithNATSStreamState(nmapiv1alpha1.StateReady),
+			),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("ConsumerInfo", streamName, consumerName).
+					Return(nil, natsgo.ErrConsumerNotFound)
+				testEnv.NatsClient.On("CreateConsumer", streamName, mock.Anything).Return(nil, ErrJetStreamErrorMsg)
+			},
+			wantErr:             ErrJetStreamErrorMsg,
+			wantState:           nmapiv1alpha1.StateError,
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonProcessingError,
+			wantK8sEvents:       []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+			givenConsumer := testutils.NewNATSConsumerCR(
+				testutils.WithNATSConsumerName(consumerName),
+				testutils.WithNATSConsumerNamespace(namespace),
+				testutils.WithNATSConsumerFinalizer(NATSConsumerFinalizerName),
+				testutils.WithNATSConsumerSpec(nmapiv1alpha1.NATSConsumerSpec{
+					StreamRef:      nmapiv1alpha1.StreamReference{Name: streamName},
+					FilterSubjects: []string{"orders.created"},
+					MaxDeliver:     3,
+					Backoff:        []kmetav1.Duration{{Duration: time.Second}, {Duration: time.Minute}},
+				}),
+			)
+			objs := []client.Object{givenNATS, givenConsumer}
+			if tc.givenStream != nil {
+				objs = append(objs, tc.givenStream)
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS, objs...)
+			tc.givenMocks(testEnv)
+			reconciler := testEnv.NewConsumerReconciler()
+
+			// when
+			result, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+				NamespacedName: ktypes.NamespacedName{Name: consumerName, Namespace: namespace},
+			})
+
+			// then
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantResult, result)
+
+			gotConsumer := &nmapiv1alpha1.NATSConsumer{}
+			require.NoError(t, testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: consumerName, Namespace: namespace}, gotConsumer))
+			require.Equal(t, tc.wantState, gotConsumer.Status.State)
+			require.Equal(t, tc.wantNumPending, gotConsumer.Status.NumPending)
+			gotCondition := meta.FindStatusCondition(gotConsumer.Status.Conditions, string(nmapiv1alpha1.ConditionSynced))
+			require.NotNil(t, gotCondition)
+			require.Equal(t, tc.wantConditionStatus, gotCondition.Status)
+			require.Equal(t, string(tc.wantConditionReason), gotCondition.Reason)
+
+			require.Equal(t, tc.wantK8sEvents, testEnv.GetK8sEvents())
+			testEnv.NatsClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_handleConsumerDeletion(t *testing.T) {
+	t.Parallel()
+
+	const (
+		namespace    = "kyma-system"
+		streamName   = "orders"
+		consumerName = "billing"
+	)
+
+	testCases := []struct {
+		name                string
+		givenStreamExists   bool
+		givenMocks          func(*MockedUnitTestEnvironment)
+		wantErr             error
+		wantFinalizerExists bool
+		wantK8sEvents       []string
+	}{
+		{
+			name:              "should delete the consumer and remove the finalizer",
+			givenStreamExists: true,
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("DeleteConsumer", streamName, consumerName).Return(nil)
+			},
+			wantK8sEvents: []string{"Normal Deleting Deleted consumer billing from stream orders"},
+		},
+		{
+			name:              "should remove the finalizer if the stream does not exist",
+			givenStreamExists: false,
+			givenMocks:        func(*MockedUnitTestEnvironment) {},
+			wantK8sEvents:     []string{},
+		},
+		{
+			name:              "should keep the finalizer if the consumer cannot be deleted",
+			givenStreamExists: true,
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("DeleteConsumer", streamName, consumerName).Return(ErrJetStreamErrorMsg)
+			},
+			wantErr:             ErrJetStreamErrorMsg,
+			wantFinalizerExists: true,
+			wantK8sEvents:       []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+			givenConsumer := testutils.NewNATSConsumerCR(
+				testutils.WithNATSConsumerName(consumerName),
+				testutils.WithNATSConsumerNamespace(namespace),
+				testutils.WithNATSConsumerStreamRef(streamName),
+				testutils.WithNATSConsumerFinalizer(NATSConsumerFinalizerName),
+				testutils.WithNATSConsumerDeletionTimestamp(),
+			)
+			objs := []client.Object{givenNATS, givenConsumer}
+			if tc.givenStreamExists {
+				objs = append(objs, testutils.NewNATSStreamCR(
+					testutils.WithNATSStreamName(streamName),
+					testutils.WithNATSStreamNamespace(namespace),
+				))
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS, objs...)
+			tc.givenMocks(testEnv)
+			reconciler := testEnv.NewConsumerReconciler()
+
+			// when
+			_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+				NamespacedName: ktypes.NamespacedName{Name: consumerName, Namespace: namespace},
+			})
+
+			// then
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			gotConsumer := &nmapiv1alpha1.NATSConsumer{}
+			err = testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: consumerName, Namespace: namespace}, gotConsumer)
+			if tc.wantFinalizerExists {
+				require.NoError(t, err)
+				require.True(t, controllerutil.ContainsFinalizer(gotConsumer, NATSConsumerFinalizerName))
+			} else {
+				require.True(t, kapierrors.IsNotFound(err))
+			}
+
+			require.Equal(t, tc.wantK8sEvents, testEnv.GetK8sEvents())
+			testEnv.NatsClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_consumersOfStream(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR()
+	boundConsumer := testutils.NewNATSConsumerCR(
+		testutils.WithNATSConsumerNamespace("kyma-system"),
+		testutils.WithNATSConsumerStreamRef("orders"),
+	)
+	otherConsumer := testutils.NewNATSConsumerCR(
+		testutils.WithNATSConsumerNamespace("kyma-system"),
+		testutils.WithNATSConsumerStreamRef("returns"),
+	)
+	stream := testutils.NewNATSStreamCR(
+		testutils.WithNATSStreamName("orders"),
+		testutils.WithNATSStreamNamespace("kyma-system"),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, boundConsumer, otherConsumer)
+	reconciler := testEnv.NewConsumerReconciler()
+
+	// when
+	requests := reconciler.consumersOfStream(testEnv.Context, stream)
+
+	// then
+	require.Len(t, requests, 1)
+	require.Equal(t, boundConsumer.Name, requests[0].Name)
+}
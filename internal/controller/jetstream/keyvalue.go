@@ -0,0 +1,209 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	NATSKeyValueFinalizerName = "natskeyvalue.operator.kyma-project.io/finalizer"
+
+	KeyValueCreatedMsg        = "Created key-value bucket %s"
+	KeyValueDriftCorrectedMsg = "Corrected drift of key-value bucket %s in: %s"
+	KeyValueDeletedMsg        = "Deleted key-value bucket %s"
+)
+
+// KeyValueReconciler reconciles a NATSKeyValue object.
+type KeyValueReconciler struct {
+	*natsConnector
+	recorder record.EventRecorder
+	logger   *zap.SugaredLogger
+}
+
+func NewKeyValueReconciler(
+	client client.Client,
+	logger *zap.SugaredLogger,
+	recorder record.EventRecorder,
+	natsCR *nmapiv1alpha1.NATS,
+) *KeyValueReconciler {
+	return &KeyValueReconciler{
+		natsConnector: newNATSConnector(client, natsCR),
+		recorder:      recorder,
+		logger:        logger,
+	}
+}
+
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natskeyvalues,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natskeyvalues/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natskeyvalues/finalizers,verbs=update
+
+func (r *KeyValueReconciler) Reconcile(ctx context.Context,
+	req kcontrollerruntime.Request,
+) (kcontrollerruntime.Result, error) {
+	currentKeyValue := &nmapiv1alpha1.NATSKeyValue{}
+	if err := r.Get(ctx, req.NamespacedName, currentKeyValue); err != nil {
+		return kcontrollerruntime.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Copy the object, so we don't modify the source object.
+	kv := currentKeyValue.DeepCopy()
+
+	log := r.logger.With(
+		"kind", "NATSKeyValue",
+		"namespace", kv.GetNamespace(),
+		"name", kv.GetName(),
+		"bucket", kv.BucketName(),
+	)
+
+	if kv.IsInDeletion() {
+		return r.handleKeyValueDeletion(ctx, kv, log)
+	}
+
+	if !controllerutil.ContainsFinalizer(kv, NATSKeyValueFinalizerName) {
+		controllerutil.AddFinalizer(kv, NATSKeyValueFinalizerName)
+		return kcontrollerruntime.Result{}, r.Update(ctx, kv)
+	}
+
+	return r.handleKeyValueReconcile(ctx, kv, log)
+}
+
+func (r *KeyValueReconciler) handleKeyValueReconcile(ctx context.Context, kv *nmapiv1alpha1.NATSKeyValue,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	natsCluster, err := r.getNATS(ctx)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	natsClient, err := r.connect(ctx, natsCluster)
+	if errors.Is(err, ErrNATSNotReady) {
+		log.Infof("NATS cluster is not ready, retrying in %d seconds", RequeueTimeForNATSNotReady)
+		kv.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonNATSNotReady,
+			fmt.Sprintf(NATSNotReadyMsg, r.natsCR.Namespace, r.natsCR.Name))
+		return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			r.syncKeyValueStatus(ctx, kv, log)
+	}
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncKeyValueStatusWithErr(ctx, kv, err, log)
+	}
+
+	config := toKeyValueConfig(kv)
+	info, created, drift, err := reconcileBackingStream(natsClient, keyValueStreamConfig(config), func() error {
+		return natsClient.CreateKeyValue(config)
+	})
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncKeyValueStatusWithErr(ctx, kv, err, log)
+	}
+
+	switch {
+	case created:
+		events.Normal(r.recorder, kv, nmapiv1alpha1.ConditionReasonCreated, KeyValueCreatedMsg, config.Bucket)
+		kv.Status.SetStateReady(nmapiv1alpha1.ConditionReasonCreated, "")
+	case len(drift) > 0:
+		log.Infow("Key-value bucket configuration drifted from the spec", "fields", drift)
+		msg := fmt.Sprintf(KeyValueDriftCorrectedMsg, config.Bucket, strings.Join(drift, ", "))
+		events.Warn(r.recorder, kv, nmapiv1alpha1.ConditionReasonDriftCorrected, msg)
+		kv.Status.SetStateReady(nmapiv1alpha1.ConditionReasonDriftCorrected, msg)
+	default:
+		kv.Status.SetStateReady(nmapiv1alpha1.ConditionReasonInSync, "")
+	}
+
+	kv.Status.Values = info.State.Msgs
+	kv.Status.Bytes = info.State.Bytes
+
+	return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+		r.syncKeyValueStatus(ctx, kv, log)
+}
+
+func (r *KeyValueReconciler) handleKeyValueDeletion(ctx context.Context, kv *nmapiv1alpha1.NATSKeyValue,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	// skip reconciliation for deletion if the finalizer is not set.
+	if !controllerutil.ContainsFinalizer(kv, NATSKeyValueFinalizerName) {
+		return kcontrollerruntime.Result{}, nil
+	}
+
+	natsCluster, err := r.getNATS(ctx)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	// if the NATS cluster is gone, the bucket is gone as well.
+	if natsCluster == nil || natsCluster.IsInDeletion() {
+		return kcontrollerruntime.Result{}, r.removeKeyValueFinalizer(ctx, kv)
+	}
+
+	kv.Status.SetStateDeleting()
+	natsClient, err := r.connect(ctx, natsCluster)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncKeyValueStatusWithErr(ctx, kv, err, log)
+	}
+
+	err = natsClient.DeleteKeyValue(kv.BucketName())
+	if err != nil && !errors.Is(err, nats.ErrBucketNotFound) && !errors.Is(err, nats.ErrStreamNotFound) {
+		return kcontrollerruntime.Result{}, r.syncKeyValueStatusWithErr(ctx, kv, err, log)
+	}
+	events.Normal(r.recorder, kv, nmapiv1alpha1.ConditionReasonDeleting, KeyValueDeletedMsg, kv.BucketName())
+	log.Info("Deleted key-value bucket")
+
+	return kcontrollerruntime.Result{}, r.removeKeyValueFinalizer(ctx, kv)
+}
+
+func (r *KeyValueReconciler) removeKeyValueFinalizer(ctx context.Context, kv *nmapiv1alpha1.NATSKeyValue) error {
+	controllerutil.RemoveFinalizer(kv, NATSKeyValueFinalizerName)
+	return r.Update(ctx, kv)
+}
+
+// syncKeyValueStatusWithErr sets the error state in the status and syncs it.
+// Returns the original error, so the controller triggers another reconciliation.
+func (r *KeyValueReconciler) syncKeyValueStatusWithErr(ctx context.Context, kv *nmapiv1alpha1.NATSKeyValue,
+	err error, log *zap.SugaredLogger,
+) error {
+	kv.Status.SetStateError(nmapiv1alpha1.ConditionReasonProcessingError, err.Error())
+	return errors.Join(err, r.syncKeyValueStatus(ctx, kv, log))
+}
+
+// syncKeyValueStatus updates the status of the NATSKeyValue if it was modified.
+func (r *KeyValueReconciler) syncKeyValueStatus(ctx context.Context, kv *nmapiv1alpha1.NATSKeyValue,
+	log *zap.SugaredLogger,
+) error {
+	// fetch the latest object, to avoid k8s conflict errors.
+	actualKeyValue := &nmapiv1alpha1.NATSKeyValue{}
+	if err := r.Get(ctx, ktypes.NamespacedName{Name: kv.Name, Namespace: kv.Namespace}, actualKeyValue); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if actualKeyValue.Status.IsEqual(kv.Status) {
+		return nil
+	}
+
+	desiredKeyValue := actualKeyValue.DeepCopy()
+	desiredKeyValue.Status = kv.Status
+	if err := r.Status().Update(ctx, desiredKeyValue); err != nil {
+		return err
+	}
+
+	log.Debugw("Updated NATSKeyValue status",
+		"oldStatus", actualKeyValue.Status, "newStatus", desiredKeyValue.Status)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KeyValueReconciler) SetupWithManager(mgr kcontrollerruntime.Manager) error {
+	return kcontrollerruntime.NewControllerManagedBy(mgr).
+		For(&nmapiv1alpha1.NATSKeyValue{}).
+		Complete(r)
+}
@@ -0,0 +1,256 @@
+package jetstream
+
+import (
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/testutils"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func Test_KeyValueReconcile(t *testing.T) {
+	t.Parallel()
+
+	bucketName := "sessions"
+	streamName := "KV_sessions"
+	desiredKeyValueConfig := &natsgo.KeyValueConfig{
+		Bucket:   bucketName,
+		History:  1,
+		Replicas: 1,
+		MaxBytes: unlimited,
+		Storage:  natsgo.FileStorage,
+	}
+	desiredStreamConfig := natsgo.StreamConfig{
+		Name:              streamName,
+		Subjects:          []string{"$KV.sessions.>"},
+		MaxMsgsPerSubject: 1,
+		Replicas:          1,
+		MaxBytes:          unlimited,
+		Storage:           natsgo.FileStorage,
+		Duplicates:        2 * time.Minute,
+	}
+	driftedStreamConfig := desiredStreamConfig
+	driftedStreamConfig.MaxMsgsPerSubject = 5
+	driftedStreamConfig.MaxAge = time.Minute
+
+	testCases := []struct {
+		name                string
+		givenNATS           *nmapiv1alpha1.NATS
+		givenMocks          func(*MockedUnitTestEnvironment)
+		wantResult          kcontrollerruntime.Result
+		wantErr             error
+		wantState           string
+		wantConditionReason nmapiv1alpha1.ConditionReason
+		wantValues          uint64
+		wantK8sEvents       []string
+	}{
+		{
+			name:                "should wait if NATS cluster is not ready",
+			givenNATS:           testutils.NewNATSCR(testutils.WithNATSStateProcessing()),
+			givenMocks:          func(*MockedUnitTestEnvironment) {},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			wantState:           nmapiv1alpha1.StateProcessing,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonNATSNotReady,
+			wantK8sEvents:       []string{},
+		},
+		{
+			name:      "should create the bucket if it does not exist",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("StreamInfo", streamName).Return(nil, natsgo.ErrStreamNotFound).Once()
+				testEnv.NatsClient.On("CreateKeyValue", desiredKeyValueConfig).Return(nil)
+				testEnv.NatsClient.On("StreamInfo", streamName).Return(&natsgo.StreamInfo{
+					Config: desiredStreamConfig,
+				}, nil).Once()
+			},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+			wantState:           nmapiv1alpha1.StateReady,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonCreated,
+			wantK8sEvents:       []string{"Normal Created Created key-value bucket sessions"},
+		},
+		{
+			name:      "should report the bucket state if the bucket is in sync",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("StreamInfo", streamName).Return(&natsgo.StreamInfo{
+					Config: desiredStreamConfig,
+					State:  natsgo.StreamState{Msgs: 7},
+				}, nil)
+			},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+			wantState:           nmapiv1alpha1.StateReady,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonInSync,
+			wantValues:          7,
+			wantK8sEvents:       []string{},
+		},
+		{
+			name:      "should correct the drifted fields of the backing stream",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("StreamInfo", streamName).Return(&natsgo.StreamInfo{
+					Config: driftedStreamConfig,
+				}, nil)
+				testEnv.NatsClient.On("UpdateStream", &desiredStreamConfig).Return(&natsgo.StreamInfo{
+					Config: desiredStreamConfig,
+				}, nil)
+			},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+			wantState:           nmapiv1alpha1.StateReady,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonDriftCorrected,
+			wantK8sEvents: []string{
+				"Warning DriftCorrected Corrected drift of key-value bucket sessions in: history, ttl",
+			},
+		},
+		{
+			name:      "should report an error if the bucket cannot be created",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("StreamInfo", streamName).Return(nil, natsgo.ErrStreamNotFound)
+				testEnv.NatsClient.On("CreateKeyValue", mock.Anything).Return(ErrJetStreamErrorMsg)
+			},
+			wantErr:             ErrJetStreamErrorMsg,
+			wantState:           nmapiv1alpha1.StateError,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonProcessingError,
+			wantK8sEvents:       []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenKeyValue := testutils.NewNATSKeyValueCR(
+				testutils.WithNATSKeyValueName(bucketName),
+				testutils.WithNATSKeyValueFinalizer(NATSKeyValueFinalizerName),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, tc.givenNATS, tc.givenNATS, givenKeyValue)
+			tc.givenMocks(testEnv)
+			reconciler := testEnv.NewKeyValueReconciler()
+
+			// when
+			result, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+				NamespacedName: ktypes.NamespacedName{Name: givenKeyValue.Name, Namespace: givenKeyValue.Namespace},
+			})
+
+			// then
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantResult, result)
+
+			gotKeyValue := &nmapiv1alpha1.NATSKeyValue{}
+			require.NoError(t, testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: givenKeyValue.Name, Namespace: givenKeyValue.Namespace}, gotKeyValue))
+			require.Equal(t, tc.wantState, gotKeyValue.Status.State)
+			require.Equal(t, tc.wantValues, gotKeyValue.Status.Values)
+			gotCondition := meta.FindStatusCondition(gotKeyValue.Status.Conditions,
+				string(nmapiv1alpha1.ConditionSynced))
+			require.NotNil(t, gotCondition)
+			require.Equal(t, tc.wantState == nmapiv1alpha1.StateReady,
+				gotCondition.Status == kmetav1.ConditionTrue)
+			require.Equal(t, string(tc.wantConditionReason), gotCondition.Reason)
+
+			require.Equal(t, tc.wantK8sEvents, testEnv.GetK8sEvents())
+			testEnv.NatsClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_handleKeyValueDeletion(t *testing.T) {
+	t.Parallel()
+
+	bucketName := "sessions"
+
+	testCases := []struct {
+		name                string
+		givenMocks          func(*MockedUnitTestEnvironment)
+		wantErr             error
+		wantFinalizerExists bool
+		wantK8sEvents       []string
+	}{
+		{
+			name: "should delete the bucket and remove the finalizer",
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("DeleteKeyValue", bucketName).Return(nil)
+			},
+			wantK8sEvents: []string{"Normal Deleting Deleted key-value bucket sessions"},
+		},
+		{
+			name: "should remove the finalizer if the bucket does not exist anymore",
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("DeleteKeyValue", bucketName).Return(natsgo.ErrBucketNotFound)
+			},
+			wantK8sEvents: []string{"Normal Deleting Deleted key-value bucket sessions"},
+		},
+		{
+			name: "should keep the finalizer if the bucket cannot be deleted",
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("DeleteKeyValue", bucketName).Return(ErrJetStreamErrorMsg)
+			},
+			wantErr:             ErrJetStreamErrorMsg,
+			wantFinalizerExists: true,
+			wantK8sEvents:       []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+			givenKeyValue := testutils.NewNATSKeyValueCR(
+				testutils.WithNATSKeyValueName(bucketName),
+				testutils.WithNATSKeyValueFinalizer(NATSKeyValueFinalizerName),
+				testutils.WithNATSKeyValueDeletionTimestamp(),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, givenKeyValue)
+			tc.givenMocks(testEnv)
+			reconciler := testEnv.NewKeyValueReconciler()
+
+			// when
+			_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+				NamespacedName: ktypes.NamespacedName{Name: givenKeyValue.Name, Namespace: givenKeyValue.Namespace},
+			})
+
+			// then
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			gotKeyValue := &nmapiv1alpha1.NATSKeyValue{}
+			err = testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: givenKeyValue.Name, Namespace: givenKeyValue.Namespace}, gotKeyValue)
+			if tc.wantFinalizerExists {
+				require.NoError(t, err)
+				require.True(t, controllerutil.ContainsFinalizer(gotKeyValue, NATSKeyValueFinalizerName))
+			} else {
+				require.True(t, kapierrors.IsNotFound(err))
+			}
+
+			require.Equal(t, tc.wantK8sEvents, testEnv.GetK8sEvents())
+			testEnv.NatsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmctrlclientconfig "github.com/kyma-project/nats-manager/internal/controller/nats/clientconfig"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RequeueTimeForDriftCheck defines the time in seconds after which the JetStream
+	// resources are compared again with the declared state.
+	RequeueTimeForDriftCheck = 60
+	// RequeueTimeForNATSNotReady defines the time in seconds after which the reconciliation
+	// is retried if the NATS cluster is not ready yet.
+	RequeueTimeForNATSNotReady = 10
+
+	NATSNotReadyMsg = "NATS cluster %s/%s is not ready"
+)
+
+var ErrNATSNotReady = errors.New("NATS cluster is not ready")
+
+// natsConnector provides connected NATS clients for the NATS cluster which hosts the JetStream resources.
+type natsConnector struct {
+	client.Client
+	natsCR        *nmapiv1alpha1.NATS
+	natsClients   map[string]nmnats.Client
+	newNatsClient func(*nmnats.Config) nmnats.Client
+}
+
+func newNATSConnector(k8sClient client.Client, natsCR *nmapiv1alpha1.NATS) *natsConnector {
+	return &natsConnector{
+		Client:        k8sClient,
+		natsCR:        natsCR,
+		natsClients:   make(map[string]nmnats.Client),
+		newNatsClient: nmnats.NewNatsClient,
+	}
+}
+
+// getNATS returns the NATS CR which hosts the JetStream resources.
+// Returns nil if the NATS CR does not exist.
+func (c *natsConnector) getNATS(ctx context.Context) (*nmapiv1alpha1.NATS, error) {
+	nats := &nmapiv1alpha1.NATS{}
+	err := c.Get(ctx, ktypes.NamespacedName{Name: c.natsCR.Name, Namespace: c.natsCR.Namespace}, nats)
+	if kapierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nats, nil
+}
+
+// connect returns a connected NATS client for the given NATS CR.
+// Returns ErrNATSNotReady if the NATS cluster cannot serve JetStream requests yet.
+func (c *natsConnector) connect(ctx context.Context, nats *nmapiv1alpha1.NATS) (nmnats.Client, error) {
+	if nats == nil || nats.IsInDeletion() ||
+		(nats.Status.State != nmapiv1alpha1.StateReady && nats.Status.State != nmapiv1alpha1.StateWarning) {
+		return nil, ErrNATSNotReady
+	}
+
+	crKey := nats.Namespace + "/" + nats.Name
+	if c.natsClients[crKey] == nil {
+		config, err := nmctrlclientconfig.New(ctx, c.Client, nats)
+		if err != nil {
+			return nil, errors.Join(ErrNATSNotReady, err)
+		}
+		c.natsClients[crKey] = c.newNatsClient(config)
+	}
+	if err := c.natsClients[crKey].Init(); err != nil {
+		return nil, errors.Join(ErrNATSNotReady, err)
+	}
+	return c.natsClients[crKey], nil
+}
+
+// connectSystem returns a connected NATS client with the user of the system account for the given NATS CR.
+// It is not cached, so the caller closes it.
+func (c *natsConnector) connectSystem(ctx context.Context, nats *nmapiv1alpha1.NATS) (nmnats.Client, error) {
+	config, err := nmctrlclientconfig.NewSystem(ctx, c.Client, nats)
+	if err != nil {
+		return nil, err
+	}
+	systemClient := c.newNatsClient(config)
+	if err = systemClient.Init(); err != nil {
+		return nil, err
+	}
+	return systemClient, nil
+}
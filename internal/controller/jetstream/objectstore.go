@@ -0,0 +1,212 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	NATSObjectStoreFinalizerName = "natsobjectstore.operator.kyma-project.io/finalizer"
+
+	ObjectStoreCreatedMsg        = "Created object store bucket %s"
+	ObjectStoreDriftCorrectedMsg = "Corrected drift of object store bucket %s in: %s"
+	ObjectStoreDeletedMsg        = "Deleted object store bucket %s"
+)
+
+// ObjectStoreReconciler reconciles a NATSObjectStore object.
+type ObjectStoreReconciler struct {
+	*natsConnector
+	recorder record.EventRecorder
+	logger   *zap.SugaredLogger
+}
+
+func NewObjectStoreReconciler(
+	client client.Client,
+	logger *zap.SugaredLogger,
+	recorder record.EventRecorder,
+	natsCR *nmapiv1alpha1.NATS,
+) *ObjectStoreReconciler {
+	return &ObjectStoreReconciler{
+		natsConnector: newNATSConnector(client, natsCR),
+		recorder:      recorder,
+		logger:        logger,
+	}
+}
+
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsobjectstores,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsobjectstores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsobjectstores/finalizers,verbs=update
+
+func (r *ObjectStoreReconciler) Reconcile(ctx context.Context,
+	req kcontrollerruntime.Request,
+) (kcontrollerruntime.Result, error) {
+	currentObjectStore := &nmapiv1alpha1.NATSObjectStore{}
+	if err := r.Get(ctx, req.NamespacedName, currentObjectStore); err != nil {
+		return kcontrollerruntime.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Copy the object, so we don't modify the source object.
+	objectStore := currentObjectStore.DeepCopy()
+
+	log := r.logger.With(
+		"kind", "NATSObjectStore",
+		"namespace", objectStore.GetNamespace(),
+		"name", objectStore.GetName(),
+		"bucket", objectStore.BucketName(),
+	)
+
+	if objectStore.IsInDeletion() {
+		return r.handleObjectStoreDeletion(ctx, objectStore, log)
+	}
+
+	if !controllerutil.ContainsFinalizer(objectStore, NATSObjectStoreFinalizerName) {
+		controllerutil.AddFinalizer(objectStore, NATSObjectStoreFinalizerName)
+		return kcontrollerruntime.Result{}, r.Update(ctx, objectStore)
+	}
+
+	return r.handleObjectStoreReconcile(ctx, objectStore, log)
+}
+
+func (r *ObjectStoreReconciler) handleObjectStoreReconcile(ctx context.Context,
+	objectStore *nmapiv1alpha1.NATSObjectStore, log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	natsCluster, err := r.getNATS(ctx)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	natsClient, err := r.connect(ctx, natsCluster)
+	if errors.Is(err, ErrNATSNotReady) {
+		log.Infof("NATS cluster is not ready, retrying in %d seconds", RequeueTimeForNATSNotReady)
+		objectStore.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonNATSNotReady,
+			fmt.Sprintf(NATSNotReadyMsg, r.natsCR.Namespace, r.natsCR.Name))
+		return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			r.syncObjectStoreStatus(ctx, objectStore, log)
+	}
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncObjectStoreStatusWithErr(ctx, objectStore, err, log)
+	}
+
+	config := toObjectStoreConfig(objectStore)
+	info, created, drift, err := reconcileBackingStream(natsClient, objectStoreStreamConfig(config), func() error {
+		return natsClient.CreateObjectStore(config)
+	})
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncObjectStoreStatusWithErr(ctx, objectStore, err, log)
+	}
+
+	switch {
+	case created:
+		events.Normal(r.recorder, objectStore, nmapiv1alpha1.ConditionReasonCreated, ObjectStoreCreatedMsg, config.Bucket)
+		objectStore.Status.SetStateReady(nmapiv1alpha1.ConditionReasonCreated, "")
+	case len(drift) > 0:
+		log.Infow("Object store bucket configuration drifted from the spec", "fields", drift)
+		msg := fmt.Sprintf(ObjectStoreDriftCorrectedMsg, config.Bucket, strings.Join(drift, ", "))
+		events.Warn(r.recorder, objectStore, nmapiv1alpha1.ConditionReasonDriftCorrected, msg)
+		objectStore.Status.SetStateReady(nmapiv1alpha1.ConditionReasonDriftCorrected, msg)
+	default:
+		objectStore.Status.SetStateReady(nmapiv1alpha1.ConditionReasonInSync, "")
+	}
+
+	objectStore.Status.Bytes = info.State.Bytes
+
+	return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+		r.syncObjectStoreStatus(ctx, objectStore, log)
+}
+
+func (r *ObjectStoreReconciler) handleObjectStoreDeletion(ctx context.Context,
+	objectStore *nmapiv1alpha1.NATSObjectStore, log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	// skip reconciliation for deletion if the finalizer is not set.
+	if !controllerutil.ContainsFinalizer(objectStore, NATSObjectStoreFinalizerName) {
+		return kcontrollerruntime.Result{}, nil
+	}
+
+	natsCluster, err := r.getNATS(ctx)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	// if the NATS cluster is gone, the bucket is gone as well.
+	if natsCluster == nil || natsCluster.IsInDeletion() {
+		return kcontrollerruntime.Result{}, r.removeObjectStoreFinalizer(ctx, objectStore)
+	}
+
+	objectStore.Status.SetStateDeleting()
+	natsClient, err := r.connect(ctx, natsCluster)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncObjectStoreStatusWithErr(ctx, objectStore, err, log)
+	}
+
+	err = natsClient.DeleteObjectStore(objectStore.BucketName())
+	if err != nil && !errors.Is(err, nats.ErrStreamNotFound) {
+		return kcontrollerruntime.Result{}, r.syncObjectStoreStatusWithErr(ctx, objectStore, err, log)
+	}
+	events.Normal(r.recorder, objectStore, nmapiv1alpha1.ConditionReasonDeleting,
+		ObjectStoreDeletedMsg, objectStore.BucketName())
+	log.Info("Deleted object store bucket")
+
+	return kcontrollerruntime.Result{}, r.removeObjectStoreFinalizer(ctx, objectStore)
+}
+
+func (r *ObjectStoreReconciler) removeObjectStoreFinalizer(ctx context.Context,
+	objectStore *nmapiv1alpha1.NATSObjectStore,
+) error {
+	controllerutil.RemoveFinalizer(objectStore, NATSObjectStoreFinalizerName)
+	return r.Update(ctx, objectStore)
+}
+
+// syncObjectStoreStatusWithErr sets the error state in the status and syncs it.
+// Returns the original error, so the controller triggers another reconciliation.
+func (r *ObjectStoreReconciler) syncObjectStoreStatusWithErr(ctx context.Context,
+	objectStore *nmapiv1alpha1.NATSObjectStore, err error, log *zap.SugaredLogger,
+) error {
+	objectStore.Status.SetStateError(nmapiv1alpha1.ConditionReasonProcessingError, err.Error())
+	return errors.Join(err, r.syncObjectStoreStatus(ctx, objectStore, log))
+}
+
+// syncObjectStoreStatus updates the status of the NATSObjectStore if it was modified.
+func (r *ObjectStoreReconciler) syncObjectStoreStatus(ctx context.Context, objectStore *nmapiv1alpha1.NATSObjectStore,
+	log *zap.SugaredLogger,
+) error {
+	// fetch the latest object, to avoid k8s conflict errors.
+	actualObjectStore := &nmapiv1alpha1.NATSObjectStore{}
+	namespacedName := ktypes.NamespacedName{Name: objectStore.Name, Namespace: objectStore.Namespace}
+	if err := r.Get(ctx, namespacedName, actualObjectStore); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if actualObjectStore.Status.IsEqual(objectStore.Status) {
+		return nil
+	}
+
+	desiredObjectStore := actualObjectStore.DeepCopy()
+	desiredObjectStore.Status = objectStore.Status
+	if err := r.Status().Update(ctx, desiredObjectStore); err != nil {
+		return err
+	}
+
+	log.Debugw("Updated NATSObjectStore status",
+		"oldStatus", actualObjectStore.Status, "newStatus", desiredObjectStore.Status)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ObjectStoreReconciler) SetupWithManager(mgr kcontrollerruntime.Manager) error {
+	return kcontrollerruntime.NewControllerManagedBy(mgr).
+		For(&nmapiv1alpha1.NATSObjectStore{}).
+		Complete(r)
+}
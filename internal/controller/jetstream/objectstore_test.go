@@ -0,0 +1,85 @@
+package jetstream
+
+import (
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/testutils"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+)
+
+func Test_ObjectStoreReconcile_CreatesBucket(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+	givenObjectStore := testutils.NewNATSObjectStoreCR(
+		testutils.WithNATSObjectStoreName("artifacts"),
+		testutils.WithNATSObjectStoreFinalizer(NATSObjectStoreFinalizerName),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, givenObjectStore)
+	testEnv.NatsClient.On("Init").Return(nil)
+	testEnv.NatsClient.On("StreamInfo", "OBJ_artifacts").Return(nil, natsgo.ErrStreamNotFound).Once()
+	testEnv.NatsClient.On("CreateObjectStore", &natsgo.ObjectStoreConfig{
+		Bucket:   "artifacts",
+		Replicas: 1,
+		MaxBytes: unlimited,
+		Storage:  natsgo.FileStorage,
+	}).Return(nil)
+	testEnv.NatsClient.On("StreamInfo", "OBJ_artifacts").Return(&natsgo.StreamInfo{
+		Config: natsgo.StreamConfig{Name: "OBJ_artifacts", Replicas: 1, MaxBytes: unlimited},
+		State:  natsgo.StreamState{Bytes: 1024},
+	}, nil).Once()
+	reconciler := testEnv.NewObjectStoreReconciler()
+
+	// when
+	result, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenObjectStore.Name, Namespace: givenObjectStore.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second}, result)
+	gotObjectStore := &nmapiv1alpha1.NATSObjectStore{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenObjectStore.Name, Namespace: givenObjectStore.Namespace}, gotObjectStore))
+	require.Equal(t, nmapiv1alpha1.StateReady, gotObjectStore.Status.State)
+	require.Equal(t, uint64(1024), gotObjectStore.Status.Bytes)
+	require.Equal(t, []string{"Normal Created Created object store bucket artifacts"}, testEnv.GetK8sEvents())
+	testEnv.NatsClient.AssertExpectations(t)
+}
+
+func Test_handleObjectStoreDeletion(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+	givenObjectStore := testutils.NewNATSObjectStoreCR(
+		testutils.WithNATSObjectStoreName("artifacts"),
+		testutils.WithNATSObjectStoreFinalizer(NATSObjectStoreFinalizerName),
+		testutils.WithNATSObjectStoreDeletionTimestamp(),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, givenObjectStore)
+	testEnv.NatsClient.On("Init").Return(nil)
+	testEnv.NatsClient.On("DeleteObjectStore", "artifacts").Return(natsgo.ErrStreamNotFound)
+	reconciler := testEnv.NewObjectStoreReconciler()
+
+	// when
+	_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenObjectStore.Name, Namespace: givenObjectStore.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	err = testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenObjectStore.Name, Namespace: givenObjectStore.Namespace},
+		&nmapiv1alpha1.NATSObjectStore{})
+	require.True(t, kapierrors.IsNotFound(err))
+	require.Equal(t, []string{"Normal Deleting Deleted object store bucket artifacts"}, testEnv.GetK8sEvents())
+	testEnv.NatsClient.AssertExpectations(t)
+}
@@ -0,0 +1,268 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/backup"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	"go.uber.org/zap"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// RequeueTimeForBackupNotReady defines the time in seconds after which the restore is retried
+	// if the backup is not completed yet or its snapshots are missing.
+	RequeueTimeForBackupNotReady = 10
+
+	BackupNotReadyMsg    = "NATSBackup %s/%s is not ready"
+	StreamNotInBackupMsg = "Stream %s is not in NATSBackup %s/%s"
+	RestoreStartedMsg    = "Restoring %d streams"
+	RestoreCompletedMsg  = "Restored %d streams from NATSBackup %s/%s"
+)
+
+var ErrStreamNotInBackup = errors.New("stream is not in the backup")
+
+// RestoreReconciler reconciles a NATSRestore object.
+type RestoreReconciler struct {
+	*natsConnector
+	recorder   record.EventRecorder
+	logger     *zap.SugaredLogger
+	newBackend func(storage nmapiv1alpha1.BackupStorage) (backup.Backend, error)
+}
+
+func NewRestoreReconciler(
+	client client.Client,
+	logger *zap.SugaredLogger,
+	recorder record.EventRecorder,
+	natsCR *nmapiv1alpha1.NATS,
+	backupDir string,
+) *RestoreReconciler {
+	return &RestoreReconciler{
+		natsConnector: newNATSConnector(client, natsCR),
+		recorder:      recorder,
+		logger:        logger,
+		newBackend: func(storage nmapiv1alpha1.BackupStorage) (backup.Backend, error) {
+			return backup.NewBackend(storage, backupDir)
+		},
+	}
+}
+
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsrestores,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsrestores/status,verbs=get;update;patch
+
+func (r *RestoreReconciler) Reconcile(ctx context.Context,
+	req kcontrollerruntime.Request,
+) (kcontrollerruntime.Result, error) {
+	currentRestore := &nmapiv1alpha1.NATSRestore{}
+	if err := r.Get(ctx, req.NamespacedName, currentRestore); err != nil {
+		return kcontrollerruntime.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Copy the object, so we don't modify the source object.
+	natsRestore := currentRestore.DeepCopy()
+
+	log := r.logger.With(
+		"kind", "NATSRestore",
+		"namespace", natsRestore.GetNamespace(),
+		"name", natsRestore.GetName(),
+		"backup", natsRestore.Spec.BackupName,
+	)
+
+	// a restore is done once.
+	if natsRestore.IsCompleted() {
+		return kcontrollerruntime.Result{}, nil
+	}
+
+	return r.handleRestore(ctx, natsRestore, log)
+}
+
+// handleRestore recreates every stream of the restore from its snapshot. The streams which were restored
+// already are skipped, so that a failed restore can be retried.
+func (r *RestoreReconciler) handleRestore(ctx context.Context,
+	natsRestore *nmapiv1alpha1.NATSRestore, log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	natsBackup := &nmapiv1alpha1.NATSBackup{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: natsRestore.Spec.BackupName, Namespace: natsRestore.Namespace},
+		natsBackup)
+	if err != nil && !kapierrors.IsNotFound(err) {
+		return kcontrollerruntime.Result{}, err
+	}
+	if kapierrors.IsNotFound(err) || !natsBackup.IsCompleted() || natsBackup.IsInDeletion() ||
+		natsBackup.Status.State == nmapiv1alpha1.StateError {
+		log.Infof("NATSBackup is not ready, retrying in %d seconds", RequeueTimeForBackupNotReady)
+		natsRestore.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonBackupNotReady,
+			fmt.Sprintf(BackupNotReadyMsg, natsRestore.Namespace, natsRestore.Spec.BackupName))
+		return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForBackupNotReady * time.Second},
+			r.syncRestoreStatus(ctx, natsRestore, log)
+	}
+
+	streamNames, err := restoreStreamNames(natsRestore, natsBackup)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncRestoreStatusWithErr(ctx, natsRestore, err, log)
+	}
+
+	natsCluster, err := r.getNATS(ctx)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	natsClient, err := r.connect(ctx, natsCluster)
+	if errors.Is(err, ErrNATSNotReady) {
+		log.Infof("NATS cluster is not ready, retrying in %d seconds", RequeueTimeForNATSNotReady)
+		natsRestore.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonNATSNotReady,
+			fmt.Sprintf(NATSNotReadyMsg, r.natsCR.Namespace, r.natsCR.Name))
+		return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			r.syncRestoreStatus(ctx, natsRestore, log)
+	}
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncRestoreStatusWithErr(ctx, natsRestore, err, log)
+	}
+
+	backend, err := r.newBackend(natsBackup.Spec.Storage)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncRestoreStatusWithErr(ctx, natsRestore, err, log)
+	}
+
+	natsRestore.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonRestoring,
+		fmt.Sprintf(RestoreStartedMsg, len(streamNames)))
+	if err = r.syncRestoreStatus(ctx, natsRestore, log); err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	for _, streamName := range streamNames {
+		if slices.Contains(natsRestore.Status.RestoredStreams, streamName) {
+			continue
+		}
+		if err = restoreStream(ctx, natsClient, backend, natsBackup.Status.Location, streamName); err != nil {
+			err = fmt.Errorf("failed to restore stream %s: %w", streamName, err)
+			return kcontrollerruntime.Result{}, r.syncRestoreStatusWithErr(ctx, natsRestore, err, log)
+		}
+		natsRestore.Status.RestoredStreams = append(natsRestore.Status.RestoredStreams, streamName)
+	}
+
+	completionTime := kmetav1.Now()
+	natsRestore.Status.CompletionTime = &completionTime
+	msg := fmt.Sprintf(RestoreCompletedMsg, len(streamNames), natsBackup.Namespace, natsBackup.Name)
+	natsRestore.Status.SetStateReady(nmapiv1alpha1.ConditionReasonRestored, msg)
+	events.Normal(r.recorder, natsRestore, nmapiv1alpha1.ConditionReasonRestored, msg)
+	log.Infow("Restored streams", "streams", len(streamNames))
+
+	return kcontrollerruntime.Result{}, r.syncRestoreStatus(ctx, natsRestore, log)
+}
+
+// restoreStreamNames returns the names of the streams to restore, which are all streams of the backup if none
+// are set.
+func restoreStreamNames(natsRestore *nmapiv1alpha1.NATSRestore, natsBackup *nmapiv1alpha1.NATSBackup,
+) ([]string, error) {
+	backupStreams := make([]string, 0, len(natsBackup.Status.Streams))
+	for _, stream := range natsBackup.Status.Streams {
+		backupStreams = append(backupStreams, stream.Name)
+	}
+	if len(natsRestore.Spec.Streams) == 0 {
+		return backupStreams, nil
+	}
+	for _, streamName := range natsRestore.Spec.Streams {
+		if !slices.Contains(backupStreams, streamName) {
+			return nil, fmt.Errorf("%w: %s", ErrStreamNotInBackup,
+				fmt.Sprintf(StreamNotInBackupMsg, streamName, natsBackup.Namespace, natsBackup.Name))
+		}
+	}
+	return natsRestore.Spec.Streams, nil
+}
+
+// restoreStream recreates the given stream from the snapshot below the given location.
+func restoreStream(ctx context.Context, natsClient nmnats.Client, backend backup.Backend,
+	location, streamName string,
+) error {
+	info, err := readSnapshotInfo(ctx, backend, location, streamName)
+	if err != nil {
+		return err
+	}
+	reader, err := backend.Reader(ctx, snapshotKey(location, streamName))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = natsClient.RestoreStream(info, reader)
+	return err
+}
+
+// syncRestoreStatusWithErr sets the error state in the status and syncs it.
+// Returns the original error, so the controller triggers another reconciliation.
+func (r *RestoreReconciler) syncRestoreStatusWithErr(ctx context.Context,
+	natsRestore *nmapiv1alpha1.NATSRestore, err error, log *zap.SugaredLogger,
+) error {
+	natsRestore.Status.SetStateError(nmapiv1alpha1.ConditionReasonProcessingError, err.Error())
+	return errors.Join(err, r.syncRestoreStatus(ctx, natsRestore, log))
+}
+
+// syncRestoreStatus updates the status of the NATSRestore if it was modified.
+func (r *RestoreReconciler) syncRestoreStatus(ctx context.Context, natsRestore *nmapiv1alpha1.NATSRestore,
+	log *zap.SugaredLogger,
+) error {
+	// fetch the latest object, to avoid k8s conflict errors.
+	actualRestore := &nmapiv1alpha1.NATSRestore{}
+	namespacedName := ktypes.NamespacedName{Name: natsRestore.Name, Namespace: natsRestore.Namespace}
+	if err := r.Get(ctx, namespacedName, actualRestore); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if actualRestore.Status.IsEqual(natsRestore.Status) {
+		return nil
+	}
+
+	desiredRestore := actualRestore.DeepCopy()
+	desiredRestore.Status = natsRestore.Status
+	if err := r.Status().Update(ctx, desiredRestore); err != nil {
+		return err
+	}
+
+	log.Debugw("Updated NATSRestore status",
+		"oldStatus", actualRestore.Status, "newStatus", desiredRestore.Status)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RestoreReconciler) SetupWithManager(mgr kcontrollerruntime.Manager) error {
+	return kcontrollerruntime.NewControllerManagedBy(mgr).
+		For(&nmapiv1alpha1.NATSRestore{}).
+		Watches(
+			&nmapiv1alpha1.NATSBackup{}, // watch for the backups the restores wait for.
+			handler.EnqueueRequestsFromMapFunc(r.restoresOfBackup),
+		).
+		Complete(r)
+}
+
+// restoresOfBackup returns reconcile requests for all NATSRestores which restore the given NATSBackup.
+func (r *RestoreReconciler) restoresOfBackup(ctx context.Context, obj client.Object) []reconcile.Request {
+	restores := &nmapiv1alpha1.NATSRestoreList{}
+	if err := r.List(ctx, restores, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.logger.Errorw("Failed to list NATSRestores", "error", err)
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, natsRestore := range restores.Items {
+		if natsRestore.Spec.BackupName == obj.GetName() && !natsRestore.IsCompleted() {
+			requests = append(requests, reconcile.Request{NamespacedName: ktypes.NamespacedName{
+				Name:      natsRestore.Name,
+				Namespace: natsRestore.Namespace,
+			}})
+		}
+	}
+	return requests
+}
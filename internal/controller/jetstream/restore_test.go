@@ -0,0 +1,228 @@
+package jetstream
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/backup"
+	nmnatsmocks "github.com/kyma-project/nats-manager/pkg/nats/mocks"
+	"github.com/kyma-project/nats-manager/testutils"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+)
+
+func Test_RestoreReconcile_RestoresStreams(t *testing.T) {
+	t.Parallel()
+
+	// given
+	backupDir := t.TempDir()
+	givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+	givenBackup := newCompletedBackup(t, backupDir, "events", "orders")
+	givenRestore := testutils.NewNATSRestoreCR(
+		testutils.WithNATSRestoreName("restore"),
+		testutils.WithNATSRestoreNamespace(givenBackup.Namespace),
+		testutils.WithNATSRestoreBackupName(givenBackup.Name),
+		testutils.WithNATSRestoreStreams("orders"),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, givenBackup, givenRestore)
+	testEnv.NatsClient.On("Init").Return(nil)
+	testEnv.NatsClient.On("RestoreStream", mock.MatchedBy(func(info *natsgo.StreamInfo) bool {
+		return info.Config.Name == "orders"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		data, err := io.ReadAll(args.Get(1).(io.Reader))
+		require.NoError(t, err)
+		require.Equal(t, "orders-data", string(data))
+	}).Return(&natsgo.StreamInfo{}, nil)
+	reconciler := testEnv.NewRestoreReconciler(backupDir)
+
+	// when
+	_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenRestore.Name, Namespace: givenRestore.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	gotRestore := &nmapiv1alpha1.NATSRestore{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenRestore.Name, Namespace: givenRestore.Namespace}, gotRestore))
+	require.Equal(t, nmapiv1alpha1.StateReady, gotRestore.Status.State)
+	require.Equal(t, []string{"orders"}, gotRestore.Status.RestoredStreams)
+	require.NotNil(t, gotRestore.Status.CompletionTime)
+	require.Equal(t, []string{"Normal Restored Restored 1 streams from NATSBackup kyma-system/nightly"},
+		testEnv.GetK8sEvents())
+	testEnv.NatsClient.AssertExpectations(t)
+}
+
+func Test_RestoreReconcile_SkipsRestoredStreams(t *testing.T) {
+	t.Parallel()
+
+	// given
+	backupDir := t.TempDir()
+	givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+	givenBackup := newCompletedBackup(t, backupDir, "events", "orders")
+	givenRestore := testutils.NewNATSRestoreCR(
+		testutils.WithNATSRestoreName("restore"),
+		testutils.WithNATSRestoreNamespace(givenBackup.Namespace),
+		testutils.WithNATSRestoreBackupName(givenBackup.Name),
+		testutils.WithNATSRestoreStatus(nmapiv1alpha1.NATSRestoreStatus{RestoredStreams: []string{"events"}}),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, givenBackup, givenRestore)
+	testEnv.NatsClient.On("Init").Return(nil)
+	testEnv.NatsClient.On("RestoreStream", mock.MatchedBy(func(info *natsgo.StreamInfo) bool {
+		return info.Config.Name == "orders"
+	}), mock.Anything).Return(&natsgo.StreamInfo{}, nil).Once()
+	reconciler := testEnv.NewRestoreReconciler(backupDir)
+
+	// when
+	_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenRestore.Name, Namespace: givenRestore.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	gotRestore := &nmapiv1alpha1.NATSRestore{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenRestore.Name, Namespace: givenRestore.Namespace}, gotRestore))
+	require.Equal(t, nmapiv1alpha1.StateReady, gotRestore.Status.State)
+	require.Equal(t, []string{"events", "orders"}, gotRestore.Status.RestoredStreams)
+	testEnv.NatsClient.AssertExpectations(t)
+}
+
+func Test_RestoreReconcile_WaitsForBackup(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		givenStatus nmapiv1alpha1.NATSBackupStatus
+	}{
+		{
+			name: "should wait for a backup which is not completed",
+		},
+		{
+			name: "should wait for a backup whose snapshots are missing",
+			givenStatus: nmapiv1alpha1.NATSBackupStatus{
+				State:          nmapiv1alpha1.StateError,
+				CompletionTime: &kmetav1.Time{Time: time.Now()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+			givenBackup := testutils.NewNATSBackupCR(
+				testutils.WithNATSBackupName("nightly"),
+				testutils.WithNATSBackupNamespace("kyma-system"),
+				testutils.WithNATSBackupStatus(tc.givenStatus),
+			)
+			givenRestore := testutils.NewNATSRestoreCR(
+				testutils.WithNATSRestoreName("restore"),
+				testutils.WithNATSRestoreNamespace(givenBackup.Namespace),
+				testutils.WithNATSRestoreBackupName(givenBackup.Name),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, givenBackup, givenRestore)
+			reconciler := testEnv.NewRestoreReconciler(t.TempDir())
+
+			// when
+			result, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+				NamespacedName: ktypes.NamespacedName{Name: givenRestore.Name, Namespace: givenRestore.Namespace},
+			})
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, kcontrollerruntime.Result{RequeueAfter: RequeueTimeForBackupNotReady * time.Second},
+				result)
+			gotRestore := &nmapiv1alpha1.NATSRestore{}
+			require.NoError(t, testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: givenRestore.Name, Namespace: givenRestore.Namespace}, gotRestore))
+			require.Equal(t, nmapiv1alpha1.StateProcessing, gotRestore.Status.State)
+			testEnv.NatsClient.AssertNotCalled(t, "RestoreStream", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func Test_restoreStreamNames(t *testing.T) {
+	t.Parallel()
+
+	givenBackup := testutils.NewNATSBackupCR(testutils.WithNATSBackupStatus(nmapiv1alpha1.NATSBackupStatus{
+		Streams: []nmapiv1alpha1.BackupStream{{Name: "events"}, {Name: "orders"}},
+	}))
+
+	testCases := []struct {
+		name         string
+		givenStreams []string
+		wantStreams  []string
+		wantErr      error
+	}{
+		{
+			name:        "should restore all streams of the backup",
+			wantStreams: []string{"events", "orders"},
+		},
+		{
+			name:         "should restore the given streams",
+			givenStreams: []string{"orders"},
+			wantStreams:  []string{"orders"},
+		},
+		{
+			name:         "should fail if a stream is not in the backup",
+			givenStreams: []string{"orders", "audit"},
+			wantErr:      ErrStreamNotInBackup,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenRestore := testutils.NewNATSRestoreCR(testutils.WithNATSRestoreStreams(tc.givenStreams...))
+
+			// when
+			gotStreams, err := restoreStreamNames(givenRestore, givenBackup)
+
+			// then
+			require.ErrorIs(t, err, tc.wantErr)
+			require.Equal(t, tc.wantStreams, gotStreams)
+		})
+	}
+}
+
+// newCompletedBackup returns a completed NATSBackup with a snapshot of each given stream in the backup directory.
+func newCompletedBackup(t *testing.T, backupDir string, streamNames ...string) *nmapiv1alpha1.NATSBackup {
+	t.Helper()
+
+	natsBackup := testutils.NewNATSBackupCR(
+		testutils.WithNATSBackupName("nightly"),
+		testutils.WithNATSBackupNamespace("kyma-system"),
+	)
+	backend, err := backup.NewBackend(natsBackup.Spec.Storage, backupDir)
+	require.NoError(t, err)
+
+	location := natsBackup.StorageLocation()
+	natsClient := new(nmnatsmocks.Client)
+	for _, streamName := range streamNames {
+		natsClient.On("SnapshotStream", streamName, mock.Anything).Run(func(args mock.Arguments) {
+			_, err := args.Get(1).(io.Writer).Write([]byte(streamName + "-data"))
+			require.NoError(t, err)
+		}).Return(&natsgo.StreamInfo{Config: natsgo.StreamConfig{Name: streamName}}, nil)
+		stream, err := snapshotStream(t.Context(), natsClient, backend, location, streamName)
+		require.NoError(t, err)
+		natsBackup.Status.Streams = append(natsBackup.Status.Streams, *stream)
+	}
+	completionTime := kmetav1.Now()
+	natsBackup.Status.Location = location
+	natsBackup.Status.CompletionTime = &completionTime
+	natsBackup.Status.SetStateReady(nmapiv1alpha1.ConditionReasonBackedUp, "")
+	require.DirExists(t, filepath.Join(backupDir, location))
+	return natsBackup
+}
@@ -0,0 +1,213 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	NATSStreamFinalizerName = "natsstream.operator.kyma-project.io/finalizer"
+
+	StreamCreatedMsg        = "Created stream %s"
+	StreamDriftCorrectedMsg = "Corrected drift of stream %s in: %s"
+	StreamDeletedMsg        = "Deleted stream %s"
+)
+
+// StreamReconciler reconciles a NATSStream object.
+type StreamReconciler struct {
+	*natsConnector
+	recorder record.EventRecorder
+	logger   *zap.SugaredLogger
+}
+
+func NewStreamReconciler(
+	client client.Client,
+	logger *zap.SugaredLogger,
+	recorder record.EventRecorder,
+	natsCR *nmapiv1alpha1.NATS,
+) *StreamReconciler {
+	return &StreamReconciler{
+		natsConnector: newNATSConnector(client, natsCR),
+		recorder:      recorder,
+		logger:        logger,
+	}
+}
+
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsstreams,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsstreams/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operator.kyma-project.io,resources=natsstreams/finalizers,verbs=update
+
+func (r *StreamReconciler) Reconcile(ctx context.Context,
+	req kcontrollerruntime.Request,
+) (kcontrollerruntime.Result, error) {
+	currentStream := &nmapiv1alpha1.NATSStream{}
+	if err := r.Get(ctx, req.NamespacedName, currentStream); err != nil {
+		return kcontrollerruntime.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Copy the object, so we don't modify the source object.
+	stream := currentStream.DeepCopy()
+
+	log := r.logger.With(
+		"kind", "NATSStream",
+		"namespace", stream.GetNamespace(),
+		"name", stream.GetName(),
+		"stream", stream.StreamName(),
+	)
+
+	if stream.IsInDeletion() {
+		return r.handleStreamDeletion(ctx, stream, log)
+	}
+
+	if !controllerutil.ContainsFinalizer(stream, NATSStreamFinalizerName) {
+		controllerutil.AddFinalizer(stream, NATSStreamFinalizerName)
+		return kcontrollerruntime.Result{}, r.Update(ctx, stream)
+	}
+
+	return r.handleStreamReconcile(ctx, stream, log)
+}
+
+func (r *StreamReconciler) handleStreamReconcile(ctx context.Context, stream *nmapiv1alpha1.NATSStream,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	natsCluster, err := r.getNATS(ctx)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	natsClient, err := r.connect(ctx, natsCluster)
+	if errors.Is(err, ErrNATSNotReady) {
+		log.Infof("NATS cluster is not ready, retrying in %d seconds", RequeueTimeForNATSNotReady)
+		stream.Status.SetStateProcessing(nmapiv1alpha1.ConditionReasonNATSNotReady,
+			fmt.Sprintf(NATSNotReadyMsg, r.natsCR.Namespace, r.natsCR.Name))
+		return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			r.syncStreamStatus(ctx, stream, log)
+	}
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncStreamStatusWithErr(ctx, stream, err, log)
+	}
+
+	desired := toStreamConfig(stream)
+	info, err := natsClient.StreamInfo(desired.Name)
+	switch {
+	case errors.Is(err, nats.ErrStreamNotFound):
+		if info, err = natsClient.CreateStream(desired); err != nil {
+			return kcontrollerruntime.Result{}, r.syncStreamStatusWithErr(ctx, stream, err, log)
+		}
+		events.Normal(r.recorder, stream, nmapiv1alpha1.ConditionReasonCreated, StreamCreatedMsg, desired.Name)
+		stream.Status.SetStateReady(nmapiv1alpha1.ConditionReasonCreated, "")
+	case err != nil:
+		return kcontrollerruntime.Result{}, r.syncStreamStatusWithErr(ctx, stream, err, log)
+	default:
+		drift := streamConfigDrift(desired, &info.Config)
+		if len(drift) == 0 {
+			stream.Status.SetStateReady(nmapiv1alpha1.ConditionReasonInSync, "")
+			break
+		}
+		log.Infow("Stream configuration drifted from the spec", "fields", drift)
+		if info, err = natsClient.UpdateStream(mergeStreamConfig(desired, &info.Config)); err != nil {
+			return kcontrollerruntime.Result{}, r.syncStreamStatusWithErr(ctx, stream, err, log)
+		}
+		msg := fmt.Sprintf(StreamDriftCorrectedMsg, desired.Name, strings.Join(drift, ", "))
+		events.Warn(r.recorder, stream, nmapiv1alpha1.ConditionReasonDriftCorrected, msg)
+		stream.Status.SetStateReady(nmapiv1alpha1.ConditionReasonDriftCorrected, msg)
+	}
+
+	stream.Status.Messages = info.State.Msgs
+	stream.Status.Bytes = info.State.Bytes
+	stream.Status.Consumers = info.State.Consumers
+
+	return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+		r.syncStreamStatus(ctx, stream, log)
+}
+
+func (r *StreamReconciler) handleStreamDeletion(ctx context.Context, stream *nmapiv1alpha1.NATSStream,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	// skip reconciliation for deletion if the finalizer is not set.
+	if !controllerutil.ContainsFinalizer(stream, NATSStreamFinalizerName) {
+		return kcontrollerruntime.Result{}, nil
+	}
+
+	natsCluster, err := r.getNATS(ctx)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+
+	// if the NATS cluster is gone, the stream is gone as well.
+	if natsCluster == nil || natsCluster.IsInDeletion() {
+		return kcontrollerruntime.Result{}, r.removeStreamFinalizer(ctx, stream)
+	}
+
+	stream.Status.SetStateDeleting()
+	natsClient, err := r.connect(ctx, natsCluster)
+	if err != nil {
+		return kcontrollerruntime.Result{}, r.syncStreamStatusWithErr(ctx, stream, err, log)
+	}
+
+	if err = natsClient.DeleteStream(stream.StreamName()); err != nil && !errors.Is(err, nats.ErrStreamNotFound) {
+		return kcontrollerruntime.Result{}, r.syncStreamStatusWithErr(ctx, stream, err, log)
+	}
+	events.Normal(r.recorder, stream, nmapiv1alpha1.ConditionReasonDeleting, StreamDeletedMsg, stream.StreamName())
+	log.Info("Deleted stream")
+
+	return kcontrollerruntime.Result{}, r.removeStreamFinalizer(ctx, stream)
+}
+
+func (r *StreamReconciler) removeStreamFinalizer(ctx context.Context, stream *nmapiv1alpha1.NATSStream) error {
+	controllerutil.RemoveFinalizer(stream, NATSStreamFinalizerName)
+	return r.Update(ctx, stream)
+}
+
+// syncStreamStatusWithErr sets the error state in the status and syncs it.
+// Returns the original error, so the controller triggers another reconciliation.
+func (r *StreamReconciler) syncStreamStatusWithErr(ctx context.Context, stream *nmapiv1alpha1.NATSStream,
+	err error, log *zap.SugaredLogger,
+) error {
+	stream.Status.SetStateError(nmapiv1alpha1.ConditionReasonProcessingError, err.Error())
+	return errors.Join(err, r.syncStreamStatus(ctx, stream, log))
+}
+
+// syncStreamStatus updates the status of the NATSStream if it was modified.
+func (r *StreamReconciler) syncStreamStatus(ctx context.Context, stream *nmapiv1alpha1.NATSStream,
+	log *zap.SugaredLogger,
+) error {
+	// fetch the latest object, to avoid k8s conflict errors.
+	actualStream := &nmapiv1alpha1.NATSStream{}
+	if err := r.Get(ctx, ktypes.NamespacedName{Name: stream.Name, Namespace: stream.Namespace}, actualStream); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if actualStream.Status.IsEqual(stream.Status) {
+		return nil
+	}
+
+	desiredStream := actualStream.DeepCopy()
+	desiredStream.Status = stream.Status
+	if err := r.Status().Update(ctx, desiredStream); err != nil {
+		return err
+	}
+
+	log.Debugw("Updated NATSStream status", "oldStatus", actualStream.Status, "newStatus", desiredStream.Status)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *StreamReconciler) SetupWithManager(mgr kcontrollerruntime.Manager) error {
+	return kcontrollerruntime.NewControllerManagedBy(mgr).
+		For(&nmapiv1alpha1.NATSStream{}).
+		Complete(r)
+}
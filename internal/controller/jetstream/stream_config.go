@@ -0,0 +1,122 @@
+package jetstream
+
+import (
+	"slices"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/nats-io/nats.go"
+)
+
+// unlimited is the value JetStream uses for limits which are not set.
+const unlimited = -1
+
+// toStreamConfig converts the spec of a NATSStream to a JetStream stream configuration.
+func toStreamConfig(stream *nmapiv1alpha1.NATSStream) *nats.StreamConfig {
+	config := &nats.StreamConfig{
+		Name:        stream.StreamName(),
+		Description: stream.Spec.Description,
+		Subjects:    stream.Spec.Subjects,
+		Retention:   toRetentionPolicy(stream.Spec.Retention),
+		Replicas:    max(stream.Spec.Replicas, 1),
+		MaxBytes:    unlimited,
+		MaxMsgs:     unlimited,
+		Storage:     toStorageType(stream.Spec.Storage),
+		Discard:     toDiscardPolicy(stream.Spec.Discard),
+	}
+	if stream.Spec.MaxBytes != nil {
+		config.MaxBytes = stream.Spec.MaxBytes.Value()
+	}
+	if stream.Spec.MaxMsgs > 0 {
+		config.MaxMsgs = stream.Spec.MaxMsgs
+	}
+	if stream.Spec.MaxAge != nil {
+		config.MaxAge = stream.Spec.MaxAge.Duration
+	}
+	return config
+}
+
+func toRetentionPolicy(retention string) nats.RetentionPolicy {
+	switch retention {
+	case nmapiv1alpha1.RetentionPolicyInterest:
+		return nats.InterestPolicy
+	case nmapiv1alpha1.RetentionPolicyWorkQueue:
+		return nats.WorkQueuePolicy
+	default:
+		return nats.LimitsPolicy
+	}
+}
+
+func toStorageType(storage string) nats.StorageType {
+	if storage == nmapiv1alpha1.StorageTypeMemory {
+		return nats.MemoryStorage
+	}
+	return nats.FileStorage
+}
+
+func toDiscardPolicy(discard string) nats.DiscardPolicy {
+	if discard == nmapiv1alpha1.DiscardPolicyNew {
+		return nats.DiscardNew
+	}
+	return nats.DiscardOld
+}
+
+// streamConfigDrift returns the names of the spec fields for which the actual stream configuration
+// differs from the desired one. Fields which are not managed by the NATSStream are ignored.
+func streamConfigDrift(desired, actual *nats.StreamConfig) []string {
+	var drift []string
+	if desired.Description != actual.Description {
+		drift = append(drift, "description")
+	}
+	if !sameElements(desired.Subjects, actual.Subjects) {
+		drift = append(drift, "subjects")
+	}
+	if desired.Retention != actual.Retention {
+		drift = append(drift, "retention")
+	}
+	if desired.Replicas != max(actual.Replicas, 1) {
+		drift = append(drift, "replicas")
+	}
+	if desired.MaxBytes != actual.MaxBytes {
+		drift = append(drift, "maxBytes")
+	}
+	if desired.MaxMsgs != actual.MaxMsgs {
+		drift = append(drift, "maxMsgs")
+	}
+	if desired.MaxAge != actual.MaxAge {
+		drift = append(drift, "maxAge")
+	}
+	if desired.Storage != actual.Storage {
+		drift = append(drift, "storage")
+	}
+	if desired.Discard != actual.Discard {
+		drift = append(drift, "discard")
+	}
+	return drift
+}
+
+// mergeStreamConfig returns a copy of the actual stream configuration with all fields managed
+// by the NATSStream set to the desired values. Fields set by NATS itself are kept untouched.
+func mergeStreamConfig(desired, actual *nats.StreamConfig) *nats.StreamConfig {
+	merged := *actual
+	merged.Description = desired.Description
+	merged.Subjects = desired.Subjects
+	merged.Retention = desired.Retention
+	merged.Replicas = desired.Replicas
+	merged.MaxBytes = desired.MaxBytes
+	merged.MaxMsgs = desired.MaxMsgs
+	merged.MaxAge = desired.MaxAge
+	merged.Storage = desired.Storage
+	merged.Discard = desired.Discard
+	return &merged
+}
+
+// sameElements checks if both slices contain the same elements regardless of their order.
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA, sortedB := slices.Clone(a), slices.Clone(b)
+	slices.Sort(sortedA)
+	slices.Sort(sortedB)
+	return slices.Equal(sortedA, sortedB)
+}
@@ -0,0 +1,132 @@
+package jetstream
+
+import (
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/testutils"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_toStreamConfig(t *testing.T) {
+	t.Parallel()
+
+	maxBytes := resource.MustParse("1Mi")
+
+	testCases := []struct {
+		name       string
+		givenSpec  nmapiv1alpha1.NATSStreamSpec
+		wantConfig *natsgo.StreamConfig
+	}{
+		{
+			name: "should use unlimited limits and defaults if not set",
+			givenSpec: nmapiv1alpha1.NATSStreamSpec{
+				Subjects: []string{"orders.>"},
+			},
+			wantConfig: &natsgo.StreamConfig{
+				Name:      "orders",
+				Subjects:  []string{"orders.>"},
+				Retention: natsgo.LimitsPolicy,
+				Replicas:  1,
+				MaxBytes:  unlimited,
+				MaxMsgs:   unlimited,
+				Storage:   natsgo.FileStorage,
+				Discard:   natsgo.DiscardOld,
+			},
+		},
+		{
+			name: "should convert all fields",
+			givenSpec: nmapiv1alpha1.NATSStreamSpec{
+				Name:        "orders-v2",
+				Description: "all orders",
+				Subjects:    []string{"orders.>", "returns.>"},
+				Retention:   nmapiv1alpha1.RetentionPolicyWorkQueue,
+				Replicas:    3,
+				MaxBytes:    &maxBytes,
+				MaxMsgs:     1000,
+				MaxAge:      &kmetav1.Duration{Duration: time.Hour},
+				Storage:     nmapiv1alpha1.StorageTypeMemory,
+				Discard:     nmapiv1alpha1.DiscardPolicyNew,
+			},
+			wantConfig: &natsgo.StreamConfig{
+				Name:        "orders-v2",
+				Description: "all orders",
+				Subjects:    []string{"orders.>", "returns.>"},
+				Retention:   natsgo.WorkQueuePolicy,
+				Replicas:    3,
+				MaxBytes:    1024 * 1024,
+				MaxMsgs:     1000,
+				MaxAge:      time.Hour,
+				Storage:     natsgo.MemoryStorage,
+				Discard:     natsgo.DiscardNew,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			stream := testutils.NewNATSStreamCR(
+				testutils.WithNATSStreamName("orders"),
+				testutils.WithNATSStreamSpec(tc.givenSpec),
+			)
+
+			// when, then
+			require.Equal(t, tc.wantConfig, toStreamConfig(stream))
+		})
+	}
+}
+
+func Test_streamConfigDrift(t *testing.T) {
+	t.Parallel()
+
+	desired := &natsgo.StreamConfig{
+		Name:     "orders",
+		Subjects: []string{"a", "b"},
+		Replicas: 1,
+		MaxBytes: unlimited,
+		MaxMsgs:  unlimited,
+	}
+
+	testCases := []struct {
+		name        string
+		givenActual func() *natsgo.StreamConfig
+		wantDrift   []string
+	}{
+		{
+			name: "should ignore the order of subjects and fields not managed by the NATSStream",
+			givenActual: func() *natsgo.StreamConfig {
+				actual := *desired
+				actual.Subjects = []string{"b", "a"}
+				actual.Duplicates = time.Minute
+				return &actual
+			},
+			wantDrift: nil,
+		},
+		{
+			name: "should report all drifted fields",
+			givenActual: func() *natsgo.StreamConfig {
+				actual := *desired
+				actual.Subjects = []string{"a"}
+				actual.Replicas = 3
+				actual.MaxMsgs = 10
+				actual.Discard = natsgo.DiscardNew
+				return &actual
+			},
+			wantDrift: []string{"subjects", "replicas", "maxMsgs", "discard"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.wantDrift, streamConfigDrift(desired, tc.givenActual()))
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package jetstream
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/testutils"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var ErrJetStreamErrorMsg = errors.New("JetStream error")
+
+func Test_StreamReconcile(t *testing.T) {
+	t.Parallel()
+
+	streamName := "orders"
+	givenStreamOpts := []testutils.NATSStreamOption{
+		testutils.WithNATSStreamName(streamName),
+		testutils.WithNATSStreamSubjects(streamName + ".>"),
+		testutils.WithNATSStreamFinalizer(NATSStreamFinalizerName),
+	}
+	desiredConfig := natsgo.StreamConfig{
+		Name:      streamName,
+		Subjects:  []string{streamName + ".>"},
+		Retention: natsgo.LimitsPolicy,
+		Replicas:  1,
+		MaxBytes:  unlimited,
+		MaxMsgs:   unlimited,
+		Storage:   natsgo.FileStorage,
+		Discard:   natsgo.DiscardOld,
+	}
+	driftedConfig := desiredConfig
+	driftedConfig.Subjects = []string{"other.>"}
+	driftedConfig.MaxAge = time.Hour
+	driftedConfig.Duplicates = 2 * time.Minute
+	correctedConfig := desiredConfig
+	correctedConfig.Duplicates = 2 * time.Minute
+
+	testCases := []struct {
+		name                string
+		givenNATS           *nmapiv1alpha1.NATS
+		givenMocks          func(*MockedUnitTestEnvironment)
+		wantResult          kcontrollerruntime.Result
+		wantErr             error
+		wantState           string
+		wantConditionStatus kmetav1.ConditionStatus
+		wantConditionReason nmapiv1alpha1.ConditionReason
+		wantMessages        uint64
+		wantK8sEvents       []string
+	}{
+		{
+			name:                "should wait if NATS cluster is not ready",
+			givenNATS:           testutils.NewNATSCR(testutils.WithNATSStateProcessing()),
+			givenMocks:          func(*MockedUnitTestEnvironment) {},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			wantState:           nmapiv1alpha1.StateProcessing,
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonNATSNotReady,
+			wantK8sEvents:       []string{},
+		},
+		{
+			name:      "should wait if NATS server cannot be reached",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(ErrJetStreamErrorMsg)
+			},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForNATSNotReady * time.Second},
+			wantState:           nmapiv1alpha1.StateProcessing,
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonNATSNotReady,
+			wantK8sEvents:       []string{},
+		},
+		{
+			name:      "should create the stream if it does not exist",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("StreamInfo", streamName).Return(nil, natsgo.ErrStreamNotFound)
+				testEnv.NatsClient.On("CreateStream", &desiredConfig).Return(&natsgo.StreamInfo{
+					Config: desiredConfig,
+				}, nil)
+			},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+			wantState:           nmapiv1alpha1.StateReady,
+			wantConditionStatus: kmetav1.ConditionTrue,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonCreated,
+			wantK8sEvents:       []string{"Normal Created Created stream orders"},
+		},
+		{
+			name:      "should report the stream state if the stream is in sync",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateWarning()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("StreamInfo", streamName).Return(&natsgo.StreamInfo{
+					Config: desiredConfig,
+					State:  natsgo.StreamState{Msgs: 42},
+				}, nil)
+			},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+			wantState:           nmapiv1alpha1.StateReady,
+			wantConditionStatus: kmetav1.ConditionTrue,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonInSync,
+			wantMessages:        42,
+			wantK8sEvents:       []string{},
+		},
+		{
+			name:      "should correct the drifted fields and keep the fields managed by NATS",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("StreamInfo", streamName).Return(&natsgo.StreamInfo{
+					Config: driftedConfig,
+				}, nil)
+				testEnv.NatsClient.On("UpdateStream", &correctedConfig).Return(&natsgo.StreamInfo{
+					Config: correctedConfig,
+				}, nil)
+			},
+			wantResult:          kcontrollerruntime.Result{RequeueAfter: RequeueTimeForDriftCheck * time.Second},
+			wantState:           nmapiv1alpha1.StateReady,
+			wantConditionStatus: kmetav1.ConditionTrue,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonDriftCorrected,
+			wantK8sEvents: []string{
+				"Warning DriftCorrected Corrected drift of stream orders in: subjects, maxAge",
+			},
+		},
+		{
+			name:      "should report an error if the stream cannot be created",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("StreamInfo", streamName).Return(nil, natsgo.ErrStreamNotFound)
+				testEnv.NatsClient.On("CreateStream", mock.Anything).Return(nil, ErrJetStreamErrorMsg)
+			},
+			wantErr:             ErrJetStreamErrorMsg,
+			wantState:           nmapiv1alpha1.StateError,
+			wantConditionStatus: kmetav1.ConditionFalse,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonProcessingError,
+			wantK8sEvents:       []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenStream := testutils.NewNATSStreamCR(givenStreamOpts...)
+			testEnv := NewMockedUnitTestEnvironment(t, tc.givenNATS, tc.givenNATS, givenStream)
+			tc.givenMocks(testEnv)
+			reconciler := testEnv.NewStreamReconciler()
+
+			// when
+			result, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+				NamespacedName: ktypes.NamespacedName{Name: givenStream.Name, Namespace: givenStream.Namespace},
+			})
+
+			// then
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantResult, result)
+
+			gotStream := &nmapiv1alpha1.NATSStream{}
+			require.NoError(t, testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: givenStream.Name, Namespace: givenStream.Namespace}, gotStream))
+			require.Equal(t, tc.wantState, gotStream.Status.State)
+			require.Equal(t, tc.wantMessages, gotStream.Status.Messages)
+			gotCondition := meta.FindStatusCondition(gotStream.Status.Conditions, string(nmapiv1alpha1.ConditionSynced))
+			require.NotNil(t, gotCondition)
+			require.Equal(t, tc.wantConditionStatus, gotCondition.Status)
+			require.Equal(t, string(tc.wantConditionReason), gotCondition.Reason)
+
+			require.Equal(t, tc.wantK8sEvents, testEnv.GetK8sEvents())
+			testEnv.NatsClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StreamReconcile_AddsFinalizer(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(testutils.WithNATSStateReady())
+	givenStream := testutils.NewNATSStreamCR()
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenNATS, givenStream)
+	reconciler := testEnv.NewStreamReconciler()
+
+	// when
+	_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+		NamespacedName: ktypes.NamespacedName{Name: givenStream.Name, Namespace: givenStream.Namespace},
+	})
+
+	// then
+	require.NoError(t, err)
+	gotStream := &nmapiv1alpha1.NATSStream{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: givenStream.Name, Namespace: givenStream.Namespace}, gotStream))
+	require.True(t, controllerutil.ContainsFinalizer(gotStream, NATSStreamFinalizerName))
+	testEnv.NatsClient.AssertExpectations(t)
+}
+
+func Test_handleStreamDeletion(t *testing.T) {
+	t.Parallel()
+
+	streamName := "orders"
+
+	testCases := []struct {
+		name                string
+		givenNATS           *nmapiv1alpha1.NATS
+		givenMocks          func(*MockedUnitTestEnvironment)
+		wantErr             error
+		wantFinalizerExists bool
+		wantK8sEvents       []string
+	}{
+		{
+			name:      "should delete the stream and remove the finalizer",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("DeleteStream", streamName).Return(nil)
+			},
+			wantK8sEvents: []string{"Normal Deleting Deleted stream orders"},
+		},
+		{
+			name:      "should remove the finalizer if the stream does not exist anymore",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("DeleteStream", streamName).Return(natsgo.ErrStreamNotFound)
+			},
+			wantK8sEvents: []string{"Normal Deleting Deleted stream orders"},
+		},
+		{
+			name:          "should remove the finalizer if the NATS CR does not exist",
+			givenNATS:     nil,
+			givenMocks:    func(*MockedUnitTestEnvironment) {},
+			wantK8sEvents: []string{},
+		},
+		{
+			name:      "should keep the finalizer if the stream cannot be deleted",
+			givenNATS: testutils.NewNATSCR(testutils.WithNATSStateReady()),
+			givenMocks: func(testEnv *MockedUnitTestEnvironment) {
+				testEnv.NatsClient.On("Init").Return(nil)
+				testEnv.NatsClient.On("DeleteStream", streamName).Return(ErrJetStreamErrorMsg)
+			},
+			wantErr:             ErrJetStreamErrorMsg,
+			wantFinalizerExists: true,
+			wantK8sEvents:       []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenStream := testutils.NewNATSStreamCR(
+				testutils.WithNATSStreamName(streamName),
+				testutils.WithNATSStreamFinalizer(NATSStreamFinalizerName),
+				testutils.WithNATSStreamDeletionTimestamp(),
+			)
+			natsCR := tc.givenNATS
+			var testEnv *MockedUnitTestEnvironment
+			if natsCR == nil {
+				natsCR = testutils.NewNATSCR()
+				testEnv = NewMockedUnitTestEnvironment(t, natsCR, givenStream)
+			} else {
+				testEnv = NewMockedUnitTestEnvironment(t, natsCR, natsCR, givenStream)
+			}
+			tc.givenMocks(testEnv)
+			reconciler := testEnv.NewStreamReconciler()
+
+			// when
+			_, err := reconciler.Reconcile(testEnv.Context, kcontrollerruntime.Request{
+				NamespacedName: ktypes.NamespacedName{Name: givenStream.Name, Namespace: givenStream.Namespace},
+			})
+
+			// then
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			gotStream := &nmapiv1alpha1.NATSStream{}
+			err = testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: givenStream.Name, Namespace: givenStream.Namespace}, gotStream)
+			if tc.wantFinalizerExists {
+				require.NoError(t, err)
+				require.True(t, controllerutil.ContainsFinalizer(gotStream, NATSStreamFinalizerName))
+			} else {
+				// the object is removed by the fake client once the last finalizer is gone.
+				require.True(t, kapierrors.IsNotFound(err))
+			}
+
+			require.Equal(t, tc.wantK8sEvents, testEnv.GetK8sEvents())
+			testEnv.NatsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package jetstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/backup"
+	"github.com/kyma-project/nats-manager/pkg/metrics"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	nmnatsmocks "github.com/kyma-project/nats-manager/pkg/nats/mocks"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	kcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// MockedUnitTestEnvironment provides mocked resources for unit tests.
+type MockedUnitTestEnvironment struct {
+	Context    context.Context
+	Client     client.Client
+	NatsClient *nmnatsmocks.Client
+	Logger     *zap.SugaredLogger
+	Recorder   *record.FakeRecorder
+	connector  *natsConnector
+}
+
+func NewMockedUnitTestEnvironment(t *testing.T, natsCR *nmapiv1alpha1.NATS,
+	objs ...client.Object,
+) *MockedUnitTestEnvironment {
+	t.Helper()
+
+	// setup logger
+	sugaredLogger, err := testutils.NewSugaredLogger()
+	require.NoError(t, err)
+
+	// setup fake client for k8s
+	newScheme := runtime.NewScheme()
+	require.NoError(t, nmapiv1alpha1.AddToScheme(newScheme))
+	require.NoError(t, kcorev1.AddToScheme(newScheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme).
+		WithObjects(objs...).WithStatusSubresource(objs...).Build()
+
+	// setup a mocked NATS client which is returned by the connector.
+	natsClient := new(nmnatsmocks.Client)
+	connector := newNATSConnector(fakeClient, natsCR)
+	connector.newNatsClient = func(*nmnats.Config) nmnats.Client {
+		return natsClient
+	}
+
+	return &MockedUnitTestEnvironment{
+		Context:    context.Background(),
+		Client:     fakeClient,
+		NatsClient: natsClient,
+		Logger:     sugaredLogger,
+		Recorder:   record.NewFakeRecorder(3),
+		connector:  connector,
+	}
+}
+
+func (testEnv *MockedUnitTestEnvironment) GetK8sEvents() []string {
+	eventList := make([]string, 0, cap(testEnv.Recorder.Events))
+	close(testEnv.Recorder.Events)
+
+	for event := range testEnv.Recorder.Events {
+		eventList = append(eventList, event)
+	}
+	return eventList
+}
+
+func (testEnv *MockedUnitTestEnvironment) NewStreamReconciler() *StreamReconciler {
+	return &StreamReconciler{
+		natsConnector: testEnv.connector,
+		recorder:      testEnv.Recorder,
+		logger:        testEnv.Logger,
+	}
+}
+
+func (testEnv *MockedUnitTestEnvironment) NewConsumerReconciler() *ConsumerReconciler {
+	return &ConsumerReconciler{
+		natsConnector: testEnv.connector,
+		recorder:      testEnv.Recorder,
+		logger:        testEnv.Logger,
+	}
+}
+
+func (testEnv *MockedUnitTestEnvironment) NewKeyValueReconciler() *KeyValueReconciler {
+	return &KeyValueReconciler{
+		natsConnector: testEnv.connector,
+		recorder:      testEnv.Recorder,
+		logger:        testEnv.Logger,
+	}
+}
+
+func (testEnv *MockedUnitTestEnvironment) NewObjectStoreReconciler() *ObjectStoreReconciler {
+	return &ObjectStoreReconciler{
+		natsConnector: testEnv.connector,
+		recorder:      testEnv.Recorder,
+		logger:        testEnv.Logger,
+	}
+}
+
+func (testEnv *MockedUnitTestEnvironment) NewBackupReconciler(backupDir string) *BackupReconciler {
+	return &BackupReconciler{
+		natsConnector: testEnv.connector,
+		recorder:      testEnv.Recorder,
+		logger:        testEnv.Logger,
+		newBackend: func(storage nmapiv1alpha1.BackupStorage) (backup.Backend, error) {
+			return backup.NewBackend(storage, backupDir)
+		},
+	}
+}
+
+func (testEnv *MockedUnitTestEnvironment) NewRestoreReconciler(backupDir string) *RestoreReconciler {
+	return &RestoreReconciler{
+		natsConnector: testEnv.connector,
+		recorder:      testEnv.Recorder,
+		logger:        testEnv.Logger,
+		newBackend: func(storage nmapiv1alpha1.BackupStorage) (backup.Backend, error) {
+			return backup.NewBackend(storage, backupDir)
+		},
+	}
+}
+
+func (testEnv *MockedUnitTestEnvironment) NewBackupScheduleReconciler(collector metrics.Collector,
+	now time.Time,
+) *BackupScheduleReconciler {
+	return &BackupScheduleReconciler{
+		Client:    testEnv.Client,
+		recorder:  testEnv.Recorder,
+		logger:    testEnv.Logger,
+		collector: collector,
+		now:       func() time.Time { return now },
+	}
+}
@@ -0,0 +1,128 @@
+package clientconfig
+
+import (
+	"context"
+	"regexp"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmctrlurl "github.com/kyma-project/nats-manager/internal/controller/nats/url"
+	"github.com/kyma-project/nats-manager/pkg/auth"
+	"github.com/kyma-project/nats-manager/pkg/certs"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	kcorev1 "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ResolverConfigKey is the key of the resolver configuration in the accounts Secret of the NATS cluster.
+	// The config reloader of the NATS servers watches the mounted file.
+	ResolverConfigKey = "resolver.conf"
+	// AdminUser is the user of the system account in the resolver configuration.
+	AdminUser = "admin"
+)
+
+// adminPasswordPattern matches the password of the first user in the resolver configuration, which is the admin
+// of the system account, both in the configuration rendered by the chart and by the account controller.
+var adminPasswordPattern = regexp.MustCompile(`"?password"?\s*:\s*"([^"]+)"`)
+
+// New returns the configuration which the NATS manager uses to connect to the given NATS cluster.
+// If the client listener uses TLS, the CA of its certificate is read from the referenced Secret.
+// In JWT authentication mode, the manager connects with the user of its own account.
+func New(ctx context.Context, reader client.Reader, nats *nmapiv1alpha1.NATS) (*nmnats.Config, error) {
+	return newWithCredentials(ctx, reader, nats, auth.ManagerUserCredentialsKey)
+}
+
+// NewSystem returns the configuration with which the NATS manager connects to the given NATS cluster
+// as the user of the system account, which is allowed to push account JWTs to the resolver and to remove
+// NATS servers from the JetStream cluster. Without JWT authentication, the manager connects as the admin
+// of the system account in the accounts Secret.
+func NewSystem(ctx context.Context, reader client.Reader, nats *nmapiv1alpha1.NATS) (*nmnats.Config, error) {
+	config, err := newWithCredentials(ctx, reader, nats, auth.SystemUserCredentialsKey)
+	if err != nil || nats.IsJWTAuthEnabled() {
+		return config, err
+	}
+
+	secret := &kcorev1.Secret{}
+	key := ktypes.NamespacedName{Name: nats.Name + nmapiv1alpha1.AccountsSecretSuffix, Namespace: nats.Namespace}
+	if err = reader.Get(ctx, key, secret); err != nil {
+		return nil, err
+	}
+	config.User = AdminUser
+	config.Password = AdminPassword(secret.Data[ResolverConfigKey])
+	return config, nil
+}
+
+// AdminPassword returns the password of the admin of the system account from the given resolver configuration.
+func AdminPassword(resolverConfig []byte) string {
+	match := adminPasswordPattern.FindSubmatch(resolverConfig)
+	if match == nil {
+		return ""
+	}
+	return string(match[1])
+}
+
+func newWithCredentials(ctx context.Context, reader client.Reader, nats *nmapiv1alpha1.NATS,
+	credentialsKey string,
+) (*nmnats.Config, error) {
+	config := &nmnats.Config{URL: nmctrlurl.ForNATS(nats)}
+	if nats.IsJWTAuthEnabled() {
+		secret := &kcorev1.Secret{}
+		key := ktypes.NamespacedName{Name: nats.OperatorSecretName(), Namespace: nats.Namespace}
+		if err := reader.Get(ctx, key, secret); err != nil {
+			return nil, err
+		}
+		config.Credentials = secret.Data[credentialsKey]
+	}
+	if !nats.IsClientTLSEnabled() {
+		return config, nil
+	}
+
+	rootCAs, err := clientRootCAs(ctx, reader, nats)
+	if err != nil {
+		return nil, err
+	}
+	config.RootCAs = rootCAs
+	return config, nil
+}
+
+// NewMonitor returns the configuration which the NATS manager uses to read the monitoring endpoint
+// of the given NATS cluster. The monitoring endpoint is served with the certificate of the client listener.
+func NewMonitor(ctx context.Context, reader client.Reader, nats *nmapiv1alpha1.NATS) (*monitor.Config, error) {
+	config := &monitor.Config{URL: nmctrlurl.ForMonitoring(nats)}
+	if !nats.Spec.TLS.Monitoring.Enabled {
+		return config, nil
+	}
+
+	rootCAs, err := clientRootCAs(ctx, reader, nats)
+	if err != nil {
+		return nil, err
+	}
+	config.RootCAs = rootCAs
+	return config, nil
+}
+
+// NewPodMonitor returns the configuration which the NATS manager uses to read the monitoring endpoint
+// of the NATS server in the given Pod of the NATS cluster.
+func NewPodMonitor(ctx context.Context, reader client.Reader, nats *nmapiv1alpha1.NATS,
+	podName string,
+) (*monitor.Config, error) {
+	config, err := NewMonitor(ctx, reader, nats)
+	if err != nil {
+		return nil, err
+	}
+	config.URL = nmctrlurl.ForPodMonitoring(nats, podName)
+	return config, nil
+}
+
+// clientRootCAs reads the CA of the client listener's certificate from the referenced Secret.
+func clientRootCAs(ctx context.Context, reader client.Reader, nats *nmapiv1alpha1.NATS) ([]byte, error) {
+	secret := &kcorev1.Secret{}
+	key := ktypes.NamespacedName{Name: nats.TLSSecretName(nats.Spec.TLS.Client), Namespace: nats.Namespace}
+	if err := reader.Get(ctx, key, secret); err != nil {
+		return nil, err
+	}
+	// without a CA in the Secret, the certificate is verified with the system root CAs.
+	return secret.Data[certs.CAKey], nil
+}
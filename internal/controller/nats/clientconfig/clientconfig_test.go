@@ -0,0 +1,284 @@
+package clientconfig
+
+import (
+	"context"
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/auth"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	givenCASecret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: "nats-tls", Namespace: "kyma-system"},
+		Data:       map[string][]byte{"ca.crt": []byte("ca")},
+	}
+	givenOperatorSecret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: "eventing-nats-operator", Namespace: "kyma-system"},
+		Data: map[string][]byte{
+			auth.ManagerUserCredentialsKey: []byte("manager-creds"),
+			auth.SystemUserCredentialsKey:  []byte("sys-creds"),
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		givenNATS       *nmapiv1alpha1.NATS
+		givenObjects    []client.Object
+		wantURL         string
+		wantRootCAs     []byte
+		wantCredentials []byte
+		wantIsNotFound  bool
+	}{
+		{
+			name: "should return the plain URL if client TLS is disabled",
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+			),
+			wantURL: "nats://eventing-nats.kyma-system.svc.cluster.local:4222",
+		},
+		{
+			name: "should read the CA of the referenced Secret",
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSTLS(nmapiv1alpha1.TLS{
+					Client: nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "nats-tls"},
+				}),
+			),
+			givenObjects: []client.Object{givenCASecret},
+			wantURL:      "tls://eventing-nats.kyma-system.svc.cluster.local:4222",
+			wantRootCAs:  []byte("ca"),
+		},
+		{
+			name: "should read the credentials of the manager in JWT authentication mode",
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSAuthMode(nmapiv1alpha1.AuthModeJWT),
+			),
+			givenObjects:    []client.Object{givenOperatorSecret},
+			wantURL:         "nats://eventing-nats.kyma-system.svc.cluster.local:4222",
+			wantCredentials: []byte("manager-creds"),
+		},
+		{
+			name: "should fail if the operator Secret does not exist yet",
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSAuthMode(nmapiv1alpha1.AuthModeJWT),
+			),
+			wantIsNotFound: true,
+		},
+		{
+			name: "should fail if the generated Secret does not exist yet",
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSTLS(nmapiv1alpha1.TLS{
+					Client: nmapiv1alpha1.TLSListener{Enabled: true},
+				}),
+			),
+			givenObjects:   []client.Object{givenCASecret},
+			wantIsNotFound: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.givenObjects...).Build()
+
+			// when
+			config, err := New(context.Background(), fakeClient, tc.givenNATS)
+
+			// then
+			if tc.wantIsNotFound {
+				require.True(t, kapierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantURL, config.URL)
+			require.Equal(t, tc.wantRootCAs, config.RootCAs)
+			require.Equal(t, tc.wantCredentials, config.Credentials)
+		})
+	}
+}
+
+func Test_NewSystem(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+		testutils.WithNATSAuthMode(nmapiv1alpha1.AuthModeJWT),
+	)
+	givenOperatorSecret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: "eventing-nats-operator", Namespace: "kyma-system"},
+		Data: map[string][]byte{
+			auth.ManagerUserCredentialsKey: []byte("manager-creds"),
+			auth.SystemUserCredentialsKey:  []byte("sys-creds"),
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(givenOperatorSecret).Build()
+
+	// when
+	config, err := NewSystem(context.Background(), fakeClient, givenNATS)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []byte("sys-creds"), config.Credentials)
+	require.Empty(t, config.User)
+}
+
+func Test_NewSystem_MemoryResolver(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+	)
+	givenAccountsSecret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: "eventing-nats-secret", Namespace: "kyma-system"},
+		Data: map[string][]byte{
+			ResolverConfigKey: []byte(`accounts: { "$SYS": { users: [ { user: "admin", password: "admin-pw" } ] } }`),
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(givenAccountsSecret).Build()
+
+	// when
+	config, err := NewSystem(context.Background(), fakeClient, givenNATS)
+
+	// then
+	require.NoError(t, err)
+	require.Empty(t, config.Credentials)
+	require.Equal(t, "admin", config.User)
+	require.Equal(t, "admin-pw", config.Password)
+}
+
+func Test_AdminPassword(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                string
+		givenResolverConfig string
+		wantPassword        string
+	}{
+		{
+			name: "should return the password from the configuration rendered by the chart",
+			givenResolverConfig: `accounts: {
+  "$SYS": {
+    users: [
+      {
+        user: "admin",
+        password: "secret"
+      }
+    ]
+  },
+}
+system_account: "$SYS"`,
+			wantPassword: "secret",
+		},
+		{
+			name: "should return the password from the configuration rendered by the account controller",
+			givenResolverConfig: `accounts: {
+  "$SYS": {
+    "users": [
+      {
+        "user": "admin",
+        "password": "secret"
+      }
+    ]
+  }
+}
+system_account: "$SYS"`,
+			wantPassword: "secret",
+		},
+		{
+			name:                "should return an empty password if there is none",
+			givenResolverConfig: "",
+			wantPassword:        "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.wantPassword, AdminPassword([]byte(tc.givenResolverConfig)))
+		})
+	}
+}
+
+func Test_NewMonitor(t *testing.T) {
+	t.Parallel()
+
+	givenCASecret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: "nats-tls", Namespace: "kyma-system"},
+		Data:       map[string][]byte{"ca.crt": []byte("ca")},
+	}
+
+	testCases := []struct {
+		name        string
+		givenTLS    nmapiv1alpha1.TLS
+		wantURL     string
+		wantRootCAs []byte
+	}{
+		{
+			name:    "should return the http URL if monitoring TLS is disabled",
+			wantURL: "http://eventing-nats.kyma-system.svc.cluster.local:8222",
+		},
+		{
+			name: "should not read the CA if only client TLS is enabled",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client: nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "nats-tls"},
+			},
+			wantURL: "http://eventing-nats.kyma-system.svc.cluster.local:8222",
+		},
+		{
+			name: "should read the CA of the client listener if monitoring TLS is enabled",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client:     nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "nats-tls"},
+				Monitoring: nmapiv1alpha1.MonitoringTLS{Enabled: true},
+			},
+			wantURL:     "https://eventing-nats.kyma-system.svc.cluster.local:8222",
+			wantRootCAs: []byte("ca"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSTLS(tc.givenTLS),
+			)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(givenCASecret).Build()
+
+			// when
+			config, err := NewMonitor(context.Background(), fakeClient, givenNATS)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantURL, config.URL)
+			require.Equal(t, tc.wantRootCAs, config.RootCAs)
+		})
+	}
+}
@@ -19,6 +19,7 @@ package nats
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
 	"github.com/kyma-project/nats-manager/pkg/events"
@@ -27,19 +28,21 @@ import (
 	nmmgr "github.com/kyma-project/nats-manager/pkg/manager"
 	"github.com/kyma-project/nats-manager/pkg/metrics"
 	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
 	"go.uber.org/zap"
 	kappsv1 "k8s.io/api/apps/v1"
 	kcorev1 "k8s.io/api/core/v1"
 	kapipolicyv1 "k8s.io/api/policy/v1"
 	kapierrors "k8s.io/apimachinery/pkg/api/errors"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	kcontrollerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -63,6 +66,8 @@ type Reconciler struct {
 	controller                  controller.Controller
 	kubeClient                  k8s.Client
 	natsClients                 map[string]nmnats.Client
+	newNatsClient               func(*nmnats.Config) nmnats.Client
+	newMonitorClient            func(*monitor.Config) (monitor.Client, error)
 	chartRenderer               chart.Renderer
 	scheme                      *runtime.Scheme
 	recorder                    record.EventRecorder
@@ -72,10 +77,15 @@ type Reconciler struct {
 	destinationRuleWatchStarted bool
 	allowedNATSCR               *nmapiv1alpha1.NATS
 	collector                   metrics.Collector
+	statusCheckBackoff          StatusCheckBackoff
+	storageWarningPercent       int
 	// cloudProvider caches the provider name read from the Gardener shoot-info ConfigMap.
 	// nil means not yet resolved; pointer to empty string means non-Gardener cluster.
 	// Since shoot-info never changes, it is read at most once per controller process lifetime.
 	cloudProvider *string
+	// plannedSecrets holds the changes of the generated Secrets of each NATS CR in dry-run mode,
+	// which are listed in the plan instead of being written.
+	plannedSecrets map[string][]PlanChange
 }
 
 func NewReconciler(
@@ -88,11 +98,16 @@ func NewReconciler(
 	natsManager nmmgr.Manager,
 	allowedNATSCR *nmapiv1alpha1.NATS,
 	collector metrics.Collector,
+	statusCheckBackoff StatusCheckBackoff,
+	storageWarningPercent int,
 ) *Reconciler {
 	return &Reconciler{
 		Client:                      client,
 		kubeClient:                  kubeClient,
 		natsClients:                 make(map[string]nmnats.Client),
+		plannedSecrets:              make(map[string][]PlanChange),
+		newNatsClient:               nmnats.NewNatsClient,
+		newMonitorClient:            monitor.NewClient,
 		chartRenderer:               chartRenderer,
 		scheme:                      scheme,
 		recorder:                    recorder,
@@ -101,6 +116,8 @@ func NewReconciler(
 		destinationRuleWatchStarted: false,
 		allowedNATSCR:               allowedNATSCR,
 		collector:                   collector,
+		statusCheckBackoff:          statusCheckBackoff,
+		storageWarningPercent:       storageWarningPercent,
 		controller:                  nil,
 	}
 }
@@ -109,7 +126,7 @@ func NewReconciler(
 //nolint:lll
 //+kubebuilder:rbac:groups="",resourceNames=eventing-nats-secret,resources=secrets,verbs=get;list;watch;update;patch;create;delete
 //+kubebuilder:rbac:groups="",resourceNames=eventing-nats,resources=services,verbs=get;list;watch;update;patch;create;delete
-//+kubebuilder:rbac:groups="",resourceNames=eventing-nats-config,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resourceNames=eventing-nats-config;eventing-nats-plan,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resourceNames=shoot-info,resources=configmaps,verbs=get;list;watch
 //+kubebuilder:rbac:groups="apps",resourceNames=eventing-nats,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="networking.istio.io",resourceNames=eventing-nats,resources=destinationrules,verbs=get;list;watch;create;update;patch;delete
@@ -119,13 +136,18 @@ func NewReconciler(
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=list;watch
 //+kubebuilder:rbac:groups="",resources=services,verbs=list;watch
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=list;watch
-//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=list;delete;watch
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=list;delete;watch;patch
+//+kubebuilder:rbac:groups="storage.k8s.io",resources=storageclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups="",resources=pods,verbs=list;watch;get
 //+kubebuilder:rbac:groups="",resources=nodes,verbs=list;watch;get
 //+kubebuilder:rbac:groups="apps",resources=statefulsets,verbs=list;watch
 //+kubebuilder:rbac:groups="networking.istio.io",resources=destinationrules,verbs=list;watch
 //+kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=list;watch
+//+kubebuilder:rbac:groups="snapshot.storage.k8s.io",resources=volumesnapshots,verbs=get;list;create
+
+// RBAC permissions in the namespace of the NATS CR for the generated and the referenced Secrets
+//+kubebuilder:rbac:groups="",namespace=kyma-system,resources=secrets,verbs=get;create;update
 
 //nolint:lll
 //+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;create;update;patch;delete
@@ -216,7 +238,7 @@ func (r *Reconciler) initNATSInstance(ctx context.Context, nats *nmapiv1alpha1.N
 	log.Infof("Istio enabled on cluster: %t", istioExists)
 
 	// Check if NATS account secret exists.
-	accountSecretName := nats.Name + "-secret"
+	accountSecretName := nats.Name + nmapiv1alpha1.AccountsSecretSuffix
 	accountSecret, err := r.kubeClient.GetSecret(ctx, accountSecretName, nats.Namespace)
 	if err != nil && !kapierrors.IsNotFound(err) {
 		log.Errorf("Failed to fetch secret: %s", accountSecretName)
@@ -225,15 +247,68 @@ func (r *Reconciler) initNATSInstance(ctx context.Context, nats *nmapiv1alpha1.N
 	}
 	log.Infof("NATS account secret (name: %s) exists: %t", accountSecretName, accountSecret != nil)
 
+	// In dry-run mode, the changes of the generated Secrets below are planned instead of written.
+	delete(r.plannedSecrets, nats.Namespace+"/"+nats.Name)
+
+	// Generate the certificate for the TLS listeners without a referenced Secret.
+	caFingerprint, err := r.syncGeneratedCertificate(ctx, nats)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.syncCertificatesStatus(ctx, nats); err != nil {
+		return nil, err
+	}
+
+	// Sync the encryption keys of the file storage, rotating the key if it was changed.
+	encryptionKeySecret, err := r.syncEncryptionKey(ctx, nats)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate the operator once NATS runs in operator mode.
+	if err = r.syncOperator(ctx, nats); err != nil {
+		return nil, err
+	}
+
+	// Restart the NATS servers one by one if the StatefulSet has a new revision.
+	updatePartition, err := r.syncUpgrade(ctx, nats, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Move the streams off the NATS servers which are removed if the cluster was scaled down.
+	replicas, err := r.syncScaleDown(ctx, nats, log)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate overrides for helm chart.
-	cloudProvider := ""
-	if r.cloudProvider != nil {
-		cloudProvider = *r.cloudProvider
+	overrides, err := r.natsManager.GenerateOverrides(&nats.Spec, istioExists, accountSecret == nil,
+		r.getCloudProvider())
+	if err != nil {
+		return nil, err
 	}
-	overrides, err := r.natsManager.GenerateOverrides(&nats.Spec, istioExists, accountSecret == nil, cloudProvider)
+
+	// Expand the PVCs of the NATS servers if the file storage was increased or grown automatically.
+	fileStorageSize, claimSize, err := r.syncFileStorage(ctx, nats, log)
 	if err != nil {
 		return nil, err
 	}
+	overrides[nmmgr.FileStorageSizeKey] = fileStorageSize.String()
+	if !claimSize.Equal(fileStorageSize) {
+		overrides[nmmgr.FileStorageClaimSizeKey] = claimSize.String()
+	}
+	if caFingerprint != "" {
+		// rotating the CA restarts the NATS servers one by one, so that all connections use the new CA.
+		overrides[nmmgr.CAFingerprintKey] = caFingerprint
+	}
+	if encryptionKeySecret != nil {
+		overrides[nmmgr.EncryptionKeyVersionKey] = encryptionKeySecret.ResourceVersion
+		_, hasPreviousKey := encryptionKeySecret.Data[nmapiv1alpha1.EncryptionPreviousKeySecretKey]
+		overrides[nmmgr.EncryptionPreviousKeyKey] = hasPreviousKey
+	}
+	overrides[nmmgr.UpdatePartitionKey] = updatePartition
+	overrides[nmmgr.ClusterSizeKey] = replicas
 	log.Debugw("using overrides", "overrides", overrides)
 
 	// Init a release instance.
@@ -251,9 +326,9 @@ func (r *Reconciler) SetupWithManager(mgr kcontrollerruntime.Manager) error {
 	r.ctrlManager = mgr
 	var err error
 
-	// define labelSelectorPredicate for NATS pods.
+	// define labelSelectorPredicate for the Pods of all NATS CRs.
 	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(
-		kmetav1.LabelSelector{MatchLabels: getNATSPodsMatchLabels()})
+		kmetav1.LabelSelector{MatchLabels: getNATSPodsCommonLabels()})
 	if err != nil {
 		return err
 	}
@@ -268,25 +343,54 @@ func (r *Reconciler) SetupWithManager(mgr kcontrollerruntime.Manager) error {
 		Owns(&kapipolicyv1.PodDisruptionBudget{}). // watch for PodDisruptionBudgets.
 		Watches(
 			&kcorev1.Pod{}, // watch for NATS Pods.
-			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
-				if r.allowedNATSCR == nil {
-					return []reconcile.Request{}
-				}
-				// Enqueue a reconcile request for the NATS resource.
-				return []reconcile.Request{
-					{NamespacedName: types.NamespacedName{
-						Namespace: r.allowedNATSCR.Namespace,
-						Name:      r.allowedNATSCR.Name,
-					}},
-				}
-			}),
-			builder.WithPredicates(labelSelectorPredicate),
+			handler.EnqueueRequestsFromMapFunc(r.mapPodToNATS),
+			builder.WithPredicates(labelSelectorPredicate, podReadinessChangedPredicate()),
 		).
 		Build(r)
 
 	return err
 }
 
+// podReadinessChangedPredicate passes the updates of Pods which change their readiness, their node, or their
+// labels. The readiness of the NATS CR is derived from these events, so that other status updates of the Pods,
+// e.g. of the container statuses, do not trigger a reconciliation.
+func podReadinessChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, oldOK := e.ObjectOld.(*kcorev1.Pod)
+			newPod, newOK := e.ObjectNew.(*kcorev1.Pod)
+			if !oldOK || !newOK {
+				return true
+			}
+			return oldPod.Status.Phase != newPod.Status.Phase ||
+				isPodReady(oldPod) != isPodReady(newPod) ||
+				oldPod.Spec.NodeName != newPod.Spec.NodeName ||
+				oldPod.DeletionTimestamp.IsZero() != newPod.DeletionTimestamp.IsZero() ||
+				!maps.Equal(oldPod.Labels, newPod.Labels)
+		},
+	}
+}
+
+// mapPodToNATS enqueues a reconcile request for the NATS CR which the given Pod belongs to.
+func (r *Reconciler) mapPodToNATS(ctx context.Context, pod client.Object) []reconcile.Request {
+	natsList := &nmapiv1alpha1.NATSList{}
+	if err := r.List(ctx, natsList, client.InNamespace(pod.GetNamespace())); err != nil {
+		r.logger.Errorw("Failed to list NATS CRs for Pod", "namespace", pod.GetNamespace(),
+			"name", pod.GetName(), "error", err)
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for i := range natsList.Items {
+		nats := &natsList.Items[i]
+		selector := labels.SelectorFromSet(getNATSPodsMatchLabels(nats))
+		if selector.Matches(labels.Set(pod.GetLabels())) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(nats)})
+		}
+	}
+	return requests
+}
+
 // loggerWithNATS returns a logger with the given NATS CR details.
 func (r *Reconciler) loggerWithNATS(nats *nmapiv1alpha1.NATS) *zap.SugaredLogger {
 	return r.logger.With(
@@ -10,8 +10,12 @@ import (
 	"github.com/kyma-project/nats-manager/testutils"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func Test_generateNatsResources(t *testing.T) {
@@ -227,3 +231,124 @@ func Test_handleNATSCRAllowedCheck(t *testing.T) {
 		})
 	}
 }
+
+func Test_mapPodToNATS(t *testing.T) {
+	t.Parallel()
+
+	givenEventingNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+	)
+	givenTeamNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("team-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+	)
+
+	// define test cases
+	testCases := []struct {
+		name         string
+		givenPod     *kcorev1.Pod
+		wantRequests []reconcile.Request
+	}{
+		{
+			name:     "should enqueue the NATS CR of Kyma eventing",
+			givenPod: newNATSPod("eventing-nats-0", "kyma-system", getNATSPodsMatchLabels(givenEventingNATS)),
+			wantRequests: []reconcile.Request{
+				{NamespacedName: client.ObjectKeyFromObject(givenEventingNATS)},
+			},
+		},
+		{
+			name:     "should enqueue the NATS CR which the Pod belongs to",
+			givenPod: newNATSPod("team-nats-0", "kyma-system", getNATSPodsMatchLabels(givenTeamNATS)),
+			wantRequests: []reconcile.Request{
+				{NamespacedName: client.ObjectKeyFromObject(givenTeamNATS)},
+			},
+		},
+		{
+			name:         "should not enqueue NATS CRs of another namespace",
+			givenPod:     newNATSPod("team-nats-0", "team", getNATSPodsMatchLabels(givenTeamNATS)),
+			wantRequests: []reconcile.Request{},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			testEnv := NewMockedUnitTestEnvironment(t, givenEventingNATS, givenTeamNATS)
+
+			// when
+			requests := testEnv.Reconciler.mapPodToNATS(testEnv.Context, tc.givenPod)
+
+			// then
+			require.Equal(t, tc.wantRequests, requests)
+		})
+	}
+}
+
+func Test_podReadinessChangedPredicate(t *testing.T) {
+	t.Parallel()
+
+	newPod := func(ready kcorev1.ConditionStatus, nodeName string) *kcorev1.Pod {
+		pod := newNATSPod("eventing-nats-0", "kyma-system", map[string]string{"app": "nats"})
+		pod.Spec.NodeName = nodeName
+		pod.Status.Phase = kcorev1.PodRunning
+		pod.Status.Conditions = []kcorev1.PodCondition{{Type: kcorev1.PodReady, Status: ready}}
+		return pod
+	}
+
+	// define test cases
+	testCases := []struct {
+		name     string
+		givenOld *kcorev1.Pod
+		givenNew *kcorev1.Pod
+		wantPass bool
+	}{
+		{
+			name:     "should pass when the Pod gets ready",
+			givenOld: newPod(kcorev1.ConditionFalse, "node-1"),
+			givenNew: newPod(kcorev1.ConditionTrue, "node-1"),
+			wantPass: true,
+		},
+		{
+			name:     "should pass when the Pod is scheduled to another node",
+			givenOld: newPod(kcorev1.ConditionTrue, ""),
+			givenNew: newPod(kcorev1.ConditionTrue, "node-1"),
+			wantPass: true,
+		},
+		{
+			name:     "should not pass when the readiness of the Pod did not change",
+			givenOld: newPod(kcorev1.ConditionTrue, "node-1"),
+			givenNew: func() *kcorev1.Pod {
+				pod := newPod(kcorev1.ConditionTrue, "node-1")
+				pod.Status.ContainerStatuses = []kcorev1.ContainerStatus{{Name: "nats", RestartCount: 1}}
+				return pod
+			}(),
+			wantPass: false,
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			pass := podReadinessChangedPredicate().Update(event.UpdateEvent{
+				ObjectOld: tc.givenOld,
+				ObjectNew: tc.givenNew,
+			})
+
+			// then
+			require.Equal(t, tc.wantPass, pass)
+		})
+	}
+}
+
+func newNATSPod(name, namespace string, labels map[string]string) *kcorev1.Pod {
+	return &kcorev1.Pod{
+		ObjectMeta: kmetav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+	}
+}
@@ -2,31 +2,46 @@ package nats
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
 
 	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
-	nmctrlurl "github.com/kyma-project/nats-manager/internal/controller/nats/url"
+	nmctrlclientconfig "github.com/kyma-project/nats-manager/internal/controller/nats/clientconfig"
 	"github.com/kyma-project/nats-manager/pkg/events"
 	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
 	"go.uber.org/zap"
+	kappsv1 "k8s.io/api/apps/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
 	kcontrollerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+var errInvalidStreamPattern = errors.New("invalid stream pattern")
+
 const (
-	StreamExistsErrorMsg   = "Cannot delete NATS cluster as customer stream exists"
-	ConsumerExistsErrorMsg = "Cannot delete NATS cluster as stream consumer exists"
+	StreamExistsErrorMsg   = "Cannot delete NATS cluster as protected streams exist"
+	ConsumerExistsErrorMsg = "Cannot delete NATS cluster as consumers of ignored streams exist"
+	BucketExistsErrorMsg   = "Cannot delete NATS cluster as key-value or object store buckets exist"
 	InstanceLabelKey       = "app.kubernetes.io/instance"
-	SapStreamName          = "sap"
+
+	// DeletionCheckInterval is the interval in which a deletion which cannot proceed is checked again.
+	DeletionCheckInterval = 10 * time.Second
 )
 
 func (r *Reconciler) handleNATSDeletion(ctx context.Context, nats *nmapiv1alpha1.NATS,
 	log *zap.SugaredLogger,
 ) (kcontrollerruntime.Result, error) {
 	// reset metrics.
-	r.collector.ResetAvailabilityZonesUsedMetric()
-	r.collector.ResetClusterSizeMetric()
+	r.collector.ResetAvailabilityZonesUsedMetric(nats.Namespace, nats.Name)
+	r.collector.ResetClusterSizeMetric(nats.Namespace, nats.Name)
+	r.collector.ResetJetStreamUsageMetric(nats.Namespace, nats.Name)
 
 	// skip reconciliation for deletion if the finalizer is not set.
 	if !r.containsFinalizer(nats) {
@@ -38,83 +53,214 @@ func (r *Reconciler) handleNATSDeletion(ctx context.Context, nats *nmapiv1alpha1
 	nats.Status.SetStateDeleting()
 	events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonDeleting, "Deleting the NATS cluster.")
 
-	// create a new NATS client instance.
-	if err := r.createAndConnectNatsClient(nats); err != nil {
+	switch nats.GetDeletionPolicy() {
+	case nmapiv1alpha1.DeletionPolicyRetain:
+		return r.retainPVCsAndRemoveFinalizer(ctx, nats, log)
+	case nmapiv1alpha1.DeletionPolicyForce:
 		return r.deletePVCsAndRemoveFinalizer(ctx, nats, r.logger)
+	case nmapiv1alpha1.DeletionPolicySnapshot:
+		return r.handleNATSDeletionWithSnapshots(ctx, nats, log)
+	default:
+		return r.handleNATSDeletionBlockedByData(ctx, nats, log)
 	}
+}
 
-	customerStreamExists, err := r.customerStreamExists(nats)
+// handleNATSDeletionBlockedByData deletes the PVCs unless streams, consumers or buckets exist in any account,
+// in which case the deletion is blocked. If the NATS servers are unreachable, the data cannot be checked, so the
+// PVCs are deleted once the deletion grace period is over.
+func (r *Reconciler) handleNATSDeletionBlockedByData(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	streams, err := r.getClusterStreams(ctx, nats)
 	if err != nil {
-		return r.deletePVCsAndRemoveFinalizer(ctx, nats, r.logger)
+		return r.handleNATSUnreachable(ctx, nats, err, log)
 	}
-	// if any streams exists except for 'sap' stream, block the deletion.
-	if customerStreamExists {
-		nats.Status.SetStateWarning()
-		nats.Status.UpdateConditionDeletion(kmetav1.ConditionFalse,
-			nmapiv1alpha1.ConditionReasonDeletionError, StreamExistsErrorMsg)
-		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonDeletionError, StreamExistsErrorMsg)
-		return kcontrollerruntime.Result{Requeue: true}, r.syncNATSStatus(ctx, nats, log)
+
+	// if any key-value or object store bucket exists, block the deletion.
+	if buckets := bucketStreams(streams); len(buckets) > 0 {
+		return r.blockNATSDeletion(ctx, nats, fmt.Sprintf("%s: %s.", BucketExistsErrorMsg,
+			strings.Join(buckets, ", ")), log)
 	}
 
-	sapStreamConsumerExists, err := r.sapStreamConsumerExists(nats)
+	// if any protected stream or any consumer of an ignored stream exists, block the deletion.
+	protectedStreams, consumers, err := blockingStreamsAndConsumers(nats, streams)
 	if err != nil {
-		return r.deletePVCsAndRemoveFinalizer(ctx, nats, r.logger)
+		return r.blockNATSDeletion(ctx, nats, err.Error(), log)
 	}
-	// if any 'sap' stream consumer exists, block the deletion.
-	if sapStreamConsumerExists {
-		nats.Status.SetStateWarning()
-		nats.Status.UpdateConditionDeletion(kmetav1.ConditionFalse,
-			nmapiv1alpha1.ConditionReasonDeletionError, ConsumerExistsErrorMsg)
-		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonDeletionError, ConsumerExistsErrorMsg)
-		return kcontrollerruntime.Result{Requeue: true}, r.syncNATSStatus(ctx, nats, log)
+	var messages []string
+	if len(protectedStreams) > 0 {
+		messages = append(messages, fmt.Sprintf("%s: %s.", StreamExistsErrorMsg, strings.Join(protectedStreams, ", ")))
+	}
+	if len(consumers) > 0 {
+		messages = append(messages, fmt.Sprintf("%s: %s.", ConsumerExistsErrorMsg, strings.Join(consumers, ", ")))
+	}
+	if len(messages) > 0 {
+		return r.blockNATSDeletion(ctx, nats, strings.Join(messages, " "), log)
 	}
 
 	return r.deletePVCsAndRemoveFinalizer(ctx, nats, r.logger)
 }
 
-// check if any other stream exists except for 'sap' stream.
-func (r *Reconciler) customerStreamExists(nats *nmapiv1alpha1.NATS) (bool, error) {
-	// check if any other stream exists except for 'sap' stream.
-	streams, err := r.getNatsClient(nats).GetStreams()
+// getClusterStreams returns the streams of all accounts of the NATS cluster. They are read with the user of
+// the system account from every NATS server of the StatefulSet, as the user of the NATS manager only sees the
+// streams of its own account.
+func (r *Reconciler) getClusterStreams(ctx context.Context, nats *nmapiv1alpha1.NATS,
+) ([]*monitor.StreamDetail, error) {
+	servers := int32(nats.Spec.Cluster.Size)
+	sts := &kappsv1.StatefulSet{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: nats.Name, Namespace: nats.Namespace}, sts)
+	if err != nil && !kapierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && sts.Spec.Replicas != nil {
+		servers = *sts.Spec.Replicas
+	}
+
+	systemClient, err := r.connectSystemNatsClient(ctx, nats)
+	if err != nil {
+		return nil, err
+	}
+	defer systemClient.Close()
+	accounts, err := systemClient.ClusterStreams(int(servers))
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	var streams []*monitor.StreamDetail
+	for _, account := range accounts {
+		streams = append(streams, account.Streams...)
+	}
+	return streams, nil
+}
+
+// handleNATSUnreachable waits for the NATS servers to get reachable until the deletion grace period is over.
+// Afterwards, the PVCs are deleted without checking the data.
+func (r *Reconciler) handleNATSUnreachable(ctx context.Context, nats *nmapiv1alpha1.NATS, err error,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	remaining := remainingDeletionGracePeriod(nats)
+	if remaining <= 0 {
+		if gracePeriod := nats.GetDeletionGracePeriod(); gracePeriod > 0 {
+			events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonDeletionEscalated,
+				"Deleting the PVCs, because the NATS servers were unreachable for %s: %s", gracePeriod, err)
+		}
+		return r.deletePVCsAndRemoveFinalizer(ctx, nats, r.logger)
+	}
+
+	nats.Status.UpdateConditionDeletion(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonNATSUnreachable,
+		fmt.Sprintf("Waiting %s for the NATS servers to get reachable before the PVCs are deleted: %s",
+			remaining.Round(time.Second), err))
+	return kcontrollerruntime.Result{RequeueAfter: min(remaining, DeletionCheckInterval)},
+		r.syncNATSStatus(ctx, nats, log)
+}
+
+// remainingDeletionGracePeriod returns how long the deletion may not proceed before it is escalated.
+func remainingDeletionGracePeriod(nats *nmapiv1alpha1.NATS) time.Duration {
+	if nats.DeletionTimestamp == nil {
+		return nats.GetDeletionGracePeriod()
+	}
+	return nats.GetDeletionGracePeriod() - time.Since(nats.DeletionTimestamp.Time)
+}
+
+// blockNATSDeletion keeps the NATS CR and reports why the deletion is blocked.
+func (r *Reconciler) blockNATSDeletion(ctx context.Context, nats *nmapiv1alpha1.NATS, message string,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	nats.Status.SetStateWarning()
+	nats.Status.UpdateConditionDeletion(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonDeletionError, message)
+	events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonDeletionError, message)
+	return kcontrollerruntime.Result{Requeue: true}, r.syncNATSStatus(ctx, nats, log)
+}
+
+// bucketStreams returns the names of the streams which back key-value or object store buckets.
+func bucketStreams(streams []*monitor.StreamDetail) []string {
+	var names []string
 	for _, stream := range streams {
-		if stream.Config.Name != SapStreamName {
-			return true, nil
+		if strings.HasPrefix(stream.Name, nmnats.KeyValueStreamPrefix) ||
+			strings.HasPrefix(stream.Name, nmnats.ObjectStoreStreamPrefix) {
+			names = append(names, stream.Name)
 		}
 	}
-	return false, nil
+	return names
 }
 
-func (r *Reconciler) sapStreamConsumerExists(nats *nmapiv1alpha1.NATS) (bool, error) {
-	// check if 'sap' stream exists.
-	streams, err := r.getNatsClient(nats).GetStreams()
+// blockingStreamsAndConsumers returns the names of the protected streams and the consumers of the ignored streams,
+// e.g. sap/billing, which block the deletion. The streams which are neither protected nor ignored are deleted.
+func blockingStreamsAndConsumers(nats *nmapiv1alpha1.NATS,
+	streams []*monitor.StreamDetail,
+) ([]string, []string, error) {
+	ignored, err := compileStreamPatterns(nats.GetIgnoredStreams())
 	if err != nil {
-		return false, err
+		return nil, nil, err
 	}
-	sapStreamExists := false
+	protected, err := compileStreamPatterns(nats.GetProtectedStreams())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var protectedStreams, consumers []string
 	for _, stream := range streams {
-		if stream.Config.Name == SapStreamName {
-			sapStreamExists = true
-			break
+		switch {
+		case ignored.match(stream.Name):
+			for _, consumer := range stream.Consumers {
+				consumers = append(consumers, stream.Name+"/"+consumer.Name)
+			}
+		case protected.match(stream.Name):
+			protectedStreams = append(protectedStreams, stream.Name)
 		}
 	}
-	// if 'sap' stream does not exist, return false.
-	if !sapStreamExists {
-		return false, nil
+	return protectedStreams, consumers, nil
+}
+
+// streamPatterns matches stream names against globs, e.g. orders-*, and regular expressions enclosed
+// in slashes, e.g. /^orders-[0-9]+$/.
+type streamPatterns struct {
+	globs   []string
+	regexps []*regexp.Regexp
+}
+
+func compileStreamPatterns(patterns []string) (*streamPatterns, error) {
+	compiled := &streamPatterns{}
+	for _, pattern := range patterns {
+		if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			expression, err := regexp.Compile(pattern[1 : len(pattern)-1])
+			if err != nil {
+				return nil, fmt.Errorf("%w %s: %w", errInvalidStreamPattern, pattern, err)
+			}
+			compiled.regexps = append(compiled.regexps, expression)
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w %s: %w", errInvalidStreamPattern, pattern, err)
+		}
+		compiled.globs = append(compiled.globs, pattern)
 	}
+	return compiled, nil
+}
 
-	return r.getNatsClient(nats).ConsumersExist(SapStreamName)
+func (p *streamPatterns) match(name string) bool {
+	for _, glob := range p.globs {
+		// the pattern is validated already.
+		if matched, _ := path.Match(glob, name); matched {
+			return true
+		}
+	}
+	for _, expression := range p.regexps {
+		if expression.MatchString(name) {
+			return true
+		}
+	}
+	return false
 }
 
 // create a new NATS client instance and connect to the NATS server.
-func (r *Reconciler) createAndConnectNatsClient(nats *nmapiv1alpha1.NATS) error {
+func (r *Reconciler) createAndConnectNatsClient(ctx context.Context, nats *nmapiv1alpha1.NATS) error {
 	// create a new instance if it does not exist.
 	if r.getNatsClient(nats) == nil {
-		r.setNatsClient(nats, nmnats.NewNatsClient(&nmnats.Config{
-			URL: nmctrlurl.Format(nats.Name, nats.Namespace),
-		}))
+		config, err := nmctrlclientconfig.New(ctx, r.Client, nats)
+		if err != nil {
+			return err
+		}
+		r.setNatsClient(nats, r.newNatsClient(config))
 	}
 	return r.getNatsClient(nats).Init()
 }
@@ -122,12 +268,8 @@ func (r *Reconciler) createAndConnectNatsClient(nats *nmapiv1alpha1.NATS) error
 func (r *Reconciler) deletePVCsAndRemoveFinalizer(ctx context.Context,
 	nats *nmapiv1alpha1.NATS, log *zap.SugaredLogger,
 ) (kcontrollerruntime.Result, error) {
-	labelValue := nats.Name
-	if nats.Name == "eventing-nats" {
-		labelValue = "eventing"
-	}
 	// delete PVCs with the label selector.
-	labelSelector := fmt.Sprintf("%s=%s", InstanceLabelKey, labelValue)
+	labelSelector := fmt.Sprintf("%s=%s", InstanceLabelKey, instanceLabelValue(nats))
 	if err := r.kubeClient.DeletePVCsWithLabel(ctx, labelSelector, nats.Name, nats.Namespace); err != nil {
 		return kcontrollerruntime.Result{}, client.IgnoreNotFound(err)
 	}
@@ -138,6 +280,20 @@ func (r *Reconciler) deletePVCsAndRemoveFinalizer(ctx context.Context,
 	return r.removeFinalizer(ctx, nats)
 }
 
+// retainPVCsAndRemoveFinalizer removes the finalizer without deleting the PVCs, so that the data of the NATS
+// servers is kept once the StatefulSet is deleted.
+func (r *Reconciler) retainPVCsAndRemoveFinalizer(ctx context.Context,
+	nats *nmapiv1alpha1.NATS, log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	// close the nats connection and remove the client instance.
+	r.closeNatsClient(nats)
+
+	events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonPVCsRetained,
+		"Retaining the PVCs of the NATS cluster.")
+	log.Info("retained the PVCs of the NATS cluster")
+	return r.removeFinalizer(ctx, nats)
+}
+
 func (r *Reconciler) getNatsClient(nats *nmapiv1alpha1.NATS) nmnats.Client {
 	crKey := nats.Namespace + "/" + nats.Name
 	return r.natsClients[crKey]
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
 	"github.com/kyma-project/nats-manager/pkg/k8s/chart"
@@ -12,8 +13,8 @@ import (
 	nmmgr "github.com/kyma-project/nats-manager/pkg/manager"
 	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
 	"github.com/kyma-project/nats-manager/pkg/nats/mocks"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
 	"github.com/kyma-project/nats-manager/testutils"
-	natsgo "github.com/nats-io/nats.go"
 	ptestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -69,13 +70,13 @@ func Test_handleNATSDeletion(t *testing.T) {
 			wantResult:          kcontrollerruntime.Result{},
 		},
 		{
-			name:                 "should delete resources if natsClients GetStreams returns unexpected error",
+			name:                 "should delete resources if the streams of the NATS servers cannot be read",
 			givenWithNATSCreated: true,
 			wantNATSStatusState:  nmapiv1alpha1.StateDeleting,
 			mockNatsClientFunc: func() nmnats.Client {
 				natsClient := new(mocks.Client)
 				natsClient.On("Init").Return(nil)
-				natsClient.On("GetStreams").Return(nil, ErrUnexpectedErrorMsg)
+				natsClient.On("ClusterStreams", mock.Anything).Return(nil, nmnats.ErrClusterStreams)
 				natsClient.On("Close").Return()
 				return natsClient
 			},
@@ -83,28 +84,34 @@ func Test_handleNATSDeletion(t *testing.T) {
 			wantResult:    kcontrollerruntime.Result{},
 		},
 		{
-			name:                 "should delete resources if natsClients ConsumersExist returns unexpected error",
+			name:                 "should block deletion if non 'sap' stream exists",
 			givenWithNATSCreated: true,
-			wantNATSStatusState:  nmapiv1alpha1.StateDeleting,
+			wantNATSStatusState:  nmapiv1alpha1.StateWarning,
+			wantCondition: &kmetav1.Condition{
+				Type:               string(nmapiv1alpha1.ConditionDeleted),
+				Status:             kmetav1.ConditionFalse,
+				LastTransitionTime: kmetav1.Now(),
+				Reason:             string(nmapiv1alpha1.ConditionReasonDeletionError),
+				Message:            StreamExistsErrorMsg + ": non-sap.",
+			},
 			mockNatsClientFunc: func() nmnats.Client {
 				natsClient := new(mocks.Client)
 				natsClient.On("Init").Return(nil)
-				natsClient.On("GetStreams").Return([]*natsgo.StreamInfo{
-					{
-						Config: natsgo.StreamConfig{
-							Name: SapStreamName,
-						},
-					},
+				natsClient.On("ClusterStreams", mock.Anything).Return([]*monitor.AccountDetail{
+					{Name: "$G", Streams: []*monitor.StreamDetail{{Name: "non-sap"}}},
 				}, nil)
-				natsClient.On("ConsumersExist", mock.Anything).Return(false, ErrUnexpectedErrorMsg)
 				natsClient.On("Close").Return()
 				return natsClient
 			},
-			wantK8sEvents: []string{"Normal Deleting Deleting the NATS cluster."}, //nolint: dupword // reason: This is the required result
-			wantResult:    kcontrollerruntime.Result{},
+			wantFinalizerExists: true,
+			wantK8sEvents: []string{
+				"Normal Deleting Deleting the NATS cluster.", //nolint: dupword // reason: This is the required result
+				"Warning DeletionError " + StreamExistsErrorMsg + ": non-sap.",
+			},
+			wantResult: kcontrollerruntime.Result{Requeue: true},
 		},
 		{
-			name:                 "should block deletion if non 'sap' stream exists",
+			name:                 "should block deletion if a stream exists in another account",
 			givenWithNATSCreated: true,
 			wantNATSStatusState:  nmapiv1alpha1.StateWarning,
 			wantCondition: &kmetav1.Condition{
@@ -112,17 +119,14 @@ func Test_handleNATSDeletion(t *testing.T) {
 				Status:             kmetav1.ConditionFalse,
 				LastTransitionTime: kmetav1.Now(),
 				Reason:             string(nmapiv1alpha1.ConditionReasonDeletionError),
-				Message:            StreamExistsErrorMsg,
+				Message:            StreamExistsErrorMsg + ": payments.",
 			},
 			mockNatsClientFunc: func() nmnats.Client {
 				natsClient := new(mocks.Client)
 				natsClient.On("Init").Return(nil)
-				natsClient.On("GetStreams").Return([]*natsgo.StreamInfo{
-					{
-						Config: natsgo.StreamConfig{
-							Name: "non-sap",
-						},
-					},
+				natsClient.On("ClusterStreams", mock.Anything).Return([]*monitor.AccountDetail{
+					{Name: "$G", Streams: []*monitor.StreamDetail{{Name: nmapiv1alpha1.DefaultIgnoredStreams}}},
+					{Name: "orders", Streams: []*monitor.StreamDetail{{Name: "payments"}}},
 				}, nil)
 				natsClient.On("Close").Return()
 				return natsClient
@@ -130,7 +134,64 @@ func Test_handleNATSDeletion(t *testing.T) {
 			wantFinalizerExists: true,
 			wantK8sEvents: []string{
 				"Normal Deleting Deleting the NATS cluster.", //nolint: dupword // reason: This is the required result
-				"Warning DeletionError " + StreamExistsErrorMsg,
+				"Warning DeletionError " + StreamExistsErrorMsg + ": payments.",
+			},
+			wantResult: kcontrollerruntime.Result{Requeue: true},
+		},
+		{
+			name:                 "should block deletion if key-value bucket exists",
+			givenWithNATSCreated: true,
+			wantNATSStatusState:  nmapiv1alpha1.StateWarning,
+			wantCondition: &kmetav1.Condition{
+				Type:               string(nmapiv1alpha1.ConditionDeleted),
+				Status:             kmetav1.ConditionFalse,
+				LastTransitionTime: kmetav1.Now(),
+				Reason:             string(nmapiv1alpha1.ConditionReasonDeletionError),
+				Message:            BucketExistsErrorMsg + ": KV_flags.",
+			},
+			mockNatsClientFunc: func() nmnats.Client {
+				natsClient := new(mocks.Client)
+				natsClient.On("Init").Return(nil)
+				natsClient.On("ClusterStreams", mock.Anything).Return([]*monitor.AccountDetail{
+					{Name: "$G", Streams: []*monitor.StreamDetail{{Name: nmnats.KeyValueStreamName("flags")}}},
+				}, nil)
+				natsClient.On("Close").Return()
+				return natsClient
+			},
+			wantFinalizerExists: true,
+			wantK8sEvents: []string{
+				"Normal Deleting Deleting the NATS cluster.", //nolint: dupword // reason: This is the required result
+				"Warning DeletionError " + BucketExistsErrorMsg + ": KV_flags.",
+			},
+			wantResult: kcontrollerruntime.Result{Requeue: true},
+		},
+		{
+			name:                 "should block deletion if object store bucket exists",
+			givenWithNATSCreated: true,
+			wantNATSStatusState:  nmapiv1alpha1.StateWarning,
+			wantCondition: &kmetav1.Condition{
+				Type:               string(nmapiv1alpha1.ConditionDeleted),
+				Status:             kmetav1.ConditionFalse,
+				LastTransitionTime: kmetav1.Now(),
+				Reason:             string(nmapiv1alpha1.ConditionReasonDeletionError),
+				Message:            BucketExistsErrorMsg + ": OBJ_artifacts.",
+			},
+			mockNatsClientFunc: func() nmnats.Client {
+				natsClient := new(mocks.Client)
+				natsClient.On("Init").Return(nil)
+				natsClient.On("ClusterStreams", mock.Anything).Return([]*monitor.AccountDetail{
+					{Name: "$G", Streams: []*monitor.StreamDetail{
+						{Name: nmapiv1alpha1.DefaultIgnoredStreams},
+						{Name: nmnats.ObjectStoreStreamName("artifacts")},
+					}},
+				}, nil)
+				natsClient.On("Close").Return()
+				return natsClient
+			},
+			wantFinalizerExists: true,
+			wantK8sEvents: []string{
+				"Normal Deleting Deleting the NATS cluster.", //nolint: dupword // reason: This is the required result
+				"Warning DeletionError " + BucketExistsErrorMsg + ": OBJ_artifacts.",
 			},
 			wantResult: kcontrollerruntime.Result{Requeue: true},
 		},
@@ -143,26 +204,26 @@ func Test_handleNATSDeletion(t *testing.T) {
 				Status:             kmetav1.ConditionFalse,
 				LastTransitionTime: kmetav1.Now(),
 				Reason:             string(nmapiv1alpha1.ConditionReasonDeletionError),
-				Message:            ConsumerExistsErrorMsg,
+				Message:            ConsumerExistsErrorMsg + ": sap/billing, sap/shipping.",
 			},
 			mockNatsClientFunc: func() nmnats.Client {
 				natsClient := new(mocks.Client)
 				natsClient.On("Init").Return(nil)
-				natsClient.On("GetStreams").Return([]*natsgo.StreamInfo{
-					{
-						Config: natsgo.StreamConfig{
-							Name: SapStreamName,
+				natsClient.On("ClusterStreams", mock.Anything).Return([]*monitor.AccountDetail{
+					{Name: "$G", Streams: []*monitor.StreamDetail{
+						{
+							Name:      nmapiv1alpha1.DefaultIgnoredStreams,
+							Consumers: []*monitor.ConsumerDetail{{Name: "billing"}, {Name: "shipping"}},
 						},
-					},
+					}},
 				}, nil)
-				natsClient.On("ConsumersExist", mock.Anything).Return(true, nil)
 				natsClient.On("Close").Return()
 				return natsClient
 			},
 			wantFinalizerExists: true,
 			wantK8sEvents: []string{
 				"Normal Deleting Deleting the NATS cluster.", //nolint: dupword // reason: This is the required result
-				"Warning DeletionError " + ConsumerExistsErrorMsg,
+				"Warning DeletionError " + ConsumerExistsErrorMsg + ": sap/billing, sap/shipping.",
 			},
 			wantResult: kcontrollerruntime.Result{Requeue: true},
 		},
@@ -173,14 +234,10 @@ func Test_handleNATSDeletion(t *testing.T) {
 			mockNatsClientFunc: func() nmnats.Client {
 				natsClient := new(mocks.Client)
 				natsClient.On("Init").Return(nil)
-				natsClient.On("GetStreams").Return([]*natsgo.StreamInfo{
-					{
-						Config: natsgo.StreamConfig{
-							Name: SapStreamName,
-						},
-					},
+				natsClient.On("ClusterStreams", mock.Anything).Return([]*monitor.AccountDetail{
+					{Name: "$G", Streams: []*monitor.StreamDetail{{Name: nmapiv1alpha1.DefaultIgnoredStreams}}},
+					{Name: "$SYS"},
 				}, nil)
-				natsClient.On("ConsumersExist", mock.Anything).Return(false, nil)
 				natsClient.On("Close").Return()
 				return natsClient
 			},
@@ -212,15 +269,17 @@ func Test_handleNATSDeletion(t *testing.T) {
 			}
 			var objs []client.Object
 			if tc.givenWithNATSCreated {
-				objs = append(objs, givenNats)
+				objs = append(objs, givenNats, newAccountsSecret(givenNats))
 			}
 
 			testEnv := NewMockedUnitTestEnvironment(t, objs...)
 			reconciler := testEnv.Reconciler
 
 			// set values in metrics, which should be reset by the method.
-			reconciler.collector.RecordAvailabilityZonesUsedMetric(5)
-			reconciler.collector.RecordClusterSizeMetric(5)
+			reconciler.collector.RecordAvailabilityZonesUsedMetric(givenNats.Namespace, givenNats.Name, 5)
+			reconciler.collector.RecordClusterSizeMetric(givenNats.Namespace, givenNats.Name, 5)
+			// set values in metrics of another NATS CR, which should be kept.
+			reconciler.collector.RecordAvailabilityZonesUsedMetric(givenNats.Namespace, "other-nats", 3)
 
 			nats := givenNats.DeepCopy()
 
@@ -252,7 +311,8 @@ func Test_handleNATSDeletion(t *testing.T) {
 			)
 
 			if tc.mockNatsClientFunc != nil {
-				reconciler.natsClients[nats.Namespace+"/"+nats.Name] = tc.mockNatsClientFunc()
+				natsClient := tc.mockNatsClientFunc()
+				reconciler.newNatsClient = func(*nmnats.Config) nmnats.Client { return natsClient }
 			}
 
 			// when
@@ -272,14 +332,18 @@ func Test_handleNATSDeletion(t *testing.T) {
 			}
 
 			// check that metrics are reset.
-			gotAZMetric, err := reconciler.collector.GetAvailabilityZonesUsedMetric()
+			gotAZMetric, err := reconciler.collector.GetAvailabilityZonesUsedMetric(nats.Namespace, nats.Name)
 			require.NoError(t, err)
 			require.Equal(t, 0.0, ptestutil.ToFloat64(gotAZMetric))
 
-			gotSizeMetric, err := reconciler.collector.GetClusterSizeMetric()
+			gotSizeMetric, err := reconciler.collector.GetClusterSizeMetric(nats.Namespace, nats.Name)
 			require.NoError(t, err)
 			require.Equal(t, 0.0, ptestutil.ToFloat64(gotSizeMetric))
 
+			gotOtherAZMetric, err := reconciler.collector.GetAvailabilityZonesUsedMetric(nats.Namespace, "other-nats")
+			require.NoError(t, err)
+			require.Equal(t, 3.0, ptestutil.ToFloat64(gotOtherAZMetric))
+
 			// check k8s events
 			gotEvents := testEnv.GetK8sEvents()
 			require.Equal(t, tc.wantK8sEvents, gotEvents)
@@ -289,6 +353,74 @@ func Test_handleNATSDeletion(t *testing.T) {
 	}
 }
 
+func Test_blockingStreamsAndConsumers(t *testing.T) {
+	t.Parallel()
+
+	givenStreams := []*monitor.StreamDetail{
+		{Name: "sap", Consumers: []*monitor.ConsumerDetail{{Name: "billing"}}},
+		{Name: "orders-1"},
+		{Name: "orders-cache"},
+		{Name: "audit"},
+	}
+
+	testCases := []struct {
+		name               string
+		givenProtected     []string
+		givenIgnored       []string
+		wantStreams        []string
+		wantConsumers      []string
+		wantErrorContained string
+	}{
+		{
+			name:          "should block the deletion by all streams except for the consumers of the 'sap' stream",
+			wantStreams:   []string{"orders-1", "orders-cache", "audit"},
+			wantConsumers: []string{"sap/billing"},
+		},
+		{
+			name:           "should block the deletion by the streams which match a glob or regular expression",
+			givenProtected: []string{"orders-*", "/^aud.t$/"},
+			givenIgnored:   []string{},
+			wantStreams:    []string{"orders-1", "orders-cache", "audit"},
+		},
+		{
+			name:           "should let the ignored streams take precedence over the protected streams",
+			givenProtected: []string{"*"},
+			givenIgnored:   []string{"/-cache$/", "sap", "audit"},
+			wantStreams:    []string{"orders-1"},
+			wantConsumers:  []string{"sap/billing"},
+		},
+		{
+			name:               "should fail if a pattern is invalid",
+			givenProtected:     []string{"/orders-[/"},
+			wantErrorContained: "invalid stream pattern /orders-[/",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSDeletionProtection(tc.givenProtected, tc.givenIgnored),
+			)
+
+			// when
+			gotStreams, gotConsumers, err := blockingStreamsAndConsumers(givenNATS, givenStreams)
+
+			// then
+			if tc.wantErrorContained != "" {
+				require.ErrorIs(t, err, errInvalidStreamPattern)
+				require.ErrorContains(t, err, tc.wantErrorContained)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantStreams, gotStreams)
+			require.Equal(t, tc.wantConsumers, gotConsumers)
+		})
+	}
+}
+
 func Test_DeletePVCsAndRemoveFinalizer(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -401,7 +533,7 @@ func Test_CreateAndConnectNatsClient(t *testing.T) {
 			r.setNatsClient(tt.nats, new(mocks.Client))
 			r.getNatsClient(tt.nats).(*mocks.Client).On("Init").Return(tt.initErr)
 
-			err := r.createAndConnectNatsClient(tt.nats)
+			err := r.createAndConnectNatsClient(context.Background(), tt.nats)
 			if err != nil {
 				require.Equal(t, tt.expectedErr.Error(), err.Error())
 			}
@@ -451,3 +583,100 @@ func Test_CloseNatsClient(t *testing.T) {
 		})
 	}
 }
+
+func Test_handleNATSDeletion_DeletionPolicies(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                string
+		givenPolicy         nmapiv1alpha1.DeletionPolicy
+		givenGracePeriod    time.Duration
+		givenDeletedAgo     time.Duration
+		givenInitErr        error
+		wantPVCsDeleted     bool
+		wantFinalizerExists bool
+		wantConditionReason nmapiv1alpha1.ConditionReason
+		wantK8sEvent        string
+		wantRequeue         bool
+	}{
+		{
+			name:            "should keep the PVCs with the Retain policy",
+			givenPolicy:     nmapiv1alpha1.DeletionPolicyRetain,
+			wantPVCsDeleted: false,
+			wantK8sEvent:    "Normal PVCsRetained Retaining the PVCs of the NATS cluster.",
+		},
+		{
+			name:            "should delete the PVCs without checking the data with the Force policy",
+			givenPolicy:     nmapiv1alpha1.DeletionPolicyForce,
+			wantPVCsDeleted: true,
+		},
+		{
+			name:                "should wait for unreachable NATS servers during the grace period",
+			givenPolicy:         nmapiv1alpha1.DeletionPolicyBlock,
+			givenGracePeriod:    time.Minute,
+			givenDeletedAgo:     time.Second,
+			givenInitErr:        ErrConnectionErrorMsg,
+			wantFinalizerExists: true,
+			wantConditionReason: nmapiv1alpha1.ConditionReasonNATSUnreachable,
+			wantRequeue:         true,
+		},
+		{
+			name:             "should delete the PVCs once the NATS servers were unreachable for the grace period",
+			givenPolicy:      nmapiv1alpha1.DeletionPolicyBlock,
+			givenGracePeriod: time.Minute,
+			givenDeletedAgo:  2 * time.Minute,
+			givenInitErr:     ErrConnectionErrorMsg,
+			wantPVCsDeleted:  true,
+			wantK8sEvent: "Warning DeletionEscalated Deleting the PVCs, because the NATS servers " +
+				"were unreachable for 1m0s: " + ErrConnectionErrorMsg.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRStatusInitialized(),
+				testutils.WithNATSCRFinalizer(NATSFinalizerName),
+				testutils.WithNATSDeletionPolicy(tc.givenPolicy, tc.givenGracePeriod),
+			)
+			deletionTimestamp := kmetav1.NewTime(time.Now().Add(-tc.givenDeletedAgo))
+			givenNATS.DeletionTimestamp = &deletionTimestamp
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS, newAccountsSecret(givenNATS))
+			reconciler := testEnv.Reconciler
+			nats := givenNATS.DeepCopy()
+
+			testEnv.kubeClient.On("DeletePVCsWithLabel",
+				mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			natsClient := new(mocks.Client)
+			natsClient.On("Init").Return(tc.givenInitErr)
+			natsClient.On("Close").Return()
+			reconciler.newNatsClient = func(*nmnats.Config) nmnats.Client { return natsClient }
+
+			// when
+			result, err := reconciler.handleNATSDeletion(testEnv.Context, nats, testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantRequeue, result.RequeueAfter > 0)
+			require.Equal(t, tc.wantFinalizerExists, controllerutil.ContainsFinalizer(nats, NATSFinalizerName))
+			if tc.wantPVCsDeleted {
+				testEnv.kubeClient.AssertCalled(t, "DeletePVCsWithLabel",
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				testEnv.kubeClient.AssertNotCalled(t, "DeletePVCsWithLabel",
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tc.wantConditionReason != "" {
+				gotCondition := nats.Status.FindCondition(nmapiv1alpha1.ConditionDeleted)
+				require.NotNil(t, gotCondition)
+				require.Equal(t, string(tc.wantConditionReason), gotCondition.Reason)
+			}
+			if tc.wantK8sEvent != "" {
+				require.Contains(t, testEnv.GetK8sEvents(), tc.wantK8sEvent)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	"github.com/kyma-project/nats-manager/pkg/k8s"
+	"github.com/kyma-project/nats-manager/pkg/k8s/chart"
+	"go.uber.org/zap"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// syncDrift compares the live NATS resources with the rendered manifests before they are applied. A resource
+// has drifted if another field manager, e.g. kubectl edit, has set a field of the rendered manifest to a different
+// value. The drifted resources are reported in the Drifted condition and as an event. By default, the drift is
+// corrected by applying the rendered manifests. If the NATS CR has the report-only drift policy, the drifted
+// resources are not applied and the returned release instance only contains the resources which are in sync.
+func (r *Reconciler) syncDrift(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	instance *chart.ReleaseInstance, log *zap.SugaredLogger,
+) (*chart.ReleaseInstance, error) {
+	var drifts []string
+	inSync := make([]*unstructured.Unstructured, 0, len(instance.RenderedManifests.Items))
+	for _, object := range instance.RenderedManifests.Items {
+		conflicts, err := r.kubeClient.GetFieldConflicts(ctx, object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s %s/%s for drift: %w", object.GetKind(),
+				object.GetNamespace(), object.GetName(), err)
+		}
+		if len(conflicts) == 0 {
+			inSync = append(inSync, object)
+			continue
+		}
+		log.Infow("detected drift of NATS resource", "kind", object.GetKind(),
+			"namespace", object.GetNamespace(), "name", object.GetName(), "conflicts", conflicts)
+		drifts = append(drifts, driftSummary(object, conflicts))
+	}
+
+	if len(drifts) == 0 {
+		// the condition is only added once a drift was detected.
+		if condition := nats.Status.FindCondition(nmapiv1alpha1.ConditionDrifted); condition != nil &&
+			condition.Status == kmetav1.ConditionTrue {
+			nats.Status.UpdateConditionDrifted(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonInSync,
+				"The NATS resources match the rendered manifests.")
+		}
+		return instance, nil
+	}
+
+	summary := strings.Join(drifts, "; ")
+	if nats.IsDriftReportOnly() {
+		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonDriftDetected,
+			"Detected drift of NATS resources which is not corrected: %s", summary)
+		nats.Status.UpdateConditionDrifted(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonDriftDetected,
+			fmt.Sprintf("The drifted NATS resources are not applied because of the %s drift policy: %s",
+				nmapiv1alpha1.DriftPolicyReportOnly, summary))
+		reportOnlyInstance := *instance
+		reportOnlyInstance.SetRenderedManifests(chart.ManifestResources{Items: inSync})
+		return &reportOnlyInstance, nil
+	}
+
+	events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonDriftCorrected,
+		"Corrected drift of NATS resources: %s", summary)
+	nats.Status.UpdateConditionDrifted(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonDriftCorrected,
+		fmt.Sprintf("Corrected drift of NATS resources: %s", summary))
+	return instance, nil
+}
+
+// driftSummary lists the drifted fields of the object and who changed them,
+// e.g. ConfigMap kyma-system/eventing-nats: .data.nats.conf ("kubectl-edit" using v1).
+func driftSummary(object *unstructured.Unstructured, conflicts []k8s.FieldConflict) string {
+	fields := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		fields = append(fields, fmt.Sprintf("%s (%s)", conflict.Field, conflict.Manager))
+	}
+	return fmt.Sprintf("%s %s/%s: %s", object.GetKind(), object.GetNamespace(), object.GetName(),
+		strings.Join(fields, ", "))
+}
@@ -0,0 +1,123 @@
+package nats
+
+import (
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/k8s"
+	"github.com/kyma-project/nats-manager/pkg/k8s/chart"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_syncDrift(t *testing.T) {
+	t.Parallel()
+
+	statefulSet := newRenderedObject(nmapiv1alpha1.InventoryEntry{
+		APIVersion: "apps/v1", Kind: "StatefulSet", Name: "eventing-nats", Namespace: "kyma-system",
+	})
+	configMap := newRenderedObject(nmapiv1alpha1.InventoryEntry{
+		APIVersion: "v1", Kind: "ConfigMap", Name: "eventing-nats-config", Namespace: "kyma-system",
+	})
+	configMapConflicts := []k8s.FieldConflict{
+		{Field: ".data.nats.conf", Manager: `"kubectl-edit" using v1`},
+	}
+	wantSummary := `ConfigMap kyma-system/eventing-nats-config: .data.nats.conf ("kubectl-edit" using v1)`
+
+	testCases := []struct {
+		name                   string
+		givenDriftPolicy       string
+		givenConflicts         []k8s.FieldConflict
+		givenDriftedStatus     kmetav1.ConditionStatus
+		wantDeployed           []*unstructured.Unstructured
+		wantConditionStatus    kmetav1.ConditionStatus
+		wantConditionReason    nmapiv1alpha1.ConditionReason
+		wantConditionContains  string
+		wantK8sEventsContained string
+	}{
+		{
+			name:         "should not add the condition if the NATS resources never drifted",
+			wantDeployed: []*unstructured.Unstructured{statefulSet, configMap},
+		},
+		{
+			name:                   "should correct the drift by default",
+			givenConflicts:         configMapConflicts,
+			wantDeployed:           []*unstructured.Unstructured{statefulSet, configMap},
+			wantConditionStatus:    kmetav1.ConditionFalse,
+			wantConditionReason:    nmapiv1alpha1.ConditionReasonDriftCorrected,
+			wantConditionContains:  wantSummary,
+			wantK8sEventsContained: "Warning DriftCorrected Corrected drift of NATS resources: " + wantSummary,
+		},
+		{
+			name:                   "should only report the drift with the report-only drift policy",
+			givenDriftPolicy:       nmapiv1alpha1.DriftPolicyReportOnly,
+			givenConflicts:         configMapConflicts,
+			wantDeployed:           []*unstructured.Unstructured{statefulSet},
+			wantConditionStatus:    kmetav1.ConditionTrue,
+			wantConditionReason:    nmapiv1alpha1.ConditionReasonDriftDetected,
+			wantConditionContains:  wantSummary,
+			wantK8sEventsContained: "Warning DriftDetected Detected drift of NATS resources which is not corrected",
+		},
+		{
+			name:                  "should report that the drifted NATS resources are in sync again",
+			givenDriftPolicy:      nmapiv1alpha1.DriftPolicyReportOnly,
+			givenDriftedStatus:    kmetav1.ConditionTrue,
+			wantDeployed:          []*unstructured.Unstructured{statefulSet, configMap},
+			wantConditionStatus:   kmetav1.ConditionFalse,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonInSync,
+			wantConditionContains: "The NATS resources match the rendered manifests.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+			)
+			if tc.givenDriftPolicy != "" {
+				givenNATS.Annotations = map[string]string{nmapiv1alpha1.DriftPolicyAnnotation: tc.givenDriftPolicy}
+			}
+			if tc.givenDriftedStatus != "" {
+				givenNATS.Status.UpdateConditionDrifted(tc.givenDriftedStatus,
+					nmapiv1alpha1.ConditionReasonDriftDetected, "")
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+			testEnv.kubeClient.On("GetFieldConflicts", mock.Anything, statefulSet).Return(nil, nil)
+			testEnv.kubeClient.On("GetFieldConflicts", mock.Anything, configMap).Return(tc.givenConflicts, nil)
+
+			instance := chart.NewReleaseInstance(givenNATS.Name, givenNATS.Namespace, false, nil)
+			instance.SetRenderedManifests(chart.ManifestResources{
+				Items: []*unstructured.Unstructured{statefulSet, configMap},
+			})
+
+			// when
+			gotInstance, err := testEnv.Reconciler.syncDrift(testEnv.Context, givenNATS, instance, testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantDeployed, gotInstance.RenderedManifests.Items)
+			// the rendered manifests are kept, so that the drifted NATS resources are not pruned.
+			require.Len(t, instance.RenderedManifests.Items, 2)
+
+			if tc.wantK8sEventsContained != "" {
+				require.Contains(t, testEnv.GetK8sEvents()[0], tc.wantK8sEventsContained)
+			}
+			gotCondition := givenNATS.Status.FindCondition(nmapiv1alpha1.ConditionDrifted)
+			if tc.wantConditionStatus == "" {
+				require.Nil(t, gotCondition)
+				return
+			}
+			require.NotNil(t, gotCondition)
+			require.Equal(t, tc.wantConditionStatus, gotCondition.Status)
+			require.Equal(t, string(tc.wantConditionReason), gotCondition.Reason)
+			require.Contains(t, gotCondition.Message, tc.wantConditionContains)
+		})
+	}
+}
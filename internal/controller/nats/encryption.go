@@ -0,0 +1,165 @@
+package nats
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// EncryptionKeyVersionAnnotation is the annotation of the NATS Pods with the version of the Secret
+	// with the encryption keys which the NATS server was started with.
+	EncryptionKeyVersionAnnotation = "jetstream/encryption-key-version"
+	// EncryptionKeyCheckInterval is the interval in which the Secret referenced in the NATS CR is checked
+	// for a new encryption key if the encryption is enabled.
+	EncryptionKeyCheckInterval = 5 * time.Minute
+)
+
+var ErrEncryptionKeyInvalid = errors.New("the encryption key of the file storage is invalid")
+
+// syncEncryptionKey makes sure that the Secret with the encryption keys used by the NATS servers contains
+// the key of the Secret referenced in the NATS CR. A new key is rotated in two steps:
+//  1. The new key is stored with the current key as previous key. The NATS servers are restarted one by one
+//     and re-encrypt the file storage with the new key on startup.
+//  2. Once all NATS servers were restarted, the previous key is removed and the NATS servers are restarted again.
+//
+// A new key is only taken over when no rotation is in progress, so that every NATS server is able to decrypt
+// its file storage whenever it is restarted. It returns the Secret used by the NATS servers,
+// or nil if the encryption is disabled.
+func (r *Reconciler) syncEncryptionKey(ctx context.Context, nats *nmapiv1alpha1.NATS) (*kcorev1.Secret, error) {
+	if !nats.Spec.Encryption.Enabled {
+		nats.Status.RemoveCondition(nmapiv1alpha1.ConditionEncryptionKey)
+		return nil, nil
+	}
+
+	keySecret, err := r.getSecret(ctx, nats.EncryptionKeySecretName(), nats.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	key, message, err := r.getEncryptionKey(ctx, nats)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		nats.Status.UpdateConditionEncryptionKey(kmetav1.ConditionFalse,
+			nmapiv1alpha1.ConditionReasonKeyInvalid, message)
+		if keySecret == nil {
+			// the NATS servers cannot be started without a key.
+			return nil, fmt.Errorf("%w: %s", ErrEncryptionKeyInvalid, message)
+		}
+		// the NATS servers keep using the current key.
+		return keySecret, nil
+	}
+
+	if keySecret == nil {
+		data := map[string][]byte{nmapiv1alpha1.EncryptionKeySecretKey: key}
+		if err = r.createManagedSecret(ctx, nats, nats.EncryptionKeySecretName(), kcorev1.SecretTypeOpaque,
+			data); err != nil {
+			return nil, err
+		}
+		updateConditionEncryptionKeyActive(nats)
+		if nats.IsDryRun() {
+			// the Secret is only planned, so the resources are rendered with the key which it would contain.
+			return &kcorev1.Secret{Data: data}, nil
+		}
+		return r.getSecret(ctx, nats.EncryptionKeySecretName(), nats.Namespace)
+	}
+
+	currentKey := keySecret.Data[nmapiv1alpha1.EncryptionKeySecretKey]
+	_, isRotating := keySecret.Data[nmapiv1alpha1.EncryptionPreviousKeySecretKey]
+	isNewKey := !bytes.Equal(currentKey, key)
+	if !isRotating && !isNewKey {
+		updateConditionEncryptionKeyActive(nats)
+		return keySecret, nil
+	}
+
+	if isRotating {
+		rolledOut, rolloutErr := r.isEncryptionKeyRolledOut(ctx, nats, keySecret.ResourceVersion)
+		if rolloutErr != nil {
+			return nil, rolloutErr
+		}
+		if !rolledOut {
+			message = "The encryption key is being rotated and the NATS servers are restarted one by one."
+			if isNewKey {
+				message += fmt.Sprintf(" The new key of Secret %s is used once the rotation is completed.",
+					nats.Spec.Encryption.SecretName)
+			}
+			nats.Status.UpdateConditionEncryptionKey(kmetav1.ConditionFalse,
+				nmapiv1alpha1.ConditionReasonKeyRotating, message)
+			return keySecret, nil
+		}
+	}
+
+	data := map[string][]byte{nmapiv1alpha1.EncryptionKeySecretKey: key}
+	message = "The encryption key is rotated, the previous key is removed from the NATS servers."
+	if isNewKey {
+		data[nmapiv1alpha1.EncryptionPreviousKeySecretKey] = currentKey
+		message = "The encryption key is being rotated and the NATS servers are restarted one by one."
+	}
+	if err = r.updateManagedSecret(ctx, nats, keySecret, data); err != nil {
+		return nil, err
+	}
+	nats.Status.UpdateConditionEncryptionKey(kmetav1.ConditionFalse,
+		nmapiv1alpha1.ConditionReasonKeyRotating, message)
+	return keySecret, nil
+}
+
+// getEncryptionKey returns the encryption key of the Secret referenced in the NATS CR.
+// If the key is missing, it returns nil and a message which describes the problem.
+func (r *Reconciler) getEncryptionKey(ctx context.Context, nats *nmapiv1alpha1.NATS) ([]byte, string, error) {
+	secretName := nats.Spec.Encryption.SecretName
+	secret, err := r.getSecret(ctx, secretName, nats.Namespace)
+	if err != nil {
+		return nil, "", err
+	}
+	if secret == nil {
+		return nil, fmt.Sprintf("Secret %s does not exist.", secretName), nil
+	}
+	key := secret.Data[nmapiv1alpha1.EncryptionKeySecretKey]
+	if len(key) == 0 {
+		return nil, fmt.Sprintf("Secret %s does not contain the key %q.",
+			secretName, nmapiv1alpha1.EncryptionKeySecretKey), nil
+	}
+	return key, "", nil
+}
+
+// isEncryptionKeyRolledOut checks if all NATS servers were restarted with the given version of the Secret
+// with the encryption keys.
+func (r *Reconciler) isEncryptionKeyRolledOut(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	version string,
+) (bool, error) {
+	sts := &kappsv1.StatefulSet{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: nats.Name, Namespace: nats.Namespace}, sts)
+	if kapierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if sts.Spec.Template.Annotations[EncryptionKeyVersionAnnotation] != version {
+		return false, nil
+	}
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	return sts.Status.ObservedGeneration == sts.Generation &&
+		sts.Status.CurrentRevision == sts.Status.UpdateRevision &&
+		sts.Status.UpdatedReplicas == replicas &&
+		sts.Status.ReadyReplicas == replicas, nil
+}
+
+func updateConditionEncryptionKeyActive(nats *nmapiv1alpha1.NATS) {
+	nats.Status.UpdateConditionEncryptionKey(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonKeyActive,
+		fmt.Sprintf("The file storage is encrypted with the key of Secret %s.", nats.Spec.Encryption.SecretName))
+}
@@ -0,0 +1,235 @@
+package nats
+
+import (
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/require"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_syncEncryptionKey(t *testing.T) {
+	t.Parallel()
+
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+		testutils.WithNATSEncryption(nmapiv1alpha1.JetStreamEncryption{
+			Enabled:    true,
+			SecretName: "nats-encryption",
+			Cipher:     nmapiv1alpha1.EncryptionCipherChaCha,
+		}),
+	)
+	newKeySecret := func(name string, data map[string]string) *kcorev1.Secret {
+		secret := &kcorev1.Secret{
+			ObjectMeta: kmetav1.ObjectMeta{Name: name, Namespace: "kyma-system", ResourceVersion: "5"},
+			Data:       map[string][]byte{},
+		}
+		for key, value := range data {
+			secret.Data[key] = []byte(value)
+		}
+		return secret
+	}
+	newStatefulSet := func(version string) *kappsv1.StatefulSet {
+		replicas := int32(3)
+		return &kappsv1.StatefulSet{
+			ObjectMeta: kmetav1.ObjectMeta{Name: "eventing-nats", Namespace: "kyma-system"},
+			Spec: kappsv1.StatefulSetSpec{
+				Replicas: &replicas,
+				Template: kcorev1.PodTemplateSpec{
+					ObjectMeta: kmetav1.ObjectMeta{
+						Annotations: map[string]string{EncryptionKeyVersionAnnotation: version},
+					},
+				},
+			},
+			Status: kappsv1.StatefulSetStatus{
+				CurrentRevision: "eventing-nats-2",
+				UpdateRevision:  "eventing-nats-2",
+				UpdatedReplicas: 3,
+				ReadyReplicas:   3,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		givenEncryption  *nmapiv1alpha1.JetStreamEncryption
+		givenObjects     []client.Object
+		wantErr          error
+		wantKeys         map[string]string
+		wantReason       nmapiv1alpha1.ConditionReason
+		wantNoCondition  bool
+		wantSecretAbsent bool
+	}{
+		{
+			name:             "should do nothing if the encryption is disabled",
+			givenEncryption:  &nmapiv1alpha1.JetStreamEncryption{},
+			wantNoCondition:  true,
+			wantSecretAbsent: true,
+		},
+		{
+			name:             "should fail if the Secret does not exist",
+			wantErr:          ErrEncryptionKeyInvalid,
+			wantReason:       nmapiv1alpha1.ConditionReasonKeyInvalid,
+			wantSecretAbsent: true,
+		},
+		{
+			name: "should keep the current key if the Secret misses the key",
+			givenObjects: []client.Object{
+				newKeySecret("nats-encryption", map[string]string{"other": "value"}),
+				newKeySecret("eventing-nats-jetstream-encryption", map[string]string{"key": "k1"}),
+			},
+			wantKeys:   map[string]string{"key": "k1"},
+			wantReason: nmapiv1alpha1.ConditionReasonKeyInvalid,
+		},
+		{
+			name: "should copy the key",
+			givenObjects: []client.Object{
+				newKeySecret("nats-encryption", map[string]string{"key": "k1"}),
+			},
+			wantKeys:   map[string]string{"key": "k1"},
+			wantReason: nmapiv1alpha1.ConditionReasonKeyActive,
+		},
+		{
+			name: "should keep the key if it did not change",
+			givenObjects: []client.Object{
+				newKeySecret("nats-encryption", map[string]string{"key": "k1"}),
+				newKeySecret("eventing-nats-jetstream-encryption", map[string]string{"key": "k1"}),
+			},
+			wantKeys:   map[string]string{"key": "k1"},
+			wantReason: nmapiv1alpha1.ConditionReasonKeyActive,
+		},
+		{
+			name: "should start the rotation of a new key",
+			givenObjects: []client.Object{
+				newKeySecret("nats-encryption", map[string]string{"key": "k2"}),
+				newKeySecret("eventing-nats-jetstream-encryption", map[string]string{"key": "k1"}),
+			},
+			wantKeys:   map[string]string{"key": "k2", "previousKey": "k1"},
+			wantReason: nmapiv1alpha1.ConditionReasonKeyRotating,
+		},
+		{
+			name: "should not take over a new key while the NATS servers are restarted",
+			givenObjects: []client.Object{
+				newKeySecret("nats-encryption", map[string]string{"key": "k3"}),
+				newKeySecret("eventing-nats-jetstream-encryption", map[string]string{"key": "k2", "previousKey": "k1"}),
+				newStatefulSet("4"),
+			},
+			wantKeys:   map[string]string{"key": "k2", "previousKey": "k1"},
+			wantReason: nmapiv1alpha1.ConditionReasonKeyRotating,
+		},
+		{
+			name: "should remove the previous key once the NATS servers were restarted",
+			givenObjects: []client.Object{
+				newKeySecret("nats-encryption", map[string]string{"key": "k2"}),
+				newKeySecret("eventing-nats-jetstream-encryption", map[string]string{"key": "k2", "previousKey": "k1"}),
+				newStatefulSet("5"),
+			},
+			wantKeys:   map[string]string{"key": "k2"},
+			wantReason: nmapiv1alpha1.ConditionReasonKeyRotating,
+		},
+		{
+			name: "should take over a new key once the NATS servers were restarted",
+			givenObjects: []client.Object{
+				newKeySecret("nats-encryption", map[string]string{"key": "k3"}),
+				newKeySecret("eventing-nats-jetstream-encryption", map[string]string{"key": "k2", "previousKey": "k1"}),
+				newStatefulSet("5"),
+			},
+			wantKeys:   map[string]string{"key": "k3", "previousKey": "k2"},
+			wantReason: nmapiv1alpha1.ConditionReasonKeyRotating,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			nats := givenNATS.DeepCopy()
+			if tc.givenEncryption != nil {
+				nats.Spec.Encryption = *tc.givenEncryption
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, append(tc.givenObjects, nats)...)
+
+			// when
+			gotSecret, err := testEnv.Reconciler.syncEncryptionKey(testEnv.Context, nats)
+
+			// then
+			require.ErrorIs(t, err, tc.wantErr)
+			gotCondition := nats.Status.FindCondition(nmapiv1alpha1.ConditionEncryptionKey)
+			if tc.wantNoCondition {
+				require.Nil(t, gotCondition)
+			} else {
+				require.NotNil(t, gotCondition)
+				require.Equal(t, string(tc.wantReason), gotCondition.Reason)
+			}
+
+			keySecret := &kcorev1.Secret{}
+			err = testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: "eventing-nats-jetstream-encryption", Namespace: "kyma-system"}, keySecret)
+			if tc.wantSecretAbsent {
+				require.Error(t, err)
+				require.Nil(t, gotSecret)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, keySecret.ResourceVersion, gotSecret.ResourceVersion)
+			gotKeys := map[string]string{}
+			for key, value := range keySecret.Data {
+				gotKeys[key] = string(value)
+			}
+			require.Equal(t, tc.wantKeys, gotKeys)
+		})
+	}
+}
+
+func Test_syncEncryptionKey_DryRun(t *testing.T) {
+	t.Parallel()
+
+	// given
+	nats := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+		testutils.WithNATSEncryption(nmapiv1alpha1.JetStreamEncryption{
+			Enabled:    true,
+			SecretName: "nats-encryption",
+			Cipher:     nmapiv1alpha1.EncryptionCipherChaCha,
+		}),
+	)
+	nats.Annotations = map[string]string{nmapiv1alpha1.DryRunAnnotation: "true"}
+	testEnv := NewMockedUnitTestEnvironment(t, nats,
+		&kcorev1.Secret{
+			ObjectMeta: kmetav1.ObjectMeta{Name: "nats-encryption", Namespace: "kyma-system"},
+			Data:       map[string][]byte{"key": []byte("k2")},
+		},
+		&kcorev1.Secret{
+			ObjectMeta: kmetav1.ObjectMeta{Name: "eventing-nats-jetstream-encryption", Namespace: "kyma-system"},
+			Data:       map[string][]byte{"key": []byte("k1")},
+		},
+	)
+
+	// when
+	gotSecret, err := testEnv.Reconciler.syncEncryptionKey(testEnv.Context, nats)
+
+	// then, the resources are rendered with the rotated keys, but the Secret keeps the current key.
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"key": []byte("k2"), "previousKey": []byte("k1")}, gotSecret.Data)
+
+	keySecret := &kcorev1.Secret{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: "eventing-nats-jetstream-encryption", Namespace: "kyma-system"}, keySecret))
+	require.Equal(t, map[string][]byte{"key": []byte("k1")}, keySecret.Data)
+
+	require.Equal(t, []PlanChange{{
+		InventoryEntry: nmapiv1alpha1.InventoryEntry{
+			APIVersion: "v1", Kind: "Secret", Name: "eventing-nats-jetstream-encryption", Namespace: "kyma-system",
+		},
+		Action: PlanActionModify,
+		Fields: []string{".data.key", ".data.previousKey"},
+	}}, testEnv.Reconciler.plannedSecrets["kyma-system/eventing-nats"])
+}
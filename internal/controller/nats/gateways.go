@@ -0,0 +1,75 @@
+package nats
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmctrlclientconfig "github.com/kyma-project/nats-manager/internal/controller/nats/clientconfig"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	"go.uber.org/zap"
+)
+
+// syncGatewaysStatus reports the remote gateways of the supercluster and their connections in the NATS CR status.
+// The gateways are read from the monitoring endpoint of one of the NATS servers. If the endpoint cannot be read,
+// the last known gateways are kept, because the reachability of the gateways does not affect the NATS state.
+func (r *Reconciler) syncGatewaysStatus(ctx context.Context, nats *nmapiv1alpha1.NATS, log *zap.SugaredLogger) {
+	if !nats.Spec.Gateways.Enabled {
+		nats.Status.Gateways = nil
+		return
+	}
+
+	gatewayz, err := r.getGatewayz(ctx, nats)
+	if err != nil {
+		log.Warnw("failed to read the gateways from the NATS monitoring endpoint", "error", err)
+		return
+	}
+	nats.Status.Gateways = gatewayStatuses(gatewayz)
+}
+
+func (r *Reconciler) getGatewayz(ctx context.Context, nats *nmapiv1alpha1.NATS) (*monitor.Gatewayz, error) {
+	config, err := nmctrlclientconfig.NewMonitor(ctx, r.Client, nats)
+	if err != nil {
+		return nil, err
+	}
+	monitorClient, err := r.newMonitorClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return monitorClient.Gatewayz(ctx)
+}
+
+// gatewayStatuses returns the status of all remote gateways with an outbound or inbound connection, sorted by name.
+// The own gateway is skipped, as the same list of gateways is usually configured in all clusters of a supercluster.
+func gatewayStatuses(gatewayz *monitor.Gatewayz) []nmapiv1alpha1.GatewayStatus {
+	statuses := map[string]*nmapiv1alpha1.GatewayStatus{}
+	status := func(name string) *nmapiv1alpha1.GatewayStatus {
+		if _, ok := statuses[name]; !ok {
+			statuses[name] = &nmapiv1alpha1.GatewayStatus{Name: name}
+		}
+		return statuses[name]
+	}
+
+	for name, outbound := range gatewayz.OutboundGateways {
+		if name == gatewayz.Name {
+			continue
+		}
+		status(name).Connected = outbound != nil && outbound.Connection != nil
+	}
+	for name, inbound := range gatewayz.InboundGateways {
+		if name == gatewayz.Name {
+			continue
+		}
+		status(name).InboundConnections = len(inbound)
+	}
+
+	result := make([]nmapiv1alpha1.GatewayStatus, 0, len(statuses))
+	for _, gatewayStatus := range statuses {
+		result = append(result, *gatewayStatus)
+	}
+	slices.SortFunc(result, func(a, b nmapiv1alpha1.GatewayStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result
+}
@@ -0,0 +1,91 @@
+package nats
+
+import (
+	"errors"
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	nmmonitormocks "github.com/kyma-project/nats-manager/pkg/nats/monitor/mocks"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_syncGatewaysStatus(t *testing.T) {
+	t.Parallel()
+
+	givenGatewayz := &monitor.Gatewayz{
+		Name: "eu",
+		OutboundGateways: map[string]*monitor.RemoteGatewayz{
+			"eu": {IsConfigured: true},
+			"us": {IsConfigured: true, Connection: &monitor.ConnInfo{Cid: 1}},
+			"ap": {IsConfigured: true},
+		},
+		InboundGateways: map[string][]*monitor.RemoteGatewayz{
+			"us": {{Connection: &monitor.ConnInfo{Cid: 2}}, {Connection: &monitor.ConnInfo{Cid: 3}}},
+		},
+	}
+	givenStatus := []nmapiv1alpha1.GatewayStatus{{Name: "us", Connected: true}}
+
+	testCases := []struct {
+		name          string
+		givenGateways nmapiv1alpha1.Gateways
+		givenGatewayz *monitor.Gatewayz
+		givenError    error
+		wantStatus    []nmapiv1alpha1.GatewayStatus
+	}{
+		{
+			name:          "should clear the status if gateways are disabled",
+			givenGateways: nmapiv1alpha1.Gateways{},
+			wantStatus:    nil,
+		},
+		{
+			name:          "should report the remote gateways sorted by name",
+			givenGateways: nmapiv1alpha1.Gateways{Enabled: true, Name: "eu"},
+			givenGatewayz: givenGatewayz,
+			wantStatus: []nmapiv1alpha1.GatewayStatus{
+				{Name: "ap", Connected: false},
+				{Name: "us", Connected: true, InboundConnections: 2},
+			},
+		},
+		{
+			name:          "should keep the last known gateways if the monitoring endpoint fails",
+			givenGateways: nmapiv1alpha1.Gateways{Enabled: true, Name: "eu"},
+			givenError:    errors.New("connection refused"),
+			wantStatus:    givenStatus,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSGateways(tc.givenGateways),
+			)
+			givenNATS.Status.Gateways = givenStatus
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+
+			monitorClient := new(nmmonitormocks.Client)
+			monitorClient.On("Gatewayz", mock.Anything).Return(tc.givenGatewayz, tc.givenError)
+			var gotConfig *monitor.Config
+			testEnv.Reconciler.newMonitorClient = func(config *monitor.Config) (monitor.Client, error) {
+				gotConfig = config
+				return monitorClient, nil
+			}
+
+			// when
+			testEnv.Reconciler.syncGatewaysStatus(testEnv.Context, givenNATS, testEnv.Logger)
+
+			// then
+			require.Equal(t, tc.wantStatus, givenNATS.Status.Gateways)
+			if tc.givenGateways.Enabled {
+				require.Equal(t, "http://eventing-nats.kyma-system.svc.cluster.local:8222", gotConfig.URL)
+			}
+		})
+	}
+}
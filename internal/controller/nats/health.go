@@ -0,0 +1,164 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmctrlclientconfig "github.com/kyma-project/nats-manager/internal/controller/nats/clientconfig"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	nmmgr "github.com/kyma-project/nats-manager/pkg/manager"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	"go.uber.org/zap"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// natsServerState is the state of a NATS server as reported by its monitoring endpoint.
+type natsServerState struct {
+	podName string
+	varz    *monitor.Varz
+	routez  *monitor.Routez
+	jsz     *monitor.Jsz
+	healthz *monitor.Healthz
+	// err is set if the monitoring endpoint could not be read.
+	err error
+}
+
+// syncClusterHealth sets the ClusterFormed and JetStreamHealthy conditions from the state of all NATS servers.
+// A ready StatefulSet only means that the Pods are ready, but not that the NATS servers formed a cluster or
+// that JetStream elected a meta leader. It returns false if one of the conditions is not met. If a monitoring
+// endpoint could not be read, the conditions are unknown, but the NATS state is not affected.
+func (r *Reconciler) syncClusterHealth(nats *nmapiv1alpha1.NATS, servers []*natsServerState,
+	log *zap.SugaredLogger,
+) bool {
+	clustered := nats.Spec.Cluster.Size >= nmmgr.MinClusterSize
+	if !clustered {
+		nats.Status.UpdateConditionClusterFormed(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonNotConfigured,
+			"NATS is not configured to run in cluster mode (i.e. spec.cluster.size < 3).")
+	}
+
+	for _, server := range servers {
+		if server.err == nil {
+			continue
+		}
+		log.Warnw("failed to read the NATS monitoring endpoints", "error", server.err)
+		if clustered {
+			nats.Status.UpdateConditionClusterFormed(kmetav1.ConditionUnknown,
+				nmapiv1alpha1.ConditionReasonNATSUnreachable, server.err.Error())
+		}
+		nats.Status.UpdateConditionJetStreamHealthy(kmetav1.ConditionUnknown,
+			nmapiv1alpha1.ConditionReasonNATSUnreachable, server.err.Error())
+		return true
+	}
+
+	healthy := true
+	if clustered {
+		if msg := missingRoutes(servers); msg != "" {
+			nats.Status.UpdateConditionClusterFormed(kmetav1.ConditionFalse,
+				nmapiv1alpha1.ConditionReasonRoutesMissing, msg)
+			events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonRoutesMissing, msg)
+			healthy = false
+		} else {
+			nats.Status.UpdateConditionClusterFormed(kmetav1.ConditionTrue,
+				nmapiv1alpha1.ConditionReasonRoutesEstablished, "All NATS servers are connected to each other.")
+		}
+	}
+
+	if msg := jetStreamUnhealthyReason(servers, clustered); msg != "" {
+		nats.Status.UpdateConditionJetStreamHealthy(kmetav1.ConditionFalse,
+			nmapiv1alpha1.ConditionReasonJetStreamUnhealthy, msg)
+		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonJetStreamUnhealthy, msg)
+		healthy = false
+	} else {
+		nats.Status.UpdateConditionJetStreamHealthy(kmetav1.ConditionTrue,
+			nmapiv1alpha1.ConditionReasonJetStreamHealthy, "JetStream is healthy on all NATS servers.")
+	}
+	return healthy
+}
+
+// getNATSServerStates reads the state of all NATS servers of the cluster from their monitoring endpoints.
+func (r *Reconciler) getNATSServerStates(ctx context.Context, nats *nmapiv1alpha1.NATS) []*natsServerState {
+	servers := make([]*natsServerState, 0, nats.Spec.Cluster.Size)
+	for ordinal := range nats.Spec.Cluster.Size {
+		podName := fmt.Sprintf("%s-%d", nats.Name, ordinal)
+		server, err := r.getNATSServerState(ctx, nats, podName)
+		if err != nil {
+			server = &natsServerState{
+				podName: podName,
+				err:     fmt.Errorf("failed to read the monitoring endpoint of NATS server %s: %w", podName, err),
+			}
+		}
+		servers = append(servers, server)
+	}
+	return servers
+}
+
+func (r *Reconciler) getNATSServerState(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	podName string,
+) (*natsServerState, error) {
+	config, err := nmctrlclientconfig.NewPodMonitor(ctx, r.Client, nats, podName)
+	if err != nil {
+		return nil, err
+	}
+	monitorClient, err := r.newMonitorClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &natsServerState{podName: podName}
+	if server.varz, err = monitorClient.Varz(ctx); err != nil {
+		return nil, err
+	}
+	if server.routez, err = monitorClient.Routez(ctx); err != nil {
+		return nil, err
+	}
+	if server.jsz, err = monitorClient.Jsz(ctx); err != nil {
+		return nil, err
+	}
+	if server.healthz, err = monitorClient.Healthz(ctx); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// missingRoutes returns which NATS server is not connected to all other NATS servers, or an empty string
+// if the cluster is formed. With route pooling, a NATS server has multiple routes to each other NATS server,
+// so the routes are counted by the name of the remote NATS server.
+func missingRoutes(servers []*natsServerState) string {
+	for _, server := range servers {
+		var missing []string
+		for _, other := range servers {
+			if other == server {
+				continue
+			}
+			connected := slices.ContainsFunc(server.routez.Routes, func(route *monitor.RouteInfo) bool {
+				return route.RemoteName == other.varz.ServerName
+			})
+			if !connected {
+				missing = append(missing, other.varz.ServerName)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Sprintf("NATS server %s has no route to %s.", server.podName, strings.Join(missing, ", "))
+		}
+	}
+	return ""
+}
+
+// jetStreamUnhealthyReason returns why JetStream is not healthy on one of the NATS servers, or an empty string
+// if it is healthy on all of them. In cluster mode, each NATS server must know the leader of the meta group.
+func jetStreamUnhealthyReason(servers []*natsServerState, clustered bool) string {
+	for _, server := range servers {
+		switch {
+		case server.jsz.Disabled:
+			return fmt.Sprintf("JetStream is disabled on NATS server %s.", server.podName)
+		case clustered && (server.jsz.Meta == nil || server.jsz.Meta.Leader == ""):
+			return fmt.Sprintf("NATS server %s has no JetStream meta leader.", server.podName)
+		case !server.healthz.IsHealthy():
+			return fmt.Sprintf("NATS server %s is not healthy: %s.", server.podName, server.healthz.Error)
+		}
+	}
+	return ""
+}
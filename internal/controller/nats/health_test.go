@@ -0,0 +1,240 @@
+package nats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/k8s/chart"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	nmmonitormocks "github.com/kyma-project/nats-manager/pkg/nats/monitor/mocks"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_syncClusterHealth(t *testing.T) {
+	t.Parallel()
+
+	healthyCluster := func() map[string]*natsServerState {
+		return map[string]*natsServerState{
+			"eventing-nats-0": newNATSServerState("eventing-nats-0", "eventing-nats-1", "eventing-nats-2"),
+			"eventing-nats-1": newNATSServerState("eventing-nats-1", "eventing-nats-0", "eventing-nats-2"),
+			"eventing-nats-2": newNATSServerState("eventing-nats-2", "eventing-nats-0", "eventing-nats-1"),
+		}
+	}
+
+	testCases := []struct {
+		name                 string
+		givenClusterSize     int
+		givenServers         func() map[string]*natsServerState
+		wantHealthy          bool
+		wantClusterFormed    kmetav1.Condition
+		wantJetStreamHealthy kmetav1.Condition
+	}{
+		{
+			name:             "should report a formed cluster with healthy JetStream",
+			givenClusterSize: 3,
+			givenServers:     healthyCluster,
+			wantHealthy:      true,
+			wantClusterFormed: kmetav1.Condition{
+				Status:  kmetav1.ConditionTrue,
+				Reason:  string(nmapiv1alpha1.ConditionReasonRoutesEstablished),
+				Message: "All NATS servers are connected to each other.",
+			},
+			wantJetStreamHealthy: kmetav1.Condition{
+				Status:  kmetav1.ConditionTrue,
+				Reason:  string(nmapiv1alpha1.ConditionReasonJetStreamHealthy),
+				Message: "JetStream is healthy on all NATS servers.",
+			},
+		},
+		{
+			name:             "should report the missing routes of a NATS server",
+			givenClusterSize: 3,
+			givenServers: func() map[string]*natsServerState {
+				servers := healthyCluster()
+				servers["eventing-nats-1"] = newNATSServerState("eventing-nats-1", "eventing-nats-0")
+				return servers
+			},
+			wantHealthy: false,
+			wantClusterFormed: kmetav1.Condition{
+				Status:  kmetav1.ConditionFalse,
+				Reason:  string(nmapiv1alpha1.ConditionReasonRoutesMissing),
+				Message: "NATS server eventing-nats-1 has no route to eventing-nats-2.",
+			},
+			wantJetStreamHealthy: kmetav1.Condition{
+				Status:  kmetav1.ConditionTrue,
+				Reason:  string(nmapiv1alpha1.ConditionReasonJetStreamHealthy),
+				Message: "JetStream is healthy on all NATS servers.",
+			},
+		},
+		{
+			name:             "should report JetStream as unhealthy without a meta leader",
+			givenClusterSize: 3,
+			givenServers: func() map[string]*natsServerState {
+				servers := healthyCluster()
+				servers["eventing-nats-2"].jsz.Meta.Leader = ""
+				return servers
+			},
+			wantHealthy: false,
+			wantClusterFormed: kmetav1.Condition{
+				Status:  kmetav1.ConditionTrue,
+				Reason:  string(nmapiv1alpha1.ConditionReasonRoutesEstablished),
+				Message: "All NATS servers are connected to each other.",
+			},
+			wantJetStreamHealthy: kmetav1.Condition{
+				Status:  kmetav1.ConditionFalse,
+				Reason:  string(nmapiv1alpha1.ConditionReasonJetStreamUnhealthy),
+				Message: "NATS server eventing-nats-2 has no JetStream meta leader.",
+			},
+		},
+		{
+			name:             "should report JetStream as unhealthy if a NATS server is not healthy",
+			givenClusterSize: 3,
+			givenServers: func() map[string]*natsServerState {
+				servers := healthyCluster()
+				servers["eventing-nats-0"].healthz = &monitor.Healthz{
+					Status: "unavailable",
+					Error:  "JetStream stream 'orders' is not current",
+				}
+				return servers
+			},
+			wantHealthy: false,
+			wantClusterFormed: kmetav1.Condition{
+				Status:  kmetav1.ConditionTrue,
+				Reason:  string(nmapiv1alpha1.ConditionReasonRoutesEstablished),
+				Message: "All NATS servers are connected to each other.",
+			},
+			wantJetStreamHealthy: kmetav1.Condition{
+				Status: kmetav1.ConditionFalse,
+				Reason: string(nmapiv1alpha1.ConditionReasonJetStreamUnhealthy),
+				Message: "NATS server eventing-nats-0 is not healthy: " +
+					"JetStream stream 'orders' is not current.",
+			},
+		},
+		{
+			name:             "should not require a cluster for a single NATS server",
+			givenClusterSize: 1,
+			givenServers: func() map[string]*natsServerState {
+				server := newNATSServerState("eventing-nats-0")
+				server.jsz.Meta = nil
+				return map[string]*natsServerState{"eventing-nats-0": server}
+			},
+			wantHealthy: true,
+			wantClusterFormed: kmetav1.Condition{
+				Status:  kmetav1.ConditionFalse,
+				Reason:  string(nmapiv1alpha1.ConditionReasonNotConfigured),
+				Message: "NATS is not configured to run in cluster mode (i.e. spec.cluster.size < 3).",
+			},
+			wantJetStreamHealthy: kmetav1.Condition{
+				Status:  kmetav1.ConditionTrue,
+				Reason:  string(nmapiv1alpha1.ConditionReasonJetStreamHealthy),
+				Message: "JetStream is healthy on all NATS servers.",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSClusterSize(tc.givenClusterSize),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+			mockMonitorClients(t, testEnv.Reconciler, tc.givenServers())
+
+			// when
+			servers := testEnv.Reconciler.getNATSServerStates(testEnv.Context, givenNATS)
+			gotHealthy := testEnv.Reconciler.syncClusterHealth(givenNATS, servers, testEnv.Logger)
+
+			// then
+			require.Equal(t, tc.wantHealthy, gotHealthy)
+			gotClusterFormed := givenNATS.Status.FindCondition(nmapiv1alpha1.ConditionClusterFormed)
+			require.NotNil(t, gotClusterFormed)
+			require.Equal(t, tc.wantClusterFormed.Status, gotClusterFormed.Status)
+			require.Equal(t, tc.wantClusterFormed.Reason, gotClusterFormed.Reason)
+			require.Equal(t, tc.wantClusterFormed.Message, gotClusterFormed.Message)
+			gotJetStreamHealthy := givenNATS.Status.FindCondition(nmapiv1alpha1.ConditionJetStreamHealthy)
+			require.NotNil(t, gotJetStreamHealthy)
+			require.Equal(t, tc.wantJetStreamHealthy.Status, gotJetStreamHealthy.Status)
+			require.Equal(t, tc.wantJetStreamHealthy.Reason, gotJetStreamHealthy.Reason)
+			require.Equal(t, tc.wantJetStreamHealthy.Message, gotJetStreamHealthy.Message)
+		})
+	}
+}
+
+func Test_handleNATSState_UnhealthyCluster(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+		testutils.WithNATSClusterSize(3),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+	mockMonitorClients(t, testEnv.Reconciler, map[string]*natsServerState{
+		"eventing-nats-0": newNATSServerState("eventing-nats-0", "eventing-nats-1"),
+		"eventing-nats-1": newNATSServerState("eventing-nats-1", "eventing-nats-0"),
+		"eventing-nats-2": newNATSServerState("eventing-nats-2"),
+	})
+	testEnv.natsManager.On("IsNATSStatefulSetReady", mock.Anything, mock.Anything).Return(true, nil).Once()
+	testEnv.kubeClient.On("GetNumberOfAvailabilityZonesUsedByPods",
+		mock.Anything, mock.Anything, mock.Anything).Return(3, nil).Once()
+
+	// when
+	result, err := testEnv.Reconciler.handleNATSState(testEnv.Context, givenNATS,
+		&chart.ReleaseInstance{Name: givenNATS.Name, Namespace: givenNATS.Namespace}, testEnv.Logger)
+
+	// then
+	require.NoError(t, err)
+	require.InDelta(t, DefaultStatusCheckMinInterval, result.RequeueAfter, float64(2*time.Second))
+	gotNATS, err := testEnv.GetNATS(givenNATS.Name, givenNATS.Namespace)
+	require.NoError(t, err)
+	require.Equal(t, nmapiv1alpha1.StateWarning, gotNATS.Status.State)
+	require.Equal(t, []string{
+		"Normal Deployed StatefulSet is ready and NATS is deployed.",
+		"Warning RoutesMissing NATS server eventing-nats-0 has no route to eventing-nats-2.",
+		"Normal Deployed NATS is deployed in different availability zones.",
+	}, testEnv.GetK8sEvents())
+}
+
+// newNATSServerState returns the state of a healthy NATS server with routes to the given NATS servers.
+func newNATSServerState(name string, routes ...string) *natsServerState {
+	server := &natsServerState{
+		podName: name,
+		varz:    &monitor.Varz{ServerName: name},
+		routez:  &monitor.Routez{ServerName: name},
+		jsz:     &monitor.Jsz{Meta: &monitor.MetaClusterInfo{Leader: "eventing-nats-0"}},
+		healthz: &monitor.Healthz{Status: monitor.HealthzStatusOK},
+	}
+	for _, route := range routes {
+		server.routez.Routes = append(server.routez.Routes, &monitor.RouteInfo{RemoteName: route})
+	}
+	return server
+}
+
+// mockMonitorClients mocks the monitoring endpoints of the given NATS servers by their Pod name.
+func mockMonitorClients(t *testing.T, reconciler *Reconciler, servers map[string]*natsServerState) {
+	t.Helper()
+
+	reconciler.newMonitorClient = func(config *monitor.Config) (monitor.Client, error) {
+		// the url of the Pod's monitoring endpoint starts with the name of the Pod.
+		podName, _, _ := strings.Cut(strings.TrimPrefix(config.URL, "http://"), ".")
+		server, ok := servers[podName]
+		require.True(t, ok, "unexpected NATS server %s", podName)
+
+		monitorClient := new(nmmonitormocks.Client)
+		monitorClient.On("Varz", mock.Anything).Return(server.varz, nil)
+		monitorClient.On("Routez", mock.Anything).Return(server.routez, nil)
+		monitorClient.On("Jsz", mock.Anything).Return(server.jsz, nil)
+		monitorClient.On("Healthz", mock.Anything).Return(server.healthz, nil)
+		return monitorClient, nil
+	}
+}
@@ -38,6 +38,18 @@ const (
 	kindNATS       = "NATS"
 	size           = "size"
 	enabled        = "enabled"
+	tls            = "tls"
+	client         = "client"
+	monitoring     = "monitoring"
+	leafNodes      = "leafNodes"
+	remotes        = "remotes"
+	urls           = "urls"
+	gateways       = "gateways"
+	encryption     = "encryption"
+	secretName     = "secretName"
+	cipher         = "cipher"
+	auth           = "auth"
+	mode           = "mode"
 	apiVersionNATS = "operator.kyma-project.io/v1alpha1"
 )
 
@@ -196,6 +208,245 @@ func Test_Validate_CreateNATS(t *testing.T) {
 			},
 			wantErrMsg: "can only be enabled if size is not 0",
 		},
+		{
+			name: `validation of spec.tls passes if monitoring and client TLS are enabled`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						tls: map[string]any{
+							client: map[string]any{
+								enabled: true,
+							},
+							monitoring: map[string]any{
+								enabled: true,
+							},
+						},
+					},
+				},
+			},
+			wantErrMsg: noError,
+		},
+		{
+			name: `validation of spec.tls fails if monitoring TLS is enabled without client TLS`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						tls: map[string]any{
+							monitoring: map[string]any{
+								enabled: true,
+							},
+						},
+					},
+				},
+			},
+			wantErrMsg: "monitoring TLS requires TLS for the client listener",
+		},
+		{
+			name: `validation of spec.leafNodes fails if TLS is enabled without the leaf node listener`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						leafNodes: map[string]any{
+							tls: map[string]any{
+								enabled: true,
+							},
+						},
+					},
+				},
+			},
+			wantErrMsg: "TLS requires the leaf node listener to be enabled",
+		},
+		{
+			name: `validation of spec.leafNodes fails if a remote has no URLs`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						leafNodes: map[string]any{
+							remotes: []any{
+								map[string]any{
+									urls: []any{},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErrMsg: "spec.leafNodes.remotes[0].urls in body should have at least 1 items",
+		},
+		{
+			name: `validation of spec.gateways fails if gateways are enabled without name`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						gateways: map[string]any{
+							enabled: true,
+						},
+					},
+				},
+			},
+			wantErrMsg: "name is required if gateways are enabled",
+		},
+		{
+			name: `validation of spec.gateways passes if gateways are enabled with name and remotes`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						gateways: map[string]any{
+							enabled: true,
+							name:    "eu",
+							remotes: []any{
+								map[string]any{
+									name: "us",
+									urls: []any{"nats://nats-us.example.com:7522"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErrMsg: noError,
+		},
+		{
+			name: `validation of spec.jetStream.encryption fails if encryption is enabled without secretName`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						jetStream: map[string]any{
+							encryption: map[string]any{
+								enabled: true,
+							},
+						},
+					},
+				},
+			},
+			wantErrMsg: "secretName is required if encryption is enabled",
+		},
+		{
+			name: `validation of spec.jetStream.encryption fails for an unknown cipher`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						jetStream: map[string]any{
+							encryption: map[string]any{
+								enabled:    true,
+								secretName: "nats-encryption",
+								cipher:     "DES",
+							},
+						},
+					},
+				},
+			},
+			wantErrMsg: `spec.jetStream.encryption.cipher: Unsupported value: "DES"`,
+		},
+		{
+			name: `validation of spec.jetStream.encryption passes if encryption is enabled with secretName`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						jetStream: map[string]any{
+							encryption: map[string]any{
+								enabled:    true,
+								secretName: "nats-encryption",
+								cipher:     "AES",
+							},
+						},
+					},
+				},
+			},
+			wantErrMsg: noError,
+		},
+		{
+			name: `validation of spec.auth fails for an unknown mode`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						auth: map[string]any{
+							mode: "token",
+						},
+					},
+				},
+			},
+			wantErrMsg: `spec.auth.mode: Unsupported value: "token"`,
+		},
+		{
+			name: `validation of spec.auth passes for the jwt mode`,
+			givenUnstructuredNATS: unstructured.Unstructured{
+				Object: map[string]any{
+					kind:       kindNATS,
+					apiVersion: apiVersionNATS,
+					metadata: map[string]any{
+						name:      testutils.GetRandK8sName(7),
+						namespace: testutils.GetRandK8sName(7),
+					},
+					spec: map[string]any{
+						auth: map[string]any{
+							mode: "jwt",
+						},
+					},
+				},
+			},
+			wantErrMsg: noError,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -228,7 +479,7 @@ func Test_Validate_UpdateNATS(t *testing.T) {
 		wantErrMsg   string
 	}{
 		{
-			name: `validation of fileStorage fails, if fileStorage.size gets changed`,
+			name: `validation of fileStorage passes, if fileStorage.size gets increased`,
 			givenNATS: testutils.NewNATSCR(
 				testutils.WithNATSFileStorage(defaultFileStorage()),
 			),
@@ -241,7 +492,29 @@ func Test_Validate_UpdateNATS(t *testing.T) {
 					Size:             resource.MustParse("2Gi"),
 				}),
 			},
-			wantErrMsg: "fileStorage is immutable once it was set",
+			wantErrMsg: noError,
+		},
+		{
+			name: `validation of fileStorage fails, if fileStorage.size gets decreased`,
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSFileStorage(nmapiv1alpha1.FileStorage{
+					StorageClassName: defaultFileStorage().StorageClassName,
+					Size:             resource.MustParse("2Gi"),
+				}),
+			),
+			wantMatches: gomega.And(
+				nmtsmatchers.HaveSpecJetStreamFileStorage(nmapiv1alpha1.FileStorage{
+					StorageClassName: defaultFileStorage().StorageClassName,
+					Size:             resource.MustParse("2Gi"),
+				}),
+			),
+			givenUpdates: []testutils.NATSOption{
+				testutils.WithNATSFileStorage(nmapiv1alpha1.FileStorage{
+					StorageClassName: defaultFileStorage().StorageClassName,
+					Size:             resource.MustParse("1Gi"),
+				}),
+			},
+			wantErrMsg: "size can only be increased",
 		},
 		{
 			name: `validation of fileStorage fails, if fileStorage.storageClassName gets changed`,
@@ -290,6 +563,34 @@ func Test_Validate_UpdateNATS(t *testing.T) {
 			},
 			wantErrMsg: noError,
 		},
+		{
+			name: `validation of jetStream.encryption fails, if encryption.cipher gets changed`,
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSEncryption(nmapiv1alpha1.JetStreamEncryption{
+					Enabled:    true,
+					SecretName: "nats-encryption",
+					Cipher:     nmapiv1alpha1.EncryptionCipherChaCha,
+				}),
+			),
+			wantMatches: gomega.Not(gomega.BeNil()),
+			givenUpdates: []testutils.NATSOption{
+				testutils.WithNATSEncryption(nmapiv1alpha1.JetStreamEncryption{
+					Enabled:    true,
+					SecretName: "nats-encryption",
+					Cipher:     nmapiv1alpha1.EncryptionCipherAES,
+				}),
+			},
+			wantErrMsg: "cipher is immutable once it was set",
+		},
+		{
+			name:        `validation of auth fails, if auth.mode gets changed`,
+			givenNATS:   testutils.NewNATSCR(),
+			wantMatches: gomega.Not(gomega.BeNil()),
+			givenUpdates: []testutils.NATSOption{
+				testutils.WithNATSAuthMode(nmapiv1alpha1.AuthModeJWT),
+			},
+			wantErrMsg: "mode is immutable once it was set",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -373,6 +674,102 @@ func Test_Validate_UpdateNATS_WithoutFileStorageSize(t *testing.T) {
 	require.NoError(t, err, "updating NATS CR with only an annotation change should not trigger fileStorage immutability validation")
 }
 
+// Test_Validate_UpdateNATS_RemoveFileStorageSize makes sure that fileStorage.size cannot be removed,
+// as the size would fall back to the default, which may be smaller than the PVCs of the NATS servers.
+func Test_Validate_UpdateNATS_RemoveFileStorageSize(t *testing.T) {
+	// given
+	ns := testutils.GetRandK8sName(7)
+	testEnvironment.EnsureNamespaceCreation(t, ns)
+	givenUnstructuredNATS := unstructured.Unstructured{
+		Object: map[string]any{
+			kind:       kindNATS,
+			apiVersion: apiVersionNATS,
+			metadata: map[string]any{
+				name:      testutils.GetRandK8sName(7),
+				namespace: ns,
+			},
+			spec: map[string]any{
+				jetStream: map[string]any{
+					fileStorage: map[string]any{
+						"storageClassName": "default",
+						size:               "2Gi",
+					},
+				},
+			},
+		},
+	}
+	testEnvironment.EnsureK8sUnStructResourceCreated(t, &givenUnstructuredNATS)
+
+	// when
+	unstructured.RemoveNestedField(givenUnstructuredNATS.Object, spec, jetStream, fileStorage, size)
+	err := testEnvironment.UpdateK8sResource(&givenUnstructuredNATS)
+
+	// then
+	require.ErrorContains(t, err, "size cannot be removed once it was set")
+}
+
+func Test_Validate_UpdateNATSStream(t *testing.T) {
+	testCases := []struct {
+		name         string
+		givenName    string
+		givenUpdates []testutils.NATSStreamOption
+		wantErrMsg   string
+	}{
+		{
+			name: `validation of spec passes, if a stream without a name gets updated`,
+			givenUpdates: []testutils.NATSStreamOption{
+				testutils.WithNATSStreamSubjects("orders.>"),
+			},
+			wantErrMsg: noError,
+		},
+		{
+			name: `validation of spec fails, if the name gets set later`,
+			givenUpdates: []testutils.NATSStreamOption{
+				testutils.WithNATSStreamSpecName("orders"),
+			},
+			wantErrMsg: "name cannot be added or removed once the NATSStream was created",
+		},
+		{
+			name:      `validation of spec fails, if the name gets removed`,
+			givenName: "orders",
+			givenUpdates: []testutils.NATSStreamOption{
+				testutils.WithNATSStreamSpecName(""),
+			},
+			wantErrMsg: "name cannot be added or removed once the NATSStream was created",
+		},
+		{
+			name:      `validation of name fails, if the name gets changed`,
+			givenName: "orders",
+			givenUpdates: []testutils.NATSStreamOption{
+				testutils.WithNATSStreamSpecName("invoices"),
+			},
+			wantErrMsg: "name is immutable once it was set",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			givenStream := testutils.NewNATSStreamCR(testutils.WithNATSStreamSpecName(tc.givenName))
+			testEnvironment.EnsureNamespaceCreation(t, givenStream.GetNamespace())
+			testEnvironment.EnsureK8sResourceCreated(t, givenStream)
+
+			// when
+			for _, update := range tc.givenUpdates {
+				require.NoError(t, update(givenStream))
+			}
+			err := testEnvironment.UpdateK8sResource(givenStream)
+
+			// then
+			if tc.wantErrMsg == noError {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErrMsg)
+			}
+		})
+	}
+}
+
 func Test_NATS_Defaulting(t *testing.T) {
 	testCases := []struct {
 		name string
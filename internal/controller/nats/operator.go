@@ -0,0 +1,29 @@
+package nats
+
+import (
+	"context"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/auth"
+	kcorev1 "k8s.io/api/core/v1"
+)
+
+// syncOperator generates the Secret with the operator, the system account and the account of the NATS manager
+// if NATS runs in the JWT authentication mode. The operator is generated only once, as the account JWTs which
+// were pushed to the resolver are signed by it. An existing Secret is kept as it is.
+func (r *Reconciler) syncOperator(ctx context.Context, nats *nmapiv1alpha1.NATS) error {
+	if !nats.IsJWTAuthEnabled() {
+		return nil
+	}
+
+	secret, err := r.getSecret(ctx, nats.OperatorSecretName(), nats.Namespace)
+	if err != nil || secret != nil {
+		return err
+	}
+
+	data, err := auth.NewOperatorSecretData(nats.Name)
+	if err != nil {
+		return err
+	}
+	return r.createManagedSecret(ctx, nats, nats.OperatorSecretName(), kcorev1.SecretTypeOpaque, data)
+}
@@ -0,0 +1,105 @@
+package nats
+
+import (
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/auth"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_syncOperator(t *testing.T) {
+	t.Parallel()
+
+	givenOperatorSecret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: "eventing-nats-operator", Namespace: "kyma-system"},
+		Data:       map[string][]byte{auth.OperatorConfigKey: []byte("existing")},
+	}
+
+	testCases := []struct {
+		name             string
+		givenAuthMode    string
+		givenObjects     []client.Object
+		givenDryRun      bool
+		wantSecretAbsent bool
+		wantGenerated    bool
+		wantPlanned      bool
+	}{
+		{
+			name:             "should not generate the operator in memory authentication mode",
+			givenAuthMode:    nmapiv1alpha1.AuthModeMemory,
+			wantSecretAbsent: true,
+		},
+		{
+			name:          "should generate the operator in JWT authentication mode",
+			givenAuthMode: nmapiv1alpha1.AuthModeJWT,
+			wantGenerated: true,
+		},
+		{
+			name:             "should only plan the operator in dry-run mode",
+			givenAuthMode:    nmapiv1alpha1.AuthModeJWT,
+			givenDryRun:      true,
+			wantSecretAbsent: true,
+			wantPlanned:      true,
+		},
+		{
+			name:          "should keep an existing operator",
+			givenAuthMode: nmapiv1alpha1.AuthModeJWT,
+			givenObjects:  []client.Object{givenOperatorSecret},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			nats := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSAuthMode(tc.givenAuthMode),
+			)
+			if tc.givenDryRun {
+				nats.Annotations = map[string]string{nmapiv1alpha1.DryRunAnnotation: "true"}
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, append(tc.givenObjects, nats)...)
+
+			// when
+			err := testEnv.Reconciler.syncOperator(testEnv.Context, nats)
+
+			// then
+			require.NoError(t, err)
+			gotSecret := &kcorev1.Secret{}
+			err = testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: "eventing-nats-operator", Namespace: "kyma-system"}, gotSecret)
+			if tc.wantPlanned {
+				require.Equal(t, []PlanChange{{
+					InventoryEntry: nmapiv1alpha1.InventoryEntry{
+						APIVersion: "v1", Kind: "Secret", Name: "eventing-nats-operator", Namespace: "kyma-system",
+					},
+					Action: PlanActionCreate,
+				}}, testEnv.Reconciler.plannedSecrets["kyma-system/eventing-nats"])
+			}
+			if tc.wantSecretAbsent {
+				require.True(t, kapierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			if !tc.wantGenerated {
+				require.Equal(t, "existing", string(gotSecret.Data[auth.OperatorConfigKey]))
+				return
+			}
+			require.Equal(t, ManagedByLabelValue, gotSecret.Labels[ManagedByLabelKey])
+			require.Len(t, gotSecret.OwnerReferences, 1)
+			_, err = auth.PublicKey(gotSecret.Data[auth.OperatorSeedKey])
+			require.NoError(t, err)
+			require.NotEmpty(t, gotSecret.Data[auth.OperatorConfigKey])
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	"github.com/kyma-project/nats-manager/pkg/k8s/chart"
+	"go.uber.org/zap"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+// PlanDataKey is the key of the change plan in the ConfigMap of the dry-run mode.
+const PlanDataKey = "plan.yaml"
+
+type PlanAction string
+
+const (
+	PlanActionCreate PlanAction = "Create"
+	PlanActionModify PlanAction = "Modify"
+	PlanActionDelete PlanAction = "Delete"
+)
+
+// Plan lists the changes which the NATS manager would make to the managed resources of the NATS CR.
+type Plan struct {
+	// Generation is the generation of the NATS CR which the plan was computed for.
+	Generation int64 `json:"generation"`
+	// Disruptive is true if any of the changes is disruptive.
+	Disruptive bool         `json:"disruptive"`
+	Changes    []PlanChange `json:"changes"`
+}
+
+// PlanChange is the change of a single managed resource.
+type PlanChange struct {
+	nmapiv1alpha1.InventoryEntry `json:",inline"`
+	Action                       PlanAction `json:"action"`
+	// Fields are the paths of the fields which would be modified.
+	Fields []string `json:"fields,omitempty"`
+	// Disruptive is true if the change disrupts the NATS servers, and Reason explains why.
+	Disruptive bool   `json:"disruptive"`
+	Reason     string `json:"reason,omitempty"`
+	// Error is the reason why the API server would reject the change.
+	Error string `json:"error,omitempty"`
+}
+
+// handleNATSDryRun plans the changes of the managed resources instead of applying them. The rendered resources
+// are applied with server-side apply in dry-run mode and compared with the live resources. The resources
+// which are no longer rendered would be pruned. The plan is stored in a ConfigMap which is referenced
+// in the status of the NATS CR.
+func (r *Reconciler) handleNATSDryRun(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	instance *chart.ReleaseInstance, log *zap.SugaredLogger,
+) error {
+	plan, err := r.computePlan(ctx, nats, instance)
+	if err != nil {
+		return err
+	}
+	if err = r.storePlan(ctx, nats, plan); err != nil {
+		return err
+	}
+
+	nats.Status.Plan = &nmapiv1alpha1.PlanStatus{
+		ConfigMapName:      nats.PlanConfigMapName(),
+		ObservedGeneration: plan.Generation,
+		Changes:            len(plan.Changes),
+		Disruptive:         plan.Disruptive,
+	}
+	log.Infow("planned changes of NATS resources in dry-run mode", "changes", len(plan.Changes),
+		"disruptive", plan.Disruptive)
+	events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonPlanned,
+		"Planned %d changes of NATS resources (disruptive: %t) in ConfigMap %s instead of applying them.",
+		len(plan.Changes), plan.Disruptive, nats.PlanConfigMapName())
+	return nil
+}
+
+func (r *Reconciler) computePlan(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	instance *chart.ReleaseInstance,
+) (*Plan, error) {
+	plan := &Plan{Generation: nats.Generation, Changes: []PlanChange{}}
+	rendered := map[string]bool{}
+	for _, object := range instance.RenderedManifests.Items {
+		rendered[inventoryKey(inventoryEntry(object))] = true
+		change, err := r.planChange(ctx, object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan the change of %s %s/%s: %w", object.GetKind(),
+				object.GetNamespace(), object.GetName(), err)
+		}
+		if change != nil {
+			plan.Changes = append(plan.Changes, *change)
+		}
+	}
+
+	plan.Changes = append(plan.Changes, r.plannedSecrets[nats.Namespace+"/"+nats.Name]...)
+
+	for _, entry := range nats.Status.Inventory {
+		if rendered[inventoryKey(entry)] || isRenderedOnce(nats, entry) {
+			continue
+		}
+		plan.Changes = append(plan.Changes, PlanChange{InventoryEntry: entry, Action: PlanActionDelete})
+	}
+
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		change.Reason = disruptionReason(change)
+		change.Disruptive = change.Reason != ""
+		plan.Disruptive = plan.Disruptive || change.Disruptive
+	}
+	return plan, nil
+}
+
+// planChange returns the change of the given rendered object, or nil if the live object would not change.
+func (r *Reconciler) planChange(ctx context.Context, object *unstructured.Unstructured) (*PlanChange, error) {
+	change := &PlanChange{InventoryEntry: inventoryEntry(object), Action: PlanActionModify}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(object.GroupVersionKind())
+	err := r.Get(ctx, client.ObjectKeyFromObject(object), live)
+	if kapierrors.IsNotFound(err) {
+		change.Action = PlanActionCreate
+	} else if err != nil {
+		return nil, err
+	}
+
+	planned, err := r.kubeClient.PatchApplyDryRun(ctx, object)
+	if kapierrors.IsInvalid(err) {
+		change.Error = err.Error()
+		return change, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if change.Action == PlanActionCreate {
+		return change, nil
+	}
+
+	change.Fields = changedFields("", comparableContent(live), comparableContent(planned))
+	if len(change.Fields) == 0 {
+		return nil, nil
+	}
+	return change, nil
+}
+
+// planSecretChange records the change of a Secret generated for the NATS CR in dry-run mode instead of writing
+// it. The NATS servers would otherwise pick up the Secret, e.g. a rotated encryption key, before the ConfigMap
+// and the StatefulSet which match it are applied.
+func (r *Reconciler) planSecretChange(nats *nmapiv1alpha1.NATS, change PlanChange) {
+	key := nats.Namespace + "/" + nats.Name
+	r.plannedSecrets[key] = append(r.plannedSecrets[key], change)
+}
+
+func secretInventoryEntry(secret *kcorev1.Secret) nmapiv1alpha1.InventoryEntry {
+	return nmapiv1alpha1.InventoryEntry{
+		APIVersion: "v1", Kind: "Secret", Name: secret.Name, Namespace: secret.Namespace,
+	}
+}
+
+// secretDataContent returns the data of a Secret in the form which changedFields compares.
+func secretDataContent(data map[string][]byte) map[string]any {
+	content := make(map[string]any, len(data))
+	for key, value := range data {
+		content[key] = value
+	}
+	return content
+}
+
+// comparableContent returns the content of the object without the fields which are maintained by the API server.
+func comparableContent(object *unstructured.Unstructured) map[string]any {
+	content := object.DeepCopy().Object
+	delete(content, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "generation", "uid", "creationTimestamp"} {
+		unstructured.RemoveNestedField(content, "metadata", field)
+	}
+	return content
+}
+
+// changedFields returns the paths of the fields which differ, e.g. .spec.replicas. Lists are compared as a whole.
+func changedFields(path string, live, planned any) []string {
+	liveMap, liveIsMap := live.(map[string]any)
+	plannedMap, plannedIsMap := planned.(map[string]any)
+	if !liveIsMap || !plannedIsMap {
+		if reflect.DeepEqual(live, planned) {
+			return nil
+		}
+		return []string{path}
+	}
+
+	keys := make([]string, 0, len(liveMap)+len(plannedMap))
+	for key := range liveMap {
+		keys = append(keys, key)
+	}
+	for key := range plannedMap {
+		if _, ok := liveMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var fields []string
+	for _, key := range keys {
+		fields = append(fields, changedFields(path+"."+key, liveMap[key], plannedMap[key])...)
+	}
+	return fields
+}
+
+// disruptionReason explains why the change disrupts the NATS servers,
+// or returns an empty string if the change is not disruptive.
+func disruptionReason(change *PlanChange) string {
+	switch {
+	case change.Kind == "StatefulSet" && change.Action == PlanActionDelete:
+		return "All NATS servers are deleted."
+	case change.Kind == "Service" && change.Action == PlanActionDelete:
+		return "The NATS servers are no longer reachable through the Service."
+	case change.Kind != "StatefulSet" || change.Action != PlanActionModify:
+		return ""
+	}
+
+	var reasons []string
+	if hasFieldWithPrefix(change.Fields, ".spec.replicas") {
+		reasons = append(reasons, "The number of NATS servers changes.")
+	}
+	if hasFieldWithPrefix(change.Fields, ".spec.volumeClaimTemplates") {
+		reasons = append(reasons, "The storage of the NATS servers changes.")
+	}
+	if hasFieldWithPrefix(change.Fields, ".spec.template") {
+		reasons = append(reasons, "The NATS servers are restarted one at a time.")
+	}
+	return strings.Join(reasons, " ")
+}
+
+func hasFieldWithPrefix(fields []string, prefix string) bool {
+	return slices.ContainsFunc(fields, func(field string) bool {
+		return field == prefix || strings.HasPrefix(field, prefix+".")
+	})
+}
+
+// storePlan applies the ConfigMap with the change plan.
+func (r *Reconciler) storePlan(ctx context.Context, nats *nmapiv1alpha1.NATS, plan *Plan) error {
+	data, err := yaml.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	configMap := &kcorev1.ConfigMap{
+		TypeMeta: kmetav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      nats.PlanConfigMapName(),
+			Namespace: nats.Namespace,
+			Labels:    map[string]string{ManagedByLabelKey: ManagedByLabelValue},
+		},
+		Data: map[string]string{PlanDataKey: string(data)},
+	}
+	if err = controllerutil.SetControllerReference(nats, configMap, r.scheme); err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(configMap)
+	if err != nil {
+		return err
+	}
+	return r.kubeClient.PatchApply(ctx, &unstructured.Unstructured{Object: content})
+}
+
+// deletePlan deletes the ConfigMap with the change plan once the dry-run mode is disabled.
+func (r *Reconciler) deletePlan(ctx context.Context, nats *nmapiv1alpha1.NATS) error {
+	if nats.Status.Plan == nil {
+		return nil
+	}
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetName(nats.Status.Plan.ConfigMapName)
+	configMap.SetNamespace(nats.Namespace)
+	if err := r.kubeClient.Delete(ctx, configMap); err != nil {
+		return err
+	}
+	nats.Status.Plan = nil
+	return nil
+}
@@ -0,0 +1,220 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/k8s/chart"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+func newPlanObject(apiVersion, kind, name string, spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name, "namespace": "kyma-system"},
+		"spec":       spec,
+	}}
+}
+
+func Test_handleNATSDryRun(t *testing.T) {
+	t.Parallel()
+
+	liveStatefulSet := newPlanObject("apps/v1", "StatefulSet", "eventing-nats", map[string]any{
+		"replicas": int64(3),
+		"template": map[string]any{"metadata": map[string]any{"labels": map[string]any{"app": "nats"}}},
+	})
+	liveService := newPlanObject("v1", "Service", "eventing-nats", map[string]any{"clusterIP": "10.0.0.1"})
+	destinationRule := nmapiv1alpha1.InventoryEntry{
+		APIVersion: "networking.istio.io/v1beta1", Kind: "DestinationRule", Name: "eventing-nats",
+		Namespace: "kyma-system",
+	}
+
+	encryptionKeySecret := nmapiv1alpha1.InventoryEntry{
+		APIVersion: "v1", Kind: "Secret", Name: "eventing-nats-jetstream-encryption", Namespace: "kyma-system",
+	}
+
+	testCases := []struct {
+		name           string
+		givenRendered  []*unstructured.Unstructured
+		givenPlanned   func(planned *unstructured.Unstructured) error
+		givenApplyErr  error
+		givenInventory []nmapiv1alpha1.InventoryEntry
+		givenSecrets   []PlanChange
+		wantChanges    []PlanChange
+		wantDisruptive bool
+	}{
+		{
+			name:          "should plan no changes if the live objects match",
+			givenRendered: []*unstructured.Unstructured{liveStatefulSet, liveService},
+			wantChanges:   []PlanChange{},
+		},
+		{
+			name: "should plan to scale the NATS servers as disruptive change",
+			givenRendered: []*unstructured.Unstructured{
+				newPlanObject("apps/v1", "StatefulSet", "eventing-nats", map[string]any{"replicas": int64(5)}),
+			},
+			givenPlanned: func(planned *unstructured.Unstructured) error {
+				return errors.Join(
+					unstructured.SetNestedField(planned.Object, int64(5), "spec", "replicas"),
+					unstructured.SetNestedField(planned.Object, "2", "spec", "template", "metadata", "labels", "version"),
+				)
+			},
+			wantChanges: []PlanChange{{
+				InventoryEntry: inventoryEntry(liveStatefulSet),
+				Action:         PlanActionModify,
+				Fields:         []string{".spec.replicas", ".spec.template.metadata.labels.version"},
+				Disruptive:     true,
+				Reason:         "The number of NATS servers changes. The NATS servers are restarted one at a time.",
+			}},
+			wantDisruptive: true,
+		},
+		{
+			name: "should plan to create and delete objects",
+			givenRendered: []*unstructured.Unstructured{
+				newPlanObject("policy/v1", "PodDisruptionBudget", "eventing-nats", map[string]any{}),
+			},
+			givenInventory: []nmapiv1alpha1.InventoryEntry{destinationRule},
+			wantChanges: []PlanChange{
+				{
+					InventoryEntry: nmapiv1alpha1.InventoryEntry{
+						APIVersion: "policy/v1", Kind: "PodDisruptionBudget", Name: "eventing-nats",
+						Namespace: "kyma-system",
+					},
+					Action: PlanActionCreate,
+				},
+				{InventoryEntry: destinationRule, Action: PlanActionDelete},
+			},
+		},
+		{
+			name:          "should plan the changes of the generated Secrets",
+			givenRendered: []*unstructured.Unstructured{liveStatefulSet},
+			givenSecrets: []PlanChange{{
+				InventoryEntry: encryptionKeySecret,
+				Action:         PlanActionModify,
+				Fields:         []string{".data.key", ".data.previousKey"},
+			}},
+			wantChanges: []PlanChange{{
+				InventoryEntry: encryptionKeySecret,
+				Action:         PlanActionModify,
+				Fields:         []string{".data.key", ".data.previousKey"},
+			}},
+		},
+		{
+			name: "should plan changes which the API server rejects",
+			givenRendered: []*unstructured.Unstructured{
+				newPlanObject("apps/v1", "StatefulSet", "eventing-nats", map[string]any{"serviceName": "other"}),
+			},
+			givenApplyErr: kapierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "StatefulSet"},
+				"eventing-nats", field.ErrorList{field.Forbidden(field.NewPath("spec"), "immutable")}),
+			wantChanges: []PlanChange{{
+				InventoryEntry: inventoryEntry(liveStatefulSet),
+				Action:         PlanActionModify,
+				Error:          `StatefulSet.apps "eventing-nats" is invalid: spec: Forbidden: immutable`,
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+			)
+			givenNATS.Generation = 4
+			givenNATS.Status.Inventory = tc.givenInventory
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS, liveStatefulSet.DeepCopy(), liveService.DeepCopy())
+			testEnv.Reconciler.plannedSecrets["kyma-system/eventing-nats"] = tc.givenSecrets
+
+			testEnv.kubeClient.On("PatchApplyDryRun", mock.Anything, mock.Anything).Return(
+				func(_ context.Context, object *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+					if tc.givenApplyErr != nil {
+						return nil, tc.givenApplyErr
+					}
+					// the API server merges the applied object into the live object.
+					planned := &unstructured.Unstructured{}
+					planned.SetGroupVersionKind(object.GroupVersionKind())
+					err := testEnv.Client.Get(testEnv.Context, client.ObjectKeyFromObject(object), planned)
+					if kapierrors.IsNotFound(err) {
+						return object.DeepCopy(), nil
+					}
+					require.NoError(t, err)
+					if tc.givenPlanned != nil {
+						require.NoError(t, tc.givenPlanned(planned))
+					}
+					return planned, nil
+				})
+			var gotConfigMap *unstructured.Unstructured
+			testEnv.kubeClient.On("PatchApply", mock.Anything, mock.Anything).Return(nil).
+				Run(func(args mock.Arguments) {
+					gotConfigMap, _ = args.Get(1).(*unstructured.Unstructured)
+				})
+
+			instance := chart.NewReleaseInstance(givenNATS.Name, givenNATS.Namespace, false, nil)
+			instance.SetRenderedManifests(chart.ManifestResources{Items: tc.givenRendered})
+
+			// when
+			err := testEnv.Reconciler.handleNATSDryRun(testEnv.Context, givenNATS, instance, testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, &nmapiv1alpha1.PlanStatus{
+				ConfigMapName:      "eventing-nats-plan",
+				ObservedGeneration: 4,
+				Changes:            len(tc.wantChanges),
+				Disruptive:         tc.wantDisruptive,
+			}, givenNATS.Status.Plan)
+
+			require.NotNil(t, gotConfigMap)
+			require.Equal(t, "eventing-nats-plan", gotConfigMap.GetName())
+			require.Equal(t, "eventing-nats", gotConfigMap.GetOwnerReferences()[0].Name)
+			data, _, err := unstructured.NestedString(gotConfigMap.Object, "data", PlanDataKey)
+			require.NoError(t, err)
+			gotPlan := &Plan{}
+			require.NoError(t, yaml.Unmarshal([]byte(data), gotPlan))
+			require.Equal(t, &Plan{Generation: 4, Disruptive: tc.wantDisruptive, Changes: tc.wantChanges}, gotPlan)
+		})
+	}
+}
+
+func Test_deletePlan(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+	)
+	givenNATS.Status.Plan = &nmapiv1alpha1.PlanStatus{ConfigMapName: "eventing-nats-plan"}
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+	testEnv.kubeClient.On("Delete", mock.Anything, mock.MatchedBy(func(object *unstructured.Unstructured) bool {
+		return object.GetKind() == "ConfigMap" &&
+			client.ObjectKeyFromObject(object) == client.ObjectKey{Name: "eventing-nats-plan", Namespace: "kyma-system"}
+	})).Return(nil).Once()
+
+	// when
+	err := testEnv.Reconciler.deletePlan(testEnv.Context, givenNATS)
+
+	// then
+	require.NoError(t, err)
+	require.Nil(t, givenNATS.Status.Plan)
+
+	// when the plan was deleted already.
+	require.NoError(t, testEnv.Reconciler.deletePlan(testEnv.Context, givenNATS))
+
+	// then
+	testEnv.kubeClient.AssertExpectations(t)
+}
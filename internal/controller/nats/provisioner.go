@@ -17,7 +17,26 @@ import (
 	kcontrollerruntime "sigs.k8s.io/controller-runtime"
 )
 
-const RequeueTimeForStatusCheck = 10
+const (
+	// DefaultStatusCheckMinInterval and DefaultStatusCheckMaxInterval bound the interval in which the readiness of
+	// the StatefulSet is checked again if no event of the StatefulSet or its Pods triggered a reconciliation.
+	DefaultStatusCheckMinInterval = 10 * time.Second
+	DefaultStatusCheckMaxInterval = 5 * time.Minute
+)
+
+// StatusCheckBackoff defines the fallback for the readiness of the StatefulSet. The readiness is detected from
+// the events of the StatefulSet and its Pods, so the NATS CR is only requeued in case an event was missed.
+type StatusCheckBackoff struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+}
+
+// Next returns the interval after which the readiness is checked again if the StatefulSet is not ready since
+// the given time. The interval grows with the waiting time, so that the waiting time doubles with each check
+// until the interval reaches the maximum.
+func (b StatusCheckBackoff) Next(waiting time.Duration) time.Duration {
+	return min(max(waiting, b.MinInterval), b.MaxInterval)
+}
 
 func (r *Reconciler) handleNATSReconcile(ctx context.Context,
 	nats *nmapiv1alpha1.NATS, log *zap.SugaredLogger,
@@ -25,7 +44,7 @@ func (r *Reconciler) handleNATSReconcile(ctx context.Context,
 	log.Info("handling NATS reconciliation...")
 
 	// record metric.
-	r.collector.RecordClusterSizeMetric(nats.Spec.Cluster.Size)
+	r.collector.RecordClusterSizeMetric(nats.Namespace, nats.Name, nats.Spec.Cluster.Size)
 
 	// read cloud provider from shoot-info and store in reconciler.
 	r.syncCloudProvider(ctx, log)
@@ -48,14 +67,46 @@ func (r *Reconciler) handleNATSReconcile(ctx context.Context,
 		return kcontrollerruntime.Result{}, r.syncNATSStatusWithErr(ctx, nats, err, log)
 	}
 
+	if nats.IsDryRun() {
+		// plan the changes of the NATS resources instead of applying them.
+		if err = r.handleNATSDryRun(ctx, nats, instance, log); err != nil {
+			events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonProcessingError,
+				"Error while changes of NATS resources were planned: %s", err)
+			return kcontrollerruntime.Result{}, r.syncNATSStatusWithErr(ctx, nats, err, log)
+		}
+		return r.handleNATSState(ctx, nats, instance, log)
+	}
+
+	// the plan of the dry-run mode is outdated once the changes are applied.
+	if err = r.deletePlan(ctx, nats); err != nil {
+		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonProcessingError,
+			"Error while the plan of the dry-run mode was deleted: %s", err)
+		return kcontrollerruntime.Result{}, r.syncNATSStatusWithErr(ctx, nats, err, log)
+	}
+
+	// detect changes of the NATS resources which were not made by the NATS manager.
+	deployInstance, err := r.syncDrift(ctx, nats, instance, log)
+	if err != nil {
+		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonProcessingError,
+			"Error while NATS resources were checked for drift: %s", err)
+		return kcontrollerruntime.Result{}, r.syncNATSStatusWithErr(ctx, nats, err, log)
+	}
+
 	log.Info("deploying NATS resources...")
 	// deploy NATS resources
-	if err = r.natsManager.DeployInstance(ctx, instance); err != nil {
+	if err = r.natsManager.DeployInstance(ctx, deployInstance); err != nil {
 		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonProcessingError,
 			"Error while NATS resources were deployed: %s", err)
 		return kcontrollerruntime.Result{}, r.syncNATSStatusWithErr(ctx, nats, err, log)
 	}
 
+	// delete NATS resources which are no longer rendered.
+	if err = r.pruneNATSResources(ctx, nats, instance, log); err != nil {
+		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonProcessingError,
+			"Error while NATS resources were pruned: %s", err)
+		return kcontrollerruntime.Result{}, r.syncNATSStatusWithErr(ctx, nats, err, log)
+	}
+
 	// watchers for dynamic resources managed by controller.
 	if instance.IstioEnabled && !r.destinationRuleWatchStarted {
 		if err = r.watchDestinationRule(log); err != nil {
@@ -73,6 +124,15 @@ func (r *Reconciler) handleNATSReconcile(ctx context.Context,
 	return r.handleNATSState(ctx, nats, instance, log)
 }
 
+// waitingTime returns how long the condition of the given type is false.
+func waitingTime(nats *nmapiv1alpha1.NATS, conditionType nmapiv1alpha1.ConditionType) time.Duration {
+	condition := nats.Status.FindCondition(conditionType)
+	if condition == nil || condition.Status != kmetav1.ConditionFalse {
+		return 0
+	}
+	return time.Since(condition.LastTransitionTime.Time)
+}
+
 // handleNATSState checks if NATS resources are ready.
 // It also syncs the NATS CR status.
 func (r *Reconciler) handleNATSState(ctx context.Context, nats *nmapiv1alpha1.NATS, instance *chart.ReleaseInstance,
@@ -94,26 +154,38 @@ func (r *Reconciler) handleNATSState(ctx context.Context, nats *nmapiv1alpha1.NA
 	if !isSTSReady {
 		nats.Status.SetWaitingStateForStatefulSet()
 		nats.Status.AvailabilityZonesUsed = 0
+		nats.Status.Servers = nil
 		// record metric.
-		r.collector.RecordAvailabilityZonesUsedMetric(nats.Status.AvailabilityZonesUsed)
+		r.collector.RecordAvailabilityZonesUsedMetric(nats.Namespace, nats.Name, nats.Status.AvailabilityZonesUsed)
 		// publish k8s event.
 		events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonDeploying,
 			"NATS is being deployed, waiting for StatefulSet to get ready.")
-		r.logger.Info("Reconciliation successful: waiting for STS to get ready...")
-		return kcontrollerruntime.Result{RequeueAfter: RequeueTimeForStatusCheck * time.Second}, r.syncNATSStatus(ctx, nats, log)
+		requeueAfter := r.statusCheckBackoff.Next(waitingTime(nats, nmapiv1alpha1.ConditionStatefulSet))
+		r.logger.Infow("Reconciliation successful: waiting for STS to get ready...", "requeueAfter", requeueAfter)
+		return kcontrollerruntime.Result{RequeueAfter: requeueAfter}, r.syncNATSStatus(ctx, nats, log)
 	}
 
 	// set status to ready.
 	nats.Status.SetStateReady()
-	nats.Status.SetURL(nmctrlurl.Format(nats.Name, nats.Namespace))
+	nats.Status.SetURL(nmctrlurl.ForNATS(nats))
 	events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonDeployed, "StatefulSet is ready and NATS is deployed.")
 
+	// sync status for the gateways of the supercluster.
+	r.syncGatewaysStatus(ctx, nats, log)
+
+	// check that the NATS servers formed a cluster and that JetStream is healthy.
+	servers := r.getNATSServerStates(ctx, nats)
+	healthy := r.syncClusterHealth(nats, servers, log)
+	r.syncServersStatus(ctx, nats, servers, log)
+	storageAvailable := r.syncJetStreamUsage(nats, servers)
+	r.autoGrowFileStorage(nats, servers, log)
+
 	// sync status for AvailabilityZones.
 	nats.Status.AvailabilityZonesUsed, err = r.kubeClient.GetNumberOfAvailabilityZonesUsedByPods(ctx,
-		nats.GetNamespace(), getNATSPodsMatchLabels())
+		nats.GetNamespace(), getNATSPodsMatchLabels(nats))
 
 	// record metric.
-	r.collector.RecordAvailabilityZonesUsedMetric(nats.Status.AvailabilityZonesUsed)
+	r.collector.RecordAvailabilityZonesUsedMetric(nats.Namespace, nats.Name, nats.Status.AvailabilityZonesUsed)
 
 	switch {
 	case err != nil:
@@ -149,6 +221,76 @@ func (r *Reconciler) handleNATSState(ctx context.Context, nats *nmapiv1alpha1.NA
 		nats.Status.SetStateWarning()
 	}
 
+	result := kcontrollerruntime.Result{}
+	if certificates := nats.Status.FindCondition(nmapiv1alpha1.ConditionCertificatesValid); certificates != nil {
+		if certificates.Reason != string(nmapiv1alpha1.ConditionReasonCertificatesValid) {
+			events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReason(certificates.Reason), certificates.Message)
+			nats.Status.SetStateWarning()
+		}
+		// check the certificates regularly, so that they are re-issued before they expire.
+		result.RequeueAfter = CertificateCheckInterval
+	}
+	if encryptionKey := nats.Status.FindCondition(nmapiv1alpha1.ConditionEncryptionKey); encryptionKey != nil {
+		if encryptionKey.Reason == string(nmapiv1alpha1.ConditionReasonKeyInvalid) {
+			events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonKeyInvalid, encryptionKey.Message)
+			nats.Status.SetStateWarning()
+		}
+		// the Secret with the encryption key is not watched, so it is checked regularly for a new key.
+		result.RequeueAfter = EncryptionKeyCheckInterval
+	}
+	if !healthy {
+		nats.Status.SetStateWarning()
+		// no event is triggered once the NATS servers are healthy, so they are checked again with a backoff.
+		requeueAfter := r.statusCheckBackoff.Next(max(waitingTime(nats, nmapiv1alpha1.ConditionClusterFormed),
+			waitingTime(nats, nmapiv1alpha1.ConditionJetStreamHealthy)))
+		if result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+			result.RequeueAfter = requeueAfter
+		}
+	}
+	if !storageAvailable {
+		nats.Status.SetStateWarning()
+	}
+	if expanding := nats.Status.FindCondition(nmapiv1alpha1.ConditionExpandingStorage); expanding != nil {
+		switch expanding.Reason {
+		case string(nmapiv1alpha1.ConditionReasonExpansionUnsupported):
+			events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonExpansionUnsupported, "%s", expanding.Message)
+			nats.Status.SetStateWarning()
+		case string(nmapiv1alpha1.ConditionReasonExpanding):
+			// the PVCs are not watched, so the expansion is checked again with a backoff.
+			requeueAfter := r.statusCheckBackoff.Next(time.Since(expanding.LastTransitionTime.Time))
+			if result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+				result.RequeueAfter = requeueAfter
+			}
+		}
+	}
+	if scalingDown := nats.Status.FindCondition(nmapiv1alpha1.ConditionScalingDown); scalingDown != nil &&
+		scalingDown.Reason != string(nmapiv1alpha1.ConditionReasonScaledDown) {
+		if scalingDown.Reason == string(nmapiv1alpha1.ConditionReasonScaleDownRefused) {
+			events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonScaleDownRefused, "%s", scalingDown.Message)
+			nats.Status.SetStateWarning()
+		}
+		// the streams are not watched, so the moves and the replicas of the streams are checked again with a backoff.
+		requeueAfter := r.statusCheckBackoff.Next(time.Since(scalingDown.LastTransitionTime.Time))
+		if result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+			result.RequeueAfter = requeueAfter
+		}
+	}
+	if upgrading := nats.Status.FindCondition(nmapiv1alpha1.ConditionUpgrading); upgrading != nil &&
+		upgrading.Status == kmetav1.ConditionTrue {
+		// the health of the NATS servers does not trigger an event, e.g. if their monitoring endpoint could
+		// not be read, so the upgrade is checked again with a backoff.
+		requeueAfter := r.statusCheckBackoff.Next(time.Since(upgrading.LastTransitionTime.Time))
+		if result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+			result.RequeueAfter = requeueAfter
+		}
+	}
+	if nats.Status.FindCondition(nmapiv1alpha1.ConditionStorageCapacity) != nil {
+		// the usage of JetStream does not trigger an event, so it is read again regularly.
+		if result.RequeueAfter == 0 || JetStreamUsageCheckInterval < result.RequeueAfter {
+			result.RequeueAfter = JetStreamUsageCheckInterval
+		}
+	}
+
 	r.logger.Info("Reconciliation successful")
-	return kcontrollerruntime.Result{}, r.syncNATSStatus(ctx, nats, log)
+	return result, r.syncNATSStatus(ctx, nats, log)
 }
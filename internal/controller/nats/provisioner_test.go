@@ -2,7 +2,9 @@ package nats
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
 	"github.com/kyma-project/nats-manager/pkg/k8s"
@@ -21,8 +23,65 @@ import (
 var (
 	ErrDeployErrorMsg = errors.New("deploy error")
 	ErrUnknownMsg     = errors.New("unknown")
+	// errMonitoringEndpointUnreachable is reported for the first NATS server of the NATS CR eventing-nats.
+	errMonitoringEndpointUnreachable = fmt.Errorf("failed to read the monitoring endpoint of NATS server "+
+		"eventing-nats-0: %w", errMonitoringEndpointNotMocked)
 )
 
+func Test_handleNATSState_RequeuesWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		givenWaitingTime time.Duration
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:             "should requeue after the minimum interval when the StatefulSet just became unready",
+			givenWaitingTime: 0,
+			wantRequeueAfter: DefaultStatusCheckMinInterval,
+		},
+		{
+			name:             "should requeue after the waiting time to double it",
+			givenWaitingTime: time.Minute,
+			wantRequeueAfter: time.Minute,
+		},
+		{
+			name:             "should requeue after the maximum interval when the StatefulSet is unready for long",
+			givenWaitingTime: time.Hour,
+			wantRequeueAfter: DefaultStatusCheckMaxInterval,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(testutils.WithNATSClusterSize(1))
+			if tc.givenWaitingTime > 0 {
+				givenNATS.Status.Conditions = []kmetav1.Condition{{
+					Type:               string(nmapiv1alpha1.ConditionStatefulSet),
+					Status:             kmetav1.ConditionFalse,
+					LastTransitionTime: kmetav1.NewTime(time.Now().Add(-tc.givenWaitingTime)),
+					Reason:             string(nmapiv1alpha1.ConditionReasonStatefulSetPending),
+				}}
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+			testEnv.natsManager.On("IsNATSStatefulSetReady", mock.Anything, mock.Anything).Return(false, nil)
+			releaseInstance := &chart.ReleaseInstance{Name: givenNATS.Name, Namespace: givenNATS.Namespace}
+
+			// when
+			result, err := testEnv.Reconciler.handleNATSState(testEnv.Context, givenNATS, releaseInstance,
+				testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			require.InDelta(t, tc.wantRequeueAfter, result.RequeueAfter, float64(2*time.Second))
+		})
+	}
+}
+
 func Test_handleNATSState(t *testing.T) {
 	t.Parallel()
 
@@ -109,6 +168,20 @@ func Test_handleNATSState(t *testing.T) {
 					Reason:             string(nmapiv1alpha1.ConditionReasonNotConfigured),
 					Message:            "NATS is not configured to run in cluster mode (i.e. spec.cluster.size < 3).",
 				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionClusterFormed),
+					Status:             kmetav1.ConditionFalse,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNotConfigured),
+					Message:            "NATS is not configured to run in cluster mode (i.e. spec.cluster.size < 3).",
+				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionJetStreamHealthy),
+					Status:             kmetav1.ConditionUnknown,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNATSUnreachable),
+					Message:            errMonitoringEndpointUnreachable.Error(),
+				},
 			},
 			wantK8sEvents: []string{
 				"Normal Deployed StatefulSet is ready and NATS is deployed.",
@@ -148,6 +221,20 @@ func Test_handleNATSState(t *testing.T) {
 					Message: "NATS is not currently using enough availability " +
 						"zones (Recommended: 3, current: 2).",
 				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionClusterFormed),
+					Status:             kmetav1.ConditionUnknown,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNATSUnreachable),
+					Message:            errMonitoringEndpointUnreachable.Error(),
+				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionJetStreamHealthy),
+					Status:             kmetav1.ConditionUnknown,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNATSUnreachable),
+					Message:            errMonitoringEndpointUnreachable.Error(),
+				},
 			},
 			wantK8sEvents: []string{
 				"Normal Deployed StatefulSet is ready and NATS is deployed.",
@@ -188,6 +275,20 @@ func Test_handleNATSState(t *testing.T) {
 					Reason:             string(nmapiv1alpha1.ConditionReasonDeployed),
 					Message:            "NATS is deployed in different availability zones.",
 				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionClusterFormed),
+					Status:             kmetav1.ConditionUnknown,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNATSUnreachable),
+					Message:            errMonitoringEndpointUnreachable.Error(),
+				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionJetStreamHealthy),
+					Status:             kmetav1.ConditionUnknown,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNATSUnreachable),
+					Message:            errMonitoringEndpointUnreachable.Error(),
+				},
 			},
 			wantK8sEvents: []string{
 				"Normal Deployed StatefulSet is ready and NATS is deployed.",
@@ -227,6 +328,20 @@ func Test_handleNATSState(t *testing.T) {
 					Reason:             string(nmapiv1alpha1.ConditionReasonProcessingError),
 					Message:            k8s.ErrNodeZoneLabelMissing.Error(),
 				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionClusterFormed),
+					Status:             kmetav1.ConditionUnknown,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNATSUnreachable),
+					Message:            errMonitoringEndpointUnreachable.Error(),
+				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionJetStreamHealthy),
+					Status:             kmetav1.ConditionUnknown,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNATSUnreachable),
+					Message:            errMonitoringEndpointUnreachable.Error(),
+				},
 			},
 			wantK8sEvents: []string{
 				"Normal Deployed StatefulSet is ready and NATS is deployed.",
@@ -267,6 +382,20 @@ func Test_handleNATSState(t *testing.T) {
 					Reason:             string(nmapiv1alpha1.ConditionReasonProcessingError),
 					Message:            ErrUnknownMsg.Error(),
 				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionClusterFormed),
+					Status:             kmetav1.ConditionUnknown,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNATSUnreachable),
+					Message:            errMonitoringEndpointUnreachable.Error(),
+				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionJetStreamHealthy),
+					Status:             kmetav1.ConditionUnknown,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNATSUnreachable),
+					Message:            errMonitoringEndpointUnreachable.Error(),
+				},
 			},
 			wantK8sEvents: []string{
 				"Normal Deployed StatefulSet is ready and NATS is deployed.",
@@ -313,7 +442,8 @@ func Test_handleNATSState(t *testing.T) {
 			require.True(t, nmapiv1alpha1.ConditionsEquals(tc.wantConditions, gotNATS.Status.Conditions))
 
 			// check metrics.
-			gotZonesMetric, err := reconciler.collector.GetAvailabilityZonesUsedMetric()
+			gotZonesMetric, err := reconciler.collector.GetAvailabilityZonesUsedMetric(
+				tc.givenNATS.Namespace, tc.givenNATS.Name)
 			require.NoError(t, err)
 			require.Equal(t, float64(tc.givenAvailabilityZones), ptestutil.ToFloat64(gotZonesMetric))
 
@@ -416,6 +546,28 @@ func Test_handleNATSReconcile(t *testing.T) {
 					Reason:             string(nmapiv1alpha1.ConditionReasonNotConfigured),
 					Message:            "NATS is not configured to run in cluster mode (i.e. spec.cluster.size < 3).",
 				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionClusterFormed),
+					Status:             kmetav1.ConditionFalse,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNotConfigured),
+					Message:            "NATS is not configured to run in cluster mode (i.e. spec.cluster.size < 3).",
+				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionJetStreamHealthy),
+					Status:             kmetav1.ConditionTrue,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonJetStreamHealthy),
+					Message:            "JetStream is healthy on all NATS servers.",
+				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionStorageCapacity),
+					Status:             kmetav1.ConditionTrue,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonStorageAvailable),
+					Message: "JetStream uses up to 0% of the file storage and 0% of the memory storage " +
+						"of a NATS server.",
+				},
 			},
 			wantK8sEvents: []string{
 				"Normal Processing Initializing NATS resource.",
@@ -454,6 +606,28 @@ func Test_handleNATSReconcile(t *testing.T) {
 					Reason:             string(nmapiv1alpha1.ConditionReasonNotConfigured),
 					Message:            "NATS is not configured to run in cluster mode (i.e. spec.cluster.size < 3).",
 				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionClusterFormed),
+					Status:             kmetav1.ConditionFalse,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonNotConfigured),
+					Message:            "NATS is not configured to run in cluster mode (i.e. spec.cluster.size < 3).",
+				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionJetStreamHealthy),
+					Status:             kmetav1.ConditionTrue,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonJetStreamHealthy),
+					Message:            "JetStream is healthy on all NATS servers.",
+				},
+				{
+					Type:               string(nmapiv1alpha1.ConditionStorageCapacity),
+					Status:             kmetav1.ConditionTrue,
+					LastTransitionTime: kmetav1.Now(),
+					Reason:             string(nmapiv1alpha1.ConditionReasonStorageAvailable),
+					Message: "JetStream uses up to 0% of the file storage and 0% of the memory storage " +
+						"of a NATS server.",
+				},
 			},
 			wantDestinationRuleWatchStarted: true,
 			wantK8sEvents: []string{
@@ -496,6 +670,8 @@ func Test_handleNATSReconcile(t *testing.T) {
 			}
 			testEnv.natsManager.On("GenerateNATSResources",
 				mock.Anything, mock.Anything, mock.Anything).Return(natsResources, nil)
+			testEnv.kubeClient.On("GetFieldConflicts",
+				mock.Anything, mock.Anything).Return(nil, nil)
 			testEnv.natsManager.On("DeployInstance",
 				mock.Anything, mock.Anything).Return(tc.givenDeployError)
 			testEnv.natsManager.On("GenerateOverrides",
@@ -537,7 +713,8 @@ func Test_handleNATSReconcile(t *testing.T) {
 			require.Equal(t, tc.wantState, gotNATS.Status.State)
 
 			// check metrics.
-			gotClusterSizeMetric, err := reconciler.collector.GetClusterSizeMetric()
+			gotClusterSizeMetric, err := reconciler.collector.GetClusterSizeMetric(
+				tc.givenNATS.Namespace, tc.givenNATS.Name)
 			require.Equal(t, float64(tc.givenNATS.Spec.Cluster.Size), ptestutil.ToFloat64(gotClusterSizeMetric))
 			require.NoError(t, err)
 
@@ -0,0 +1,91 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/k8s/chart"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// pruneNATSResources deletes the objects of the inventory in the NATS CR status which are no longer rendered
+// from the NATS chart, for example, the DestinationRule once Istio was removed. Afterwards, the inventory lists
+// the rendered objects and the objects which could not be deleted.
+func (r *Reconciler) pruneNATSResources(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	instance *chart.ReleaseInstance, log *zap.SugaredLogger,
+) error {
+	inventory := make([]nmapiv1alpha1.InventoryEntry, 0, len(instance.RenderedManifests.Items))
+	rendered := map[string]bool{}
+	for _, object := range instance.RenderedManifests.Items {
+		entry := inventoryEntry(object)
+		inventory = append(inventory, entry)
+		rendered[inventoryKey(entry)] = true
+	}
+
+	var errs []error
+	for _, entry := range nats.Status.Inventory {
+		if rendered[inventoryKey(entry)] {
+			continue
+		}
+		if isRenderedOnce(nats, entry) {
+			inventory = append(inventory, entry)
+			continue
+		}
+		if err := r.deleteInventoryEntry(ctx, entry); err != nil {
+			errs = append(errs, fmt.Errorf("failed to prune %s %s/%s: %w", entry.Kind, entry.Namespace, entry.Name, err))
+			inventory = append(inventory, entry)
+			continue
+		}
+		log.Infow("pruned object which is no longer rendered", "kind", entry.Kind,
+			"namespace", entry.Namespace, "name", entry.Name)
+	}
+
+	slices.SortFunc(inventory, func(a, b nmapiv1alpha1.InventoryEntry) int {
+		return strings.Compare(inventoryKey(a), inventoryKey(b))
+	})
+	nats.Status.Inventory = inventory
+	return errors.Join(errs...)
+}
+
+func (r *Reconciler) deleteInventoryEntry(ctx context.Context, entry nmapiv1alpha1.InventoryEntry) error {
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion(entry.APIVersion)
+	object.SetKind(entry.Kind)
+	object.SetName(entry.Name)
+	object.SetNamespace(entry.Namespace)
+	err := r.kubeClient.Delete(ctx, object)
+	if meta.IsNoMatchError(err) {
+		// the object is gone together with its CRD.
+		return nil
+	}
+	return err
+}
+
+func inventoryEntry(object *unstructured.Unstructured) nmapiv1alpha1.InventoryEntry {
+	return nmapiv1alpha1.InventoryEntry{
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Name:       object.GetName(),
+		Namespace:  object.GetNamespace(),
+	}
+}
+
+// isRenderedOnce returns true for the objects which the NATS chart only renders until they exist.
+// The Secret with the passwords of the accounts is kept, so that the passwords do not change.
+func isRenderedOnce(nats *nmapiv1alpha1.NATS, entry nmapiv1alpha1.InventoryEntry) bool {
+	return !nats.IsJWTAuthEnabled() && entry.Kind == "Secret" &&
+		entry.Name == nats.Name+nmapiv1alpha1.AccountsSecretSuffix
+}
+
+// inventoryKey identifies the object of the inventory entry independent of its API version.
+func inventoryKey(entry nmapiv1alpha1.InventoryEntry) string {
+	group := schema.FromAPIVersionAndKind(entry.APIVersion, entry.Kind).Group
+	return strings.Join([]string{group, entry.Kind, entry.Namespace, entry.Name}, "/")
+}
@@ -0,0 +1,129 @@
+package nats
+
+import (
+	"errors"
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/k8s/chart"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_pruneNATSResources(t *testing.T) {
+	t.Parallel()
+
+	statefulSet := nmapiv1alpha1.InventoryEntry{
+		APIVersion: "apps/v1", Kind: "StatefulSet", Name: "eventing-nats", Namespace: "kyma-system",
+	}
+	podDisruptionBudget := nmapiv1alpha1.InventoryEntry{
+		APIVersion: "policy/v1", Kind: "PodDisruptionBudget", Name: "eventing-nats", Namespace: "kyma-system",
+	}
+	destinationRule := nmapiv1alpha1.InventoryEntry{
+		APIVersion: "networking.istio.io/v1beta1", Kind: "DestinationRule", Name: "eventing-nats",
+		Namespace: "kyma-system",
+	}
+	accountsSecret := nmapiv1alpha1.InventoryEntry{
+		APIVersion: "v1", Kind: "Secret", Name: "eventing-nats-secret", Namespace: "kyma-system",
+	}
+
+	testCases := []struct {
+		name             string
+		givenInventory   []nmapiv1alpha1.InventoryEntry
+		givenDeleteError error
+		wantDeleted      []string
+		wantInventory    []nmapiv1alpha1.InventoryEntry
+		wantError        bool
+	}{
+		{
+			name:          "should list the rendered objects in the inventory",
+			wantInventory: []nmapiv1alpha1.InventoryEntry{statefulSet, podDisruptionBudget},
+		},
+		{
+			name:           "should delete the objects which are no longer rendered",
+			givenInventory: []nmapiv1alpha1.InventoryEntry{statefulSet, podDisruptionBudget, destinationRule},
+			wantDeleted:    []string{"DestinationRule"},
+			wantInventory:  []nmapiv1alpha1.InventoryEntry{statefulSet, podDisruptionBudget},
+		},
+		{
+			name: "should not delete objects whose API version changed",
+			givenInventory: []nmapiv1alpha1.InventoryEntry{statefulSet, {
+				APIVersion: "policy/v1beta1", Kind: "PodDisruptionBudget", Name: "eventing-nats",
+				Namespace: "kyma-system",
+			}},
+			wantInventory: []nmapiv1alpha1.InventoryEntry{statefulSet, podDisruptionBudget},
+		},
+		{
+			name:           "should keep the Secret with the passwords of the accounts",
+			givenInventory: []nmapiv1alpha1.InventoryEntry{statefulSet, podDisruptionBudget, accountsSecret},
+			wantInventory:  []nmapiv1alpha1.InventoryEntry{statefulSet, podDisruptionBudget, accountsSecret},
+		},
+		{
+			name:             "should consider objects as deleted whose CRD does not exist anymore",
+			givenInventory:   []nmapiv1alpha1.InventoryEntry{statefulSet, destinationRule},
+			givenDeleteError: &meta.NoKindMatchError{GroupKind: schema.GroupKind{Kind: "DestinationRule"}},
+			wantDeleted:      []string{"DestinationRule"},
+			wantInventory:    []nmapiv1alpha1.InventoryEntry{statefulSet, podDisruptionBudget},
+		},
+		{
+			name:             "should keep the objects in the inventory which could not be deleted",
+			givenInventory:   []nmapiv1alpha1.InventoryEntry{statefulSet, destinationRule},
+			givenDeleteError: errors.New("forbidden"),
+			wantDeleted:      []string{"DestinationRule"},
+			wantInventory:    []nmapiv1alpha1.InventoryEntry{statefulSet, destinationRule, podDisruptionBudget},
+			wantError:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+			)
+			givenNATS.Status.Inventory = tc.givenInventory
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+
+			var gotDeleted []string
+			testEnv.kubeClient.On("Delete", mock.Anything, mock.Anything).Return(tc.givenDeleteError).
+				Run(func(args mock.Arguments) {
+					object, ok := args.Get(1).(*unstructured.Unstructured)
+					require.True(t, ok)
+					gotDeleted = append(gotDeleted, object.GetKind())
+				})
+
+			instance := chart.NewReleaseInstance(givenNATS.Name, givenNATS.Namespace, false, nil)
+			instance.SetRenderedManifests(chart.ManifestResources{Items: []*unstructured.Unstructured{
+				newRenderedObject(statefulSet), newRenderedObject(podDisruptionBudget),
+			}})
+
+			// when
+			err := testEnv.Reconciler.pruneNATSResources(testEnv.Context, givenNATS, instance, testEnv.Logger)
+
+			// then
+			if tc.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantDeleted, gotDeleted)
+			require.ElementsMatch(t, tc.wantInventory, givenNATS.Status.Inventory)
+		})
+	}
+}
+
+func newRenderedObject(entry nmapiv1alpha1.InventoryEntry) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion(entry.APIVersion)
+	object.SetKind(entry.Kind)
+	object.SetName(entry.Name)
+	object.SetNamespace(entry.Namespace)
+	return object
+}
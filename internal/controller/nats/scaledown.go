@@ -0,0 +1,323 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmctrlclientconfig "github.com/kyma-project/nats-manager/internal/controller/nats/clientconfig"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	"go.uber.org/zap"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// accountStream is a stream of the NATS cluster together with the account it belongs to.
+type accountStream struct {
+	account *monitor.AccountDetail
+	stream  *monitor.StreamDetail
+}
+
+// syncScaleDown scales the NATS cluster down one NATS server at a time and returns the number of replicas of
+// the StatefulSet. The NATS server with the highest ordinal is only removed once all NATS servers are healthy
+// and no stream has a replica on it anymore. Its streams are moved to the remaining NATS servers first, which
+// also moves the only replica of streams with a single replica. The scale-down is refused if a stream has more
+// replicas than the new size of the cluster. Once the Pod of the departed NATS server is gone, the NATS server
+// is removed from the JetStream cluster and its PVC is deleted. The progress is reported in the ScalingDown
+// condition.
+func (r *Reconciler) syncScaleDown(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	log *zap.SugaredLogger,
+) (int32, error) {
+	size := int32(nats.Spec.Cluster.Size)
+	sts := &kappsv1.StatefulSet{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: nats.Name, Namespace: nats.Namespace}, sts)
+	if kapierrors.IsNotFound(err) {
+		// a new StatefulSet starts with the size of the cluster.
+		return size, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if nats.IsDryRun() {
+		// nothing is applied in the dry-run mode, so the plan shows the size of the cluster.
+		return size, nil
+	}
+
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	pods, err := r.getNATSPodsByOrdinal(ctx, nats)
+	if err != nil {
+		return 0, err
+	}
+	departed, err := r.removeDepartedServers(ctx, nats, max(replicas, size), pods, log)
+	if err != nil {
+		return 0, err
+	}
+
+	if replicas <= size {
+		condition := nats.Status.FindCondition(nmapiv1alpha1.ConditionScalingDown)
+		switch {
+		case len(departed) > 0:
+			nats.Status.UpdateConditionScalingDown(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonScalingDown,
+				fmt.Sprintf("Removing NATS servers %s from the JetStream cluster.", strings.Join(departed, ", ")))
+		case condition == nil:
+		case condition.Reason == string(nmapiv1alpha1.ConditionReasonScaleDownRefused):
+			// the size of the cluster was increased again.
+			nats.Status.RemoveCondition(nmapiv1alpha1.ConditionScalingDown)
+		case condition.Status == kmetav1.ConditionTrue:
+			msg := fmt.Sprintf("The NATS cluster was scaled down to %d NATS servers.", replicas)
+			nats.Status.UpdateConditionScalingDown(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonScaledDown, msg)
+			events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonScaledDown, msg)
+		}
+		return size, nil
+	}
+
+	progress := fmt.Sprintf("Scaling down from %d to %d NATS servers.", replicas, size)
+	if len(departed) > 0 {
+		nats.Status.UpdateConditionScalingDown(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonScalingDown,
+			fmt.Sprintf("%s Removing NATS servers %s from the JetStream cluster.", progress, strings.Join(departed, ", ")))
+		return replicas, nil
+	}
+	if message := r.checkNATSServersHealthy(ctx, nats, pods, replicas); message != "" {
+		nats.Status.UpdateConditionScalingDown(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonScalingDown,
+			fmt.Sprintf("%s %s", progress, message))
+		return replicas, nil
+	}
+
+	streams, err := r.getStreams(ctx, nats, pods, replicas)
+	if err != nil {
+		nats.Status.UpdateConditionScalingDown(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonScalingDown,
+			fmt.Sprintf("%s Failed to read the streams: %s.", progress, err))
+		return replicas, nil
+	}
+	var tooManyReplicas []string
+	for _, s := range streams {
+		if s.stream.Config != nil && s.stream.Config.Replicas > int(size) {
+			tooManyReplicas = append(tooManyReplicas, fmt.Sprintf("%s (%d replicas)", s.stream.Name,
+				s.stream.Config.Replicas))
+		}
+	}
+	if len(tooManyReplicas) > 0 {
+		nats.Status.UpdateConditionScalingDown(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonScaleDownRefused,
+			fmt.Sprintf("The NATS cluster cannot be scaled down to %d NATS servers, because the streams %s have "+
+				"more replicas. Reduce the replicas of the streams or keep the size of the cluster.",
+				size, strings.Join(tooManyReplicas, ", ")))
+		return replicas, nil
+	}
+
+	departing := fmt.Sprintf("%s-%d", nats.Name, replicas-1)
+	var moving []accountStream
+	for _, s := range streams {
+		if hasReplicaOn(s.stream, departing) {
+			moving = append(moving, s)
+		}
+	}
+	if len(moving) > 0 {
+		message := r.moveStreams(ctx, nats, moving, departing, log)
+		nats.Status.UpdateConditionScalingDown(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonScalingDown,
+			fmt.Sprintf("%s %s", progress, message))
+		return replicas, nil
+	}
+
+	log.Infow("removing NATS server to scale down", "pod", departing, "size", size)
+	events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonScalingDown,
+		"Removing NATS server %s to scale the NATS cluster down to %d NATS servers.", departing, size)
+	nats.Status.UpdateConditionScalingDown(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonScalingDown,
+		fmt.Sprintf("%s Removing NATS server %s.", progress, departing))
+	return replicas - 1, nil
+}
+
+// moveStreams moves the given streams off the departing NATS server and returns the progress of the moves.
+// The moves complete asynchronously, so a stream is requested to move again until it left the NATS server,
+// which JetStream ignores while the stream is being moved.
+func (r *Reconciler) moveStreams(ctx context.Context, nats *nmapiv1alpha1.NATS, streams []accountStream,
+	departing string, log *zap.SugaredLogger,
+) string {
+	systemClient, err := r.connectSystemNatsClient(ctx, nats)
+	if err != nil {
+		return fmt.Sprintf("Failed to connect to NATS to move the streams off NATS server %s: %s.", departing, err)
+	}
+	defer systemClient.Close()
+
+	names := make([]string, 0, len(streams))
+	for _, s := range streams {
+		if err = systemClient.MoveStream(s.account.ID, s.stream.Name, departing); err != nil {
+			log.Warnw("failed to move stream", "stream", s.stream.Name, "account", s.account.Name, "error", err)
+			return fmt.Sprintf("Failed to move stream %s off NATS server %s: %s.", s.stream.Name, departing, err)
+		}
+		names = append(names, s.stream.Name)
+	}
+	return fmt.Sprintf("Moving the streams %s off NATS server %s.", strings.Join(names, ", "), departing)
+}
+
+// removeDepartedServers removes the NATS servers with an ordinal of at least the given number of NATS servers
+// from the JetStream cluster and deletes their PVCs. A NATS server is only removed once its Pod is gone, as
+// a running NATS server would rejoin the cluster. It returns the NATS servers which are not removed yet.
+func (r *Reconciler) removeDepartedServers(ctx context.Context, nats *nmapiv1alpha1.NATS, servers int32,
+	pods map[int32]*kcorev1.Pod, log *zap.SugaredLogger,
+) ([]string, error) {
+	pvcs, err := r.getNATSPVCs(ctx, nats)
+	if err != nil {
+		return nil, err
+	}
+	departed := map[int32]*kcorev1.PersistentVolumeClaim{}
+	for i := range pvcs {
+		if ordinal, ok := pvcOrdinal(&pvcs[i]); ok && ordinal >= servers {
+			departed[ordinal] = &pvcs[i]
+		}
+	}
+	if len(departed) == 0 {
+		return nil, nil
+	}
+
+	var systemClient nmnats.Client
+	defer func() {
+		if systemClient != nil {
+			systemClient.Close()
+		}
+	}()
+	var pending []string
+	for _, ordinal := range slices.Sorted(maps.Keys(departed)) {
+		serverName := fmt.Sprintf("%s-%d", nats.Name, ordinal)
+		if _, ok := pods[ordinal]; ok {
+			pending = append(pending, serverName)
+			continue
+		}
+		if systemClient == nil {
+			if systemClient, err = r.connectSystemNatsClient(ctx, nats); err != nil {
+				log.Warnw("failed to connect to NATS to remove the departed NATS servers", "error", err)
+				pending = append(pending, serverName)
+				continue
+			}
+		}
+		if err = systemClient.RemoveServer(serverName); err != nil {
+			log.Warnw("failed to remove NATS server from the JetStream cluster", "server", serverName, "error", err)
+			pending = append(pending, serverName)
+			continue
+		}
+		pvc := departed[ordinal]
+		if err = r.Delete(ctx, pvc); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		log.Infow("removed departed NATS server", "server", serverName, "pvc", pvc.Name)
+		events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonScalingDown,
+			"Removed NATS server %s from the JetStream cluster and deleted its PVC %s.", serverName, pvc.Name)
+	}
+	return pending, nil
+}
+
+// getClusterServers returns the number of NATS servers which take part in the NATS cluster. While the cluster is
+// scaled down, this includes the departing NATS servers until they are removed from the JetStream cluster, i.e.
+// as long as their Pods or PVCs exist.
+func (r *Reconciler) getClusterServers(ctx context.Context, nats *nmapiv1alpha1.NATS) (int32, error) {
+	servers := int32(nats.Spec.Cluster.Size)
+	sts := &kappsv1.StatefulSet{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: nats.Name, Namespace: nats.Namespace}, sts)
+	if kapierrors.IsNotFound(err) {
+		return servers, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if sts.Spec.Replicas != nil {
+		servers = max(servers, *sts.Spec.Replicas)
+	}
+
+	pods, err := r.getNATSPodsByOrdinal(ctx, nats)
+	if err != nil {
+		return 0, err
+	}
+	for ordinal := range pods {
+		servers = max(servers, ordinal+1)
+	}
+	pvcs, err := r.getNATSPVCs(ctx, nats)
+	if err != nil {
+		return 0, err
+	}
+	for i := range pvcs {
+		if ordinal, ok := pvcOrdinal(&pvcs[i]); ok {
+			servers = max(servers, ordinal+1)
+		}
+	}
+	return servers, nil
+}
+
+// getStreams returns the streams of all accounts of the NATS cluster. Every stream is listed by the NATS server
+// which leads it, as only the leader knows the state of all replicas of the stream.
+func (r *Reconciler) getStreams(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	pods map[int32]*kcorev1.Pod, replicas int32,
+) ([]accountStream, error) {
+	var streams []accountStream
+	for ordinal := range replicas {
+		config, err := nmctrlclientconfig.NewPodMonitor(ctx, r.Client, nats, pods[ordinal].Name)
+		if err != nil {
+			return nil, err
+		}
+		monitorClient, err := r.newMonitorClient(config)
+		if err != nil {
+			return nil, err
+		}
+		jsz, err := monitorClient.JszStreams(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range jsz.AccountDetails {
+			for _, stream := range account.Streams {
+				streams = append(streams, accountStream{account: account, stream: stream})
+			}
+		}
+	}
+	return streams, nil
+}
+
+// connectSystemNatsClient connects to NATS as the user of the system account, which may move the streams
+// of all accounts and remove NATS servers from the JetStream cluster. The caller must close the client.
+func (r *Reconciler) connectSystemNatsClient(ctx context.Context, nats *nmapiv1alpha1.NATS) (nmnats.Client, error) {
+	config, err := nmctrlclientconfig.NewSystem(ctx, r.Client, nats)
+	if err != nil {
+		return nil, err
+	}
+	systemClient := r.newNatsClient(config)
+	if err = systemClient.Init(); err != nil {
+		return nil, err
+	}
+	return systemClient, nil
+}
+
+// hasReplicaOn returns whether the given NATS server leads the stream or holds one of its replicas.
+func hasReplicaOn(stream *monitor.StreamDetail, serverName string) bool {
+	if stream.Cluster == nil {
+		return false
+	}
+	if stream.Cluster.Leader == serverName {
+		return true
+	}
+	return slices.ContainsFunc(stream.Cluster.Replicas, func(peer *monitor.PeerInfo) bool {
+		return peer.Name == serverName
+	})
+}
+
+// pvcOrdinal returns the ordinal of the NATS server which the PVC of the StatefulSet belongs to.
+func pvcOrdinal(pvc *kcorev1.PersistentVolumeClaim) (int32, bool) {
+	index := strings.LastIndex(pvc.Name, "-")
+	if index < 0 {
+		return 0, false
+	}
+	ordinal, err := strconv.ParseInt(pvc.Name[index+1:], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(ordinal), true
+}
@@ -0,0 +1,308 @@
+package nats
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmnats "github.com/kyma-project/nats-manager/pkg/nats"
+	"github.com/kyma-project/nats-manager/pkg/nats/mocks"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	nmmonitormocks "github.com/kyma-project/nats-manager/pkg/nats/monitor/mocks"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_syncScaleDown(t *testing.T) {
+	t.Parallel()
+
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+		testutils.WithNATSClusterSize(3),
+	)
+
+	testCases := []struct {
+		name                  string
+		givenReplicas         int32
+		givenPods             int
+		givenNotReadyPod      string
+		givenPVCs             int
+		givenStreams          map[string][]*monitor.StreamDetail
+		givenConditionReason  nmapiv1alpha1.ConditionReason
+		givenDryRun           bool
+		wantReplicas          int32
+		wantMovedStreams      []string
+		wantRemovedServer     string
+		wantPVCs              int
+		wantConditionStatus   kmetav1.ConditionStatus
+		wantConditionReason   nmapiv1alpha1.ConditionReason
+		wantConditionContains string
+	}{
+		{
+			name:         "should start a new StatefulSet with the size of the cluster",
+			wantReplicas: 3,
+		},
+		{
+			name:          "should keep the size of the cluster if it is not scaled down",
+			givenReplicas: 3,
+			givenPods:     3,
+			givenPVCs:     3,
+			wantReplicas:  3,
+			wantPVCs:      3,
+		},
+		{
+			name:          "should remove the NATS server with the highest ordinal if it has no streams",
+			givenReplicas: 5,
+			givenPods:     5,
+			givenPVCs:     5,
+			givenStreams: map[string][]*monitor.StreamDetail{
+				"eventing-nats-0": {newStreamDetail("sap", 3, "eventing-nats-0", "eventing-nats-1", "eventing-nats-2")},
+			},
+			wantReplicas:          4,
+			wantPVCs:              5,
+			wantConditionStatus:   kmetav1.ConditionTrue,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonScalingDown,
+			wantConditionContains: "Scaling down from 5 to 3 NATS servers. Removing NATS server eventing-nats-4.",
+		},
+		{
+			name:          "should move the streams off the NATS server with the highest ordinal",
+			givenReplicas: 5,
+			givenPods:     5,
+			givenPVCs:     5,
+			givenStreams: map[string][]*monitor.StreamDetail{
+				"eventing-nats-2": {newStreamDetail("sap", 3, "eventing-nats-2", "eventing-nats-3", "eventing-nats-4")},
+				"eventing-nats-4": {newStreamDetail("orders", 1, "eventing-nats-4")},
+			},
+			wantReplicas:          5,
+			wantMovedStreams:      []string{"sap", "orders"},
+			wantPVCs:              5,
+			wantConditionStatus:   kmetav1.ConditionTrue,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonScalingDown,
+			wantConditionContains: "Moving the streams sap, orders off NATS server eventing-nats-4.",
+		},
+		{
+			name:          "should refuse the scale-down if a stream has more replicas than the new size",
+			givenReplicas: 5,
+			givenPods:     5,
+			givenPVCs:     5,
+			givenStreams: map[string][]*monitor.StreamDetail{
+				"eventing-nats-1": {newStreamDetail("sap", 5, "eventing-nats-1",
+					"eventing-nats-0", "eventing-nats-2", "eventing-nats-3", "eventing-nats-4")},
+			},
+			wantReplicas:          5,
+			wantPVCs:              5,
+			wantConditionStatus:   kmetav1.ConditionFalse,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonScaleDownRefused,
+			wantConditionContains: "because the streams sap (5 replicas) have more replicas",
+		},
+		{
+			name:                  "should wait until all NATS servers are ready",
+			givenReplicas:         5,
+			givenPods:             5,
+			givenNotReadyPod:      "eventing-nats-1",
+			givenPVCs:             5,
+			wantReplicas:          5,
+			wantPVCs:              5,
+			wantConditionStatus:   kmetav1.ConditionTrue,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonScalingDown,
+			wantConditionContains: "Waiting for NATS server eventing-nats-1 to get ready.",
+		},
+		{
+			name:                  "should wait until the Pod of the departed NATS server is gone",
+			givenReplicas:         4,
+			givenPods:             5,
+			givenPVCs:             5,
+			wantReplicas:          4,
+			wantPVCs:              5,
+			wantConditionStatus:   kmetav1.ConditionTrue,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonScalingDown,
+			wantConditionContains: "Removing NATS servers eventing-nats-4 from the JetStream cluster.",
+		},
+		{
+			name:                  "should remove the departed NATS server and delete its PVC",
+			givenReplicas:         4,
+			givenPods:             4,
+			givenPVCs:             5,
+			wantReplicas:          3,
+			wantRemovedServer:     "eventing-nats-4",
+			wantPVCs:              4,
+			wantConditionStatus:   kmetav1.ConditionTrue,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonScalingDown,
+			wantConditionContains: "Scaling down from 4 to 3 NATS servers. Removing NATS server eventing-nats-3.",
+		},
+		{
+			name:                  "should complete the scale-down once the last departed NATS server is removed",
+			givenReplicas:         3,
+			givenPods:             3,
+			givenPVCs:             4,
+			givenConditionReason:  nmapiv1alpha1.ConditionReasonScalingDown,
+			wantReplicas:          3,
+			wantRemovedServer:     "eventing-nats-3",
+			wantPVCs:              3,
+			wantConditionStatus:   kmetav1.ConditionFalse,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonScaledDown,
+			wantConditionContains: "The NATS cluster was scaled down to 3 NATS servers.",
+		},
+		{
+			name:                 "should remove the refused scale-down once the size was increased again",
+			givenReplicas:        3,
+			givenPods:            3,
+			givenPVCs:            3,
+			givenConditionReason: nmapiv1alpha1.ConditionReasonScaleDownRefused,
+			wantReplicas:         3,
+			wantPVCs:             3,
+		},
+		{
+			name:          "should not scale down in the dry-run mode",
+			givenReplicas: 5,
+			givenPods:     5,
+			givenPVCs:     5,
+			givenDryRun:   true,
+			wantReplicas:  3,
+			wantPVCs:      5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			nats := givenNATS.DeepCopy()
+			if tc.givenConditionReason != "" {
+				status := kmetav1.ConditionTrue
+				if tc.givenConditionReason == nmapiv1alpha1.ConditionReasonScaleDownRefused {
+					status = kmetav1.ConditionFalse
+				}
+				nats.Status.UpdateConditionScalingDown(status, tc.givenConditionReason, "")
+			}
+			if tc.givenDryRun {
+				nats.Annotations = map[string]string{nmapiv1alpha1.DryRunAnnotation: "true"}
+			}
+			objs := []client.Object{nats, newAccountsSecret(nats)}
+			if tc.givenReplicas > 0 {
+				objs = append(objs, newScaleDownStatefulSet(nats, tc.givenReplicas))
+			}
+			for ordinal := range tc.givenPods {
+				ready := fmt.Sprintf("%s-%d", nats.Name, ordinal) != tc.givenNotReadyPod
+				objs = append(objs, newUpgradePod(nats, ordinal, newRevision, ready))
+			}
+			for ordinal := range tc.givenPVCs {
+				objs = append(objs, newNATSPVC(fmt.Sprintf("eventing-nats-js-pvc-eventing-nats-%d", ordinal),
+					instanceLabelValue(nats)))
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, objs...)
+			testEnv.Reconciler.newMonitorClient = func(config *monitor.Config) (monitor.Client, error) {
+				podName, _, _ := strings.Cut(strings.TrimPrefix(config.URL, "http://"), ".")
+				monitorClient := new(nmmonitormocks.Client)
+				monitorClient.On("Healthz", mock.Anything).Return(
+					&monitor.Healthz{Status: monitor.HealthzStatusOK}, nil)
+				monitorClient.On("JszStreams", mock.Anything).Return(&monitor.Jsz{
+					AccountDetails: []*monitor.AccountDetail{
+						{Name: "$G", ID: "$G", Streams: tc.givenStreams[podName]},
+					},
+				}, nil)
+				return monitorClient, nil
+			}
+			systemClient := new(mocks.Client)
+			testEnv.Reconciler.newNatsClient = func(config *nmnats.Config) nmnats.Client {
+				require.Equal(t, "admin", config.User)
+				require.Equal(t, "admin-pw", config.Password)
+				return systemClient
+			}
+			if len(tc.wantMovedStreams) > 0 || tc.wantRemovedServer != "" {
+				systemClient.On("Init").Return(nil)
+				systemClient.On("Close").Return()
+			}
+			for _, stream := range tc.wantMovedStreams {
+				systemClient.On("MoveStream", "$G", stream, "eventing-nats-4").Return(nil).Once()
+			}
+			if tc.wantRemovedServer != "" {
+				systemClient.On("RemoveServer", tc.wantRemovedServer).Return(nil).Once()
+			}
+
+			// when
+			replicas, err := testEnv.Reconciler.syncScaleDown(testEnv.Context, nats, testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantReplicas, replicas)
+			systemClient.AssertExpectations(t)
+			gotPVCs, err := testEnv.Reconciler.getNATSPVCs(testEnv.Context, nats)
+			require.NoError(t, err)
+			require.Len(t, gotPVCs, tc.wantPVCs)
+			if tc.wantRemovedServer != "" {
+				for _, pvc := range gotPVCs {
+					require.NotEqual(t, "eventing-nats-js-pvc-"+tc.wantRemovedServer, pvc.Name)
+				}
+			}
+
+			gotCondition := nats.Status.FindCondition(nmapiv1alpha1.ConditionScalingDown)
+			if tc.wantConditionStatus == "" {
+				require.Nil(t, gotCondition)
+				return
+			}
+			require.NotNil(t, gotCondition)
+			require.Equal(t, tc.wantConditionStatus, gotCondition.Status)
+			require.Equal(t, string(tc.wantConditionReason), gotCondition.Reason)
+			require.Contains(t, gotCondition.Message, tc.wantConditionContains)
+		})
+	}
+}
+
+func newScaleDownStatefulSet(nats *nmapiv1alpha1.NATS, replicas int32) *kappsv1.StatefulSet {
+	return &kappsv1.StatefulSet{
+		ObjectMeta: kmetav1.ObjectMeta{Name: nats.Name, Namespace: nats.Namespace},
+		Spec:       kappsv1.StatefulSetSpec{Replicas: &replicas},
+	}
+}
+
+// newScaleDownPods returns the given number of ready Pods of the NATS servers.
+func newScaleDownPods(nats *nmapiv1alpha1.NATS, pods int) []client.Object {
+	objs := make([]client.Object, 0, pods)
+	for ordinal := range pods {
+		objs = append(objs, newUpgradePod(nats, ordinal, newRevision, true))
+	}
+	return objs
+}
+
+// newScaleDownPVCs returns the given number of PVCs of the NATS servers.
+func newScaleDownPVCs(nats *nmapiv1alpha1.NATS, pvcs int) []client.Object {
+	objs := make([]client.Object, 0, pvcs)
+	for ordinal := range pvcs {
+		objs = append(objs, newNATSPVC(fmt.Sprintf("%s-js-pvc-%s-%d", nats.Name, nats.Name, ordinal),
+			instanceLabelValue(nats)))
+	}
+	return objs
+}
+
+func newAccountsSecret(nats *nmapiv1alpha1.NATS) *kcorev1.Secret {
+	return &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name: nats.Name + nmapiv1alpha1.AccountsSecretSuffix, Namespace: nats.Namespace,
+		},
+		Data: map[string][]byte{
+			"resolver.conf": []byte(`accounts: { "$SYS": { users: [ { user: "admin", password: "admin-pw" } ] } }`),
+		},
+	}
+}
+
+// newStreamDetail returns a stream which is led by the given NATS server and replicated to the followers.
+func newStreamDetail(name string, replicas int, leader string, followers ...string) *monitor.StreamDetail {
+	stream := &monitor.StreamDetail{
+		Name:    name,
+		Config:  &monitor.StreamConfig{Replicas: replicas},
+		Cluster: &monitor.ClusterInfo{Leader: leader},
+	}
+	for _, follower := range followers {
+		stream.Cluster.Replicas = append(stream.Cluster.Replicas, &monitor.PeerInfo{Name: follower, Current: true})
+	}
+	return stream
+}
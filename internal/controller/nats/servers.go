@@ -0,0 +1,44 @@
+package nats
+
+import (
+	"context"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"go.uber.org/zap"
+)
+
+// syncServersStatus reports each NATS server in the NATS CR status, with the node and zone of its Pod and
+// the state read from its monitoring endpoint. A NATS server whose monitoring endpoint could not be read is
+// reported with the error, so that the status still shows where its Pod runs.
+func (r *Reconciler) syncServersStatus(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	servers []*natsServerState, log *zap.SugaredLogger,
+) {
+	pods, err := r.getNATSPodsByOrdinal(ctx, nats)
+	if err != nil {
+		log.Warnw("failed to list the Pods of the NATS servers", "error", err)
+	}
+
+	statuses := make([]nmapiv1alpha1.ServerStatus, 0, len(servers))
+	for ordinal, server := range servers {
+		status := nmapiv1alpha1.ServerStatus{Pod: server.podName}
+		if pod, ok := pods[int32(ordinal)]; ok && pod.Spec.NodeName != "" {
+			status.Node = pod.Spec.NodeName
+			// a missing zone is already reported by the AvailabilityZones condition.
+			status.Zone, _ = r.kubeClient.GetNodeZone(ctx, pod.Spec.NodeName)
+		}
+
+		if server.err != nil {
+			status.Error = server.err.Error()
+			statuses = append(statuses, status)
+			continue
+		}
+		status.ServerName = server.varz.ServerName
+		status.Version = server.varz.Version
+		status.Connections = server.varz.Connections
+		status.Routes = server.routez.NumRoutes
+		status.JetStreamStorageBytes = server.jsz.Store
+		status.MetaLeader = server.jsz.Meta != nil && server.jsz.Meta.Leader == server.varz.ServerName
+		statuses = append(statuses, status)
+	}
+	nats.Status.Servers = statuses
+}
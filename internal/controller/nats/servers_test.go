@@ -0,0 +1,97 @@
+package nats
+
+import (
+	"errors"
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_syncServersStatus(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+		testutils.WithNATSClusterSize(3),
+	)
+	givenPod0 := newNATSPod("eventing-nats-0", "kyma-system", getNATSPodsMatchLabels(givenNATS))
+	givenPod0.Spec.NodeName = "node-a"
+	givenPod1 := newNATSPod("eventing-nats-1", "kyma-system", getNATSPodsMatchLabels(givenNATS))
+	givenPod1.Spec.NodeName = "node-b"
+	// the Pod of the third NATS server is not scheduled yet.
+	givenPod2 := newNATSPod("eventing-nats-2", "kyma-system", getNATSPodsMatchLabels(givenNATS))
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS, givenPod0, givenPod1, givenPod2)
+	testEnv.kubeClient.On("GetNodeZone", testEnv.Context, "node-a").Return("zone-a", nil).Once()
+	testEnv.kubeClient.On("GetNodeZone", testEnv.Context, "node-b").Return("", errors.New("zone label missing")).Once()
+
+	givenLeader := newNATSServerState("eventing-nats-0", "eventing-nats-1", "eventing-nats-1")
+	givenLeader.varz.Version = "2.12.1"
+	givenLeader.varz.Connections = 12
+	givenLeader.routez.NumRoutes = 2
+	givenLeader.jsz.Store = 2048
+	givenFollower := newNATSServerState("eventing-nats-1", "eventing-nats-0")
+	givenFollower.varz.Version = "2.12.1"
+	givenFollower.routez.NumRoutes = 1
+	givenServers := []*natsServerState{
+		givenLeader,
+		givenFollower,
+		{podName: "eventing-nats-2", err: errors.New("connection refused")},
+	}
+
+	// when
+	testEnv.Reconciler.syncServersStatus(testEnv.Context, givenNATS, givenServers, testEnv.Logger)
+
+	// then
+	require.Equal(t, []nmapiv1alpha1.ServerStatus{
+		{
+			Pod:                   "eventing-nats-0",
+			ServerName:            "eventing-nats-0",
+			Zone:                  "zone-a",
+			Node:                  "node-a",
+			Version:               "2.12.1",
+			Routes:                2,
+			Connections:           12,
+			JetStreamStorageBytes: 2048,
+			MetaLeader:            true,
+		},
+		{
+			Pod:        "eventing-nats-1",
+			ServerName: "eventing-nats-1",
+			Node:       "node-b",
+			Version:    "2.12.1",
+			Routes:     1,
+		},
+		{
+			Pod:   "eventing-nats-2",
+			Error: "connection refused",
+		},
+	}, givenNATS.Status.Servers)
+	testEnv.kubeClient.AssertExpectations(t)
+}
+
+func Test_syncServersStatus_WithoutMetaGroup(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+		testutils.WithNATSClusterSize(1),
+	)
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+	givenServer := newNATSServerState("eventing-nats-0")
+	givenServer.jsz = &monitor.Jsz{}
+
+	// when
+	testEnv.Reconciler.syncServersStatus(testEnv.Context, givenNATS, []*natsServerState{givenServer}, testEnv.Logger)
+
+	// then a single NATS server without a meta group is not reported as meta leader.
+	require.Equal(t, []nmapiv1alpha1.ServerStatus{
+		{Pod: "eventing-nats-0", ServerName: "eventing-nats-0"},
+	}, givenNATS.Status.Servers)
+}
@@ -38,3 +38,11 @@ func (r *Reconciler) syncCloudProvider(ctx context.Context, log *zap.SugaredLogg
 	r.cloudProvider = &provider
 	log.Infow("cloud provider resolved from shoot-info", "provider", provider)
 }
+
+// getCloudProvider returns the cached cloud provider, or an empty string if it was not read yet.
+func (r *Reconciler) getCloudProvider() string {
+	if r.cloudProvider == nil {
+		return ""
+	}
+	return *r.cloudProvider
+}
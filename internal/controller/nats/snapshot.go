@@ -0,0 +1,212 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	"go.uber.org/zap"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kcontrollerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//nolint:gochecknoglobals // the GroupVersionKind of the CSI snapshots.
+var volumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// handleNATSDeletionWithSnapshots takes a VolumeSnapshot of every PVC of the NATS servers and deletes the PVCs
+// once all snapshots are ready to use. The NATS servers are stopped before, so that the snapshots contain the data
+// which the NATS servers flushed on shutdown. If the NATS servers are not stopped or the snapshots are not ready
+// within the deletion grace period, the PVCs are retained instead, so that the data is not lost.
+func (r *Reconciler) handleNATSDeletionWithSnapshots(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	log *zap.SugaredLogger,
+) (kcontrollerruntime.Result, error) {
+	pvcs, err := r.getNATSPVCs(ctx, nats)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+	if len(pvcs) == 0 {
+		return r.deletePVCsAndRemoveFinalizer(ctx, nats, r.logger)
+	}
+
+	// without the VolumeSnapshot API, no snapshot can ever get ready, so the deletion does not wait for it.
+	supported, err := r.volumeSnapshotsSupported(ctx, nats.Namespace)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+	if !supported {
+		message := fmt.Sprintf("Cannot take snapshots of the PVCs, because the VolumeSnapshot API %s is not "+
+			"installed in the cluster. Install the snapshot CRDs and a CSI driver which supports snapshots, "+
+			"or change spec.deletionPolicy.", volumeSnapshotGVK.GroupVersion())
+		nats.Status.SetStateWarning()
+		nats.Status.UpdateConditionDeletion(kmetav1.ConditionFalse,
+			nmapiv1alpha1.ConditionReasonSnapshotsUnavailable, message)
+		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonSnapshotsUnavailable, message)
+		return kcontrollerruntime.Result{RequeueAfter: DeletionCheckInterval}, r.syncNATSStatus(ctx, nats, log)
+	}
+
+	stopped, err := r.stopNATSServers(ctx, nats)
+	if err != nil {
+		return kcontrollerruntime.Result{}, err
+	}
+	var message string
+	if stopped {
+		message, err = r.syncVolumeSnapshots(ctx, nats, pvcs)
+		if err != nil {
+			return kcontrollerruntime.Result{}, err
+		}
+		if message == "" {
+			events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonSnapshotted,
+				"Took snapshots of %d PVCs of the NATS cluster.", len(pvcs))
+			return r.deletePVCsAndRemoveFinalizer(ctx, nats, r.logger)
+		}
+	} else {
+		message = "Waiting for the NATS servers to stop before the snapshots of the PVCs are taken."
+	}
+
+	remaining := remainingDeletionGracePeriod(nats)
+	if remaining <= 0 {
+		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonDeletionEscalated,
+			"Retaining the PVCs, because the snapshots were not ready within %s: %s",
+			nats.GetDeletionGracePeriod(), message)
+		return r.retainPVCsAndRemoveFinalizer(ctx, nats, log)
+	}
+
+	nats.Status.UpdateConditionDeletion(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonSnapshotting, message)
+	return kcontrollerruntime.Result{RequeueAfter: min(remaining, DeletionCheckInterval)},
+		r.syncNATSStatus(ctx, nats, log)
+}
+
+// syncVolumeSnapshots takes a VolumeSnapshot of every given PVC. It returns how many snapshots are ready
+// and why a snapshot failed, or an empty string if all snapshots are ready to use.
+func (r *Reconciler) syncVolumeSnapshots(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	pvcs []kcorev1.PersistentVolumeClaim,
+) (string, error) {
+	ready := 0
+	var problems []string
+	for i := range pvcs {
+		snapshot, err := r.syncVolumeSnapshot(ctx, nats, &pvcs[i])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("failed to take snapshot of PVC %s: %s", pvcs[i].Name, err))
+			continue
+		}
+		if readyToUse, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse"); readyToUse {
+			ready++
+			continue
+		}
+		if message, _, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message"); message != "" {
+			problems = append(problems, fmt.Sprintf("snapshot %s failed: %s", snapshot.GetName(), message))
+		}
+	}
+	if ready == len(pvcs) {
+		return "", nil
+	}
+
+	message := fmt.Sprintf("%d of %d snapshots of the PVCs are ready.", ready, len(pvcs))
+	if len(problems) > 0 {
+		message = fmt.Sprintf("%s Problems: %s.", message, strings.Join(problems, "; "))
+	}
+	return message, nil
+}
+
+// volumeSnapshotsSupported checks if the VolumeSnapshot CRD of the CSI external-snapshotter is installed.
+func (r *Reconciler) volumeSnapshotsSupported(ctx context.Context, namespace string) (bool, error) {
+	snapshots := &unstructured.UnstructuredList{}
+	snapshots.SetGroupVersionKind(volumeSnapshotGVK.GroupVersion().WithKind(volumeSnapshotGVK.Kind + "List"))
+	err := r.List(ctx, snapshots, client.InNamespace(namespace), client.Limit(1))
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// stopNATSServers scales the StatefulSet of the NATS servers down to zero replicas and returns whether all
+// NATS servers are stopped.
+func (r *Reconciler) stopNATSServers(ctx context.Context, nats *nmapiv1alpha1.NATS) (bool, error) {
+	sts := &kappsv1.StatefulSet{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: nats.Name, Namespace: nats.Namespace}, sts)
+	if client.IgnoreNotFound(err) != nil {
+		return false, err
+	}
+	if err == nil && (sts.Spec.Replicas == nil || *sts.Spec.Replicas > 0) {
+		patch := client.MergeFrom(sts.DeepCopy())
+		sts.Spec.Replicas = new(int32(0))
+		if err = r.Patch(ctx, sts, patch); err != nil {
+			return false, err
+		}
+	}
+
+	pods, err := r.getNATSPodsByOrdinal(ctx, nats)
+	if err != nil {
+		return false, err
+	}
+	return len(pods) == 0, nil
+}
+
+// getNATSPVCs returns the PVCs of the NATS servers, i.e. the PVCs which would be deleted with the NATS CR.
+func (r *Reconciler) getNATSPVCs(ctx context.Context,
+	nats *nmapiv1alpha1.NATS,
+) ([]kcorev1.PersistentVolumeClaim, error) {
+	pvcList := &kcorev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, pvcList, client.InNamespace(nats.Namespace),
+		client.MatchingLabels{InstanceLabelKey: instanceLabelValue(nats)}); err != nil {
+		return nil, err
+	}
+
+	var pvcs []kcorev1.PersistentVolumeClaim
+	for _, pvc := range pvcList.Items {
+		if strings.HasPrefix(pvc.Name, nats.Name) {
+			pvcs = append(pvcs, pvc)
+		}
+	}
+	return pvcs, nil
+}
+
+// syncVolumeSnapshot returns the VolumeSnapshot of the given PVC, which is created if it does not exist yet.
+// The VolumeSnapshot is not owned by the NATS CR, so that it is kept after the deletion.
+func (r *Reconciler) syncVolumeSnapshot(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	pvc *kcorev1.PersistentVolumeClaim,
+) (*unstructured.Unstructured, error) {
+	// the name is unique per deletion, so that snapshots of a previous NATS CR with the same name are kept.
+	name := fmt.Sprintf("%s-%d", pvc.Name, nats.DeletionTimestamp.Unix())
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(volumeSnapshotGVK)
+	err := r.Get(ctx, ktypes.NamespacedName{Name: name, Namespace: pvc.Namespace}, snapshot)
+	if err == nil {
+		return snapshot, nil
+	}
+	if !kapierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	snapshot.SetName(name)
+	snapshot.SetNamespace(pvc.Namespace)
+	snapshot.SetLabels(map[string]string{
+		ManagedByLabelKey: ManagedByLabelValue,
+		InstanceLabelKey:  instanceLabelValue(nats),
+	})
+	spec := map[string]any{
+		"source": map[string]any{"persistentVolumeClaimName": pvc.Name},
+	}
+	if nats.Spec.VolumeSnapshotClassName != "" {
+		spec["volumeSnapshotClassName"] = nats.Spec.VolumeSnapshotClassName
+	}
+	snapshot.Object["spec"] = spec
+	if err = r.Create(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
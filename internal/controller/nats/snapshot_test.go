@@ -0,0 +1,193 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func Test_handleNATSDeletionWithSnapshots(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                    string
+		givenSnapshotStatus     map[string]any
+		givenReplicas           int32
+		givenSnapshotAPIMissing bool
+		givenDeletedAgo         time.Duration
+		wantPVCsDeleted         bool
+		wantFinalizerExists     bool
+		wantSnapshots           int
+		wantConditionReason     nmapiv1alpha1.ConditionReason
+		wantConditionMessage    string
+		wantK8sEvent            func(snapshotSuffix int64) string
+	}{
+		{
+			name:                 "should take a snapshot of every PVC and wait until they are ready",
+			wantFinalizerExists:  true,
+			wantSnapshots:        2,
+			wantConditionReason:  nmapiv1alpha1.ConditionReasonSnapshotting,
+			wantConditionMessage: "0 of 2 snapshots of the PVCs are ready.",
+		},
+		{
+			name:                 "should stop the NATS servers before the snapshots are taken",
+			givenReplicas:        2,
+			wantFinalizerExists:  true,
+			wantConditionReason:  nmapiv1alpha1.ConditionReasonSnapshotting,
+			wantConditionMessage: "Waiting for the NATS servers to stop before the snapshots of the PVCs are taken.",
+		},
+		{
+			name:                    "should keep the NATS CR and the PVCs if the VolumeSnapshot API is not installed",
+			givenSnapshotAPIMissing: true,
+			wantFinalizerExists:     true,
+			wantConditionReason:     nmapiv1alpha1.ConditionReasonSnapshotsUnavailable,
+			wantConditionMessage: "Cannot take snapshots of the PVCs, because the VolumeSnapshot API " +
+				"snapshot.storage.k8s.io/v1 is not installed in the cluster.",
+			wantK8sEvent: func(int64) string {
+				return "Warning SnapshotsUnavailable Cannot take snapshots of the PVCs, because the VolumeSnapshot " +
+					"API snapshot.storage.k8s.io/v1 is not installed in the cluster. Install the snapshot CRDs and " +
+					"a CSI driver which supports snapshots, or change spec.deletionPolicy."
+			},
+		},
+		{
+			name:                "should delete the PVCs once all snapshots are ready",
+			givenSnapshotStatus: map[string]any{"readyToUse": true},
+			wantPVCsDeleted:     true,
+			wantSnapshots:       2,
+			wantK8sEvent: func(int64) string {
+				return "Normal Snapshotted Took snapshots of 2 PVCs of the NATS cluster."
+			},
+		},
+		{
+			name:                "should retain the PVCs if the snapshots are not ready within the grace period",
+			givenSnapshotStatus: map[string]any{"readyToUse": false, "error": map[string]any{"message": "no space"}},
+			givenDeletedAgo:     time.Hour,
+			wantSnapshots:       2,
+			wantK8sEvent: func(snapshotSuffix int64) string {
+				return fmt.Sprintf("Warning DeletionEscalated Retaining the PVCs, because the snapshots were not "+
+					"ready within 10m0s: 0 of 2 snapshots of the PVCs are ready. Problems: snapshot "+
+					"eventing-nats-data-eventing-nats-0-%[1]d failed: no space; snapshot "+
+					"eventing-nats-data-eventing-nats-1-%[1]d failed: no space.", snapshotSuffix)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSCRStatusInitialized(),
+				testutils.WithNATSCRFinalizer(NATSFinalizerName),
+			)
+			givenNATS.Spec.DeletionPolicy = nmapiv1alpha1.DeletionPolicySnapshot
+			givenNATS.Spec.VolumeSnapshotClassName = "csi-snapshots"
+			deletionTimestamp := kmetav1.NewTime(time.Now().Add(-tc.givenDeletedAgo).Truncate(time.Second))
+			givenNATS.DeletionTimestamp = &deletionTimestamp
+
+			objs := []client.Object{givenNATS, newNATSPVC("eventing-nats-data-eventing-nats-0", "eventing"),
+				newNATSPVC("eventing-nats-data-eventing-nats-1", "eventing"),
+				newNATSPVC("other-nats-data-other-nats-0", "other-nats")}
+			if tc.givenReplicas > 0 {
+				objs = append(objs, newScaleDownStatefulSet(givenNATS, tc.givenReplicas))
+				objs = append(objs, newScaleDownPods(givenNATS, int(tc.givenReplicas))...)
+			}
+			for _, pvcName := range []string{"eventing-nats-data-eventing-nats-0", "eventing-nats-data-eventing-nats-1"} {
+				if tc.givenSnapshotStatus == nil {
+					continue
+				}
+				snapshot := &unstructured.Unstructured{}
+				snapshot.SetGroupVersionKind(volumeSnapshotGVK)
+				snapshot.SetName(fmt.Sprintf("%s-%d", pvcName, deletionTimestamp.Unix()))
+				snapshot.SetNamespace("kyma-system")
+				snapshot.Object["status"] = tc.givenSnapshotStatus
+				objs = append(objs, snapshot)
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, objs...)
+			reconciler := testEnv.Reconciler
+			if tc.givenSnapshotAPIMissing {
+				fakeClient, ok := testEnv.Client.(client.WithWatch)
+				require.True(t, ok)
+				reconciler.Client = interceptor.NewClient(fakeClient, interceptor.Funcs{
+					List: func(ctx context.Context, c client.WithWatch, list client.ObjectList,
+						opts ...client.ListOption,
+					) error {
+						if list.GetObjectKind().GroupVersionKind().Group == volumeSnapshotGVK.Group {
+							return &meta.NoKindMatchError{GroupKind: volumeSnapshotGVK.GroupKind()}
+						}
+						return c.List(ctx, list, opts...)
+					},
+				})
+			}
+			nats := givenNATS.DeepCopy()
+			testEnv.kubeClient.On("DeletePVCsWithLabel",
+				mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			// when
+			_, err := reconciler.handleNATSDeletionWithSnapshots(testEnv.Context, nats, testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantFinalizerExists, controllerutil.ContainsFinalizer(nats, NATSFinalizerName))
+			if tc.wantPVCsDeleted {
+				testEnv.kubeClient.AssertCalled(t, "DeletePVCsWithLabel",
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				testEnv.kubeClient.AssertNotCalled(t, "DeletePVCsWithLabel",
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tc.wantConditionReason != "" {
+				gotCondition := nats.Status.FindCondition(nmapiv1alpha1.ConditionDeleted)
+				require.NotNil(t, gotCondition)
+				require.Equal(t, string(tc.wantConditionReason), gotCondition.Reason)
+				require.Contains(t, gotCondition.Message, tc.wantConditionMessage)
+			}
+
+			// the snapshots are taken of the PVCs of the NATS CR only, once the NATS servers are stopped.
+			snapshots := &unstructured.UnstructuredList{}
+			snapshots.SetGroupVersionKind(volumeSnapshotGVK)
+			require.NoError(t, testEnv.Client.List(testEnv.Context, snapshots))
+			require.Len(t, snapshots.Items, tc.wantSnapshots)
+			if tc.givenSnapshotStatus == nil {
+				for _, snapshot := range snapshots.Items {
+					className, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+					require.Equal(t, "csi-snapshots", className)
+					require.Empty(t, snapshot.GetOwnerReferences())
+				}
+			}
+			if tc.givenReplicas > 0 {
+				gotSTS := &kappsv1.StatefulSet{}
+				require.NoError(t, testEnv.Client.Get(testEnv.Context, client.ObjectKeyFromObject(givenNATS), gotSTS))
+				require.Equal(t, int32(0), *gotSTS.Spec.Replicas)
+			}
+			if tc.wantK8sEvent != nil {
+				require.Contains(t, testEnv.GetK8sEvents(), tc.wantK8sEvent(deletionTimestamp.Unix()))
+			}
+		})
+	}
+}
+
+func newNATSPVC(name, instance string) *kcorev1.PersistentVolumeClaim {
+	return &kcorev1.PersistentVolumeClaim{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name: name, Namespace: "kyma-system", Labels: map[string]string{InstanceLabelKey: instance},
+		},
+	}
+}
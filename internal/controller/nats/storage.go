@@ -0,0 +1,220 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	nmmgr "github.com/kyma-project/nats-manager/pkg/manager"
+	"go.uber.org/zap"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kstoragev1 "k8s.io/api/storage/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fileStorageSizeStep is the unit to which an automatically grown file storage is rounded up.
+const fileStorageSizeStep = 1 << 20
+
+// syncFileStorage expands the PVCs of the NATS servers to the size of the file storage. It returns the size
+// of the file storage for max_file_store, and the size of the PVCs in the volumeClaimTemplates of the
+// StatefulSet, which cannot be changed once the StatefulSet exists. A PVC is only expanded if its StorageClass
+// allows volume expansion. Otherwise, the file storage keeps the size of the PVCs. While the PVCs are expanded,
+// the file storage keeps the smallest capacity of the PVCs, so that no NATS server may store more than its volume
+// holds. The progress is reported in the ExpandingFileStorage condition, which is only set once a PVC had to be
+// expanded.
+func (r *Reconciler) syncFileStorage(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	log *zap.SugaredLogger,
+) (resource.Quantity, resource.Quantity, error) {
+	size, err := r.fileStorageSize(nats)
+	if err != nil {
+		return resource.Quantity{}, resource.Quantity{}, err
+	}
+
+	sts := &kappsv1.StatefulSet{}
+	err = r.Get(ctx, ktypes.NamespacedName{Name: nats.Name, Namespace: nats.Namespace}, sts)
+	if kapierrors.IsNotFound(err) {
+		// a new StatefulSet creates the PVCs with the size of the file storage.
+		return size, size, nil
+	}
+	if err != nil {
+		return resource.Quantity{}, resource.Quantity{}, err
+	}
+	claimSize, ok := volumeClaimTemplateSize(sts)
+	if !ok {
+		return size, size, nil
+	}
+
+	pvcs, err := r.getNATSPVCs(ctx, nats)
+	if err != nil {
+		return resource.Quantity{}, resource.Quantity{}, err
+	}
+	expanded, patched := 0, 0
+	smallest, smallestCapacity := size, size
+	var unsupported []string
+	for i := range pvcs {
+		pvc := &pvcs[i]
+		if request := pvc.Spec.Resources.Requests.Storage(); request.Cmp(size) < 0 {
+			allowed, err := r.allowsVolumeExpansion(ctx, pvc)
+			if err != nil {
+				return resource.Quantity{}, resource.Quantity{}, err
+			}
+			if !allowed {
+				unsupported = append(unsupported, pvc.Name)
+				if request.Cmp(smallest) < 0 {
+					smallest = *request
+				}
+				continue
+			}
+			if !nats.IsDryRun() {
+				if err = r.expandPVC(ctx, pvc, size); err != nil {
+					return resource.Quantity{}, resource.Quantity{}, err
+				}
+				patched++
+			}
+		}
+		capacity := pvc.Status.Capacity.Storage()
+		if capacity.Cmp(size) >= 0 {
+			expanded++
+		}
+		if !capacity.IsZero() && capacity.Cmp(smallestCapacity) < 0 {
+			smallestCapacity = *capacity
+		}
+	}
+
+	condition := nats.Status.FindCondition(nmapiv1alpha1.ConditionExpandingStorage)
+	switch {
+	case len(unsupported) > 0:
+		log.Warnw("the StorageClass does not allow volume expansion", "pvcs", unsupported)
+		nats.Status.UpdateConditionExpandingStorage(kmetav1.ConditionFalse,
+			nmapiv1alpha1.ConditionReasonExpansionUnsupported,
+			fmt.Sprintf("The StorageClass of the PVCs %s does not allow volume expansion, "+
+				"so the file storage keeps the size of %s.", strings.Join(unsupported, ", "), smallest.String()))
+		return smallest, claimSize, nil
+	case expanded < len(pvcs):
+		if patched > 0 {
+			events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonExpanding,
+				"Expanding %d PVCs of the NATS servers to %s.", patched, size.String())
+		}
+		nats.Status.UpdateConditionExpandingStorage(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonExpanding,
+			fmt.Sprintf("%d of %d PVCs are expanded to %s. The file storage keeps the size of %s until all "+
+				"PVCs are expanded.", expanded, len(pvcs), size.String(), smallestCapacity.String()))
+		return smallestCapacity, claimSize, nil
+	case condition != nil && condition.Reason != string(nmapiv1alpha1.ConditionReasonExpanded):
+		msg := fmt.Sprintf("All %d PVCs are expanded to %s.", len(pvcs), size.String())
+		nats.Status.UpdateConditionExpandingStorage(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonExpanded, msg)
+		events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonExpanded, msg)
+	}
+	return size, claimSize, nil
+}
+
+// fileStorageSize returns the size of the file storage, i.e. the size in the spec or, if it is larger, the size
+// to which the file storage was grown automatically. The grown size is cleared from the status once the size in
+// the spec caught up with it.
+func (r *Reconciler) fileStorageSize(nats *nmapiv1alpha1.NATS) (resource.Quantity, error) {
+	size, err := nmmgr.ResolveFileStorageSize(&nats.Spec, r.getCloudProvider())
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+	if grown := nats.Status.FileStorageSize; grown != nil {
+		if grown.Cmp(size) <= 0 {
+			nats.Status.FileStorageSize = nil
+			return size, nil
+		}
+		return *grown, nil
+	}
+	return size, nil
+}
+
+// autoGrowFileStorage grows the file storage by the increment of the auto-grow policy, once a NATS server uses
+// the warning percentage of its file storage. The grown size is stored in the status and applied with the next
+// reconciliation. The file storage only grows again once all NATS servers run with the grown size.
+func (r *Reconciler) autoGrowFileStorage(nats *nmapiv1alpha1.NATS, servers []*natsServerState,
+	log *zap.SugaredLogger,
+) {
+	policy := nats.Spec.FileStorage.AutoGrow
+	if !policy.Enabled {
+		return
+	}
+	size, err := r.fileStorageSize(nats)
+	if err != nil {
+		log.Warnw("failed to get the size of the file storage", "error", err)
+		return
+	}
+	if size.Cmp(policy.MaxSize) >= 0 {
+		return
+	}
+
+	var fullest *natsServerState
+	fullestPercent := 0
+	for _, server := range servers {
+		if server.err != nil || server.jsz.Config == nil || server.jsz.Config.MaxStore < size.Value() {
+			// the NATS server does not run with the current size of the file storage yet.
+			return
+		}
+		if percent := usagePercent(server.jsz.Store, server.jsz.Config.MaxStore); fullest == nil ||
+			percent > fullestPercent {
+			fullest, fullestPercent = server, percent
+		}
+	}
+	if fullest == nil || fullestPercent < r.storageWarningPercent {
+		return
+	}
+
+	grown := growFileStorageSize(size, policy)
+	nats.Status.FileStorageSize = &grown
+	events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonAutoGrowing,
+		"NATS server %s uses %d%% of its file storage, so the file storage grows from %s to %s.",
+		fullest.podName, fullestPercent, size.String(), grown.String())
+}
+
+// growFileStorageSize returns the size grown by the increment of the policy, rounded up to full MiB and
+// limited to the maximum size of the policy.
+func growFileStorageSize(size resource.Quantity, policy nmapiv1alpha1.FileStorageAutoGrow) resource.Quantity {
+	grown := size.Value() * int64(100+policy.IncrementPercent) / 100
+	grown = (grown + fileStorageSizeStep - 1) / fileStorageSizeStep * fileStorageSizeStep
+	if grown >= policy.MaxSize.Value() {
+		return policy.MaxSize.DeepCopy()
+	}
+	return *resource.NewQuantity(grown, resource.BinarySI)
+}
+
+// volumeClaimTemplateSize returns the size of the PVCs in the volumeClaimTemplates of the StatefulSet.
+func volumeClaimTemplateSize(sts *kappsv1.StatefulSet) (resource.Quantity, bool) {
+	for _, template := range sts.Spec.VolumeClaimTemplates {
+		if request, ok := template.Spec.Resources.Requests[kcorev1.ResourceStorage]; ok {
+			return request, true
+		}
+	}
+	return resource.Quantity{}, false
+}
+
+// allowsVolumeExpansion returns whether the StorageClass of the PVC allows volume expansion.
+func (r *Reconciler) allowsVolumeExpansion(ctx context.Context, pvc *kcorev1.PersistentVolumeClaim) (bool, error) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return false, nil
+	}
+	storageClass := &kstoragev1.StorageClass{}
+	if err := r.Get(ctx, ktypes.NamespacedName{Name: *pvc.Spec.StorageClassName}, storageClass); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion, nil
+}
+
+// expandPVC requests the given size for the PVC. The volume and the file system are then resized by Kubernetes.
+func (r *Reconciler) expandPVC(ctx context.Context, pvc *kcorev1.PersistentVolumeClaim,
+	size resource.Quantity,
+) error {
+	patch := client.MergeFrom(pvc.DeepCopy())
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = kcorev1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[kcorev1.ResourceStorage] = size
+	return r.Patch(ctx, pvc, patch)
+}
@@ -0,0 +1,358 @@
+package nats
+
+import (
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/require"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kstoragev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_syncFileStorage(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                 string
+		givenSize            string
+		givenGrownSize       string
+		givenClaimSize       string
+		givenPVCSize         string
+		givenPVCCapacity     string
+		givenExpansion       bool
+		givenCondition       *kmetav1.Condition
+		wantFileStorageSize  string
+		wantClaimSize        string
+		wantPVCSize          string
+		wantCondition        *kmetav1.Condition
+		wantEvents           []string
+		wantGrownSizeCleared bool
+	}{
+		{
+			name:                "should create the PVCs with the size of the file storage",
+			givenSize:           "2Gi",
+			wantFileStorageSize: "2Gi",
+			wantClaimSize:       "2Gi",
+			wantEvents:          []string{},
+		},
+		{
+			name:                "should not expand PVCs which have the size of the file storage",
+			givenSize:           "2Gi",
+			givenClaimSize:      "2Gi",
+			givenPVCSize:        "2Gi",
+			givenPVCCapacity:    "2Gi",
+			wantFileStorageSize: "2Gi",
+			wantClaimSize:       "2Gi",
+			wantPVCSize:         "2Gi",
+			wantEvents:          []string{},
+		},
+		{
+			name:                "should expand the PVCs if the size was increased",
+			givenSize:           "2Gi",
+			givenClaimSize:      "1Gi",
+			givenPVCSize:        "1Gi",
+			givenPVCCapacity:    "1Gi",
+			givenExpansion:      true,
+			wantFileStorageSize: "1Gi",
+			wantClaimSize:       "1Gi",
+			wantPVCSize:         "2Gi",
+			wantCondition: &kmetav1.Condition{
+				Status: kmetav1.ConditionTrue,
+				Reason: string(nmapiv1alpha1.ConditionReasonExpanding),
+				Message: "0 of 1 PVCs are expanded to 2Gi. The file storage keeps the size of 1Gi until all " +
+					"PVCs are expanded.",
+			},
+			wantEvents: []string{"Normal Expanding Expanding 1 PVCs of the NATS servers to 2Gi."},
+		},
+		{
+			name:                "should expand the PVCs to the size to which the file storage was grown",
+			givenSize:           "1Gi",
+			givenGrownSize:      "1536Mi",
+			givenClaimSize:      "1Gi",
+			givenPVCSize:        "1Gi",
+			givenPVCCapacity:    "1Gi",
+			givenExpansion:      true,
+			wantFileStorageSize: "1Gi",
+			wantClaimSize:       "1Gi",
+			wantPVCSize:         "1536Mi",
+			wantCondition: &kmetav1.Condition{
+				Status: kmetav1.ConditionTrue,
+				Reason: string(nmapiv1alpha1.ConditionReasonExpanding),
+				Message: "0 of 1 PVCs are expanded to 1536Mi. The file storage keeps the size of 1Gi until all " +
+					"PVCs are expanded.",
+			},
+			wantEvents: []string{"Normal Expanding Expanding 1 PVCs of the NATS servers to 1536Mi."},
+		},
+		{
+			name:                "should keep the smallest capacity while the expansion is pending",
+			givenSize:           "2Gi",
+			givenClaimSize:      "1Gi",
+			givenPVCSize:        "2Gi",
+			givenPVCCapacity:    "1Gi",
+			givenExpansion:      true,
+			givenCondition:      &kmetav1.Condition{Reason: string(nmapiv1alpha1.ConditionReasonExpanding)},
+			wantFileStorageSize: "1Gi",
+			wantClaimSize:       "1Gi",
+			wantPVCSize:         "2Gi",
+			wantCondition: &kmetav1.Condition{
+				Status: kmetav1.ConditionTrue,
+				Reason: string(nmapiv1alpha1.ConditionReasonExpanding),
+				Message: "0 of 1 PVCs are expanded to 2Gi. The file storage keeps the size of 1Gi until all " +
+					"PVCs are expanded.",
+			},
+			wantEvents: []string{},
+		},
+		{
+			name:                 "should clear the grown size once the size in the spec caught up",
+			givenSize:            "2Gi",
+			givenGrownSize:       "1536Mi",
+			givenClaimSize:       "2Gi",
+			givenPVCSize:         "2Gi",
+			givenPVCCapacity:     "2Gi",
+			wantFileStorageSize:  "2Gi",
+			wantClaimSize:        "2Gi",
+			wantPVCSize:          "2Gi",
+			wantEvents:           []string{},
+			wantGrownSizeCleared: true,
+		},
+		{
+			name:                "should report the expansion once the PVCs have the new capacity",
+			givenSize:           "2Gi",
+			givenClaimSize:      "1Gi",
+			givenPVCSize:        "2Gi",
+			givenPVCCapacity:    "2Gi",
+			givenExpansion:      true,
+			givenCondition:      &kmetav1.Condition{Reason: string(nmapiv1alpha1.ConditionReasonExpanding)},
+			wantFileStorageSize: "2Gi",
+			wantClaimSize:       "1Gi",
+			wantPVCSize:         "2Gi",
+			wantCondition: &kmetav1.Condition{
+				Status:  kmetav1.ConditionFalse,
+				Reason:  string(nmapiv1alpha1.ConditionReasonExpanded),
+				Message: "All 1 PVCs are expanded to 2Gi.",
+			},
+			wantEvents: []string{"Normal Expanded All 1 PVCs are expanded to 2Gi."},
+		},
+		{
+			name:                "should keep the size of the PVCs if the StorageClass does not allow expansion",
+			givenSize:           "2Gi",
+			givenClaimSize:      "1Gi",
+			givenPVCSize:        "1Gi",
+			givenPVCCapacity:    "1Gi",
+			givenExpansion:      false,
+			wantFileStorageSize: "1Gi",
+			wantClaimSize:       "1Gi",
+			wantPVCSize:         "1Gi",
+			wantCondition: &kmetav1.Condition{
+				Status: kmetav1.ConditionFalse,
+				Reason: string(nmapiv1alpha1.ConditionReasonExpansionUnsupported),
+				Message: "The StorageClass of the PVCs eventing-nats-js-pvc-eventing-nats-0 does not allow " +
+					"volume expansion, so the file storage keeps the size of 1Gi.",
+			},
+			wantEvents: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSFileStorage(nmapiv1alpha1.FileStorage{Size: resource.MustParse(tc.givenSize)}),
+			)
+			if tc.givenGrownSize != "" {
+				givenNATS.Status.FileStorageSize = new(resource.MustParse(tc.givenGrownSize))
+			}
+			if tc.givenCondition != nil {
+				givenNATS.Status.UpdateConditionExpandingStorage(kmetav1.ConditionTrue,
+					nmapiv1alpha1.ConditionReason(tc.givenCondition.Reason), "")
+			}
+			givenObjects := []client.Object{givenNATS, &kstoragev1.StorageClass{
+				ObjectMeta:           kmetav1.ObjectMeta{Name: "standard"},
+				AllowVolumeExpansion: new(tc.givenExpansion),
+			}}
+			givenPVCName := "eventing-nats-js-pvc-eventing-nats-0"
+			if tc.givenClaimSize != "" {
+				givenObjects = append(givenObjects,
+					newNATSStatefulSetWithClaimSize(givenNATS, tc.givenClaimSize),
+					newNATSPVCWithSize(givenPVCName, givenNATS, tc.givenPVCSize, tc.givenPVCCapacity))
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenObjects...)
+
+			// when
+			gotFileStorageSize, gotClaimSize, err := testEnv.Reconciler.syncFileStorage(testEnv.Context,
+				givenNATS, testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantFileStorageSize, gotFileStorageSize.String())
+			require.Equal(t, tc.wantClaimSize, gotClaimSize.String())
+			if tc.wantPVCSize != "" {
+				gotPVC := &kcorev1.PersistentVolumeClaim{}
+				require.NoError(t, testEnv.Client.Get(testEnv.Context,
+					ktypes.NamespacedName{Name: givenPVCName, Namespace: givenNATS.Namespace}, gotPVC))
+				require.Equal(t, tc.wantPVCSize, gotPVC.Spec.Resources.Requests.Storage().String())
+			}
+			gotCondition := givenNATS.Status.FindCondition(nmapiv1alpha1.ConditionExpandingStorage)
+			if tc.wantCondition == nil {
+				require.Equal(t, tc.givenCondition == nil, gotCondition == nil)
+			} else {
+				require.NotNil(t, gotCondition)
+				require.Equal(t, tc.wantCondition.Status, gotCondition.Status)
+				require.Equal(t, tc.wantCondition.Reason, gotCondition.Reason)
+				require.Equal(t, tc.wantCondition.Message, gotCondition.Message)
+			}
+			if tc.wantGrownSizeCleared {
+				require.Nil(t, givenNATS.Status.FileStorageSize)
+			}
+			require.Equal(t, tc.wantEvents, testEnv.GetK8sEvents())
+		})
+	}
+}
+
+func Test_autoGrowFileStorage(t *testing.T) {
+	t.Parallel()
+
+	const gib = int64(1 << 30)
+
+	testCases := []struct {
+		name           string
+		givenAutoGrow  nmapiv1alpha1.FileStorageAutoGrow
+		givenGrownSize string
+		givenMaxStore  int64
+		givenStore     int64
+		wantGrownSize  string
+		wantEvents     []string
+	}{
+		{
+			name: "should grow the file storage by the increment once a NATS server reaches the warning percentage",
+			givenAutoGrow: nmapiv1alpha1.FileStorageAutoGrow{
+				Enabled: true, MaxSize: resource.MustParse("10Gi"), IncrementPercent: 50,
+			},
+			givenMaxStore: 2 * gib,
+			givenStore:    gib * 7 / 4,
+			wantGrownSize: "3Gi",
+			wantEvents: []string{
+				"Normal AutoGrowing NATS server eventing-nats-1 uses 87% of its file storage, " +
+					"so the file storage grows from 2Gi to 3Gi.",
+			},
+		},
+		{
+			name: "should not grow the file storage beyond the maximum size",
+			givenAutoGrow: nmapiv1alpha1.FileStorageAutoGrow{
+				Enabled: true, MaxSize: resource.MustParse("2500Mi"), IncrementPercent: 50,
+			},
+			givenMaxStore: 2 * gib,
+			givenStore:    gib * 7 / 4,
+			wantGrownSize: "2500Mi",
+			wantEvents: []string{
+				"Normal AutoGrowing NATS server eventing-nats-1 uses 87% of its file storage, " +
+					"so the file storage grows from 2Gi to 2500Mi.",
+			},
+		},
+		{
+			name: "should not grow the file storage below the warning percentage",
+			givenAutoGrow: nmapiv1alpha1.FileStorageAutoGrow{
+				Enabled: true, MaxSize: resource.MustParse("10Gi"), IncrementPercent: 50,
+			},
+			givenMaxStore: 2 * gib,
+			givenStore:    gib,
+			wantEvents:    []string{},
+		},
+		{
+			name: "should not grow the file storage again until the NATS servers run with the grown size",
+			givenAutoGrow: nmapiv1alpha1.FileStorageAutoGrow{
+				Enabled: true, MaxSize: resource.MustParse("10Gi"), IncrementPercent: 50,
+			},
+			givenGrownSize: "3Gi",
+			givenMaxStore:  2 * gib,
+			givenStore:     gib * 7 / 4,
+			wantGrownSize:  "3Gi",
+			wantEvents:     []string{},
+		},
+		{
+			name:          "should not grow the file storage without an auto-grow policy",
+			givenMaxStore: 2 * gib,
+			givenStore:    gib * 7 / 4,
+			wantEvents:    []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSFileStorage(nmapiv1alpha1.FileStorage{
+					Size:     resource.MustParse("2Gi"),
+					AutoGrow: tc.givenAutoGrow,
+				}),
+			)
+			if tc.givenGrownSize != "" {
+				givenNATS.Status.FileStorageSize = new(resource.MustParse(tc.givenGrownSize))
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+			givenServers := []*natsServerState{
+				newNATSServerState("eventing-nats-0", "eventing-nats-1"),
+				newNATSServerState("eventing-nats-1", "eventing-nats-0"),
+			}
+			for _, server := range givenServers {
+				server.jsz.Config = &monitor.JetStreamConfig{MaxStore: tc.givenMaxStore}
+			}
+			givenServers[1].jsz.Store = tc.givenStore
+
+			// when
+			testEnv.Reconciler.autoGrowFileStorage(givenNATS, givenServers, testEnv.Logger)
+
+			// then
+			if tc.wantGrownSize == "" {
+				require.Nil(t, givenNATS.Status.FileStorageSize)
+			} else {
+				require.NotNil(t, givenNATS.Status.FileStorageSize)
+				require.Equal(t, tc.wantGrownSize, givenNATS.Status.FileStorageSize.String())
+			}
+			require.Equal(t, tc.wantEvents, testEnv.GetK8sEvents())
+		})
+	}
+}
+
+// newNATSStatefulSetWithClaimSize returns the StatefulSet of the NATS servers with the given size of the PVCs
+// in its volumeClaimTemplates.
+func newNATSStatefulSetWithClaimSize(nats *nmapiv1alpha1.NATS, claimSize string) *kappsv1.StatefulSet {
+	return &kappsv1.StatefulSet{
+		ObjectMeta: kmetav1.ObjectMeta{Name: nats.Name, Namespace: nats.Namespace},
+		Spec: kappsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []kcorev1.PersistentVolumeClaim{{
+				ObjectMeta: kmetav1.ObjectMeta{Name: nats.Name + "-js-pvc"},
+				Spec: kcorev1.PersistentVolumeClaimSpec{
+					Resources: kcorev1.VolumeResourceRequirements{
+						Requests: kcorev1.ResourceList{kcorev1.ResourceStorage: resource.MustParse(claimSize)},
+					},
+				},
+			}},
+		},
+	}
+}
+
+// newNATSPVCWithSize returns a PVC of the NATS servers with the StorageClass standard.
+func newNATSPVCWithSize(name string, nats *nmapiv1alpha1.NATS, size, capacity string,
+) *kcorev1.PersistentVolumeClaim {
+	pvc := newNATSPVC(name, instanceLabelValue(nats))
+	pvc.Spec.StorageClassName = new("standard")
+	pvc.Spec.Resources.Requests = kcorev1.ResourceList{kcorev1.ResourceStorage: resource.MustParse(size)}
+	pvc.Status.Capacity = kcorev1.ResourceList{kcorev1.ResourceStorage: resource.MustParse(capacity)}
+	return pvc
+}
@@ -0,0 +1,306 @@
+package nats
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/certs"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// GeneratedCASecretSuffix is appended to the name of the NATS CR to name the Secret
+	// with the self-signed CA which signs the generated certificate.
+	GeneratedCASecretSuffix = "-tls-ca"
+
+	CAValidity          = 10 * 365 * 24 * time.Hour
+	CertificateValidity = 365 * 24 * time.Hour
+
+	// CARenewBefore is the time before its expiry at which the CA is rotated. It must be longer than
+	// CertificateValidity, so that no certificate signed by the previous CA outlives it.
+	CARenewBefore = 2 * CertificateValidity
+	// CertificateRenewBefore is the time before its expiry at which a certificate is re-issued.
+	// Certificates of referenced Secrets which expire within this time are reported as expiring.
+	CertificateRenewBefore = 30 * 24 * time.Hour
+	// CertificateCheckInterval is the interval in which the certificates are checked if TLS is enabled.
+	CertificateCheckInterval = 6 * time.Hour
+)
+
+// syncGeneratedCertificate makes sure that the self-signed CA and the certificate exist
+// if a TLS listener of the NATS cluster does not reference a Secret.
+// The certificate is re-issued before it expires, if the cluster was scaled or if the CA was rotated.
+// It returns the fingerprint of the current CA, or an empty string if no certificate is generated.
+func (r *Reconciler) syncGeneratedCertificate(ctx context.Context, nats *nmapiv1alpha1.NATS) (string, error) {
+	if !nats.NeedsGeneratedCertificate() {
+		return "", nil
+	}
+
+	ca, trustBundle, err := r.syncCA(ctx, nats)
+	if err != nil {
+		return "", err
+	}
+	caCert, err := certs.ParseCertificate(ca.CertificatePEM)
+	if err != nil {
+		return "", err
+	}
+	fingerprint := certs.Fingerprint(caCert)
+
+	secretName := nats.Name + nmapiv1alpha1.GeneratedTLSSecretSuffix
+	certificateSecret, err := r.getSecret(ctx, secretName, nats.Namespace)
+	if err != nil {
+		return "", err
+	}
+	// the departing NATS servers keep their DNS names until they are removed, as their streams are moved
+	// over the cluster routes.
+	servers, err := r.getClusterServers(ctx, nats)
+	if err != nil {
+		return "", err
+	}
+	dnsNames := serverDNSNames(nats, servers)
+	if certificateSecret != nil && !needsNewCertificate(certificateSecret, trustBundle, dnsNames) {
+		return fingerprint, nil
+	}
+
+	keyPair, err := certs.NewServerCertificate(ca, nats.Name, dnsNames, CertificateValidity)
+	if err != nil {
+		return "", err
+	}
+	data := map[string][]byte{
+		certs.CertificateKey: keyPair.CertificatePEM,
+		certs.PrivateKeyKey:  keyPair.PrivateKeyPEM,
+		certs.CAKey:          trustBundle,
+	}
+	if certificateSecret == nil {
+		return fingerprint, r.createManagedSecret(ctx, nats, secretName, kcorev1.SecretTypeTLS, data)
+	}
+	// the config reloader of the NATS servers picks up the new certificate once the Secret is updated.
+	return fingerprint, r.updateManagedSecret(ctx, nats, certificateSecret, data)
+}
+
+// syncCA returns the self-signed CA of the NATS cluster and the PEM encoded CA certificates to trust.
+// The CA is created if it does not exist yet and rotated before it expires. After a rotation,
+// the previous CA stays trusted, so that servers with a certificate signed by it can still connect.
+func (r *Reconciler) syncCA(ctx context.Context, nats *nmapiv1alpha1.NATS) (*certs.KeyPair, []byte, error) {
+	caSecretName := nats.Name + GeneratedCASecretSuffix
+	caSecret, err := r.getSecret(ctx, caSecretName, nats.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var previousCA []byte
+	if caSecret != nil {
+		current := &certs.KeyPair{
+			CertificatePEM: caSecret.Data[certs.CertificateKey],
+			PrivateKeyPEM:  caSecret.Data[certs.PrivateKeyKey],
+		}
+		caCert, parseErr := certs.ParseCertificate(current.CertificatePEM)
+		if parseErr == nil && time.Until(caCert.NotAfter) > CARenewBefore {
+			return current, caTrustBundle(caSecret), nil
+		}
+		if parseErr == nil && time.Now().Before(caCert.NotAfter) {
+			previousCA = current.CertificatePEM
+		}
+	}
+
+	ca, err := certs.NewCA(fmt.Sprintf("%s.%s NATS CA", nats.Name, nats.Namespace), CAValidity)
+	if err != nil {
+		return nil, nil, err
+	}
+	trustBundle := slices.Concat(ca.CertificatePEM, previousCA)
+	data := map[string][]byte{
+		certs.CertificateKey: ca.CertificatePEM,
+		certs.PrivateKeyKey:  ca.PrivateKeyPEM,
+		certs.CAKey:          trustBundle,
+	}
+	if caSecret == nil {
+		err = r.createManagedSecret(ctx, nats, caSecretName, kcorev1.SecretTypeTLS, data)
+	} else {
+		err = r.updateManagedSecret(ctx, nats, caSecret, data)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return ca, trustBundle, nil
+}
+
+// caTrustBundle returns the CA certificates to trust from the CA Secret.
+// CA Secrets which were never rotated may only contain the certificate of the CA.
+func caTrustBundle(caSecret *kcorev1.Secret) []byte {
+	if trustBundle := caSecret.Data[certs.CAKey]; len(trustBundle) > 0 {
+		return trustBundle
+	}
+	return caSecret.Data[certs.CertificateKey]
+}
+
+// needsNewCertificate checks if the generated certificate in the given Secret has to be re-issued.
+func needsNewCertificate(secret *kcorev1.Secret, trustBundle []byte, dnsNames []string) bool {
+	if !bytes.Equal(secret.Data[certs.CAKey], trustBundle) {
+		return true
+	}
+	certificate, err := certs.ParseCertificate(secret.Data[certs.CertificateKey])
+	if err != nil {
+		return true
+	}
+	return time.Until(certificate.NotAfter) < CertificateRenewBefore || !slices.Equal(certificate.DNSNames, dnsNames)
+}
+
+// syncCertificatesStatus reports the validity of the certificates used by the TLS listeners
+// in the CertificatesValid condition and in the certificate expiry metric.
+func (r *Reconciler) syncCertificatesStatus(ctx context.Context, nats *nmapiv1alpha1.NATS) error {
+	r.collector.ResetCertificateExpiryMetric(nats.Namespace, nats.Name)
+
+	secretNames := tlsSecretNames(nats)
+	if len(secretNames) == 0 {
+		nats.Status.RemoveCondition(nmapiv1alpha1.ConditionCertificatesValid)
+		return nil
+	}
+
+	status := kmetav1.ConditionTrue
+	reason := nmapiv1alpha1.ConditionReasonCertificatesValid
+	var messages []string
+	// report keeps the first finding which invalidates the certificates as reason of the condition.
+	report := func(findingStatus kmetav1.ConditionStatus, findingReason nmapiv1alpha1.ConditionReason,
+		message string,
+	) {
+		messages = append(messages, message)
+		if status == kmetav1.ConditionTrue {
+			status, reason = findingStatus, findingReason
+		}
+	}
+
+	for _, secretName := range secretNames {
+		secret, err := r.getSecret(ctx, secretName, nats.Namespace)
+		if err != nil {
+			return err
+		}
+		if secret == nil {
+			report(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonCertificateInvalid,
+				fmt.Sprintf("Secret %s does not exist.", secretName))
+			continue
+		}
+		certificate, err := certs.ParseCertificate(secret.Data[certs.CertificateKey])
+		if err != nil {
+			report(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonCertificateInvalid,
+				fmt.Sprintf("Secret %s does not contain a valid certificate: %s.", secretName, err))
+			continue
+		}
+
+		r.collector.RecordCertificateExpiryMetric(nats.Namespace, nats.Name, secretName, certificate.NotAfter)
+		expiry := certificate.NotAfter.UTC().Format(time.RFC3339)
+		switch {
+		case time.Now().After(certificate.NotAfter):
+			report(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonCertificateExpired,
+				fmt.Sprintf("The certificate in Secret %s expired at %s.", secretName, expiry))
+		case time.Until(certificate.NotAfter) < CertificateRenewBefore:
+			report(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonCertificateExpiring,
+				fmt.Sprintf("The certificate in Secret %s expires at %s.", secretName, expiry))
+		}
+	}
+
+	message := "The certificates of all TLS listeners are valid."
+	if len(messages) > 0 {
+		message = strings.Join(messages, " ")
+	}
+	nats.Status.UpdateConditionCertificatesValid(status, reason, message)
+	return nil
+}
+
+// tlsSecretNames returns the names of the Secrets with the certificates of the enabled TLS listeners.
+func tlsSecretNames(nats *nmapiv1alpha1.NATS) []string {
+	var secretNames []string
+	for _, listener := range nats.TLSListeners() {
+		if listener.Enabled && !slices.Contains(secretNames, nats.TLSSecretName(listener)) {
+			secretNames = append(secretNames, nats.TLSSecretName(listener))
+		}
+	}
+	return secretNames
+}
+
+// getSecret returns the Secret with the given name or nil if it does not exist.
+func (r *Reconciler) getSecret(ctx context.Context, name, namespace string) (*kcorev1.Secret, error) {
+	secret := &kcorev1.Secret{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: name, Namespace: namespace}, secret)
+	if kapierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// createManagedSecret creates the given Secret which is generated for the NATS CR.
+// In dry-run mode, the creation is only planned.
+func (r *Reconciler) createManagedSecret(ctx context.Context, nats *nmapiv1alpha1.NATS, name string,
+	secretType kcorev1.SecretType, data map[string][]byte,
+) error {
+	secret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: nats.Namespace,
+			Labels:    map[string]string{ManagedByLabelKey: ManagedByLabelValue},
+		},
+		Type: secretType,
+		Data: data,
+	}
+	if err := controllerutil.SetControllerReference(nats, secret, r.scheme); err != nil {
+		return err
+	}
+	if nats.IsDryRun() {
+		r.planSecretChange(nats, PlanChange{InventoryEntry: secretInventoryEntry(secret), Action: PlanActionCreate})
+		return nil
+	}
+	return r.Create(ctx, secret)
+}
+
+// updateManagedSecret replaces the data of the given Secret. It also makes sure that the Secret is managed
+// by the NATS manager, in case the Secret was created with the name of a generated Secret before.
+// In dry-run mode, only the given Secret is changed and the update is planned.
+func (r *Reconciler) updateManagedSecret(ctx context.Context, nats *nmapiv1alpha1.NATS, secret *kcorev1.Secret,
+	data map[string][]byte,
+) error {
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[ManagedByLabelKey] = ManagedByLabelValue
+	fields := changedFields(".data", secretDataContent(secret.Data), secretDataContent(data))
+	secret.Data = data
+	if err := controllerutil.SetControllerReference(nats, secret, r.scheme); err != nil {
+		return err
+	}
+	if nats.IsDryRun() {
+		r.planSecretChange(nats, PlanChange{
+			InventoryEntry: secretInventoryEntry(secret), Action: PlanActionModify, Fields: fields,
+		})
+		return nil
+	}
+	return r.Update(ctx, secret)
+}
+
+// serverDNSNames returns the DNS names under which the NATS servers are reachable, i.e. the client service,
+// the given number of Pods of the StatefulSet behind the headless service and localhost for the sidecars.
+// As the names of the Pods depend on the number of NATS servers, the certificate is re-issued when the cluster
+// is scaled.
+func serverDNSNames(nats *nmapiv1alpha1.NATS, servers int32) []string {
+	service := fmt.Sprintf("%s.%s.svc", nats.Name, nats.Namespace)
+	dnsNames := []string{
+		nats.Name,
+		fmt.Sprintf("%s.%s", nats.Name, nats.Namespace),
+		service,
+		service + ".cluster.local",
+	}
+	for i := range servers {
+		pod := fmt.Sprintf("%s-%d.%s", nats.Name, i, service)
+		dnsNames = append(dnsNames, pod, pod+".cluster.local")
+	}
+	return append(dnsNames, "localhost")
+}
@@ -0,0 +1,425 @@
+package nats
+
+import (
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/certs"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_syncGeneratedCertificate(t *testing.T) {
+	t.Parallel()
+
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+		testutils.WithNATSClusterSize(3),
+	)
+	ca := newTestCA(t, CAValidity)
+	validSecret := newTestCertificateSecret(t, ca, "eventing-nats-tls", serverDNSNames(givenNATS, 3),
+		CertificateValidity)
+	expiringSecret := newTestCertificateSecret(t, ca, "eventing-nats-tls", serverDNSNames(givenNATS, 3),
+		CertificateRenewBefore-time.Hour)
+	// the certificate of a cluster with one node misses the DNS names of the other Pods.
+	singleNodeSecret := newTestCertificateSecret(t, ca, "eventing-nats-tls", serverDNSNames(givenNATS, 1),
+		CertificateValidity)
+	// the certificate of a cluster with five nodes, which is scaled down to three nodes.
+	fiveNodeSecret := newTestCertificateSecret(t, ca, "eventing-nats-tls", serverDNSNames(givenNATS, 5),
+		CertificateValidity)
+	expiringFiveNodeSecret := newTestCertificateSecret(t, ca, "eventing-nats-tls", serverDNSNames(givenNATS, 5),
+		CertificateRenewBefore-time.Hour)
+
+	testCases := []struct {
+		name              string
+		givenTLS          nmapiv1alpha1.TLS
+		givenLeafNodes    nmapiv1alpha1.LeafNodes
+		givenObjects      []client.Object
+		wantGenerated     bool
+		wantServers       int
+		wantCertificate   []byte
+		wantSecretMissing bool
+	}{
+		{
+			name:              "should not generate a certificate if TLS is disabled",
+			givenTLS:          nmapiv1alpha1.TLS{},
+			wantSecretMissing: true,
+		},
+		{
+			name: "should not generate a certificate if all listeners reference a Secret",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client:  nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "client-tls"},
+				Cluster: nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "cluster-tls"},
+			},
+			wantSecretMissing: true,
+		},
+		{
+			name: "should generate a CA and a certificate for the listener without a Secret",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client:  nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "client-tls"},
+				Cluster: nmapiv1alpha1.TLSListener{Enabled: true},
+			},
+			wantGenerated: true,
+		},
+		{
+			name: "should generate a certificate for the leaf node listener",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client: nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "client-tls"},
+			},
+			givenLeafNodes: nmapiv1alpha1.LeafNodes{
+				Enabled: true,
+				TLS:     nmapiv1alpha1.TLSListener{Enabled: true},
+			},
+			wantGenerated: true,
+		},
+		{
+			name: "should keep a valid certificate",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client: nmapiv1alpha1.TLSListener{Enabled: true},
+			},
+			givenObjects:    []client.Object{ca.DeepCopy(), validSecret.DeepCopy()},
+			wantCertificate: validSecret.Data[certs.CertificateKey],
+		},
+		{
+			name: "should re-issue a certificate which expires soon",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client: nmapiv1alpha1.TLSListener{Enabled: true},
+			},
+			givenObjects:  []client.Object{ca.DeepCopy(), expiringSecret.DeepCopy()},
+			wantGenerated: true,
+		},
+		{
+			name: "should re-issue a certificate which does not cover all Pods",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client: nmapiv1alpha1.TLSListener{Enabled: true},
+			},
+			givenObjects:  []client.Object{ca.DeepCopy(), singleNodeSecret.DeepCopy()},
+			wantGenerated: true,
+		},
+		{
+			name: "should keep the DNS names of the departing Pods while the cluster is scaled down",
+			givenTLS: nmapiv1alpha1.TLS{
+				Cluster: nmapiv1alpha1.TLSListener{Enabled: true},
+			},
+			givenObjects: slices.Concat(
+				[]client.Object{ca.DeepCopy(), fiveNodeSecret.DeepCopy(), newScaleDownStatefulSet(givenNATS, 5)},
+				newScaleDownPods(givenNATS, 5), newScaleDownPVCs(givenNATS, 5),
+			),
+			wantCertificate: fiveNodeSecret.Data[certs.CertificateKey],
+		},
+		{
+			name: "should re-issue a certificate for the departing Pods which are not removed yet",
+			givenTLS: nmapiv1alpha1.TLS{
+				Cluster: nmapiv1alpha1.TLSListener{Enabled: true},
+			},
+			// eventing-nats-4 is terminating and not removed from the JetStream cluster yet.
+			givenObjects: slices.Concat(
+				[]client.Object{ca.DeepCopy(), expiringFiveNodeSecret.DeepCopy(), newScaleDownStatefulSet(givenNATS, 4)},
+				newScaleDownPods(givenNATS, 5), newScaleDownPVCs(givenNATS, 5),
+			),
+			wantGenerated: true,
+			wantServers:   5,
+		},
+		{
+			name: "should drop the DNS names of the departed Pods once they are removed",
+			givenTLS: nmapiv1alpha1.TLS{
+				Cluster: nmapiv1alpha1.TLSListener{Enabled: true},
+			},
+			givenObjects: slices.Concat(
+				[]client.Object{ca.DeepCopy(), fiveNodeSecret.DeepCopy(), newScaleDownStatefulSet(givenNATS, 3)},
+				newScaleDownPods(givenNATS, 3), newScaleDownPVCs(givenNATS, 3),
+			),
+			wantGenerated: true,
+		},
+		{
+			name: "should re-issue an invalid certificate",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client: nmapiv1alpha1.TLSListener{Enabled: true},
+			},
+			givenObjects: []client.Object{&kcorev1.Secret{
+				ObjectMeta: kmetav1.ObjectMeta{Name: "eventing-nats-tls", Namespace: "kyma-system"},
+				Data:       map[string][]byte{certs.CertificateKey: []byte("existing")},
+			}},
+			wantGenerated: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			nats := givenNATS.DeepCopy()
+			nats.Spec.TLS = tc.givenTLS
+			nats.Spec.LeafNodes = tc.givenLeafNodes
+			testEnv := NewMockedUnitTestEnvironment(t, append(tc.givenObjects, nats)...)
+
+			// when
+			fingerprint, err := testEnv.Reconciler.syncGeneratedCertificate(testEnv.Context, nats)
+
+			// then
+			require.NoError(t, err)
+			gotSecret := &kcorev1.Secret{}
+			err = testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: "eventing-nats-tls", Namespace: "kyma-system"}, gotSecret)
+			if tc.wantSecretMissing {
+				require.Error(t, err)
+				require.Empty(t, fingerprint)
+				return
+			}
+			require.NoError(t, err)
+			if !tc.wantGenerated {
+				require.Equal(t, tc.wantCertificate, gotSecret.Data[certs.CertificateKey])
+				return
+			}
+
+			// the generated certificate must be signed by the generated CA.
+			gotCASecret := &kcorev1.Secret{}
+			require.NoError(t, testEnv.Client.Get(testEnv.Context,
+				ktypes.NamespacedName{Name: "eventing-nats-tls-ca", Namespace: "kyma-system"}, gotCASecret))
+			require.Equal(t, gotCASecret.Data[certs.CertificateKey], gotSecret.Data[certs.CAKey])
+			gotCACertificate, err := certs.ParseCertificate(gotCASecret.Data[certs.CertificateKey])
+			require.NoError(t, err)
+			require.Equal(t, certs.Fingerprint(gotCACertificate), fingerprint)
+
+			wantServers := max(tc.wantServers, 3)
+			gotCertificate := requireCertificateValidForPods(t, gotSecret, wantServers)
+			require.Equal(t, serverDNSNames(nats, int32(wantServers)), gotCertificate.DNSNames)
+			require.WithinDuration(t, time.Now().Add(CertificateValidity), gotCertificate.NotAfter, time.Hour)
+		})
+	}
+}
+
+func Test_syncGeneratedCertificate_CARotation(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+		testutils.WithNATSClusterSize(3),
+		testutils.WithNATSTLS(nmapiv1alpha1.TLS{Client: nmapiv1alpha1.TLSListener{Enabled: true}}),
+	)
+	expiringCA := newTestCA(t, CARenewBefore-time.Hour)
+	givenSecret := newTestCertificateSecret(t, expiringCA, "eventing-nats-tls", serverDNSNames(givenNATS, 3),
+		CertificateValidity)
+	testEnv := NewMockedUnitTestEnvironment(t, expiringCA, givenSecret, givenNATS)
+
+	// when
+	fingerprint, err := testEnv.Reconciler.syncGeneratedCertificate(testEnv.Context, givenNATS)
+
+	// then
+	require.NoError(t, err)
+	expiringCACertificate, err := certs.ParseCertificate(expiringCA.Data[certs.CertificateKey])
+	require.NoError(t, err)
+	require.NotEqual(t, certs.Fingerprint(expiringCACertificate), fingerprint)
+
+	// the certificate is signed by the new CA and the previous CA is still trusted.
+	gotCASecret := &kcorev1.Secret{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: "eventing-nats-tls-ca", Namespace: "kyma-system"}, gotCASecret))
+	gotSecret := &kcorev1.Secret{}
+	require.NoError(t, testEnv.Client.Get(testEnv.Context,
+		ktypes.NamespacedName{Name: "eventing-nats-tls", Namespace: "kyma-system"}, gotSecret))
+	require.NotEqual(t, givenSecret.Data[certs.CertificateKey], gotSecret.Data[certs.CertificateKey])
+	require.Equal(t, gotCASecret.Data[certs.CAKey], gotSecret.Data[certs.CAKey])
+	require.Contains(t, string(gotSecret.Data[certs.CAKey]), string(expiringCA.Data[certs.CertificateKey]))
+	requireCertificateValidForPods(t, gotSecret, 3)
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(gotCASecret.Data[certs.CertificateKey]))
+	gotCertificate, err := certs.ParseCertificate(gotSecret.Data[certs.CertificateKey])
+	require.NoError(t, err)
+	_, err = gotCertificate.Verify(x509.VerifyOptions{Roots: roots})
+	require.NoError(t, err)
+}
+
+func Test_syncCertificatesStatus(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCA(t, CAValidity)
+	dnsNames := []string{"eventing-nats.kyma-system.svc"}
+
+	testCases := []struct {
+		name          string
+		givenTLS      nmapiv1alpha1.TLS
+		givenObjects  []client.Object
+		wantCondition *kmetav1.Condition
+		wantMetrics   []string
+	}{
+		{
+			name:     "should not report the certificates if TLS is disabled",
+			givenTLS: nmapiv1alpha1.TLS{},
+		},
+		{
+			name: "should report valid certificates",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client:  nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "client-tls"},
+				Cluster: nmapiv1alpha1.TLSListener{Enabled: true},
+			},
+			givenObjects: []client.Object{
+				newTestCertificateSecret(t, ca, "client-tls", dnsNames, CertificateValidity),
+				newTestCertificateSecret(t, ca, "eventing-nats-tls", dnsNames, CertificateValidity),
+			},
+			wantCondition: &kmetav1.Condition{
+				Type:   string(nmapiv1alpha1.ConditionCertificatesValid),
+				Status: kmetav1.ConditionTrue,
+				Reason: string(nmapiv1alpha1.ConditionReasonCertificatesValid),
+			},
+			wantMetrics: []string{"client-tls", "eventing-nats-tls"},
+		},
+		{
+			name: "should report an expiring certificate",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client: nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "client-tls"},
+			},
+			givenObjects: []client.Object{
+				newTestCertificateSecret(t, ca, "client-tls", dnsNames, time.Hour),
+			},
+			wantCondition: &kmetav1.Condition{
+				Type:   string(nmapiv1alpha1.ConditionCertificatesValid),
+				Status: kmetav1.ConditionTrue,
+				Reason: string(nmapiv1alpha1.ConditionReasonCertificateExpiring),
+			},
+			wantMetrics: []string{"client-tls"},
+		},
+		{
+			name: "should report an expired certificate before an expiring one",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client:  nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "client-tls"},
+				Cluster: nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "cluster-tls"},
+			},
+			givenObjects: []client.Object{
+				newTestCertificateSecret(t, ca, "client-tls", dnsNames, time.Hour),
+				// the certificate is valid one hour before its creation to tolerate clock skews.
+				newTestCertificateSecret(t, ca, "cluster-tls", dnsNames, -time.Minute),
+			},
+			wantCondition: &kmetav1.Condition{
+				Type:   string(nmapiv1alpha1.ConditionCertificatesValid),
+				Status: kmetav1.ConditionFalse,
+				Reason: string(nmapiv1alpha1.ConditionReasonCertificateExpired),
+			},
+			wantMetrics: []string{"client-tls", "cluster-tls"},
+		},
+		{
+			name: "should report a missing Secret",
+			givenTLS: nmapiv1alpha1.TLS{
+				Client: nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "client-tls"},
+			},
+			wantCondition: &kmetav1.Condition{
+				Type:    string(nmapiv1alpha1.ConditionCertificatesValid),
+				Status:  kmetav1.ConditionFalse,
+				Reason:  string(nmapiv1alpha1.ConditionReasonCertificateInvalid),
+				Message: "Secret client-tls does not exist.",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSTLS(tc.givenTLS),
+			)
+			givenNATS.Status.UpdateConditionCertificatesValid(kmetav1.ConditionTrue,
+				nmapiv1alpha1.ConditionReasonCertificatesValid, "")
+			testEnv := NewMockedUnitTestEnvironment(t, append(tc.givenObjects, givenNATS)...)
+
+			// when
+			err := testEnv.Reconciler.syncCertificatesStatus(testEnv.Context, givenNATS)
+
+			// then
+			require.NoError(t, err)
+			gotCondition := givenNATS.Status.FindCondition(nmapiv1alpha1.ConditionCertificatesValid)
+			if tc.wantCondition == nil {
+				require.Nil(t, gotCondition)
+			} else {
+				require.NotNil(t, gotCondition)
+				require.Equal(t, tc.wantCondition.Status, gotCondition.Status)
+				require.Equal(t, tc.wantCondition.Reason, gotCondition.Reason)
+				if tc.wantCondition.Message != "" {
+					require.Equal(t, tc.wantCondition.Message, gotCondition.Message)
+				}
+			}
+
+			for _, secretName := range tc.wantMetrics {
+				gauge, err := testEnv.Reconciler.collector.GetCertificateExpiryMetric(
+					givenNATS.Namespace, givenNATS.Name, secretName)
+				require.NoError(t, err)
+				require.Positive(t, testutil.ToFloat64(gauge))
+			}
+		})
+	}
+}
+
+// newTestCA returns a CA Secret of the NATS CR eventing-nats which is valid for the given duration.
+func newTestCA(t *testing.T, validity time.Duration) *kcorev1.Secret {
+	t.Helper()
+
+	ca, err := certs.NewCA("test-ca", validity)
+	require.NoError(t, err)
+	return &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: "eventing-nats-tls-ca", Namespace: "kyma-system"},
+		Data: map[string][]byte{
+			certs.CertificateKey: ca.CertificatePEM,
+			certs.PrivateKeyKey:  ca.PrivateKeyPEM,
+		},
+	}
+}
+
+// newTestCertificateSecret returns a certificate Secret signed by the given CA Secret.
+func newTestCertificateSecret(t *testing.T, caSecret *kcorev1.Secret, name string, dnsNames []string,
+	validity time.Duration,
+) *kcorev1.Secret {
+	t.Helper()
+
+	keyPair, err := certs.NewServerCertificate(&certs.KeyPair{
+		CertificatePEM: caSecret.Data[certs.CertificateKey],
+		PrivateKeyPEM:  caSecret.Data[certs.PrivateKeyKey],
+	}, "eventing-nats", dnsNames, validity)
+	require.NoError(t, err)
+	return &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: name, Namespace: "kyma-system"},
+		Data: map[string][]byte{
+			certs.CertificateKey: keyPair.CertificatePEM,
+			certs.PrivateKeyKey:  keyPair.PrivateKeyPEM,
+			certs.CAKey:          caSecret.Data[certs.CertificateKey],
+		},
+	}
+}
+
+// requireCertificateValidForPods verifies the certificate in the Secret for the services and all Pods.
+func requireCertificateValidForPods(t *testing.T, secret *kcorev1.Secret, clusterSize int) *x509.Certificate {
+	t.Helper()
+
+	require.Equal(t, ManagedByLabelValue, secret.Labels[ManagedByLabelKey])
+	require.Len(t, secret.OwnerReferences, 1)
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(secret.Data[certs.CAKey]))
+	certificate, err := certs.ParseCertificate(secret.Data[certs.CertificateKey])
+	require.NoError(t, err)
+	dnsNames := []string{"eventing-nats.kyma-system.svc.cluster.local", "localhost"}
+	for i := range clusterSize {
+		dnsNames = append(dnsNames, fmt.Sprintf("eventing-nats-%d.eventing-nats.kyma-system.svc.cluster.local", i))
+	}
+	for _, dnsName := range dnsNames {
+		_, err = certificate.Verify(x509.VerifyOptions{DNSName: dnsName, Roots: roots})
+		require.NoError(t, err, dnsName)
+	}
+	return certificate
+}
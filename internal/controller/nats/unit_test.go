@@ -2,6 +2,7 @@ package nats
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
@@ -10,10 +11,13 @@ import (
 	nmkmocks "github.com/kyma-project/nats-manager/pkg/k8s/mocks"
 	nmmgrmocks "github.com/kyma-project/nats-manager/pkg/manager/mocks"
 	"github.com/kyma-project/nats-manager/pkg/metrics"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
 	"github.com/kyma-project/nats-manager/testutils"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	kappsv1 "k8s.io/api/apps/v1"
 	kcorev1 "k8s.io/api/core/v1"
+	kstoragev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -21,6 +25,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
+var errMonitoringEndpointNotMocked = errors.New("monitoring endpoint is not mocked")
+
 // MockedUnitTestEnvironment provides mocked resources for unit tests.
 type MockedUnitTestEnvironment struct {
 	Context       context.Context
@@ -50,6 +56,10 @@ func NewMockedUnitTestEnvironment(t *testing.T, objs ...client.Object) *MockedUn
 	require.NoError(t, err)
 	err = kcorev1.AddToScheme(newScheme)
 	require.NoError(t, err)
+	err = kappsv1.AddToScheme(newScheme)
+	require.NoError(t, err)
+	err = kstoragev1.AddToScheme(newScheme)
+	require.NoError(t, err)
 	fakeClientBuilder := fake.NewClientBuilder().WithScheme(newScheme)
 	fakeClient := fakeClientBuilder.WithObjects(objs...).WithStatusSubresource(objs...).Build()
 	recorder := record.NewFakeRecorder(3)
@@ -75,8 +85,14 @@ func NewMockedUnitTestEnvironment(t *testing.T, objs ...client.Object) *MockedUn
 		natsManager,
 		nil,
 		collector,
+		StatusCheckBackoff{MinInterval: DefaultStatusCheckMinInterval, MaxInterval: DefaultStatusCheckMaxInterval},
+		DefaultStorageWarningPercent,
 	)
 	reconciler.controller = mockController
+	// the NATS servers are not reachable in unit tests, unless the test mocks the monitoring endpoint.
+	reconciler.newMonitorClient = func(*monitor.Config) (monitor.Client, error) {
+		return nil, errMonitoringEndpointNotMocked
+	}
 	reconciler.ctrlManager = mockManager
 
 	return &MockedUnitTestEnvironment{
@@ -0,0 +1,174 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	nmctrlclientconfig "github.com/kyma-project/nats-manager/internal/controller/nats/clientconfig"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	"go.uber.org/zap"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// syncUpgrade drives the rolling upgrade of the NATS servers and returns the partition of the StatefulSet.
+// Only the NATS servers with an ordinal of at least the partition are updated to the current revision of
+// the StatefulSet. While no upgrade is in progress, the partition is the number of replicas, so that
+// a changed StatefulSet does not restart any NATS server. Once the StatefulSet has a new revision,
+// the partition is lowered by one NATS server at a time, starting with the highest ordinal. Kubernetes then
+// deletes the Pod, whose preStop hook puts the NATS server into lame duck mode, and recreates it.
+// The partition is only lowered when all NATS servers are ready and report to be healthy, i.e. JetStream is
+// current and all streams and consumers have caught up. The progress is reported in the Upgrading condition.
+func (r *Reconciler) syncUpgrade(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	log *zap.SugaredLogger,
+) (int32, error) {
+	sts := &kappsv1.StatefulSet{}
+	err := r.Get(ctx, ktypes.NamespacedName{Name: nats.Name, Namespace: nats.Namespace}, sts)
+	if kapierrors.IsNotFound(err) {
+		// a new StatefulSet starts all NATS servers with its first revision.
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	partition := int32(0)
+	if sts.Spec.UpdateStrategy.RollingUpdate != nil && sts.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		partition = *sts.Spec.UpdateStrategy.RollingUpdate.Partition
+	}
+	if nats.IsDryRun() {
+		// nothing is applied in the dry-run mode, so the upgrade does not proceed.
+		return partition, nil
+	}
+	if sts.Status.ObservedGeneration != sts.Generation || sts.Status.UpdateRevision == "" {
+		// wait until the StatefulSet controller computed the revision of the latest change.
+		return partition, nil
+	}
+
+	pods, err := r.getNATSPodsByOrdinal(ctx, nats)
+	if err != nil {
+		return partition, err
+	}
+	outdated := int32(-1)
+	upgraded := int32(0)
+	for ordinal := range replicas {
+		pod, ok := pods[ordinal]
+		if ok && pod.Labels[kappsv1.ControllerRevisionHashLabelKey] != sts.Status.UpdateRevision {
+			outdated = ordinal
+			continue
+		}
+		upgraded++
+	}
+
+	if outdated < 0 {
+		if condition := nats.Status.FindCondition(nmapiv1alpha1.ConditionUpgrading); condition != nil &&
+			condition.Status == kmetav1.ConditionTrue {
+			nats.Status.UpdateConditionUpgrading(kmetav1.ConditionFalse, nmapiv1alpha1.ConditionReasonUpgraded,
+				fmt.Sprintf("All %d NATS servers were upgraded to revision %s.", replicas, sts.Status.UpdateRevision))
+		}
+		return replicas, nil
+	}
+
+	progress := fmt.Sprintf("Upgraded %d of %d NATS servers to revision %s.",
+		upgraded, replicas, sts.Status.UpdateRevision)
+	if outdated >= partition {
+		// Kubernetes is restarting the NATS server.
+		nats.Status.UpdateConditionUpgrading(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonUpgrading, progress)
+		return partition, nil
+	}
+
+	if message := r.checkNATSServersHealthy(ctx, nats, pods, replicas); message != "" {
+		nats.Status.UpdateConditionUpgrading(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonUpgradeWaiting,
+			fmt.Sprintf("%s %s", progress, message))
+		return partition, nil
+	}
+
+	podName := pods[outdated].Name
+	log.Infow("restarting NATS server for upgrade", "pod", podName, "revision", sts.Status.UpdateRevision)
+	events.Normal(r.recorder, nats, nmapiv1alpha1.ConditionReasonUpgrading,
+		"Restarting NATS server %s to upgrade it to revision %s.", podName, sts.Status.UpdateRevision)
+	nats.Status.UpdateConditionUpgrading(kmetav1.ConditionTrue, nmapiv1alpha1.ConditionReasonUpgrading, progress)
+	return outdated, nil
+}
+
+// getNATSPodsByOrdinal returns the Pods of the NATS servers by their ordinal in the StatefulSet.
+func (r *Reconciler) getNATSPodsByOrdinal(ctx context.Context, nats *nmapiv1alpha1.NATS,
+) (map[int32]*kcorev1.Pod, error) {
+	podList := &kcorev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(nats.Namespace),
+		client.MatchingLabels(getNATSPodsMatchLabels(nats))); err != nil {
+		return nil, err
+	}
+
+	pods := map[int32]*kcorev1.Pod{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		index := strings.LastIndex(pod.Name, "-")
+		ordinal, err := strconv.ParseInt(pod.Name[index+1:], 10, 32)
+		if index < 0 || err != nil {
+			continue
+		}
+		pods[int32(ordinal)] = pod
+	}
+	return pods, nil
+}
+
+// checkNATSServersHealthy checks that all NATS servers are ready and healthy. It returns the reason why
+// a NATS server is not healthy, or an empty string if all of them are.
+func (r *Reconciler) checkNATSServersHealthy(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	pods map[int32]*kcorev1.Pod, replicas int32,
+) string {
+	for ordinal := range replicas {
+		pod, ok := pods[ordinal]
+		if !ok || pod.DeletionTimestamp != nil || !isPodReady(pod) {
+			return fmt.Sprintf("Waiting for NATS server %s-%d to get ready.", nats.Name, ordinal)
+		}
+	}
+
+	for ordinal := range replicas {
+		podName := pods[ordinal].Name
+		healthz, err := r.getHealthz(ctx, nats, podName)
+		if err != nil {
+			return fmt.Sprintf("Failed to check the health of NATS server %s: %s.", podName, err)
+		}
+		if !healthz.IsHealthy() {
+			return fmt.Sprintf("Waiting for NATS server %s to get healthy: %s.", podName, healthz.Error)
+		}
+	}
+	return ""
+}
+
+func (r *Reconciler) getHealthz(ctx context.Context, nats *nmapiv1alpha1.NATS,
+	podName string,
+) (*monitor.Healthz, error) {
+	config, err := nmctrlclientconfig.NewPodMonitor(ctx, r.Client, nats, podName)
+	if err != nil {
+		return nil, err
+	}
+	monitorClient, err := r.newMonitorClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return monitorClient.Healthz(ctx)
+}
+
+func isPodReady(pod *kcorev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == kcorev1.PodReady {
+			return condition.Status == kcorev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,280 @@
+package nats
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/k8s/chart"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	nmmonitormocks "github.com/kyma-project/nats-manager/pkg/nats/monitor/mocks"
+	"github.com/kyma-project/nats-manager/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	kappsv1 "k8s.io/api/apps/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	oldRevision = "eventing-nats-1"
+	newRevision = "eventing-nats-2"
+)
+
+func newUpgradeStatefulSet(nats *nmapiv1alpha1.NATS, partition int32) *kappsv1.StatefulSet {
+	replicas := int32(3)
+	return &kappsv1.StatefulSet{
+		ObjectMeta: kmetav1.ObjectMeta{Name: nats.Name, Namespace: nats.Namespace, Generation: 2},
+		Spec: kappsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			UpdateStrategy: kappsv1.StatefulSetUpdateStrategy{
+				Type:          kappsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &kappsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+			},
+		},
+		Status: kappsv1.StatefulSetStatus{ObservedGeneration: 2, UpdateRevision: newRevision},
+	}
+}
+
+func newUpgradePod(nats *nmapiv1alpha1.NATS, ordinal int, revision string, ready bool) *kcorev1.Pod {
+	labels := getNATSPodsMatchLabels(nats)
+	labels[kappsv1.ControllerRevisionHashLabelKey] = revision
+	readyStatus := kcorev1.ConditionFalse
+	if ready {
+		readyStatus = kcorev1.ConditionTrue
+	}
+	return &kcorev1.Pod{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-%d", nats.Name, ordinal), Namespace: nats.Namespace, Labels: labels,
+		},
+		Status: kcorev1.PodStatus{
+			Conditions: []kcorev1.PodCondition{{Type: kcorev1.PodReady, Status: readyStatus}},
+		},
+	}
+}
+
+func Test_syncUpgrade(t *testing.T) {
+	t.Parallel()
+
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+	)
+	healthy := &monitor.Healthz{Status: monitor.HealthzStatusOK}
+	unhealthy := &monitor.Healthz{Status: "unavailable", Error: "JetStream stream 'orders' is not current"}
+
+	testCases := []struct {
+		name                  string
+		givenStatefulSet      *kappsv1.StatefulSet
+		givenRevisions        []string
+		givenReady            []bool
+		givenHealthz          *monitor.Healthz
+		givenUpgradingStatus  kmetav1.ConditionStatus
+		givenDryRun           bool
+		wantPartition         int32
+		wantConditionStatus   kmetav1.ConditionStatus
+		wantConditionReason   nmapiv1alpha1.ConditionReason
+		wantConditionContains string
+	}{
+		{
+			name:          "should not hold back the NATS servers of a new StatefulSet",
+			wantPartition: 0,
+		},
+		{
+			name:             "should hold back the next revision if all NATS servers are up to date",
+			givenStatefulSet: newUpgradeStatefulSet(givenNATS, 0),
+			givenRevisions:   []string{newRevision, newRevision, newRevision},
+			givenReady:       []bool{true, true, true},
+			wantPartition:    3,
+		},
+		{
+			name:                  "should complete the upgrade once all NATS servers are upgraded",
+			givenStatefulSet:      newUpgradeStatefulSet(givenNATS, 0),
+			givenRevisions:        []string{newRevision, newRevision, newRevision},
+			givenReady:            []bool{true, true, true},
+			givenUpgradingStatus:  kmetav1.ConditionTrue,
+			wantPartition:         3,
+			wantConditionStatus:   kmetav1.ConditionFalse,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonUpgraded,
+			wantConditionContains: "All 3 NATS servers were upgraded",
+		},
+		{
+			name:                  "should restart the NATS server with the highest ordinal first",
+			givenStatefulSet:      newUpgradeStatefulSet(givenNATS, 3),
+			givenRevisions:        []string{oldRevision, oldRevision, oldRevision},
+			givenReady:            []bool{true, true, true},
+			givenHealthz:          healthy,
+			wantPartition:         2,
+			wantConditionStatus:   kmetav1.ConditionTrue,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonUpgrading,
+			wantConditionContains: "Upgraded 0 of 3 NATS servers",
+		},
+		{
+			name:                  "should wait while the NATS server is restarted",
+			givenStatefulSet:      newUpgradeStatefulSet(givenNATS, 2),
+			givenRevisions:        []string{oldRevision, oldRevision, oldRevision},
+			givenReady:            []bool{true, true, false},
+			wantPartition:         2,
+			wantConditionStatus:   kmetav1.ConditionTrue,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonUpgrading,
+			wantConditionContains: "Upgraded 0 of 3 NATS servers",
+		},
+		{
+			name:                  "should wait until the restarted NATS server is ready",
+			givenStatefulSet:      newUpgradeStatefulSet(givenNATS, 2),
+			givenRevisions:        []string{oldRevision, oldRevision, newRevision},
+			givenReady:            []bool{true, true, false},
+			wantPartition:         2,
+			wantConditionStatus:   kmetav1.ConditionTrue,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonUpgradeWaiting,
+			wantConditionContains: "Waiting for NATS server eventing-nats-2 to get ready.",
+		},
+		{
+			name:                  "should wait until the streams of all NATS servers have caught up",
+			givenStatefulSet:      newUpgradeStatefulSet(givenNATS, 2),
+			givenRevisions:        []string{oldRevision, oldRevision, newRevision},
+			givenReady:            []bool{true, true, true},
+			givenHealthz:          unhealthy,
+			wantPartition:         2,
+			wantConditionStatus:   kmetav1.ConditionTrue,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonUpgradeWaiting,
+			wantConditionContains: "JetStream stream 'orders' is not current",
+		},
+		{
+			name:                  "should restart the next NATS server once all NATS servers are healthy",
+			givenStatefulSet:      newUpgradeStatefulSet(givenNATS, 2),
+			givenRevisions:        []string{oldRevision, oldRevision, newRevision},
+			givenReady:            []bool{true, true, true},
+			givenHealthz:          healthy,
+			wantPartition:         1,
+			wantConditionStatus:   kmetav1.ConditionTrue,
+			wantConditionReason:   nmapiv1alpha1.ConditionReasonUpgrading,
+			wantConditionContains: "Upgraded 1 of 3 NATS servers",
+		},
+		{
+			name:             "should not restart any NATS server in the dry-run mode",
+			givenStatefulSet: newUpgradeStatefulSet(givenNATS, 3),
+			givenRevisions:   []string{oldRevision, oldRevision, oldRevision},
+			givenReady:       []bool{true, true, true},
+			givenDryRun:      true,
+			wantPartition:    3,
+		},
+		{
+			name: "should keep the partition until the StatefulSet controller observed the change",
+			givenStatefulSet: func() *kappsv1.StatefulSet {
+				sts := newUpgradeStatefulSet(givenNATS, 3)
+				sts.Status.ObservedGeneration = 1
+				return sts
+			}(),
+			givenRevisions: []string{oldRevision, oldRevision, oldRevision},
+			givenReady:     []bool{true, true, true},
+			wantPartition:  3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			nats := givenNATS.DeepCopy()
+			if tc.givenUpgradingStatus != "" {
+				nats.Status.UpdateConditionUpgrading(tc.givenUpgradingStatus,
+					nmapiv1alpha1.ConditionReasonUpgrading, "")
+			}
+			if tc.givenDryRun {
+				nats.Annotations = map[string]string{nmapiv1alpha1.DryRunAnnotation: "true"}
+			}
+			objs := []client.Object{nats}
+			if tc.givenStatefulSet != nil {
+				objs = append(objs, tc.givenStatefulSet)
+			}
+			for ordinal, revision := range tc.givenRevisions {
+				objs = append(objs, newUpgradePod(nats, ordinal, revision, tc.givenReady[ordinal]))
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, objs...)
+			testEnv.Reconciler.newMonitorClient = func(*monitor.Config) (monitor.Client, error) {
+				require.NotNil(t, tc.givenHealthz, "the health of the NATS servers should not be checked")
+				monitorClient := new(nmmonitormocks.Client)
+				monitorClient.On("Healthz", mock.Anything).Return(tc.givenHealthz, nil)
+				return monitorClient, nil
+			}
+
+			// when
+			partition, err := testEnv.Reconciler.syncUpgrade(testEnv.Context, nats, testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantPartition, partition)
+			gotCondition := nats.Status.FindCondition(nmapiv1alpha1.ConditionUpgrading)
+			if tc.wantConditionStatus == "" {
+				require.Nil(t, gotCondition)
+				return
+			}
+			require.NotNil(t, gotCondition)
+			require.Equal(t, tc.wantConditionStatus, gotCondition.Status)
+			require.Equal(t, string(tc.wantConditionReason), gotCondition.Reason)
+			require.Contains(t, gotCondition.Message, tc.wantConditionContains)
+		})
+	}
+}
+
+func Test_handleNATSState_RequeuesWhileUpgrading(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		givenUpgrading   kmetav1.ConditionStatus
+		givenWaitingTime time.Duration
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:             "should requeue with a backoff while the upgrade waits for healthy NATS servers",
+			givenUpgrading:   kmetav1.ConditionTrue,
+			givenWaitingTime: time.Minute,
+			wantRequeueAfter: time.Minute,
+		},
+		{
+			name:             "should not requeue with a backoff once the upgrade completed",
+			givenUpgrading:   kmetav1.ConditionFalse,
+			givenWaitingTime: time.Minute,
+			wantRequeueAfter: JetStreamUsageCheckInterval,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSClusterSize(1),
+			)
+			givenNATS.Status.Conditions = []kmetav1.Condition{{
+				Type:               string(nmapiv1alpha1.ConditionUpgrading),
+				Status:             tc.givenUpgrading,
+				LastTransitionTime: kmetav1.NewTime(time.Now().Add(-tc.givenWaitingTime)),
+				Reason:             string(nmapiv1alpha1.ConditionReasonUpgradeWaiting),
+			}}
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+			mockMonitorClients(t, testEnv.Reconciler, map[string]*natsServerState{
+				"eventing-nats-0": newNATSServerState("eventing-nats-0"),
+			})
+			testEnv.natsManager.On("IsNATSStatefulSetReady", mock.Anything, mock.Anything).Return(true, nil).Once()
+			testEnv.kubeClient.On("GetNumberOfAvailabilityZonesUsedByPods",
+				mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Once()
+
+			// when
+			result, err := testEnv.Reconciler.handleNATSState(testEnv.Context, givenNATS,
+				&chart.ReleaseInstance{Name: givenNATS.Name, Namespace: givenNATS.Namespace}, testEnv.Logger)
+
+			// then
+			require.NoError(t, err)
+			require.InDelta(t, tc.wantRequeueAfter, result.RequeueAfter, float64(2*time.Second))
+		})
+	}
+}
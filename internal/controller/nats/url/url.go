@@ -2,14 +2,49 @@ package url
 
 import (
 	"fmt"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
 )
 
 const (
-	format   = "%s://%s.%s.svc.cluster.local:%d"
-	protocol = "nats"
-	port     = 4222
+	format         = "%s://%s.%s.svc.cluster.local:%d"
+	protocol       = "nats"
+	protocolTLS    = "tls"
+	port           = 4222
+	monitoringPort = 8222
 )
 
 func Format(name, namespace string) string {
 	return fmt.Sprintf(format, protocol, name, namespace, port)
 }
+
+// FormatTLS returns the URL of a NATS cluster which only accepts TLS connections.
+func FormatTLS(name, namespace string) string {
+	return fmt.Sprintf(format, protocolTLS, name, namespace, port)
+}
+
+// ForNATS returns the URL of the given NATS cluster which clients should connect to.
+func ForNATS(nats *nmapiv1alpha1.NATS) string {
+	if nats.IsClientTLSEnabled() {
+		return FormatTLS(nats.Name, nats.Namespace)
+	}
+	return Format(nats.Name, nats.Namespace)
+}
+
+// ForMonitoring returns the URL of the HTTP monitoring endpoint of the given NATS cluster.
+func ForMonitoring(nats *nmapiv1alpha1.NATS) string {
+	return fmt.Sprintf(format, monitoringProtocol(nats), nats.Name, nats.Namespace, monitoringPort)
+}
+
+// ForPodMonitoring returns the URL of the HTTP monitoring endpoint of the NATS server in the given Pod
+// of the NATS cluster.
+func ForPodMonitoring(nats *nmapiv1alpha1.NATS, podName string) string {
+	return fmt.Sprintf(format, monitoringProtocol(nats), podName+"."+nats.Name, nats.Namespace, monitoringPort)
+}
+
+func monitoringProtocol(nats *nmapiv1alpha1.NATS) string {
+	if nats.Spec.TLS.Monitoring.Enabled {
+		return "https"
+	}
+	return "http"
+}
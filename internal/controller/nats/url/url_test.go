@@ -3,6 +3,8 @@ package url
 import (
 	"testing"
 
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/testutils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,3 +38,92 @@ func TestFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestForNATS(t *testing.T) {
+	tests := []struct {
+		name string
+		nats *nmapiv1alpha1.NATS
+		want string
+	}{
+		{
+			name: "should return the nats url if client TLS is disabled",
+			nats: testutils.NewNATSCR(
+				testutils.WithNATSCRName("test-name"),
+				testutils.WithNATSCRNamespace("test-namespace"),
+			),
+			want: "nats://test-name.test-namespace.svc.cluster.local:4222",
+		},
+		{
+			name: "should return the tls url if client TLS is enabled",
+			nats: testutils.NewNATSCR(
+				testutils.WithNATSCRName("test-name"),
+				testutils.WithNATSCRNamespace("test-namespace"),
+				testutils.WithNATSTLS(nmapiv1alpha1.TLS{
+					Client: nmapiv1alpha1.TLSListener{Enabled: true},
+				}),
+			),
+			want: "tls://test-name.test-namespace.svc.cluster.local:4222",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			got := ForNATS(tt.nats)
+
+			// then
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestForMonitoring(t *testing.T) {
+	tests := []struct {
+		name string
+		nats *nmapiv1alpha1.NATS
+		want string
+	}{
+		{
+			name: "should return the http url if monitoring TLS is disabled",
+			nats: testutils.NewNATSCR(
+				testutils.WithNATSCRName("test-name"),
+				testutils.WithNATSCRNamespace("test-namespace"),
+			),
+			want: "http://test-name.test-namespace.svc.cluster.local:8222",
+		},
+		{
+			name: "should return the https url if monitoring TLS is enabled",
+			nats: testutils.NewNATSCR(
+				testutils.WithNATSCRName("test-name"),
+				testutils.WithNATSCRNamespace("test-namespace"),
+				testutils.WithNATSTLS(nmapiv1alpha1.TLS{
+					Client:     nmapiv1alpha1.TLSListener{Enabled: true},
+					Monitoring: nmapiv1alpha1.MonitoringTLS{Enabled: true},
+				}),
+			),
+			want: "https://test-name.test-namespace.svc.cluster.local:8222",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			got := ForMonitoring(tt.nats)
+
+			// then
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestForPodMonitoring(t *testing.T) {
+	// given
+	nats := testutils.NewNATSCR(
+		testutils.WithNATSCRName("test-name"),
+		testutils.WithNATSCRNamespace("test-namespace"),
+	)
+
+	// when
+	got := ForPodMonitoring(nats, "test-name-1")
+
+	// then
+	require.Equal(t, "http://test-name-1.test-name.test-namespace.svc.cluster.local:8222", got)
+}
@@ -0,0 +1,86 @@
+package nats
+
+import (
+	"fmt"
+	"time"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/events"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultStorageWarningPercent is the usage of the JetStream storage from which on the StorageCapacity
+	// condition is false.
+	DefaultStorageWarningPercent = 80
+	// JetStreamUsageCheckInterval is the interval in which the JetStream usage is read, as a growing usage
+	// does not trigger a reconciliation.
+	JetStreamUsageCheckInterval = 5 * time.Minute
+
+	memoryStorage = "memory"
+	fileStorage   = "file"
+)
+
+// syncJetStreamUsage reports the JetStream usage of all accounts in the NATS CR status and sets the
+// StorageCapacity condition. The usage is read from the monitoring endpoints of the NATS servers, and the
+// condition is computed from the fullest NATS server, as a stream cannot use the storage of another NATS server.
+// It returns false if the memory or the file storage of a NATS server is used beyond the warning percentage.
+// The usage is only computed if the monitoring endpoints of all NATS servers could be read. Otherwise, the last
+// known usage is kept, because the unreachable NATS servers are already reported by the JetStreamHealthy condition.
+func (r *Reconciler) syncJetStreamUsage(nats *nmapiv1alpha1.NATS, servers []*natsServerState) bool {
+	for _, server := range servers {
+		if server.err != nil {
+			return true
+		}
+	}
+
+	usage, memoryPercent, storagePercent := jetStreamUsage(servers)
+	nats.Status.JetStream = usage
+	r.collector.RecordJetStreamStorageMetric(nats.Namespace, nats.Name, memoryStorage,
+		usage.MemoryBytes, usage.MaxMemoryBytes)
+	r.collector.RecordJetStreamStorageMetric(nats.Namespace, nats.Name, fileStorage,
+		usage.StorageBytes, usage.MaxStorageBytes)
+	r.collector.RecordJetStreamAssetsMetric(nats.Namespace, nats.Name, usage.Streams, usage.Consumers)
+
+	msg := fmt.Sprintf("JetStream uses up to %d%% of the file storage and %d%% of the memory storage "+
+		"of a NATS server.", storagePercent, memoryPercent)
+	if max(memoryPercent, storagePercent) >= r.storageWarningPercent {
+		msg += fmt.Sprintf(" The warning threshold is %d%%.", r.storageWarningPercent)
+		nats.Status.UpdateConditionStorageCapacity(kmetav1.ConditionFalse,
+			nmapiv1alpha1.ConditionReasonStorageLow, msg)
+		events.Warn(r.recorder, nats, nmapiv1alpha1.ConditionReasonStorageLow, "%s", msg)
+		return false
+	}
+	nats.Status.UpdateConditionStorageCapacity(kmetav1.ConditionTrue,
+		nmapiv1alpha1.ConditionReasonStorageAvailable, msg)
+	return true
+}
+
+// jetStreamUsage returns the JetStream usage of all NATS servers, and the highest percentages of the memory
+// and the file storage which a single NATS server uses. Every stream and consumer is counted once by its leader.
+func jetStreamUsage(servers []*natsServerState) (*nmapiv1alpha1.JetStreamStatus, int, int) {
+	status := &nmapiv1alpha1.JetStreamStatus{}
+	var memoryPercent, storagePercent int
+	for _, server := range servers {
+		if server.jsz == nil || server.jsz.Config == nil {
+			continue
+		}
+		status.MemoryBytes += server.jsz.Memory
+		status.MaxMemoryBytes += server.jsz.Config.MaxMemory
+		status.StorageBytes += server.jsz.Store
+		status.MaxStorageBytes += server.jsz.Config.MaxStore
+		status.Streams += server.jsz.StreamsLeader
+		status.Consumers += server.jsz.ConsumersLeader
+		memoryPercent = max(memoryPercent, usagePercent(server.jsz.Memory, server.jsz.Config.MaxMemory))
+		storagePercent = max(storagePercent, usagePercent(server.jsz.Store, server.jsz.Config.MaxStore))
+	}
+	return status, memoryPercent, storagePercent
+}
+
+// usagePercent returns how many percent of the limit are used, or 0 if the limit is unknown.
+func usagePercent(used, limit int64) int {
+	if limit <= 0 {
+		return 0
+	}
+	return int(used * 100 / limit)
+}
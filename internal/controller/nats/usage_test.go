@@ -0,0 +1,144 @@
+package nats
+
+import (
+	"errors"
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
+	"github.com/kyma-project/nats-manager/testutils"
+	ptestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_syncJetStreamUsage(t *testing.T) {
+	t.Parallel()
+
+	const gib = int64(1 << 30)
+
+	testCases := []struct {
+		name                string
+		givenJsz            []*monitor.Jsz
+		wantAvailable       bool
+		wantJetStream       *nmapiv1alpha1.JetStreamStatus
+		wantStorageCapacity kmetav1.Condition
+		wantEvents          []string
+	}{
+		{
+			name: "should sum up the usage of all NATS servers",
+			givenJsz: []*monitor.Jsz{
+				{Memory: gib / 4, Store: 2 * gib, StreamsLeader: 2, ConsumersLeader: 3},
+				{Memory: gib / 4, Store: gib, StreamsLeader: 1, ConsumersLeader: 2},
+			},
+			wantAvailable: true,
+			wantJetStream: &nmapiv1alpha1.JetStreamStatus{
+				MemoryBytes:     gib / 2,
+				MaxMemoryBytes:  2 * gib,
+				StorageBytes:    3 * gib,
+				MaxStorageBytes: 10 * gib,
+				Streams:         3,
+				Consumers:       5,
+			},
+			wantStorageCapacity: kmetav1.Condition{
+				Status:  kmetav1.ConditionTrue,
+				Reason:  string(nmapiv1alpha1.ConditionReasonStorageAvailable),
+				Message: "JetStream uses up to 40% of the file storage and 25% of the memory storage of a NATS server.",
+			},
+			wantEvents: []string{},
+		},
+		{
+			name: "should report low storage if a single NATS server is used beyond the warning percentage",
+			givenJsz: []*monitor.Jsz{
+				{Memory: gib / 2, Store: 9 * gib / 2},
+				{Store: gib},
+			},
+			wantAvailable: false,
+			wantJetStream: &nmapiv1alpha1.JetStreamStatus{
+				MemoryBytes:     gib / 2,
+				MaxMemoryBytes:  2 * gib,
+				StorageBytes:    11 * gib / 2,
+				MaxStorageBytes: 10 * gib,
+			},
+			wantStorageCapacity: kmetav1.Condition{
+				Status: kmetav1.ConditionFalse,
+				Reason: string(nmapiv1alpha1.ConditionReasonStorageLow),
+				Message: "JetStream uses up to 90% of the file storage and 50% of the memory storage of a NATS server. " +
+					"The warning threshold is 80%.",
+			},
+			wantEvents: []string{
+				"Warning StorageLow JetStream uses up to 90% of the file storage and 50% of the memory storage " +
+					"of a NATS server. The warning threshold is 80%.",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenNATS := testutils.NewNATSCR(
+				testutils.WithNATSCRName("eventing-nats"),
+				testutils.WithNATSCRNamespace("kyma-system"),
+				testutils.WithNATSClusterSize(2),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+
+			givenServers := []*natsServerState{
+				newNATSServerState("eventing-nats-0", "eventing-nats-1"),
+				newNATSServerState("eventing-nats-1", "eventing-nats-0"),
+			}
+			for i, server := range givenServers {
+				server.jsz = tc.givenJsz[i]
+				server.jsz.Config = &monitor.JetStreamConfig{MaxMemory: gib, MaxStore: 5 * gib}
+			}
+
+			// when
+			gotAvailable := testEnv.Reconciler.syncJetStreamUsage(givenNATS, givenServers)
+
+			// then
+			require.Equal(t, tc.wantAvailable, gotAvailable)
+			require.Equal(t, tc.wantJetStream, givenNATS.Status.JetStream)
+			gotStorageCapacity := givenNATS.Status.FindCondition(nmapiv1alpha1.ConditionStorageCapacity)
+			require.NotNil(t, gotStorageCapacity)
+			require.Equal(t, tc.wantStorageCapacity.Status, gotStorageCapacity.Status)
+			require.Equal(t, tc.wantStorageCapacity.Reason, gotStorageCapacity.Reason)
+			require.Equal(t, tc.wantStorageCapacity.Message, gotStorageCapacity.Message)
+			require.Equal(t, tc.wantEvents, testEnv.GetK8sEvents())
+
+			gotUsedMetric, err := testEnv.Reconciler.collector.GetJetStreamUsedMetric(givenNATS.Namespace,
+				givenNATS.Name, fileStorage)
+			require.NoError(t, err)
+			require.InDelta(t, float64(tc.wantJetStream.StorageBytes), ptestutil.ToFloat64(gotUsedMetric), 0)
+			gotStreamsMetric, err := testEnv.Reconciler.collector.GetJetStreamStreamsMetric(givenNATS.Namespace,
+				givenNATS.Name)
+			require.NoError(t, err)
+			require.InDelta(t, float64(tc.wantJetStream.Streams), ptestutil.ToFloat64(gotStreamsMetric), 0)
+		})
+	}
+}
+
+func Test_syncJetStreamUsage_KeepsLastUsage(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenNATS := testutils.NewNATSCR(
+		testutils.WithNATSCRName("eventing-nats"),
+		testutils.WithNATSCRNamespace("kyma-system"),
+	)
+	givenUsage := &nmapiv1alpha1.JetStreamStatus{StorageBytes: 1024, MaxStorageBytes: 2048}
+	givenNATS.Status.JetStream = givenUsage
+	testEnv := NewMockedUnitTestEnvironment(t, givenNATS)
+
+	// when the monitoring endpoint of a NATS server could not be read.
+	gotAvailable := testEnv.Reconciler.syncJetStreamUsage(givenNATS, []*natsServerState{
+		newNATSServerState("eventing-nats-0"),
+		{podName: "eventing-nats-1", err: errors.New("connection refused")},
+	})
+
+	// then the last usage is kept.
+	require.True(t, gotAvailable)
+	require.Same(t, givenUsage, givenNATS.Status.JetStream)
+	require.Nil(t, givenNATS.Status.FindCondition(nmapiv1alpha1.ConditionStorageCapacity))
+}
@@ -29,10 +29,27 @@ func (r *Reconciler) removeFinalizer(ctx context.Context, nats *nmapiv1alpha1.NA
 	return kcontrollerruntime.Result{}, nil
 }
 
-func getNATSPodsMatchLabels() map[string]string {
+// getNATSPodsMatchLabels returns the labels which select the Pods of the given NATS CR.
+// They match the selector labels rendered by the NATS chart.
+func getNATSPodsMatchLabels(nats *nmapiv1alpha1.NATS) map[string]string {
+	labels := getNATSPodsCommonLabels()
+	labels[InstanceLabelKey] = instanceLabelValue(nats)
+	return labels
+}
+
+// getNATSPodsCommonLabels returns the labels which all NATS Pods have, regardless of their NATS CR.
+func getNATSPodsCommonLabels() map[string]string {
 	return map[string]string{
-		"app.kubernetes.io/instance": "eventing",
-		"app.kubernetes.io/name":     "nats",
-		"kyma-project.io/dashboard":  "eventing",
+		"app.kubernetes.io/name":    "nats",
+		"kyma-project.io/dashboard": "eventing",
+	}
+}
+
+// instanceLabelValue returns the value of the instance label of the resources of the given NATS CR.
+// The resources of the NATS CR of Kyma eventing keep their historical instance name.
+func instanceLabelValue(nats *nmapiv1alpha1.NATS) string {
+	if nats.Name == "eventing-nats" {
+		return "eventing"
 	}
+	return nats.Name
 }
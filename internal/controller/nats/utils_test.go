@@ -90,3 +90,42 @@ func Test_removeFinalizer(t *testing.T) {
 		require.False(t, reconciler.containsFinalizer(&gotNats))
 	})
 }
+
+func Test_getNATSPodsMatchLabels(t *testing.T) {
+	t.Parallel()
+
+	// define test cases
+	testCases := []struct {
+		name             string
+		givenNats        *nmapiv1alpha1.NATS
+		wantInstanceName string
+	}{
+		{
+			name:             "should select the Pods of the NATS CR of Kyma eventing",
+			givenNats:        testutils.NewNATSCR(testutils.WithNATSCRName("eventing-nats")),
+			wantInstanceName: "eventing",
+		},
+		{
+			name:             "should select the Pods of any other NATS CR by its name",
+			givenNats:        testutils.NewNATSCR(testutils.WithNATSCRName("team-nats")),
+			wantInstanceName: "team-nats",
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			labels := getNATSPodsMatchLabels(tc.givenNats)
+
+			// then
+			require.Equal(t, map[string]string{
+				"app.kubernetes.io/instance": tc.wantInstanceName,
+				"app.kubernetes.io/name":     "nats",
+				"kyma-project.io/dashboard":  "eventing",
+			}, labels)
+		})
+	}
+}
@@ -0,0 +1,271 @@
+// Package auth issues the NKeys and JWTs for NATS in operator mode. It works without a connection to NATS,
+// so the issued JWTs can be verified offline.
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+)
+
+var ErrInvalidCredentials = errors.New("invalid NATS credentials")
+
+// JetStreamLimits are the JetStream limits of an account. Limits which are 0 are unlimited.
+type JetStreamLimits struct {
+	MaxMemory    int64
+	MaxFile      int64
+	MaxStreams   int64
+	MaxConsumers int64
+}
+
+// Account defines the claims of an account JWT. JetStream is disabled if no limits are set.
+type Account struct {
+	Name      string
+	JetStream *JetStreamLimits
+	Exports   []Export
+	Imports   []Import
+}
+
+// Export is a subject which an account shares with other accounts. If TokenRequired is set,
+// only accounts with an activation token may import it.
+type Export struct {
+	Subject       string
+	Service       bool
+	TokenRequired bool
+}
+
+// Import is a subject of another account which an account uses.
+type Import struct {
+	// Account is the public key of the exporting account.
+	Account string
+	Subject string
+	Service bool
+	// LocalSubject is the subject under which the import is available in the importing account.
+	LocalSubject string
+	// Token is the activation token of exports which require a token.
+	Token string
+}
+
+// User defines the claims of a user JWT.
+type User struct {
+	Name      string
+	Publish   Permission
+	Subscribe Permission
+}
+
+// Permission lists the allowed and denied subjects. If nothing is allowed, all subjects which are not denied are.
+type Permission struct {
+	Allow []string
+	Deny  []string
+}
+
+// NewOperatorSeed generates the NKey of an operator and returns its seed.
+func NewOperatorSeed() ([]byte, error) {
+	return newSeed(nkeys.CreateOperator)
+}
+
+// NewAccountSeed generates the NKey of an account and returns its seed.
+func NewAccountSeed() ([]byte, error) {
+	return newSeed(nkeys.CreateAccount)
+}
+
+// NewUserSeed generates the NKey of a user and returns its seed.
+func NewUserSeed() ([]byte, error) {
+	return newSeed(nkeys.CreateUser)
+}
+
+func newSeed(create func() (nkeys.KeyPair, error)) ([]byte, error) {
+	keyPair, err := create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate NKey: %w", err)
+	}
+	return keyPair.Seed()
+}
+
+// PublicKey returns the public key of the NKey with the given seed.
+func PublicKey(seed []byte) (string, error) {
+	keyPair, err := nkeys.FromSeed(seed)
+	if err != nil {
+		return "", err
+	}
+	return keyPair.PublicKey()
+}
+
+// IssueOperator issues the self-signed JWT of the operator with the given system account.
+func IssueOperator(operatorSeed []byte, name, systemAccount string) (string, error) {
+	operator, publicKey, err := parseSeed(operatorSeed)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.NewOperatorClaims(publicKey)
+	claims.Name = name
+	claims.SystemAccount = systemAccount
+	return claims.Encode(operator)
+}
+
+// IssueAccount issues the JWT of the account with the given public key, signed by the operator.
+func IssueAccount(operatorSeed []byte, accountPublicKey string, account Account) (string, error) {
+	operator, _, err := parseSeed(operatorSeed)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.NewAccountClaims(accountPublicKey)
+	claims.Name = account.Name
+	if limits := account.JetStream; limits != nil {
+		claims.Limits.JetStreamLimits = jwt.JetStreamLimits{
+			MemoryStorage: orNoLimit(limits.MaxMemory),
+			DiskStorage:   orNoLimit(limits.MaxFile),
+			Streams:       orNoLimit(limits.MaxStreams),
+			Consumer:      orNoLimit(limits.MaxConsumers),
+		}
+	}
+	for _, export := range account.Exports {
+		claims.Exports.Add(&jwt.Export{
+			Subject:  jwt.Subject(export.Subject),
+			Type:     exportType(export.Service),
+			TokenReq: export.TokenRequired,
+		})
+	}
+	for _, imp := range account.Imports {
+		claims.Imports.Add(&jwt.Import{
+			Account:      imp.Account,
+			Subject:      jwt.Subject(imp.Subject),
+			Type:         exportType(imp.Service),
+			LocalSubject: jwt.RenamingSubject(imp.LocalSubject),
+			Token:        imp.Token,
+		})
+	}
+
+	validation := &jwt.ValidationResults{}
+	claims.Validate(validation)
+	if validation.IsBlocking(true) {
+		return "", errors.Join(validation.Errors()...)
+	}
+	return claims.Encode(operator)
+}
+
+// IssueActivation issues the token with which the account with the given public key may import
+// the given subject from the exporting account.
+func IssueActivation(exporterSeed []byte, importerPublicKey, subject string, service bool) (string, error) {
+	exporter, _, err := parseSeed(exporterSeed)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.NewActivationClaims(importerPublicKey)
+	claims.ImportSubject = jwt.Subject(subject)
+	claims.ImportType = exportType(service)
+	return claims.Encode(exporter)
+}
+
+// IssueUser issues the JWT of the user with the given public key, signed by its account.
+func IssueUser(accountSeed []byte, userPublicKey string, user User) (string, error) {
+	account, _, err := parseSeed(accountSeed)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.NewUserClaims(userPublicKey)
+	claims.Name = user.Name
+	claims.Pub.Allow.Add(user.Publish.Allow...)
+	claims.Pub.Deny.Add(user.Publish.Deny...)
+	claims.Sub.Allow.Add(user.Subscribe.Allow...)
+	claims.Sub.Deny.Add(user.Subscribe.Deny...)
+	return claims.Encode(account)
+}
+
+// IssueDeleteRequest issues the request with which the full resolver deletes the JWTs of the given accounts.
+func IssueDeleteRequest(operatorSeed []byte, accountPublicKeys []string) (string, error) {
+	operator, publicKey, err := parseSeed(operatorSeed)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.NewGenericClaims(publicKey)
+	claims.Data["accounts"] = accountPublicKeys
+	return claims.Encode(operator)
+}
+
+// FormatCredentials returns the NATS credentials file of the user with the given JWT and seed.
+func FormatCredentials(userJWT string, userSeed []byte) ([]byte, error) {
+	return jwt.FormatUserConfig(userJWT, userSeed)
+}
+
+// ParseCredentials returns the JWT and the seed of the user from the given NATS credentials file.
+func ParseCredentials(credentials []byte) (string, []byte, error) {
+	userJWT, err := jwt.ParseDecoratedJWT(credentials)
+	if err != nil {
+		return "", nil, errors.Join(ErrInvalidCredentials, err)
+	}
+	keyPair, err := jwt.ParseDecoratedUserNKey(credentials)
+	if err != nil {
+		return "", nil, errors.Join(ErrInvalidCredentials, err)
+	}
+	seed, err := keyPair.Seed()
+	if err != nil {
+		return "", nil, errors.Join(ErrInvalidCredentials, err)
+	}
+	return userJWT, seed, nil
+}
+
+// SameClaims reports whether the given JWTs have the same claims apart from their ID and the time they
+// were issued at, including the activation tokens of the imports. This allows to keep a JWT as long as
+// the claims which would be issued do not change.
+func SameClaims(a, b string) bool {
+	claimsA, err := normalizedClaims(a)
+	if err != nil {
+		return false
+	}
+	claimsB, err := normalizedClaims(b)
+	if err != nil {
+		return false
+	}
+	return claimsA == claimsB
+}
+
+func normalizedClaims(token string) (string, error) {
+	claims, err := jwt.Decode(token)
+	if err != nil {
+		return "", err
+	}
+	claims.Claims().ID = ""
+	claims.Claims().IssuedAt = 0
+	if account, isAccount := claims.(*jwt.AccountClaims); isAccount {
+		for _, imp := range account.Imports {
+			if imp.Token == "" {
+				continue
+			}
+			if imp.Token, err = normalizedClaims(imp.Token); err != nil {
+				return "", err
+			}
+		}
+	}
+	normalized, err := json.Marshal(claims)
+	return string(normalized), err
+}
+
+func parseSeed(seed []byte) (nkeys.KeyPair, string, error) {
+	keyPair, err := nkeys.FromSeed(seed)
+	if err != nil {
+		return nil, "", err
+	}
+	publicKey, err := keyPair.PublicKey()
+	if err != nil {
+		return nil, "", err
+	}
+	return keyPair, publicKey, nil
+}
+
+func exportType(service bool) jwt.ExportType {
+	if service {
+		return jwt.Service
+	}
+	return jwt.Stream
+}
+
+func orNoLimit(limit int64) int64 {
+	if limit == 0 {
+		return jwt.NoLimit
+	}
+	return limit
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/nats-io/jwt/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func newKey(t *testing.T, newSeed func() ([]byte, error)) ([]byte, string) {
+	t.Helper()
+	seed, err := newSeed()
+	require.NoError(t, err)
+	publicKey, err := PublicKey(seed)
+	require.NoError(t, err)
+	return seed, publicKey
+}
+
+func Test_IssueOperator(t *testing.T) {
+	t.Parallel()
+
+	// given
+	operatorSeed, operatorKey := newKey(t, NewOperatorSeed)
+	_, systemAccountKey := newKey(t, NewAccountSeed)
+
+	// when
+	token, err := IssueOperator(operatorSeed, "eventing-nats", systemAccountKey)
+
+	// then
+	require.NoError(t, err)
+	claims, err := jwt.DecodeOperatorClaims(token)
+	require.NoError(t, err)
+	require.Equal(t, operatorKey, claims.Subject)
+	require.Equal(t, operatorKey, claims.Issuer)
+	require.Equal(t, "eventing-nats", claims.Name)
+	require.Equal(t, systemAccountKey, claims.SystemAccount)
+}
+
+func Test_IssueAccount(t *testing.T) {
+	t.Parallel()
+
+	operatorSeed, operatorKey := newKey(t, NewOperatorSeed)
+	exporterSeed, exporterKey := newKey(t, NewAccountSeed)
+	_, accountKey := newKey(t, NewAccountSeed)
+
+	t.Run("should issue the account with limits, exports and imports", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		activation, err := IssueActivation(exporterSeed, accountKey, "orders.created", false)
+		require.NoError(t, err)
+		account := Account{
+			Name:      "billing",
+			JetStream: &JetStreamLimits{MaxFile: 1024, MaxStreams: 10},
+			Exports:   []Export{{Subject: "billing.lookup", Service: true}},
+			Imports: []Import{
+				{Account: exporterKey, Subject: "orders.created", LocalSubject: "billing.orders.created",
+					Token: activation},
+				{Account: exporterKey, Subject: "orders.lookup", Service: true},
+			},
+		}
+
+		// when
+		token, err := IssueAccount(operatorSeed, accountKey, account)
+
+		// then
+		require.NoError(t, err)
+		claims, err := jwt.DecodeAccountClaims(token)
+		require.NoError(t, err)
+		require.Equal(t, accountKey, claims.Subject)
+		require.Equal(t, operatorKey, claims.Issuer)
+		require.Equal(t, "billing", claims.Name)
+		require.Equal(t, jwt.JetStreamLimits{
+			MemoryStorage: jwt.NoLimit,
+			DiskStorage:   1024,
+			Streams:       10,
+			Consumer:      jwt.NoLimit,
+		}, claims.Limits.JetStreamLimits)
+		require.Len(t, claims.Exports, 1)
+		require.Equal(t, jwt.Service, claims.Exports[0].Type)
+		require.Len(t, claims.Imports, 2)
+		require.Equal(t, jwt.Stream, claims.Imports[0].Type)
+		require.Equal(t, jwt.RenamingSubject("billing.orders.created"), claims.Imports[0].LocalSubject)
+
+		activationClaims, err := jwt.DecodeActivationClaims(claims.Imports[0].Token)
+		require.NoError(t, err)
+		require.Equal(t, exporterKey, activationClaims.Issuer)
+		require.Equal(t, accountKey, activationClaims.Subject)
+	})
+
+	t.Run("should disable JetStream if no limits are set", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		token, err := IssueAccount(operatorSeed, accountKey, Account{Name: "billing"})
+
+		// then
+		require.NoError(t, err)
+		claims, err := jwt.DecodeAccountClaims(token)
+		require.NoError(t, err)
+		require.False(t, claims.Limits.IsJSEnabled())
+	})
+
+	t.Run("should fail if a claim is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		_, err := IssueAccount(operatorSeed, accountKey, Account{
+			Name:    "billing",
+			Imports: []Import{{Account: exporterKey, Subject: "orders.>", LocalSubject: "billing.orders"}},
+		})
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("should fail if the seed is not the seed of an operator", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		_, err := IssueAccount([]byte("invalid"), accountKey, Account{Name: "billing"})
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func Test_IssueUser(t *testing.T) {
+	t.Parallel()
+
+	// given
+	accountSeed, accountKey := newKey(t, NewAccountSeed)
+	userSeed, userKey := newKey(t, NewUserSeed)
+
+	// when
+	token, err := IssueUser(accountSeed, userKey, User{
+		Name:      "billing.reader",
+		Publish:   Permission{Deny: []string{">"}},
+		Subscribe: Permission{Allow: []string{"billing.>"}},
+	})
+
+	// then
+	require.NoError(t, err)
+	claims, err := jwt.DecodeUserClaims(token)
+	require.NoError(t, err)
+	require.Equal(t, accountKey, claims.Issuer)
+	require.Equal(t, userKey, claims.Subject)
+	require.Equal(t, "billing.reader", claims.Name)
+	require.Equal(t, jwt.StringList{">"}, claims.Pub.Deny)
+	require.Empty(t, claims.Pub.Allow)
+	require.Equal(t, jwt.StringList{"billing.>"}, claims.Sub.Allow)
+
+	// when
+	credentials, err := FormatCredentials(token, userSeed)
+	require.NoError(t, err)
+	gotJWT, gotSeed, err := ParseCredentials(credentials)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, token, gotJWT)
+	require.Equal(t, userSeed, gotSeed)
+}
+
+func Test_ParseCredentials_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := ParseCredentials([]byte("invalid"))
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func Test_IssueDeleteRequest(t *testing.T) {
+	t.Parallel()
+
+	// given
+	operatorSeed, operatorKey := newKey(t, NewOperatorSeed)
+	_, accountKey := newKey(t, NewAccountSeed)
+
+	// when
+	token, err := IssueDeleteRequest(operatorSeed, []string{accountKey})
+
+	// then
+	require.NoError(t, err)
+	claims, err := jwt.DecodeGeneric(token)
+	require.NoError(t, err)
+	require.Equal(t, operatorKey, claims.Issuer)
+	require.Equal(t, []any{accountKey}, claims.Data["accounts"])
+}
+
+func Test_SameClaims(t *testing.T) {
+	t.Parallel()
+
+	// given
+	operatorSeed, _ := newKey(t, NewOperatorSeed)
+	exporterSeed, exporterKey := newKey(t, NewAccountSeed)
+	_, accountKey := newKey(t, NewAccountSeed)
+	issue := func(limits *JetStreamLimits) string {
+		activation, err := IssueActivation(exporterSeed, accountKey, "orders.created", false)
+		require.NoError(t, err)
+		token, err := IssueAccount(operatorSeed, accountKey, Account{
+			Name:      "billing",
+			JetStream: limits,
+			Imports:   []Import{{Account: exporterKey, Subject: "orders.created", Token: activation}},
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	// then
+	require.True(t, SameClaims(issue(nil), issue(nil)))
+	require.False(t, SameClaims(issue(nil), issue(&JetStreamLimits{})))
+	require.False(t, SameClaims(issue(nil), "invalid"))
+}
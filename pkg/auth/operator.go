@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	// Keys of the Secret with the operator which the NATS manager generates.
+	OperatorSeedKey           = "operator.seed"
+	SystemAccountSeedKey      = "sys.seed"
+	SystemUserCredentialsKey  = "sys.creds"
+	ManagerAccountSeedKey     = "manager.seed"
+	ManagerUserCredentialsKey = "manager.creds"
+	// OperatorConfigKey is the key of the NATS configuration which trusts the operator. It is the only key
+	// of the Secret which is mounted into the NATS servers.
+	OperatorConfigKey = "operator.conf"
+
+	// CredentialsKey is the key of the NATS credentials file in the credentials Secrets of users.
+	CredentialsKey = "user.creds"
+
+	// SystemAccountName is the name of the system account, which is used to push the account JWTs.
+	SystemAccountName = "SYS"
+	// ManagerAccountName is the name of the account in which the NATS manager manages JetStream resources.
+	ManagerAccountName = "nats-manager"
+
+	systemUserName  = "sys"
+	managerUserName = "nats-manager"
+)
+
+// NewOperatorSecretData generates the operator, the system account and the account of the NATS manager,
+// each account with a user, and renders the NATS configuration which trusts the operator and preloads both
+// accounts into the resolver. It returns the data of the operator Secret.
+func NewOperatorSecretData(operatorName string) (map[string][]byte, error) {
+	operatorSeed, err := NewOperatorSeed()
+	if err != nil {
+		return nil, err
+	}
+	systemAccount, err := newAccount(operatorSeed, Account{Name: SystemAccountName}, systemUserName)
+	if err != nil {
+		return nil, err
+	}
+	managerAccount, err := newAccount(operatorSeed,
+		Account{Name: ManagerAccountName, JetStream: &JetStreamLimits{}}, managerUserName)
+	if err != nil {
+		return nil, err
+	}
+	operatorJWT, err := IssueOperator(operatorSeed, operatorName, systemAccount.publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &bytes.Buffer{}
+	fmt.Fprintf(config, "operator: %q\n", operatorJWT)
+	fmt.Fprintf(config, "system_account: %q\n", systemAccount.publicKey)
+	fmt.Fprintf(config, "resolver_preload: {\n")
+	fmt.Fprintf(config, "  %q: %q\n", systemAccount.publicKey, systemAccount.jwt)
+	fmt.Fprintf(config, "  %q: %q\n", managerAccount.publicKey, managerAccount.jwt)
+	fmt.Fprintf(config, "}\n")
+
+	return map[string][]byte{
+		OperatorSeedKey:           operatorSeed,
+		SystemAccountSeedKey:      systemAccount.seed,
+		SystemUserCredentialsKey:  systemAccount.userCredentials,
+		ManagerAccountSeedKey:     managerAccount.seed,
+		ManagerUserCredentialsKey: managerAccount.userCredentials,
+		OperatorConfigKey:         config.Bytes(),
+	}, nil
+}
+
+type generatedAccount struct {
+	seed            []byte
+	publicKey       string
+	jwt             string
+	userCredentials []byte
+}
+
+// newAccount generates an account signed by the operator together with a user without restrictions.
+func newAccount(operatorSeed []byte, account Account, userName string) (*generatedAccount, error) {
+	seed, err := NewAccountSeed()
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := PublicKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	accountJWT, err := IssueAccount(operatorSeed, publicKey, account)
+	if err != nil {
+		return nil, err
+	}
+
+	userSeed, err := NewUserSeed()
+	if err != nil {
+		return nil, err
+	}
+	userPublicKey, err := PublicKey(userSeed)
+	if err != nil {
+		return nil, err
+	}
+	userJWT, err := IssueUser(seed, userPublicKey, User{Name: userName})
+	if err != nil {
+		return nil, err
+	}
+	userCredentials, err := FormatCredentials(userJWT, userSeed)
+	if err != nil {
+		return nil, err
+	}
+	return &generatedAccount{seed: seed, publicKey: publicKey, jwt: accountJWT, userCredentials: userCredentials}, nil
+}
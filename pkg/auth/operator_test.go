@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/nats-io/jwt/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewOperatorSecretData(t *testing.T) {
+	t.Parallel()
+
+	// when
+	data, err := NewOperatorSecretData("eventing-nats")
+
+	// then
+	require.NoError(t, err)
+	operatorKey, err := PublicKey(data[OperatorSeedKey])
+	require.NoError(t, err)
+	systemAccountKey, err := PublicKey(data[SystemAccountSeedKey])
+	require.NoError(t, err)
+	managerAccountKey, err := PublicKey(data[ManagerAccountSeedKey])
+	require.NoError(t, err)
+
+	// the users are issued by their accounts.
+	for credentialsKey, accountKey := range map[string]string{
+		SystemUserCredentialsKey:  systemAccountKey,
+		ManagerUserCredentialsKey: managerAccountKey,
+	} {
+		userJWT, _, err := ParseCredentials(data[credentialsKey])
+		require.NoError(t, err)
+		userClaims, err := jwt.DecodeUserClaims(userJWT)
+		require.NoError(t, err)
+		require.Equal(t, accountKey, userClaims.Issuer)
+	}
+
+	// the configuration trusts the operator and preloads the accounts.
+	config := string(data[OperatorConfigKey])
+	require.Contains(t, config, `system_account: "`+systemAccountKey+`"`)
+	require.Contains(t, config, `"`+systemAccountKey+`": "`)
+	require.Contains(t, config, `"`+managerAccountKey+`": "`)
+	require.Contains(t, config, `operator: "`)
+
+	// the second call generates a new operator.
+	otherData, err := NewOperatorSecretData("eventing-nats")
+	require.NoError(t, err)
+	otherOperatorKey, err := PublicKey(otherData[OperatorSeedKey])
+	require.NoError(t, err)
+	require.NotEqual(t, operatorKey, otherOperatorKey)
+}
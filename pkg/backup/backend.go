@@ -0,0 +1,38 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+)
+
+var (
+	ErrNotFound         = errors.New("object not found in the backup storage")
+	ErrInvalidKey       = errors.New("invalid key of the backup storage")
+	ErrNoStorageBackend = errors.New("no storage backend is set")
+	ErrInvalidPath      = errors.New("invalid path of the backup storage")
+)
+
+// Backend stores the snapshots of backups as objects. The keys of the objects are slash-separated paths,
+// e.g. kyma-system/orders-backup/orders.snapshot. Further backends, like S3-compatible storage, implement
+// this interface and are selected by NewBackend.
+type Backend interface {
+	// Writer returns a writer for the object with the given key. The object is stored once the writer is closed,
+	// and replaces an existing object with the same key.
+	Writer(ctx context.Context, key string) (io.WriteCloser, error)
+	// Reader returns a reader for the object with the given key. Returns ErrNotFound if the object does not exist.
+	Reader(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete deletes all objects whose keys start with the given prefix followed by a slash.
+	Delete(ctx context.Context, prefix string) error
+}
+
+// NewBackend returns the backend of the given storage. The filesystem backend stores the objects below
+// the given backup directory.
+func NewBackend(storage nmapiv1alpha1.BackupStorage, backupDir string) (Backend, error) {
+	if storage.Filesystem != nil {
+		return NewFilesystemBackend(backupDir, storage.Filesystem.Path)
+	}
+	return nil, ErrNoStorageBackend
+}
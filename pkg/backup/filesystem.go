@@ -0,0 +1,90 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	dirPermissions = 0o750
+	tempFilePrefix = ".tmp-"
+)
+
+// filesystemBackend stores the objects as files in a directory, e.g. on a mounted PVC.
+type filesystemBackend struct {
+	dir string
+}
+
+// NewFilesystemBackend returns a backend which stores the objects in the given subdirectory of the backup directory.
+func NewFilesystemBackend(backupDir, subDir string) (Backend, error) {
+	if subDir != "" && !filepath.IsLocal(subDir) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPath, subDir)
+	}
+	return &filesystemBackend{dir: filepath.Join(backupDir, subDir)}, nil
+}
+
+func (b *filesystemBackend) Writer(_ context.Context, key string) (io.WriteCloser, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), dirPermissions); err != nil {
+		return nil, err
+	}
+	// the data is written to a temporary file first, so that readers never see incomplete objects.
+	file, err := os.CreateTemp(filepath.Dir(path), tempFilePrefix+filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+	return &atomicFile{File: file, path: path}, nil
+}
+
+func (b *filesystemBackend) Reader(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return file, err
+}
+
+func (b *filesystemBackend) Delete(_ context.Context, prefix string) error {
+	path, err := b.path(prefix)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+// path returns the path of the file of the given key, which must not leave the directory of the backend.
+func (b *filesystemBackend) path(key string) (string, error) {
+	key = filepath.FromSlash(key)
+	if !filepath.IsLocal(key) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidKey, key)
+	}
+	return filepath.Join(b.dir, key), nil
+}
+
+// atomicFile is a temporary file which is renamed to its path once it is closed.
+type atomicFile struct {
+	*os.File
+	path string
+}
+
+func (f *atomicFile) Close() error {
+	if err := f.File.Close(); err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+	if err := os.Rename(f.Name(), f.path); err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FilesystemBackend(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	backupDir := t.TempDir()
+	backend, err := NewBackend(nmapiv1alpha1.BackupStorage{
+		Filesystem: &nmapiv1alpha1.FilesystemBackupStorage{Path: "snapshots"},
+	}, backupDir)
+	require.NoError(t, err)
+
+	// when
+	writer, err := backend.Writer(ctx, "kyma-system/orders-backup/orders.snapshot")
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("snapshot data"))
+	require.NoError(t, err)
+
+	// then, the object is not visible before the writer is closed.
+	_, err = backend.Reader(ctx, "kyma-system/orders-backup/orders.snapshot")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// when
+	require.NoError(t, writer.Close())
+
+	// then
+	reader, err := backend.Reader(ctx, "kyma-system/orders-backup/orders.snapshot")
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, "snapshot data", string(data))
+	require.FileExists(t, filepath.Join(backupDir, "snapshots", "kyma-system", "orders-backup", "orders.snapshot"))
+
+	// when
+	require.NoError(t, backend.Delete(ctx, "kyma-system/orders-backup"))
+
+	// then
+	_, err = backend.Reader(ctx, "kyma-system/orders-backup/orders.snapshot")
+	require.ErrorIs(t, err, ErrNotFound)
+	entries, err := os.ReadDir(filepath.Join(backupDir, "snapshots", "kyma-system"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func Test_FilesystemBackend_InvalidPaths(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	backupDir := t.TempDir()
+
+	// when
+	_, err := NewFilesystemBackend(backupDir, "../other")
+
+	// then
+	require.ErrorIs(t, err, ErrInvalidPath)
+
+	// given
+	backend, err := NewFilesystemBackend(backupDir, "")
+	require.NoError(t, err)
+
+	// when
+	_, err = backend.Writer(ctx, "../orders.snapshot")
+
+	// then
+	require.ErrorIs(t, err, ErrInvalidKey)
+	_, err = backend.Reader(ctx, "/etc/passwd")
+	require.ErrorIs(t, err, ErrInvalidKey)
+	require.ErrorIs(t, backend.Delete(ctx, ".."), ErrInvalidKey)
+
+	// when
+	_, err = NewBackend(nmapiv1alpha1.BackupStorage{}, backupDir)
+
+	// then
+	require.ErrorIs(t, err, ErrNoStorageBackend)
+}
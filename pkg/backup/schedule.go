@@ -0,0 +1,174 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cronFields = 5
+	// maxScheduleYears limits the search for the next time of a schedule which never matches, e.g. on February 30.
+	maxScheduleYears = 5
+)
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// scheduleMacros are the shortcuts for common schedules.
+var scheduleMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField defines the range of the values of a field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField     = cronField{name: "minute", min: 0, max: 59}
+	hourField       = cronField{name: "hour", min: 0, max: 23}
+	dayOfMonthField = cronField{name: "day of month", min: 1, max: 31}
+	monthField      = cronField{name: "month", min: 1, max: 12}
+	// the day of week 7 is Sunday like 0.
+	dayOfWeekField = cronField{name: "day of week", min: 0, max: 7}
+)
+
+// Schedule is a cron schedule with the fields minute, hour, day of month, month, and day of week.
+// Each field is a bit set of the matching values.
+type Schedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// like in cron, a day matches if the day of month or the day of week matches, unless one of them is "*".
+	dayOfMonthAny, dayOfWeekAny bool
+}
+
+// ParseSchedule parses a cron expression like "30 2 * * 1-5" or a macro like "@daily".
+// The fields support "*", values, ranges, steps, and lists.
+func ParseSchedule(expr string) (*Schedule, error) {
+	if macro, ok := scheduleMacros[strings.TrimSpace(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != cronFields {
+		return nil, fmt.Errorf("%w: %q must have %d fields", ErrInvalidSchedule, expr, cronFields)
+	}
+
+	schedule := &Schedule{
+		dayOfMonthAny: fields[2] == "*",
+		dayOfWeekAny:  fields[4] == "*",
+	}
+	var err error
+	for _, field := range []struct {
+		bits *uint64
+		expr string
+		cronField
+	}{
+		{bits: &schedule.minute, expr: fields[0], cronField: minuteField},
+		{bits: &schedule.hour, expr: fields[1], cronField: hourField},
+		{bits: &schedule.dayOfMonth, expr: fields[2], cronField: dayOfMonthField},
+		{bits: &schedule.month, expr: fields[3], cronField: monthField},
+		{bits: &schedule.dayOfWeek, expr: fields[4], cronField: dayOfWeekField},
+	} {
+		if *field.bits, err = parseCronField(field.expr, field.cronField); err != nil {
+			return nil, err
+		}
+	}
+	// Sunday can be given as 0 or 7.
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+	return schedule, nil
+}
+
+// parseCronField returns the bit set of the values of a comma separated list of values, ranges, and steps.
+func parseCronField(expr string, field cronField) (uint64, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step < 1 {
+				return 0, fmt.Errorf("%w: invalid step %q in the %s field", ErrInvalidSchedule, stepExpr, field.name)
+			}
+		}
+
+		start, end := field.min, field.max
+		if rangeExpr != "*" {
+			startExpr, endExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if start, err = parseCronValue(startExpr, field); err != nil {
+				return 0, err
+			}
+			// a single value with a step, e.g. "5/15", runs from the value to the end of the field.
+			if !hasStep {
+				end = start
+			}
+			if isRange {
+				if end, err = parseCronValue(endExpr, field); err != nil {
+					return 0, err
+				}
+			}
+			if start > end {
+				return 0, fmt.Errorf("%w: invalid range %q in the %s field", ErrInvalidSchedule, rangeExpr, field.name)
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(expr string, field cronField) (int, error) {
+	value, err := strconv.Atoi(expr)
+	if err != nil || value < field.min || value > field.max {
+		return 0, fmt.Errorf("%w: invalid value %q in the %s field", ErrInvalidSchedule, expr, field.name)
+	}
+	return value, nil
+}
+
+// Next returns the first time after the given time which matches the schedule, in the location of the given time.
+// It returns the zero time if the schedule does not match within the next years.
+func (s *Schedule) Next(after time.Time) time.Time {
+	next := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.AddDate(maxScheduleYears, 0, 0)
+
+	for next.Before(limit) {
+		switch {
+		case !hasBit(s.month, int(next.Month())):
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+		case !s.matchesDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+		case !hasBit(s.hour, next.Hour()):
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+		case !hasBit(s.minute, next.Minute()):
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := hasBit(s.dayOfMonth, t.Day())
+	dayOfWeek := hasBit(s.dayOfWeek, int(t.Weekday()))
+	if s.dayOfMonthAny || s.dayOfWeekAny {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+func hasBit(bits uint64, value int) bool {
+	return bits&(1<<value) != 0
+}
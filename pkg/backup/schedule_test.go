@@ -0,0 +1,109 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ScheduleNext(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday.
+	givenTime := time.Date(2026, time.January, 14, 10, 30, 15, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		givenSchedule string
+		wantNext      time.Time
+	}{
+		{
+			name:          "should run every minute",
+			givenSchedule: "* * * * *",
+			wantNext:      time.Date(2026, time.January, 14, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			name:          "should run at the next step",
+			givenSchedule: "*/15 * * * *",
+			wantNext:      time.Date(2026, time.January, 14, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			name:          "should run at the next day",
+			givenSchedule: "0 2 * * *",
+			wantNext:      time.Date(2026, time.January, 15, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "should run at the macro",
+			givenSchedule: "@monthly",
+			wantNext:      time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "should run at the next day of week in the range",
+			givenSchedule: "0 0 * * 5-6",
+			wantNext:      time.Date(2026, time.January, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "should run at Sunday as day of week 7",
+			givenSchedule: "0 0 * * 7",
+			wantNext:      time.Date(2026, time.January, 18, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "should run at the day of month or the day of week",
+			givenSchedule: "0 0 20 * 4",
+			wantNext:      time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "should run at the values of the list",
+			givenSchedule: "0 9,18 * * *",
+			wantNext:      time.Date(2026, time.January, 14, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "should run at the next leap day",
+			givenSchedule: "0 0 29 2 *",
+			wantNext:      time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "should never run at a day which does not exist",
+			givenSchedule: "0 0 30 2 *",
+			wantNext:      time.Time{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			schedule, err := ParseSchedule(tc.givenSchedule)
+			require.NoError(t, err)
+
+			// when
+			gotNext := schedule.Next(givenTime)
+
+			// then
+			require.Equal(t, tc.wantNext, gotNext)
+		})
+	}
+}
+
+func Test_ParseSchedule_InvalidSchedules(t *testing.T) {
+	t.Parallel()
+
+	for _, givenSchedule := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"30-10 * * * *",
+		"a * * * *",
+		"@every 5m",
+	} {
+		_, err := ParseSchedule(givenSchedule)
+		require.ErrorIs(t, err, ErrInvalidSchedule, givenSchedule)
+	}
+}
@@ -0,0 +1,134 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// Keys of the certificate data in a Secret, compatible with Secrets of type kubernetes.io/tls.
+	CertificateKey = "tls.crt"
+	PrivateKeyKey  = "tls.key"
+	CAKey          = "ca.crt"
+
+	pemTypeCertificate = "CERTIFICATE"
+	pemTypePrivateKey  = "EC PRIVATE KEY"
+	serialNumberBits   = 128
+)
+
+var ErrInvalidPEM = errors.New("invalid PEM data")
+
+// KeyPair is a PEM encoded certificate together with its private key.
+type KeyPair struct {
+	CertificatePEM []byte
+	PrivateKeyPEM  []byte
+}
+
+// NewCA generates a self-signed CA certificate which is valid for the given duration.
+func NewCA(commonName string, validity time.Duration) (*KeyPair, error) {
+	template := newTemplate(commonName, validity)
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+	return sign(template, template, &key.PublicKey, key, key)
+}
+
+// NewServerCertificate generates a certificate for the given DNS names, signed by the given CA.
+// The certificate can be used for server and client authentication, as NATS servers connect to each other.
+func NewServerCertificate(ca *KeyPair, commonName string, dnsNames []string,
+	validity time.Duration,
+) (*KeyPair, error) {
+	caCert, caKey, err := ca.parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA: %w", err)
+	}
+
+	template := newTemplate(commonName, validity)
+	template.DNSNames = dnsNames
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate key: %w", err)
+	}
+	return sign(template, caCert, &key.PublicKey, key, caKey)
+}
+
+// ParseCertificate decodes the first PEM encoded certificate.
+func ParseCertificate(certificatePEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certificatePEM)
+	if block == nil || block.Type != pemTypeCertificate {
+		return nil, ErrInvalidPEM
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// Fingerprint returns the hex encoded SHA-256 fingerprint of the certificate.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func (kp *KeyPair) parse() (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	cert, err := ParseCertificate(kp.CertificatePEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := pem.Decode(kp.PrivateKeyPEM)
+	if block == nil || block.Type != pemTypePrivateKey {
+		return nil, nil, ErrInvalidPEM
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
+
+func newTemplate(commonName string, validity time.Duration) *x509.Certificate {
+	now := time.Now()
+	return &x509.Certificate{
+		Subject: pkix.Name{CommonName: commonName},
+		// tolerate small clock skews between the NATS manager and the NATS servers.
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(validity),
+	}
+}
+
+func sign(template, parent *x509.Certificate, publicKey *ecdsa.PublicKey, key, signerKey *ecdsa.PrivateKey,
+) (*KeyPair, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberBits))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	template.SerialNumber = serialNumber
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, publicKey, signerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return &KeyPair{
+		CertificatePEM: pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: der}),
+		PrivateKeyPEM:  pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: keyDER}),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewServerCertificate(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ca, err := NewCA("nats-ca", 24*time.Hour)
+	require.NoError(t, err)
+
+	// when
+	server, err := NewServerCertificate(ca, "eventing-nats",
+		[]string{"eventing-nats.kyma-system.svc.cluster.local", "*.eventing-nats.kyma-system.svc.cluster.local"},
+		time.Hour)
+
+	// then
+	require.NoError(t, err)
+	_, err = tls.X509KeyPair(server.CertificatePEM, server.PrivateKeyPEM)
+	require.NoError(t, err)
+
+	caCert, err := ParseCertificate(ca.CertificatePEM)
+	require.NoError(t, err)
+	require.True(t, caCert.IsCA)
+
+	serverCert, err := ParseCertificate(server.CertificatePEM)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	for _, dnsName := range []string{
+		"eventing-nats.kyma-system.svc.cluster.local",
+		"eventing-nats-0.eventing-nats.kyma-system.svc.cluster.local",
+	} {
+		_, err = serverCert.Verify(x509.VerifyOptions{
+			DNSName:   dnsName,
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		})
+		require.NoError(t, err, dnsName)
+	}
+	require.WithinDuration(t, time.Now().Add(time.Hour), serverCert.NotAfter, time.Minute)
+}
+
+func Test_NewServerCertificate_InvalidCA(t *testing.T) {
+	t.Parallel()
+
+	// when
+	_, err := NewServerCertificate(&KeyPair{CertificatePEM: []byte("invalid")}, "eventing-nats", nil, time.Hour)
+
+	// then
+	require.ErrorIs(t, err, ErrInvalidPEM)
+}
+
+func Test_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ca, err := NewCA("nats-ca", time.Hour)
+	require.NoError(t, err)
+	otherCA, err := NewCA("nats-ca", time.Hour)
+	require.NoError(t, err)
+	caCert, err := ParseCertificate(ca.CertificatePEM)
+	require.NoError(t, err)
+	otherCACert, err := ParseCertificate(otherCA.CertificatePEM)
+	require.NoError(t, err)
+
+	// when
+	fingerprint := Fingerprint(caCert)
+
+	// then
+	require.Len(t, fingerprint, 64)
+	require.Equal(t, fingerprint, Fingerprint(caCert))
+	require.NotEqual(t, fingerprint, Fingerprint(otherCACert))
+}
@@ -1,6 +1,8 @@
 package env
 
 import (
+	"time"
+
 	"github.com/kelseyhightower/envconfig"
 )
 
@@ -11,12 +13,21 @@ type Config struct {
 	NATSCRName                  string `envconfig:"NATS_CR_NAME"                           required:"true"`
 	NATSCRNamespace             string `envconfig:"NATS_CR_NAMESPACE"                      required:"true"`
 	FIPSModeEnabled             bool   `default:"false"                                    envconfig:"KYMA_FIPS_MODE_ENABLED"`
+	MultiInstanceEnabled        bool   `default:"false"                                    envconfig:"NATS_MULTI_INSTANCE_ENABLED"`
 	NATSImage                   string `envconfig:"NATS_IMAGE"                             required:"true"`
 	NATSImageFIPS               string `envconfig:"NATS_IMAGE_FIPS"                        required:"true"`
 	NATSSrvCfgReloaderImage     string `envconfig:"NATS_SERVER_CONFIG_RELOADER_IMAGE"      required:"true"`
 	NATSSrvCfgReloaderImageFIPS string `envconfig:"NATS_SERVER_CONFIG_RELOADER_IMAGE_FIPS" required:"true"`
 	PrometheusExporterImage     string `envconfig:"PROMETHEUS_NATS_EXPORTER_IMAGE"         required:"true"`
 	PrometheusExporterImageFIPS string `envconfig:"PROMETHEUS_NATS_EXPORTER_IMAGE_FIPS"    required:"true"`
+	BackupDir                   string `default:"/backups"                                 envconfig:"NATS_BACKUP_DIR"`
+	// StatusCheckMinInterval and StatusCheckMaxInterval bound the fallback interval in which the readiness
+	// of the NATS StatefulSet is checked, if no event of the StatefulSet or its Pods triggered a reconciliation.
+	StatusCheckMinInterval time.Duration `default:"10s" envconfig:"NATS_STATUS_CHECK_MIN_INTERVAL"`
+	StatusCheckMaxInterval time.Duration `default:"5m"  envconfig:"NATS_STATUS_CHECK_MAX_INTERVAL"`
+	// StorageWarningPercent is the percentage of the JetStream memory or file storage from which on the
+	// StorageCapacity condition of the NATS CR is false.
+	StorageWarningPercent int `default:"80" envconfig:"NATS_STORAGE_WARNING_PERCENT"`
 }
 
 func GetConfig() (Config, error) {
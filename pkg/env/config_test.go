@@ -2,6 +2,7 @@ package env
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -28,6 +29,8 @@ func Test_GetConfig(t *testing.T) {
 	givenEnvs["NATS_SERVER_CONFIG_RELOADER_IMAGE"] = "srvr-cfg-rldr-image-url"
 	givenEnvs["NATS_SERVER_CONFIG_RELOADER_IMAGE_FIPS"] = "srvr-cfg-rldr-image-fips-url"
 	givenEnvs["KYMA_FIPS_MODE_ENABLED"] = "true"
+	givenEnvs["NATS_MULTI_INSTANCE_ENABLED"] = "true"
+	givenEnvs["NATS_STATUS_CHECK_MAX_INTERVAL"] = "2m"
 
 	for k, v := range givenEnvs {
 		t.Setenv(k, v)
@@ -44,6 +47,10 @@ func Test_GetConfig(t *testing.T) {
 	require.Equal(t, givenEnvs["PROMETHEUS_NATS_EXPORTER_IMAGE_FIPS"], config.PrometheusExporterImageFIPS)
 	require.Equal(t, givenEnvs["NATS_SERVER_CONFIG_RELOADER_IMAGE_FIPS"], config.NATSSrvCfgReloaderImageFIPS)
 	require.Equal(t, true, config.FIPSModeEnabled)
+	require.Equal(t, true, config.MultiInstanceEnabled)
+	require.Equal(t, 10*time.Second, config.StatusCheckMinInterval)
+	require.Equal(t, 2*time.Minute, config.StatusCheckMaxInterval)
+	require.Equal(t, 80, config.StorageWarningPercent)
 
 	require.Equal(t, givenEnvs["PROMETHEUS_NATS_EXPORTER_IMAGE_FIPS"], imageConfig.PrometheusExporter)
 }
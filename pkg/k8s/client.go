@@ -10,6 +10,7 @@ import (
 	kcorev1 "k8s.io/api/core/v1"
 	kapiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kapiextclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	kapierrors "k8s.io/apimachinery/pkg/api/errors"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -25,6 +26,8 @@ var _ Client = &KubeClient{}
 //go:generate go run github.com/vektra/mockery/v2 --name=Client --outpkg=mocks --case=underscore
 type Client interface {
 	PatchApply(context.Context, *unstructured.Unstructured) error
+	GetFieldConflicts(context.Context, *unstructured.Unstructured) ([]FieldConflict, error)
+	PatchApplyDryRun(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, error)
 	GetStatefulSet(context.Context, string, string) (*kappsv1.StatefulSet, error)
 	Delete(context.Context, *unstructured.Unstructured) error
 	GetSecret(context.Context, string, string) (*kcorev1.Secret, error)
@@ -44,6 +47,14 @@ const (
 
 var ErrNodeZoneLabelMissing = errors.New("zone label missing")
 
+// FieldConflict is a field of an object which another field manager has set to a different value.
+type FieldConflict struct {
+	// Field is the path of the field, e.g. .spec.replicas.
+	Field string
+	// Manager describes the field manager who set the field, e.g. "kubectl-edit" using v1.
+	Manager string
+}
+
 type KubeClient struct {
 	client         client.Client
 	clientset      kapiextclientset.Interface
@@ -67,6 +78,56 @@ func (c *KubeClient) PatchApply(ctx context.Context, object *unstructured.Unstru
 	})
 }
 
+// PatchApplyDryRun applies the object like PatchApply in dry-run mode and returns the object
+// as the API server would persist it, without changing anything.
+func (c *KubeClient) PatchApplyDryRun(ctx context.Context,
+	object *unstructured.Unstructured,
+) (*unstructured.Unstructured, error) {
+	result := object.DeepCopy()
+	if err := c.client.Patch(ctx, result, client.Apply, &client.PatchOptions{
+		Force:        new(true),
+		DryRun:       []string{kmetav1.DryRunAll},
+		FieldManager: c.fieldManager,
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetFieldConflicts applies the object in dry-run mode without forcing the ownership of its fields. It returns
+// the fields which other field managers have set to a different value, or none if the live object matches.
+func (c *KubeClient) GetFieldConflicts(ctx context.Context,
+	object *unstructured.Unstructured,
+) ([]FieldConflict, error) {
+	err := c.client.Patch(ctx, object.DeepCopy(), client.Apply, &client.PatchOptions{
+		Force:        new(false),
+		DryRun:       []string{kmetav1.DryRunAll},
+		FieldManager: c.fieldManager,
+	})
+	if err == nil {
+		return nil, nil
+	}
+
+	var statusErr kapierrors.APIStatus
+	if !kapierrors.IsConflict(err) || !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return nil, err
+	}
+	var conflicts []FieldConflict
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != kmetav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflicts = append(conflicts, FieldConflict{
+			Field:   cause.Field,
+			Manager: strings.TrimPrefix(cause.Message, "conflict with "),
+		})
+	}
+	if len(conflicts) == 0 {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
 func (c *KubeClient) Delete(ctx context.Context, object *unstructured.Unstructured) error {
 	return client.IgnoreNotFound(c.client.Delete(ctx, object))
 }
@@ -10,11 +10,13 @@ import (
 	kcorev1 "k8s.io/api/core/v1"
 	kapiextclientsetfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 const testFieldManager = "nats-manager"
@@ -228,6 +230,112 @@ func Test_PatchApply(t *testing.T) {
 	}
 }
 
+func Test_PatchApplyDryRun(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenStatefulSet := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "StatefulSet",
+		"metadata":   map[string]any{"name": "eventing-nats", "namespace": "kyma-system"},
+		"spec":       map[string]any{"replicas": int64(3)},
+	}}
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, patch client.Patch,
+			opts ...client.PatchOption,
+		) error {
+			patchOptions := &client.PatchOptions{}
+			patchOptions.ApplyOptions(opts)
+			require.Equal(t, client.Apply, patch)
+			require.Equal(t, []string{kmetav1.DryRunAll}, patchOptions.DryRun)
+			require.True(t, *patchOptions.Force)
+			// the API server returns the object with the defaults.
+			object, ok := obj.(*unstructured.Unstructured)
+			require.True(t, ok)
+			return unstructured.SetNestedField(object.Object, "Parallel", "spec", "podManagementPolicy")
+		},
+	}).Build()
+	kubeClient := NewKubeClient(fakeClient, nil, testFieldManager)
+
+	// when
+	gotStatefulSet, err := kubeClient.PatchApplyDryRun(context.Background(), givenStatefulSet)
+
+	// then
+	require.NoError(t, err)
+	gotPolicy, _, _ := unstructured.NestedString(gotStatefulSet.Object, "spec", "podManagementPolicy")
+	require.Equal(t, "Parallel", gotPolicy)
+	_, found, _ := unstructured.NestedString(givenStatefulSet.Object, "spec", "podManagementPolicy")
+	require.False(t, found)
+}
+
+func Test_GetFieldConflicts(t *testing.T) {
+	t.Parallel()
+
+	conflictErr := kapierrors.NewApplyConflict([]kmetav1.StatusCause{
+		{
+			Type:    kmetav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl-edit" using apps/v1`,
+			Field:   ".spec.replicas",
+		},
+	}, `Apply failed with 1 conflict: conflict with "kubectl-edit" using apps/v1: .spec.replicas`)
+
+	testCases := []struct {
+		name          string
+		givenPatchErr error
+		wantConflicts []FieldConflict
+		wantErr       error
+	}{
+		{
+			name: "should return no conflicts if the live object matches",
+		},
+		{
+			name:          "should return the fields which were changed by other field managers",
+			givenPatchErr: conflictErr,
+			wantConflicts: []FieldConflict{{Field: ".spec.replicas", Manager: `"kubectl-edit" using apps/v1`}},
+		},
+		{
+			name:          "should return other errors",
+			givenPatchErr: errNotFound,
+			wantErr:       errNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenStatefulSet := &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "StatefulSet",
+				"metadata":   map[string]any{"name": "eventing-nats", "namespace": "kyma-system"},
+				"spec":       map[string]any{"replicas": int64(3)},
+			}}
+			fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, patch client.Patch,
+					opts ...client.PatchOption,
+				) error {
+					patchOptions := &client.PatchOptions{}
+					patchOptions.ApplyOptions(opts)
+					require.Equal(t, client.Apply, patch)
+					require.Equal(t, []string{kmetav1.DryRunAll}, patchOptions.DryRun)
+					require.False(t, *patchOptions.Force)
+					require.NotSame(t, givenStatefulSet, obj)
+					return tc.givenPatchErr
+				},
+			}).Build()
+			kubeClient := NewKubeClient(fakeClient, nil, testFieldManager)
+
+			// when
+			conflicts, err := kubeClient.GetFieldConflicts(context.Background(), givenStatefulSet)
+
+			// then
+			require.ErrorIs(t, err, tc.wantErr)
+			require.Equal(t, tc.wantConflicts, conflicts)
+		})
+	}
+}
+
 func Test_GetCRD(t *testing.T) {
 	t.Parallel()
 
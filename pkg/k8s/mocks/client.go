@@ -9,6 +9,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	k8s "github.com/kyma-project/nats-manager/pkg/k8s"
+
 	mock "github.com/stretchr/testify/mock"
 
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -300,6 +302,65 @@ func (_c *Client_GetConfigMap_Call) RunAndReturn(run func(context.Context, strin
 	return _c
 }
 
+// GetFieldConflicts provides a mock function with given fields: _a0, _a1
+func (_m *Client) GetFieldConflicts(_a0 context.Context, _a1 *unstructured.Unstructured) ([]k8s.FieldConflict, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFieldConflicts")
+	}
+
+	var r0 []k8s.FieldConflict
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *unstructured.Unstructured) ([]k8s.FieldConflict, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *unstructured.Unstructured) []k8s.FieldConflict); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]k8s.FieldConflict)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *unstructured.Unstructured) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetFieldConflicts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFieldConflicts'
+type Client_GetFieldConflicts_Call struct {
+	*mock.Call
+}
+
+// GetFieldConflicts is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *unstructured.Unstructured
+func (_e *Client_Expecter) GetFieldConflicts(_a0 interface{}, _a1 interface{}) *Client_GetFieldConflicts_Call {
+	return &Client_GetFieldConflicts_Call{Call: _e.mock.On("GetFieldConflicts", _a0, _a1)}
+}
+
+func (_c *Client_GetFieldConflicts_Call) Run(run func(_a0 context.Context, _a1 *unstructured.Unstructured)) *Client_GetFieldConflicts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*unstructured.Unstructured))
+	})
+	return _c
+}
+
+func (_c *Client_GetFieldConflicts_Call) Return(_a0 []k8s.FieldConflict, _a1 error) *Client_GetFieldConflicts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetFieldConflicts_Call) RunAndReturn(run func(context.Context, *unstructured.Unstructured) ([]k8s.FieldConflict, error)) *Client_GetFieldConflicts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetNode provides a mock function with given fields: _a0, _a1
 func (_m *Client) GetNode(_a0 context.Context, _a1 string) (*corev1.Node, error) {
 	ret := _m.Called(_a0, _a1)
@@ -701,6 +762,65 @@ func (_c *Client_PatchApply_Call) RunAndReturn(run func(context.Context, *unstru
 	return _c
 }
 
+// PatchApplyDryRun provides a mock function with given fields: _a0, _a1
+func (_m *Client) PatchApplyDryRun(_a0 context.Context, _a1 *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchApplyDryRun")
+	}
+
+	var r0 *unstructured.Unstructured
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *unstructured.Unstructured) *unstructured.Unstructured); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*unstructured.Unstructured)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *unstructured.Unstructured) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_PatchApplyDryRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchApplyDryRun'
+type Client_PatchApplyDryRun_Call struct {
+	*mock.Call
+}
+
+// PatchApplyDryRun is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *unstructured.Unstructured
+func (_e *Client_Expecter) PatchApplyDryRun(_a0 interface{}, _a1 interface{}) *Client_PatchApplyDryRun_Call {
+	return &Client_PatchApplyDryRun_Call{Call: _e.mock.On("PatchApplyDryRun", _a0, _a1)}
+}
+
+func (_c *Client_PatchApplyDryRun_Call) Run(run func(_a0 context.Context, _a1 *unstructured.Unstructured)) *Client_PatchApplyDryRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*unstructured.Unstructured))
+	})
+	return _c
+}
+
+func (_c *Client_PatchApplyDryRun_Call) Return(_a0 *unstructured.Unstructured, _a1 error) *Client_PatchApplyDryRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_PatchApplyDryRun_Call) RunAndReturn(run func(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, error)) *Client_PatchApplyDryRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
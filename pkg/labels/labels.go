@@ -13,6 +13,8 @@ const (
 	KeyName      = "app.kubernetes.io/name"
 	KeyPartOf    = "app.kubernetes.io/part-of"
 	KeyDashboard = "kyma-project.io/dashboard"
+	// KeyNATSAccount is the label key of the credentials Secrets with the UID of their NATSAccount.
+	KeyNATSAccount = "operator.kyma-project.io/nats-account"
 
 	// Kubernetes label values used by nats-manager.
 	ValueNATS        = "nats"
@@ -28,6 +28,36 @@ const (
 	NatsImageUrl                     = "global.natsImageUrl"
 	PrometheusNATSExporterImageUrl   = "global.prometheusNatsExporterImageUrl"
 	NATSServerConfigReloaderImageUrl = "global.natsServerConfigReloaderImageUrl"
+	ClientTLSEnabledKey              = "nats.tls.client.enabled"
+	ClientTLSSecretNameKey           = "nats.tls.client.secretName"
+	ClusterTLSEnabledKey             = "nats.tls.cluster.enabled"
+	ClusterTLSSecretNameKey          = "nats.tls.cluster.secretName"
+	MonitoringTLSEnabledKey          = "nats.tls.monitoring.enabled"
+	LeafNodesEnabledKey              = "nats.leafnodes.enabled"
+	LeafNodesPortKey                 = "nats.ports.leafnodes"
+	LeafNodesAuthSecretNameKey       = "nats.leafnodes.authSecretName"
+	LeafNodesTLSEnabledKey           = "nats.leafnodes.tls.enabled"
+	LeafNodesTLSSecretNameKey        = "nats.leafnodes.tls.secretName"
+	LeafNodesRemotesKey              = "nats.leafnodes.remotes"
+	GatewayEnabledKey                = "nats.gateway.enabled"
+	GatewayNameKey                   = "nats.gateway.name"
+	GatewayPortKey                   = "nats.ports.gateways"
+	GatewayRemotesKey                = "nats.gateway.gateways"
+	ClusterNameKey                   = "cluster.name"
+	EncryptionEnabledKey             = "nats.jetstream.encryption.enabled"
+	EncryptionCipherKey              = "nats.jetstream.encryption.cipher"
+	AuthResolverTypeKey              = "auth.resolver.type"
+	// CAFingerprintKey is set by the NATS controller to the fingerprint of the generated CA.
+	CAFingerprintKey = "nats.tls.caFingerprint"
+	// EncryptionKeyVersionKey is set by the NATS controller to the version of the Secret with the encryption keys.
+	EncryptionKeyVersionKey = "nats.jetstream.encryption.keyVersion"
+	// EncryptionPreviousKeyKey is set by the NATS controller while the encryption key is rotated.
+	EncryptionPreviousKeyKey = "nats.jetstream.encryption.previousKey"
+	// UpdatePartitionKey is set by the NATS controller to the partition of the rolling upgrade of the StatefulSet.
+	UpdatePartitionKey = "nats.updatePartition"
+	// FileStorageClaimSizeKey is set by the NATS controller to the size of the PVCs in the volumeClaimTemplates
+	// of an existing StatefulSet, if the file storage was expanded since the StatefulSet was created.
+	FileStorageClaimSizeKey = "nats.jetstream.fileStorage.claimSize"
 
 	CloudProviderAlicloud = "alicloud"
 
@@ -37,10 +67,10 @@ const (
 	minFileSizeAlicloud = "20Gi"
 )
 
-// resolveFileStorageSize returns the effective file storage size given the spec value and cloud provider.
+// ResolveFileStorageSize returns the effective file storage size given the spec value and cloud provider.
 // If the spec value is empty it is defaulted; if it is set it is validated against the minimum.
 // An error is returned when the spec value is below the minimum.
-func resolveFileStorageSize(spec *nmapiv1alpha1.NATSSpec, cloudProvider string) (resource.Quantity, error) {
+func ResolveFileStorageSize(spec *nmapiv1alpha1.NATSSpec, cloudProvider string) (resource.Quantity, error) {
 	isAlicloud := cloudProvider == CloudProviderAlicloud
 
 	if spec.FileStorage.Size.IsZero() {
@@ -80,7 +110,7 @@ func (m NATSManager) GenerateOverrides(spec *nmapiv1alpha1.NATSSpec, istioEnable
 	}
 
 	// file storage – resolve size with defaulting and validation
-	fileStorageSize, err := resolveFileStorageSize(spec, cloudProvider)
+	fileStorageSize, err := ResolveFileStorageSize(spec, cloudProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +119,17 @@ func (m NATSManager) GenerateOverrides(spec *nmapiv1alpha1.NATSSpec, istioEnable
 		overrides[FileStorageClassKey] = spec.FileStorage.StorageClassName
 	}
 
+	// encryption of the file storage, the chart reads the keys from the Secret managed by the NATS controller.
+	if spec.Encryption.Enabled {
+		overrides[EncryptionEnabledKey] = true
+		overrides[EncryptionCipherKey] = encryptionCipher(spec.Encryption.Cipher)
+	}
+
+	// authentication, the operator mode uses the full resolver to which the account JWTs are pushed.
+	if spec.Auth.Mode == nmapiv1alpha1.AuthModeJWT {
+		overrides[AuthResolverTypeKey] = "full"
+	}
+
 	// memory storage
 	overrides[MemStorageEnabledKey] = spec.MemStorage.Enabled
 	if spec.MemStorage.Enabled {
@@ -123,6 +164,29 @@ func (m NATSManager) GenerateOverrides(spec *nmapiv1alpha1.NATSSpec, istioEnable
 		overrides[CommonAnnotationsKey] = spec.Annotations
 	}
 
+	// TLS listeners, the chart falls back to the generated certificate if no Secret is referenced.
+	if spec.TLS.Client.Enabled {
+		overrides[ClientTLSEnabledKey] = true
+		if spec.TLS.Client.SecretName != "" {
+			overrides[ClientTLSSecretNameKey] = spec.TLS.Client.SecretName
+		}
+	}
+	if spec.TLS.Cluster.Enabled {
+		overrides[ClusterTLSEnabledKey] = true
+		if spec.TLS.Cluster.SecretName != "" {
+			overrides[ClusterTLSSecretNameKey] = spec.TLS.Cluster.SecretName
+		}
+	}
+	if spec.TLS.Monitoring.Enabled {
+		overrides[MonitoringTLSEnabledKey] = true
+	}
+
+	// leaf nodes
+	generateLeafNodesOverrides(spec.LeafNodes, overrides)
+
+	// gateways
+	generateGatewayOverrides(spec.Gateways, overrides)
+
 	if m.images.NATS != "" {
 		overrides[NatsImageUrl] = m.images.NATS
 	}
@@ -135,3 +199,69 @@ func (m NATSManager) GenerateOverrides(spec *nmapiv1alpha1.NATSSpec, istioEnable
 
 	return overrides, nil
 }
+
+// encryptionCipher returns the name of the given cipher in the NATS server configuration.
+func encryptionCipher(cipher string) string {
+	if cipher == nmapiv1alpha1.EncryptionCipherAES {
+		return "aes"
+	}
+	return "chachapoly"
+}
+
+// generateLeafNodesOverrides adds the overrides for the leaf node listener and the remotes.
+// The remotes are passed to the chart as a list, because the chart renders one block per remote.
+func generateLeafNodesOverrides(leafNodes nmapiv1alpha1.LeafNodes, overrides map[string]any) {
+	if leafNodes.Enabled {
+		overrides[LeafNodesEnabledKey] = true
+		if leafNodes.Port != 0 {
+			overrides[LeafNodesPortKey] = leafNodes.Port
+		}
+		if leafNodes.AuthSecretName != "" {
+			overrides[LeafNodesAuthSecretNameKey] = leafNodes.AuthSecretName
+		}
+		if leafNodes.TLS.Enabled {
+			overrides[LeafNodesTLSEnabledKey] = true
+			if leafNodes.TLS.SecretName != "" {
+				overrides[LeafNodesTLSSecretNameKey] = leafNodes.TLS.SecretName
+			}
+		}
+	}
+
+	if len(leafNodes.Remotes) == 0 {
+		return
+	}
+	remotes := make([]any, 0, len(leafNodes.Remotes))
+	for _, remote := range leafNodes.Remotes {
+		remotes = append(remotes, map[string]any{
+			"urls":                  remote.URLs,
+			"credentialsSecretName": remote.CredentialsSecretName,
+			"tlsSecretName":         remote.TLSSecretName,
+		})
+	}
+	overrides[LeafNodesRemotesKey] = remotes
+}
+
+// generateGatewayOverrides adds the overrides for the gateway of the supercluster.
+// NATS requires the name of the cluster to match the name of the gateway.
+func generateGatewayOverrides(gateways nmapiv1alpha1.Gateways, overrides map[string]any) {
+	if !gateways.Enabled {
+		return
+	}
+	overrides[GatewayEnabledKey] = true
+	overrides[GatewayNameKey] = gateways.Name
+	overrides[ClusterNameKey] = gateways.Name
+	if gateways.Port != 0 {
+		overrides[GatewayPortKey] = gateways.Port
+	}
+	if len(gateways.Remotes) == 0 {
+		return
+	}
+	remotes := make([]any, 0, len(gateways.Remotes))
+	for _, remote := range gateways.Remotes {
+		remotes = append(remotes, map[string]any{
+			"name": remote.Name,
+			"urls": remote.URLs,
+		})
+	}
+	overrides[GatewayRemotesKey] = remotes
+}
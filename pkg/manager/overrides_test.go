@@ -180,6 +180,183 @@ func Test_GenerateOverrides(t *testing.T) {
 				NATSServerConfigReloaderImageUrl: "NATSSrvCfgReloaderImage",
 			},
 		},
+		{
+			name: "should override the TLS listeners when TLS is enabled",
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSEmptySpec(),
+				testutils.WithNATSTLS(nmapiv1alpha1.TLS{
+					Client:     nmapiv1alpha1.TLSListener{Enabled: true, SecretName: "client-tls"},
+					Cluster:    nmapiv1alpha1.TLSListener{Enabled: true},
+					Monitoring: nmapiv1alpha1.MonitoringTLS{Enabled: true},
+				}),
+			),
+			wantOverrides: map[string]any{
+				IstioEnabledKey:                  false,
+				RotatePasswordKey:                false,
+				ClusterSizeKey:                   0,
+				ClusterEnabledKey:                false,
+				FileStorageSizeKey:               "1Gi",
+				MemStorageEnabledKey:             false,
+				DebugEnabledKey:                  false,
+				TraceEnabledKey:                  false,
+				ResourceRequestsCPUKey:           "0",
+				ResourceRequestsMemKey:           "0",
+				ResourceLimitsCPUKey:             "0",
+				ResourceLimitsMemKey:             "0",
+				ClientTLSEnabledKey:              true,
+				ClientTLSSecretNameKey:           "client-tls",
+				ClusterTLSEnabledKey:             true,
+				MonitoringTLSEnabledKey:          true,
+				NatsImageUrl:                     "NATSImage",
+				PrometheusNATSExporterImageUrl:   "PrometheusExporterImage",
+				NATSServerConfigReloaderImageUrl: "NATSSrvCfgReloaderImage",
+			},
+		},
+		{
+			name: "should override the leaf node listener and the remotes",
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSEmptySpec(),
+				testutils.WithNATSLeafNodes(nmapiv1alpha1.LeafNodes{
+					Enabled:        true,
+					Port:           7433,
+					AuthSecretName: "leafnodes-auth",
+					TLS:            nmapiv1alpha1.TLSListener{Enabled: true},
+					Remotes: []nmapiv1alpha1.LeafNodeRemote{
+						{URLs: []string{"tls://edge-1:7422", "tls://edge-2:7422"}, CredentialsSecretName: "edge-creds"},
+						{URLs: []string{"nats://hub:7422"}, TLSSecretName: "hub-tls"},
+					},
+				}),
+			),
+			wantOverrides: map[string]any{
+				IstioEnabledKey:            false,
+				RotatePasswordKey:          false,
+				ClusterSizeKey:             0,
+				ClusterEnabledKey:          false,
+				FileStorageSizeKey:         "1Gi",
+				MemStorageEnabledKey:       false,
+				DebugEnabledKey:            false,
+				TraceEnabledKey:            false,
+				ResourceRequestsCPUKey:     "0",
+				ResourceRequestsMemKey:     "0",
+				ResourceLimitsCPUKey:       "0",
+				ResourceLimitsMemKey:       "0",
+				LeafNodesEnabledKey:        true,
+				LeafNodesPortKey:           7433,
+				LeafNodesAuthSecretNameKey: "leafnodes-auth",
+				LeafNodesTLSEnabledKey:     true,
+				LeafNodesRemotesKey: []any{
+					map[string]any{
+						"urls":                  []string{"tls://edge-1:7422", "tls://edge-2:7422"},
+						"credentialsSecretName": "edge-creds",
+						"tlsSecretName":         "",
+					},
+					map[string]any{
+						"urls":                  []string{"nats://hub:7422"},
+						"credentialsSecretName": "",
+						"tlsSecretName":         "hub-tls",
+					},
+				},
+				NatsImageUrl:                     "NATSImage",
+				PrometheusNATSExporterImageUrl:   "PrometheusExporterImage",
+				NATSServerConfigReloaderImageUrl: "NATSSrvCfgReloaderImage",
+			},
+		},
+		{
+			name: "should override the gateway and the cluster name",
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSEmptySpec(),
+				testutils.WithNATSGateways(nmapiv1alpha1.Gateways{
+					Enabled: true,
+					Name:    "eu",
+					Port:    7522,
+					Remotes: []nmapiv1alpha1.RemoteGateway{
+						{Name: "us", URLs: []string{"nats://nats-us.example.com:7522"}},
+					},
+				}),
+			),
+			wantOverrides: map[string]any{
+				IstioEnabledKey:        false,
+				RotatePasswordKey:      false,
+				ClusterSizeKey:         0,
+				ClusterEnabledKey:      false,
+				FileStorageSizeKey:     "1Gi",
+				MemStorageEnabledKey:   false,
+				DebugEnabledKey:        false,
+				TraceEnabledKey:        false,
+				ResourceRequestsCPUKey: "0",
+				ResourceRequestsMemKey: "0",
+				ResourceLimitsCPUKey:   "0",
+				ResourceLimitsMemKey:   "0",
+				GatewayEnabledKey:      true,
+				GatewayNameKey:         "eu",
+				ClusterNameKey:         "eu",
+				GatewayPortKey:         7522,
+				GatewayRemotesKey: []any{
+					map[string]any{
+						"name": "us",
+						"urls": []string{"nats://nats-us.example.com:7522"},
+					},
+				},
+				NatsImageUrl:                     "NATSImage",
+				PrometheusNATSExporterImageUrl:   "PrometheusExporterImage",
+				NATSServerConfigReloaderImageUrl: "NATSSrvCfgReloaderImage",
+			},
+		},
+		{
+			name: "should override the encryption of the file storage",
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSEmptySpec(),
+				testutils.WithNATSEncryption(nmapiv1alpha1.JetStreamEncryption{
+					Enabled:    true,
+					SecretName: "nats-encryption",
+					Cipher:     nmapiv1alpha1.EncryptionCipherAES,
+				}),
+			),
+			wantOverrides: map[string]any{
+				IstioEnabledKey:                  false,
+				RotatePasswordKey:                false,
+				ClusterSizeKey:                   0,
+				ClusterEnabledKey:                false,
+				FileStorageSizeKey:               "1Gi",
+				EncryptionEnabledKey:             true,
+				EncryptionCipherKey:              "aes",
+				MemStorageEnabledKey:             false,
+				DebugEnabledKey:                  false,
+				TraceEnabledKey:                  false,
+				ResourceRequestsCPUKey:           "0",
+				ResourceRequestsMemKey:           "0",
+				ResourceLimitsCPUKey:             "0",
+				ResourceLimitsMemKey:             "0",
+				NatsImageUrl:                     "NATSImage",
+				PrometheusNATSExporterImageUrl:   "PrometheusExporterImage",
+				NATSServerConfigReloaderImageUrl: "NATSSrvCfgReloaderImage",
+			},
+		},
+		{
+			name: "should override the resolver in JWT authentication mode",
+			givenNATS: testutils.NewNATSCR(
+				testutils.WithNATSEmptySpec(),
+				testutils.WithNATSAuthMode(nmapiv1alpha1.AuthModeJWT),
+			),
+			wantOverrides: map[string]any{
+				IstioEnabledKey:                  false,
+				RotatePasswordKey:                false,
+				ClusterSizeKey:                   0,
+				ClusterEnabledKey:                false,
+				FileStorageSizeKey:               "1Gi",
+				AuthResolverTypeKey:              "full",
+				MemStorageEnabledKey:             false,
+				DebugEnabledKey:                  false,
+				TraceEnabledKey:                  false,
+				ResourceRequestsCPUKey:           "0",
+				ResourceRequestsMemKey:           "0",
+				ResourceLimitsCPUKey:             "0",
+				ResourceLimitsMemKey:             "0",
+				NatsImageUrl:                     "NATSImage",
+				PrometheusNATSExporterImageUrl:   "PrometheusExporterImage",
+				NATSServerConfigReloaderImageUrl: "NATSSrvCfgReloaderImage",
+			},
+		},
 	}
 
 	// run test cases
@@ -240,7 +417,30 @@ func Test_Overrides_Keys(t *testing.T) {
 			"app.kubernetes.io/part-of":    "nats-manager",
 			"control-plane":                "nats-manager",
 		},
-		CommonAnnotationsKey: map[string]any{},
+		CommonAnnotationsKey:       map[string]any{},
+		ClientTLSEnabledKey:        false,
+		ClientTLSSecretNameKey:     "",
+		ClusterTLSEnabledKey:       false,
+		ClusterTLSSecretNameKey:    "",
+		MonitoringTLSEnabledKey:    false,
+		CAFingerprintKey:           "",
+		LeafNodesEnabledKey:        false,
+		LeafNodesPortKey:           float64(7422),
+		LeafNodesAuthSecretNameKey: "",
+		LeafNodesTLSEnabledKey:     false,
+		LeafNodesTLSSecretNameKey:  "",
+		LeafNodesRemotesKey:        []any{},
+		GatewayEnabledKey:          false,
+		GatewayNameKey:             "",
+		GatewayPortKey:             float64(7522),
+		GatewayRemotesKey:          []any{},
+		ClusterNameKey:             "eventing-nats",
+		EncryptionEnabledKey:       false,
+		EncryptionCipherKey:        "",
+		EncryptionKeyVersionKey:    "",
+		EncryptionPreviousKeyKey:   false,
+		FileStorageClaimSizeKey:    "",
+		AuthResolverTypeKey:        "memory",
 	}
 
 	// run test cases
@@ -1,6 +1,9 @@
 package metrics
 
 import (
+	"sync"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -16,6 +19,56 @@ const (
 	clusterSizeMetricKey = metricNamePrefix + "cr_nats_nodes_count"
 	// clusterSizeMetricHelp help text for the cluster size metric.
 	clusterSizeMetricHelp = "The cluster size configured in the NATS CR."
+
+	// certificateExpiryMetricKey name of the certificate expiry metric.
+	certificateExpiryMetricKey = metricNamePrefix + "certificate_expiry_timestamp_seconds"
+	// certificateExpiryMetricHelp help text for the certificate expiry metric.
+	certificateExpiryMetricHelp = "The expiry time of the TLS certificates used by NATS as unix timestamp."
+	// certificateSecretLabel label of the certificate expiry metric with the name of the Secret.
+	certificateSecretLabel = "secret"
+
+	// backupAgeMetricKey name of the backup age metric.
+	backupAgeMetricKey = metricNamePrefix + "backup_age_seconds"
+	// backupAgeMetricHelp help text for the backup age metric.
+	backupAgeMetricHelp = "The time since the last successful backup of the NATSBackupSchedule completed."
+
+	// backupSizeMetricKey name of the backup size metric.
+	backupSizeMetricKey = metricNamePrefix + "backup_size_bytes"
+	// backupSizeMetricHelp help text for the backup size metric.
+	backupSizeMetricHelp = "The size of the last successful backup of the NATSBackupSchedule."
+
+	// backupNamespaceLabel label of the backup metrics with the namespace of the NATSBackupSchedule.
+	backupNamespaceLabel = "namespace"
+	// backupScheduleLabel label of the backup metrics with the name of the NATSBackupSchedule.
+	backupScheduleLabel = "backup_schedule"
+
+	// jetStreamUsedMetricKey name of the JetStream usage metric.
+	jetStreamUsedMetricKey = metricNamePrefix + "jetstream_used_bytes"
+	// jetStreamUsedMetricHelp help text for the JetStream usage metric.
+	jetStreamUsedMetricHelp = "The bytes of the JetStream storage used by the account of the NATS manager."
+
+	// jetStreamLimitMetricKey name of the JetStream limit metric.
+	jetStreamLimitMetricKey = metricNamePrefix + "jetstream_limit_bytes"
+	// jetStreamLimitMetricHelp help text for the JetStream limit metric.
+	jetStreamLimitMetricHelp = "The bytes of the JetStream storage available to the account of the NATS manager."
+
+	// jetStreamStorageLabel label of the JetStream usage metrics with the storage type, i.e. memory or file.
+	jetStreamStorageLabel = "storage"
+
+	// jetStreamStreamsMetricKey name of the JetStream streams metric.
+	jetStreamStreamsMetricKey = metricNamePrefix + "jetstream_streams_count"
+	// jetStreamStreamsMetricHelp help text for the JetStream streams metric.
+	jetStreamStreamsMetricHelp = "The number of JetStream streams of the account of the NATS manager."
+
+	// jetStreamConsumersMetricKey name of the JetStream consumers metric.
+	jetStreamConsumersMetricKey = metricNamePrefix + "jetstream_consumers_count"
+	// jetStreamConsumersMetricHelp help text for the JetStream consumers metric.
+	jetStreamConsumersMetricHelp = "The number of JetStream consumers of the account of the NATS manager."
+
+	// natsNamespaceLabel label of all metrics with the namespace of the NATS CR.
+	natsNamespaceLabel = "nats_namespace"
+	// natsNameLabel label of all metrics with the name of the NATS CR.
+	natsNameLabel = "nats_name"
 )
 
 // Perform a compile time check.
@@ -24,18 +77,36 @@ var _ Collector = &PrometheusCollector{}
 //go:generate go run github.com/vektra/mockery/v2 --name=Collector --outpkg=mocks --case=underscore
 type Collector interface {
 	RegisterMetrics()
-	RecordAvailabilityZonesUsedMetric(int)
-	RecordClusterSizeMetric(int)
-	ResetAvailabilityZonesUsedMetric()
-	ResetClusterSizeMetric()
-	GetAvailabilityZonesUsedMetric() (prometheus.Gauge, error)
-	GetClusterSizeMetric() (prometheus.Gauge, error)
+	RecordAvailabilityZonesUsedMetric(namespace, name string, availabilityZonesUsed int)
+	RecordClusterSizeMetric(namespace, name string, clusterSize int)
+	RecordCertificateExpiryMetric(namespace, name, secretName string, notAfter time.Time)
+	ResetAvailabilityZonesUsedMetric(namespace, name string)
+	ResetClusterSizeMetric(namespace, name string)
+	ResetCertificateExpiryMetric(namespace, name string)
+	GetAvailabilityZonesUsedMetric(namespace, name string) (prometheus.Gauge, error)
+	GetClusterSizeMetric(namespace, name string) (prometheus.Gauge, error)
+	GetCertificateExpiryMetric(namespace, name, secretName string) (prometheus.Gauge, error)
+	RecordBackupMetric(namespace, name string, completionTime time.Time, size int64)
+	ResetBackupMetric(namespace, name string)
+	GetBackupSizeMetric(namespace, name string) (prometheus.Gauge, error)
+	RecordJetStreamStorageMetric(namespace, name, storage string, used, limit int64)
+	RecordJetStreamAssetsMetric(namespace, name string, streams, consumers int)
+	ResetJetStreamUsageMetric(namespace, name string)
+	GetJetStreamUsedMetric(namespace, name, storage string) (prometheus.Gauge, error)
+	GetJetStreamStreamsMetric(namespace, name string) (prometheus.Gauge, error)
 }
 
 // PrometheusCollector implements the prometheus.Collector interface.
 type PrometheusCollector struct {
 	availabilityZonesUsed *prometheus.GaugeVec
 	clusterSize           *prometheus.GaugeVec
+	certificateExpiry     *prometheus.GaugeVec
+	backupAge             *backupAgeCollector
+	backupSize            *prometheus.GaugeVec
+	jetStreamUsed         *prometheus.GaugeVec
+	jetStreamLimit        *prometheus.GaugeVec
+	jetStreamStreams      *prometheus.GaugeVec
+	jetStreamConsumers    *prometheus.GaugeVec
 }
 
 // NewPrometheusCollector a new instance of Collector.
@@ -47,7 +118,7 @@ func NewPrometheusCollector() Collector {
 				Name: availabilityZonesUsedMetricKey,
 				Help: availabilityZonesUsedHelp,
 			},
-			nil,
+			[]string{natsNamespaceLabel, natsNameLabel},
 		),
 		//nolint:promlinter // This is a count which can go up or down.
 		clusterSize: prometheus.NewGaugeVec(
@@ -55,7 +126,52 @@ func NewPrometheusCollector() Collector {
 				Name: clusterSizeMetricKey,
 				Help: clusterSizeMetricHelp,
 			},
-			nil,
+			[]string{natsNamespaceLabel, natsNameLabel},
+		),
+		certificateExpiry: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: certificateExpiryMetricKey,
+				Help: certificateExpiryMetricHelp,
+			},
+			[]string{natsNamespaceLabel, natsNameLabel, certificateSecretLabel},
+		),
+		backupAge: newBackupAgeCollector(),
+		backupSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: backupSizeMetricKey,
+				Help: backupSizeMetricHelp,
+			},
+			[]string{backupNamespaceLabel, backupScheduleLabel},
+		),
+		jetStreamUsed: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: jetStreamUsedMetricKey,
+				Help: jetStreamUsedMetricHelp,
+			},
+			[]string{natsNamespaceLabel, natsNameLabel, jetStreamStorageLabel},
+		),
+		jetStreamLimit: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: jetStreamLimitMetricKey,
+				Help: jetStreamLimitMetricHelp,
+			},
+			[]string{natsNamespaceLabel, natsNameLabel, jetStreamStorageLabel},
+		),
+		//nolint:promlinter // This is a count which can go up or down.
+		jetStreamStreams: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: jetStreamStreamsMetricKey,
+				Help: jetStreamStreamsMetricHelp,
+			},
+			[]string{natsNamespaceLabel, natsNameLabel},
+		),
+		//nolint:promlinter // This is a count which can go up or down.
+		jetStreamConsumers: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: jetStreamConsumersMetricKey,
+				Help: jetStreamConsumersMetricHelp,
+			},
+			[]string{natsNamespaceLabel, natsNameLabel},
 		),
 	}
 }
@@ -64,40 +180,172 @@ func NewPrometheusCollector() Collector {
 func (p *PrometheusCollector) Describe(ch chan<- *prometheus.Desc) {
 	p.availabilityZonesUsed.Describe(ch)
 	p.clusterSize.Describe(ch)
+	p.certificateExpiry.Describe(ch)
+	p.backupAge.Describe(ch)
+	p.backupSize.Describe(ch)
+	p.jetStreamUsed.Describe(ch)
+	p.jetStreamLimit.Describe(ch)
+	p.jetStreamStreams.Describe(ch)
+	p.jetStreamConsumers.Describe(ch)
 }
 
 // Collect implements the prometheus.Collector interface Collect method.
 func (p *PrometheusCollector) Collect(ch chan<- prometheus.Metric) {
 	p.availabilityZonesUsed.Collect(ch)
 	p.clusterSize.Collect(ch)
+	p.certificateExpiry.Collect(ch)
+	p.backupAge.Collect(ch)
+	p.backupSize.Collect(ch)
+	p.jetStreamUsed.Collect(ch)
+	p.jetStreamLimit.Collect(ch)
+	p.jetStreamStreams.Collect(ch)
+	p.jetStreamConsumers.Collect(ch)
 }
 
 // RegisterMetrics registers the metrics.
 func (p *PrometheusCollector) RegisterMetrics() {
 	metrics.Registry.MustRegister(p.availabilityZonesUsed)
 	metrics.Registry.MustRegister(p.clusterSize)
+	metrics.Registry.MustRegister(p.certificateExpiry)
+	metrics.Registry.MustRegister(p.backupAge)
+	metrics.Registry.MustRegister(p.backupSize)
+	metrics.Registry.MustRegister(p.jetStreamUsed)
+	metrics.Registry.MustRegister(p.jetStreamLimit)
+	metrics.Registry.MustRegister(p.jetStreamStreams)
+	metrics.Registry.MustRegister(p.jetStreamConsumers)
+}
+
+// RecordAvailabilityZonesUsedMetric records the number of availability zones used by the Pods of the NATS CR.
+func (p *PrometheusCollector) RecordAvailabilityZonesUsedMetric(namespace, name string, availabilityZonesUsed int) {
+	p.availabilityZonesUsed.WithLabelValues(namespace, name).Set(float64(availabilityZonesUsed))
+}
+
+// RecordClusterSizeMetric records the cluster size configured in the NATS CR.
+func (p *PrometheusCollector) RecordClusterSizeMetric(namespace, name string, clusterSize int) {
+	p.clusterSize.WithLabelValues(namespace, name).Set(float64(clusterSize))
 }
 
-func (p *PrometheusCollector) RecordAvailabilityZonesUsedMetric(availabilityZonesUsed int) {
-	p.availabilityZonesUsed.WithLabelValues().Set(float64(availabilityZonesUsed))
+// RecordCertificateExpiryMetric records when the certificate in the given Secret of the NATS CR expires.
+func (p *PrometheusCollector) RecordCertificateExpiryMetric(namespace, name, secretName string, notAfter time.Time) {
+	p.certificateExpiry.WithLabelValues(namespace, name, secretName).Set(float64(notAfter.Unix()))
 }
 
-func (p *PrometheusCollector) RecordClusterSizeMetric(clusterSize int) {
-	p.clusterSize.WithLabelValues().Set(float64(clusterSize))
+// ResetAvailabilityZonesUsedMetric removes the availability zones metric of the NATS CR.
+func (p *PrometheusCollector) ResetAvailabilityZonesUsedMetric(namespace, name string) {
+	p.availabilityZonesUsed.DeletePartialMatch(natsLabels(namespace, name))
 }
 
-func (p *PrometheusCollector) ResetAvailabilityZonesUsedMetric() {
-	p.availabilityZonesUsed.Reset()
+// ResetClusterSizeMetric removes the cluster size metric of the NATS CR.
+func (p *PrometheusCollector) ResetClusterSizeMetric(namespace, name string) {
+	p.clusterSize.DeletePartialMatch(natsLabels(namespace, name))
 }
 
-func (p *PrometheusCollector) ResetClusterSizeMetric() {
-	p.clusterSize.Reset()
+// ResetCertificateExpiryMetric removes the certificate expiry metrics of all Secrets of the NATS CR.
+func (p *PrometheusCollector) ResetCertificateExpiryMetric(namespace, name string) {
+	p.certificateExpiry.DeletePartialMatch(natsLabels(namespace, name))
 }
 
-func (p *PrometheusCollector) GetAvailabilityZonesUsedMetric() (prometheus.Gauge, error) {
-	return p.availabilityZonesUsed.GetMetricWithLabelValues()
+func (p *PrometheusCollector) GetAvailabilityZonesUsedMetric(namespace, name string) (prometheus.Gauge, error) {
+	return p.availabilityZonesUsed.GetMetricWithLabelValues(namespace, name)
+}
+
+func (p *PrometheusCollector) GetClusterSizeMetric(namespace, name string) (prometheus.Gauge, error) {
+	return p.clusterSize.GetMetricWithLabelValues(namespace, name)
+}
+
+func (p *PrometheusCollector) GetCertificateExpiryMetric(namespace, name, secretName string) (prometheus.Gauge, error) {
+	return p.certificateExpiry.GetMetricWithLabelValues(namespace, name, secretName)
+}
+
+// RecordBackupMetric records when the last successful backup of the NATSBackupSchedule completed and its size.
+func (p *PrometheusCollector) RecordBackupMetric(namespace, name string, completionTime time.Time, size int64) {
+	p.backupAge.set(namespace, name, completionTime)
+	p.backupSize.WithLabelValues(namespace, name).Set(float64(size))
+}
+
+// ResetBackupMetric removes the backup metrics of the NATSBackupSchedule.
+func (p *PrometheusCollector) ResetBackupMetric(namespace, name string) {
+	p.backupAge.delete(namespace, name)
+	p.backupSize.DeleteLabelValues(namespace, name)
+}
+
+func (p *PrometheusCollector) GetBackupSizeMetric(namespace, name string) (prometheus.Gauge, error) {
+	return p.backupSize.GetMetricWithLabelValues(namespace, name)
+}
+
+// RecordJetStreamStorageMetric records the used and the available bytes of the given JetStream storage type.
+func (p *PrometheusCollector) RecordJetStreamStorageMetric(namespace, name, storage string, used, limit int64) {
+	p.jetStreamUsed.WithLabelValues(namespace, name, storage).Set(float64(used))
+	p.jetStreamLimit.WithLabelValues(namespace, name, storage).Set(float64(limit))
+}
+
+// RecordJetStreamAssetsMetric records the number of JetStream streams and consumers.
+func (p *PrometheusCollector) RecordJetStreamAssetsMetric(namespace, name string, streams, consumers int) {
+	p.jetStreamStreams.WithLabelValues(namespace, name).Set(float64(streams))
+	p.jetStreamConsumers.WithLabelValues(namespace, name).Set(float64(consumers))
+}
+
+// ResetJetStreamUsageMetric removes the JetStream usage metrics of the NATS CR.
+func (p *PrometheusCollector) ResetJetStreamUsageMetric(namespace, name string) {
+	p.jetStreamUsed.DeletePartialMatch(natsLabels(namespace, name))
+	p.jetStreamLimit.DeletePartialMatch(natsLabels(namespace, name))
+	p.jetStreamStreams.DeletePartialMatch(natsLabels(namespace, name))
+	p.jetStreamConsumers.DeletePartialMatch(natsLabels(namespace, name))
+}
+
+func (p *PrometheusCollector) GetJetStreamUsedMetric(namespace, name, storage string) (prometheus.Gauge, error) {
+	return p.jetStreamUsed.GetMetricWithLabelValues(namespace, name, storage)
+}
+
+func (p *PrometheusCollector) GetJetStreamStreamsMetric(namespace, name string) (prometheus.Gauge, error) {
+	return p.jetStreamStreams.GetMetricWithLabelValues(namespace, name)
+}
+
+// backupAgeCollector computes the age of the last successful backups when the metrics are collected,
+// so that the age keeps growing while no backup completes.
+type backupAgeCollector struct {
+	desc            *prometheus.Desc
+	mutex           sync.Mutex
+	completionTimes map[[2]string]time.Time
+	now             func() time.Time
+}
+
+func newBackupAgeCollector() *backupAgeCollector {
+	return &backupAgeCollector{
+		desc: prometheus.NewDesc(backupAgeMetricKey, backupAgeMetricHelp,
+			[]string{backupNamespaceLabel, backupScheduleLabel}, nil),
+		completionTimes: map[[2]string]time.Time{},
+		now:             time.Now,
+	}
+}
+
+func (c *backupAgeCollector) set(namespace, name string, completionTime time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.completionTimes[[2]string{namespace, name}] = completionTime
+}
+
+func (c *backupAgeCollector) delete(namespace, name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.completionTimes, [2]string{namespace, name})
+}
+
+func (c *backupAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *backupAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	for key, completionTime := range c.completionTimes {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue,
+			now.Sub(completionTime).Seconds(), key[0], key[1])
+	}
 }
 
-func (p *PrometheusCollector) GetClusterSizeMetric() (prometheus.Gauge, error) {
-	return p.clusterSize.GetMetricWithLabelValues()
+// natsLabels returns the labels which identify the metrics of the NATS CR.
+func natsLabels(namespace, name string) prometheus.Labels {
+	return prometheus.Labels{natsNamespaceLabel: namespace, natsNameLabel: name}
 }
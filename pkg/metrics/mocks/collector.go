@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	time "time"
+
 	prometheus "github.com/prometheus/client_golang/prometheus"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,9 +22,9 @@ func (_m *Collector) EXPECT() *Collector_Expecter {
 	return &Collector_Expecter{mock: &_m.Mock}
 }
 
-// GetAvailabilityZonesUsedMetric provides a mock function with no fields
-func (_m *Collector) GetAvailabilityZonesUsedMetric() (prometheus.Gauge, error) {
-	ret := _m.Called()
+// GetAvailabilityZonesUsedMetric provides a mock function with given fields: namespace, name
+func (_m *Collector) GetAvailabilityZonesUsedMetric(namespace string, name string) (prometheus.Gauge, error) {
+	ret := _m.Called(namespace, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAvailabilityZonesUsedMetric")
@@ -30,19 +32,19 @@ func (_m *Collector) GetAvailabilityZonesUsedMetric() (prometheus.Gauge, error)
 
 	var r0 prometheus.Gauge
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (prometheus.Gauge, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(string, string) (prometheus.Gauge, error)); ok {
+		return rf(namespace, name)
 	}
-	if rf, ok := ret.Get(0).(func() prometheus.Gauge); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string, string) prometheus.Gauge); ok {
+		r0 = rf(namespace, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(prometheus.Gauge)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(namespace, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,13 +58,15 @@ type Collector_GetAvailabilityZonesUsedMetric_Call struct {
 }
 
 // GetAvailabilityZonesUsedMetric is a helper method to define mock.On call
-func (_e *Collector_Expecter) GetAvailabilityZonesUsedMetric() *Collector_GetAvailabilityZonesUsedMetric_Call {
-	return &Collector_GetAvailabilityZonesUsedMetric_Call{Call: _e.mock.On("GetAvailabilityZonesUsedMetric")}
+//   - namespace string
+//   - name string
+func (_e *Collector_Expecter) GetAvailabilityZonesUsedMetric(namespace interface{}, name interface{}) *Collector_GetAvailabilityZonesUsedMetric_Call {
+	return &Collector_GetAvailabilityZonesUsedMetric_Call{Call: _e.mock.On("GetAvailabilityZonesUsedMetric", namespace, name)}
 }
 
-func (_c *Collector_GetAvailabilityZonesUsedMetric_Call) Run(run func()) *Collector_GetAvailabilityZonesUsedMetric_Call {
+func (_c *Collector_GetAvailabilityZonesUsedMetric_Call) Run(run func(namespace string, name string)) *Collector_GetAvailabilityZonesUsedMetric_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
@@ -72,14 +76,133 @@ func (_c *Collector_GetAvailabilityZonesUsedMetric_Call) Return(_a0 prometheus.G
 	return _c
 }
 
-func (_c *Collector_GetAvailabilityZonesUsedMetric_Call) RunAndReturn(run func() (prometheus.Gauge, error)) *Collector_GetAvailabilityZonesUsedMetric_Call {
+func (_c *Collector_GetAvailabilityZonesUsedMetric_Call) RunAndReturn(run func(string, string) (prometheus.Gauge, error)) *Collector_GetAvailabilityZonesUsedMetric_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBackupSizeMetric provides a mock function with given fields: namespace, name
+func (_m *Collector) GetBackupSizeMetric(namespace string, name string) (prometheus.Gauge, error) {
+	ret := _m.Called(namespace, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBackupSizeMetric")
+	}
+
+	var r0 prometheus.Gauge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (prometheus.Gauge, error)); ok {
+		return rf(namespace, name)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) prometheus.Gauge); ok {
+		r0 = rf(namespace, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(prometheus.Gauge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(namespace, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Collector_GetBackupSizeMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBackupSizeMetric'
+type Collector_GetBackupSizeMetric_Call struct {
+	*mock.Call
+}
+
+// GetBackupSizeMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+func (_e *Collector_Expecter) GetBackupSizeMetric(namespace interface{}, name interface{}) *Collector_GetBackupSizeMetric_Call {
+	return &Collector_GetBackupSizeMetric_Call{Call: _e.mock.On("GetBackupSizeMetric", namespace, name)}
+}
+
+func (_c *Collector_GetBackupSizeMetric_Call) Run(run func(namespace string, name string)) *Collector_GetBackupSizeMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Collector_GetBackupSizeMetric_Call) Return(_a0 prometheus.Gauge, _a1 error) *Collector_GetBackupSizeMetric_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Collector_GetBackupSizeMetric_Call) RunAndReturn(run func(string, string) (prometheus.Gauge, error)) *Collector_GetBackupSizeMetric_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCertificateExpiryMetric provides a mock function with given fields: namespace, name, secretName
+func (_m *Collector) GetCertificateExpiryMetric(namespace string, name string, secretName string) (prometheus.Gauge, error) {
+	ret := _m.Called(namespace, name, secretName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateExpiryMetric")
+	}
+
+	var r0 prometheus.Gauge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (prometheus.Gauge, error)); ok {
+		return rf(namespace, name, secretName)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) prometheus.Gauge); ok {
+		r0 = rf(namespace, name, secretName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(prometheus.Gauge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(namespace, name, secretName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Collector_GetCertificateExpiryMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateExpiryMetric'
+type Collector_GetCertificateExpiryMetric_Call struct {
+	*mock.Call
+}
+
+// GetCertificateExpiryMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+//   - secretName string
+func (_e *Collector_Expecter) GetCertificateExpiryMetric(namespace interface{}, name interface{}, secretName interface{}) *Collector_GetCertificateExpiryMetric_Call {
+	return &Collector_GetCertificateExpiryMetric_Call{Call: _e.mock.On("GetCertificateExpiryMetric", namespace, name, secretName)}
+}
+
+func (_c *Collector_GetCertificateExpiryMetric_Call) Run(run func(namespace string, name string, secretName string)) *Collector_GetCertificateExpiryMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Collector_GetCertificateExpiryMetric_Call) Return(_a0 prometheus.Gauge, _a1 error) *Collector_GetCertificateExpiryMetric_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Collector_GetCertificateExpiryMetric_Call) RunAndReturn(run func(string, string, string) (prometheus.Gauge, error)) *Collector_GetCertificateExpiryMetric_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetClusterSizeMetric provides a mock function with no fields
-func (_m *Collector) GetClusterSizeMetric() (prometheus.Gauge, error) {
-	ret := _m.Called()
+// GetClusterSizeMetric provides a mock function with given fields: namespace, name
+func (_m *Collector) GetClusterSizeMetric(namespace string, name string) (prometheus.Gauge, error) {
+	ret := _m.Called(namespace, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetClusterSizeMetric")
@@ -87,19 +210,19 @@ func (_m *Collector) GetClusterSizeMetric() (prometheus.Gauge, error) {
 
 	var r0 prometheus.Gauge
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (prometheus.Gauge, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(string, string) (prometheus.Gauge, error)); ok {
+		return rf(namespace, name)
 	}
-	if rf, ok := ret.Get(0).(func() prometheus.Gauge); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string, string) prometheus.Gauge); ok {
+		r0 = rf(namespace, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(prometheus.Gauge)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(namespace, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -113,13 +236,15 @@ type Collector_GetClusterSizeMetric_Call struct {
 }
 
 // GetClusterSizeMetric is a helper method to define mock.On call
-func (_e *Collector_Expecter) GetClusterSizeMetric() *Collector_GetClusterSizeMetric_Call {
-	return &Collector_GetClusterSizeMetric_Call{Call: _e.mock.On("GetClusterSizeMetric")}
+//   - namespace string
+//   - name string
+func (_e *Collector_Expecter) GetClusterSizeMetric(namespace interface{}, name interface{}) *Collector_GetClusterSizeMetric_Call {
+	return &Collector_GetClusterSizeMetric_Call{Call: _e.mock.On("GetClusterSizeMetric", namespace, name)}
 }
 
-func (_c *Collector_GetClusterSizeMetric_Call) Run(run func()) *Collector_GetClusterSizeMetric_Call {
+func (_c *Collector_GetClusterSizeMetric_Call) Run(run func(namespace string, name string)) *Collector_GetClusterSizeMetric_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
@@ -129,14 +254,133 @@ func (_c *Collector_GetClusterSizeMetric_Call) Return(_a0 prometheus.Gauge, _a1
 	return _c
 }
 
-func (_c *Collector_GetClusterSizeMetric_Call) RunAndReturn(run func() (prometheus.Gauge, error)) *Collector_GetClusterSizeMetric_Call {
+func (_c *Collector_GetClusterSizeMetric_Call) RunAndReturn(run func(string, string) (prometheus.Gauge, error)) *Collector_GetClusterSizeMetric_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RecordAvailabilityZonesUsedMetric provides a mock function with given fields: _a0
-func (_m *Collector) RecordAvailabilityZonesUsedMetric(_a0 int) {
-	_m.Called(_a0)
+// GetJetStreamStreamsMetric provides a mock function with given fields: namespace, name
+func (_m *Collector) GetJetStreamStreamsMetric(namespace string, name string) (prometheus.Gauge, error) {
+	ret := _m.Called(namespace, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJetStreamStreamsMetric")
+	}
+
+	var r0 prometheus.Gauge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (prometheus.Gauge, error)); ok {
+		return rf(namespace, name)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) prometheus.Gauge); ok {
+		r0 = rf(namespace, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(prometheus.Gauge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(namespace, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Collector_GetJetStreamStreamsMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJetStreamStreamsMetric'
+type Collector_GetJetStreamStreamsMetric_Call struct {
+	*mock.Call
+}
+
+// GetJetStreamStreamsMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+func (_e *Collector_Expecter) GetJetStreamStreamsMetric(namespace interface{}, name interface{}) *Collector_GetJetStreamStreamsMetric_Call {
+	return &Collector_GetJetStreamStreamsMetric_Call{Call: _e.mock.On("GetJetStreamStreamsMetric", namespace, name)}
+}
+
+func (_c *Collector_GetJetStreamStreamsMetric_Call) Run(run func(namespace string, name string)) *Collector_GetJetStreamStreamsMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Collector_GetJetStreamStreamsMetric_Call) Return(_a0 prometheus.Gauge, _a1 error) *Collector_GetJetStreamStreamsMetric_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Collector_GetJetStreamStreamsMetric_Call) RunAndReturn(run func(string, string) (prometheus.Gauge, error)) *Collector_GetJetStreamStreamsMetric_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJetStreamUsedMetric provides a mock function with given fields: namespace, name, storage
+func (_m *Collector) GetJetStreamUsedMetric(namespace string, name string, storage string) (prometheus.Gauge, error) {
+	ret := _m.Called(namespace, name, storage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJetStreamUsedMetric")
+	}
+
+	var r0 prometheus.Gauge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (prometheus.Gauge, error)); ok {
+		return rf(namespace, name, storage)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) prometheus.Gauge); ok {
+		r0 = rf(namespace, name, storage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(prometheus.Gauge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(namespace, name, storage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Collector_GetJetStreamUsedMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJetStreamUsedMetric'
+type Collector_GetJetStreamUsedMetric_Call struct {
+	*mock.Call
+}
+
+// GetJetStreamUsedMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+//   - storage string
+func (_e *Collector_Expecter) GetJetStreamUsedMetric(namespace interface{}, name interface{}, storage interface{}) *Collector_GetJetStreamUsedMetric_Call {
+	return &Collector_GetJetStreamUsedMetric_Call{Call: _e.mock.On("GetJetStreamUsedMetric", namespace, name, storage)}
+}
+
+func (_c *Collector_GetJetStreamUsedMetric_Call) Run(run func(namespace string, name string, storage string)) *Collector_GetJetStreamUsedMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Collector_GetJetStreamUsedMetric_Call) Return(_a0 prometheus.Gauge, _a1 error) *Collector_GetJetStreamUsedMetric_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Collector_GetJetStreamUsedMetric_Call) RunAndReturn(run func(string, string, string) (prometheus.Gauge, error)) *Collector_GetJetStreamUsedMetric_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordAvailabilityZonesUsedMetric provides a mock function with given fields: namespace, name, availabilityZonesUsed
+func (_m *Collector) RecordAvailabilityZonesUsedMetric(namespace string, name string, availabilityZonesUsed int) {
+	_m.Called(namespace, name, availabilityZonesUsed)
 }
 
 // Collector_RecordAvailabilityZonesUsedMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAvailabilityZonesUsedMetric'
@@ -145,14 +389,16 @@ type Collector_RecordAvailabilityZonesUsedMetric_Call struct {
 }
 
 // RecordAvailabilityZonesUsedMetric is a helper method to define mock.On call
-//   - _a0 int
-func (_e *Collector_Expecter) RecordAvailabilityZonesUsedMetric(_a0 interface{}) *Collector_RecordAvailabilityZonesUsedMetric_Call {
-	return &Collector_RecordAvailabilityZonesUsedMetric_Call{Call: _e.mock.On("RecordAvailabilityZonesUsedMetric", _a0)}
+//   - namespace string
+//   - name string
+//   - availabilityZonesUsed int
+func (_e *Collector_Expecter) RecordAvailabilityZonesUsedMetric(namespace interface{}, name interface{}, availabilityZonesUsed interface{}) *Collector_RecordAvailabilityZonesUsedMetric_Call {
+	return &Collector_RecordAvailabilityZonesUsedMetric_Call{Call: _e.mock.On("RecordAvailabilityZonesUsedMetric", namespace, name, availabilityZonesUsed)}
 }
 
-func (_c *Collector_RecordAvailabilityZonesUsedMetric_Call) Run(run func(_a0 int)) *Collector_RecordAvailabilityZonesUsedMetric_Call {
+func (_c *Collector_RecordAvailabilityZonesUsedMetric_Call) Run(run func(namespace string, name string, availabilityZonesUsed int)) *Collector_RecordAvailabilityZonesUsedMetric_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(string), args[1].(string), args[2].(int))
 	})
 	return _c
 }
@@ -162,14 +408,86 @@ func (_c *Collector_RecordAvailabilityZonesUsedMetric_Call) Return() *Collector_
 	return _c
 }
 
-func (_c *Collector_RecordAvailabilityZonesUsedMetric_Call) RunAndReturn(run func(int)) *Collector_RecordAvailabilityZonesUsedMetric_Call {
+func (_c *Collector_RecordAvailabilityZonesUsedMetric_Call) RunAndReturn(run func(string, string, int)) *Collector_RecordAvailabilityZonesUsedMetric_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RecordBackupMetric provides a mock function with given fields: namespace, name, completionTime, size
+func (_m *Collector) RecordBackupMetric(namespace string, name string, completionTime time.Time, size int64) {
+	_m.Called(namespace, name, completionTime, size)
+}
+
+// Collector_RecordBackupMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordBackupMetric'
+type Collector_RecordBackupMetric_Call struct {
+	*mock.Call
+}
+
+// RecordBackupMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+//   - completionTime time.Time
+//   - size int64
+func (_e *Collector_Expecter) RecordBackupMetric(namespace interface{}, name interface{}, completionTime interface{}, size interface{}) *Collector_RecordBackupMetric_Call {
+	return &Collector_RecordBackupMetric_Call{Call: _e.mock.On("RecordBackupMetric", namespace, name, completionTime, size)}
+}
+
+func (_c *Collector_RecordBackupMetric_Call) Run(run func(namespace string, name string, completionTime time.Time, size int64)) *Collector_RecordBackupMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(time.Time), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *Collector_RecordBackupMetric_Call) Return() *Collector_RecordBackupMetric_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Collector_RecordBackupMetric_Call) RunAndReturn(run func(string, string, time.Time, int64)) *Collector_RecordBackupMetric_Call {
 	_c.Run(run)
 	return _c
 }
 
-// RecordClusterSizeMetric provides a mock function with given fields: _a0
-func (_m *Collector) RecordClusterSizeMetric(_a0 int) {
-	_m.Called(_a0)
+// RecordCertificateExpiryMetric provides a mock function with given fields: namespace, name, secretName, notAfter
+func (_m *Collector) RecordCertificateExpiryMetric(namespace string, name string, secretName string, notAfter time.Time) {
+	_m.Called(namespace, name, secretName, notAfter)
+}
+
+// Collector_RecordCertificateExpiryMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordCertificateExpiryMetric'
+type Collector_RecordCertificateExpiryMetric_Call struct {
+	*mock.Call
+}
+
+// RecordCertificateExpiryMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+//   - secretName string
+//   - notAfter time.Time
+func (_e *Collector_Expecter) RecordCertificateExpiryMetric(namespace interface{}, name interface{}, secretName interface{}, notAfter interface{}) *Collector_RecordCertificateExpiryMetric_Call {
+	return &Collector_RecordCertificateExpiryMetric_Call{Call: _e.mock.On("RecordCertificateExpiryMetric", namespace, name, secretName, notAfter)}
+}
+
+func (_c *Collector_RecordCertificateExpiryMetric_Call) Run(run func(namespace string, name string, secretName string, notAfter time.Time)) *Collector_RecordCertificateExpiryMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Collector_RecordCertificateExpiryMetric_Call) Return() *Collector_RecordCertificateExpiryMetric_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Collector_RecordCertificateExpiryMetric_Call) RunAndReturn(run func(string, string, string, time.Time)) *Collector_RecordCertificateExpiryMetric_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RecordClusterSizeMetric provides a mock function with given fields: namespace, name, clusterSize
+func (_m *Collector) RecordClusterSizeMetric(namespace string, name string, clusterSize int) {
+	_m.Called(namespace, name, clusterSize)
 }
 
 // Collector_RecordClusterSizeMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordClusterSizeMetric'
@@ -178,14 +496,16 @@ type Collector_RecordClusterSizeMetric_Call struct {
 }
 
 // RecordClusterSizeMetric is a helper method to define mock.On call
-//   - _a0 int
-func (_e *Collector_Expecter) RecordClusterSizeMetric(_a0 interface{}) *Collector_RecordClusterSizeMetric_Call {
-	return &Collector_RecordClusterSizeMetric_Call{Call: _e.mock.On("RecordClusterSizeMetric", _a0)}
+//   - namespace string
+//   - name string
+//   - clusterSize int
+func (_e *Collector_Expecter) RecordClusterSizeMetric(namespace interface{}, name interface{}, clusterSize interface{}) *Collector_RecordClusterSizeMetric_Call {
+	return &Collector_RecordClusterSizeMetric_Call{Call: _e.mock.On("RecordClusterSizeMetric", namespace, name, clusterSize)}
 }
 
-func (_c *Collector_RecordClusterSizeMetric_Call) Run(run func(_a0 int)) *Collector_RecordClusterSizeMetric_Call {
+func (_c *Collector_RecordClusterSizeMetric_Call) Run(run func(namespace string, name string, clusterSize int)) *Collector_RecordClusterSizeMetric_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(string), args[1].(string), args[2].(int))
 	})
 	return _c
 }
@@ -195,7 +515,80 @@ func (_c *Collector_RecordClusterSizeMetric_Call) Return() *Collector_RecordClus
 	return _c
 }
 
-func (_c *Collector_RecordClusterSizeMetric_Call) RunAndReturn(run func(int)) *Collector_RecordClusterSizeMetric_Call {
+func (_c *Collector_RecordClusterSizeMetric_Call) RunAndReturn(run func(string, string, int)) *Collector_RecordClusterSizeMetric_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RecordJetStreamAssetsMetric provides a mock function with given fields: namespace, name, streams, consumers
+func (_m *Collector) RecordJetStreamAssetsMetric(namespace string, name string, streams int, consumers int) {
+	_m.Called(namespace, name, streams, consumers)
+}
+
+// Collector_RecordJetStreamAssetsMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordJetStreamAssetsMetric'
+type Collector_RecordJetStreamAssetsMetric_Call struct {
+	*mock.Call
+}
+
+// RecordJetStreamAssetsMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+//   - streams int
+//   - consumers int
+func (_e *Collector_Expecter) RecordJetStreamAssetsMetric(namespace interface{}, name interface{}, streams interface{}, consumers interface{}) *Collector_RecordJetStreamAssetsMetric_Call {
+	return &Collector_RecordJetStreamAssetsMetric_Call{Call: _e.mock.On("RecordJetStreamAssetsMetric", namespace, name, streams, consumers)}
+}
+
+func (_c *Collector_RecordJetStreamAssetsMetric_Call) Run(run func(namespace string, name string, streams int, consumers int)) *Collector_RecordJetStreamAssetsMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Collector_RecordJetStreamAssetsMetric_Call) Return() *Collector_RecordJetStreamAssetsMetric_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Collector_RecordJetStreamAssetsMetric_Call) RunAndReturn(run func(string, string, int, int)) *Collector_RecordJetStreamAssetsMetric_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RecordJetStreamStorageMetric provides a mock function with given fields: namespace, name, storage, used, limit
+func (_m *Collector) RecordJetStreamStorageMetric(namespace string, name string, storage string, used int64, limit int64) {
+	_m.Called(namespace, name, storage, used, limit)
+}
+
+// Collector_RecordJetStreamStorageMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordJetStreamStorageMetric'
+type Collector_RecordJetStreamStorageMetric_Call struct {
+	*mock.Call
+}
+
+// RecordJetStreamStorageMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+//   - storage string
+//   - used int64
+//   - limit int64
+func (_e *Collector_Expecter) RecordJetStreamStorageMetric(namespace interface{}, name interface{}, storage interface{}, used interface{}, limit interface{}) *Collector_RecordJetStreamStorageMetric_Call {
+	return &Collector_RecordJetStreamStorageMetric_Call{Call: _e.mock.On("RecordJetStreamStorageMetric", namespace, name, storage, used, limit)}
+}
+
+func (_c *Collector_RecordJetStreamStorageMetric_Call) Run(run func(namespace string, name string, storage string, used int64, limit int64)) *Collector_RecordJetStreamStorageMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(int64), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *Collector_RecordJetStreamStorageMetric_Call) Return() *Collector_RecordJetStreamStorageMetric_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Collector_RecordJetStreamStorageMetric_Call) RunAndReturn(run func(string, string, string, int64, int64)) *Collector_RecordJetStreamStorageMetric_Call {
 	_c.Run(run)
 	return _c
 }
@@ -232,9 +625,9 @@ func (_c *Collector_RegisterMetrics_Call) RunAndReturn(run func()) *Collector_Re
 	return _c
 }
 
-// ResetAvailabilityZonesUsedMetric provides a mock function with no fields
-func (_m *Collector) ResetAvailabilityZonesUsedMetric() {
-	_m.Called()
+// ResetAvailabilityZonesUsedMetric provides a mock function with given fields: namespace, name
+func (_m *Collector) ResetAvailabilityZonesUsedMetric(namespace string, name string) {
+	_m.Called(namespace, name)
 }
 
 // Collector_ResetAvailabilityZonesUsedMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetAvailabilityZonesUsedMetric'
@@ -243,13 +636,15 @@ type Collector_ResetAvailabilityZonesUsedMetric_Call struct {
 }
 
 // ResetAvailabilityZonesUsedMetric is a helper method to define mock.On call
-func (_e *Collector_Expecter) ResetAvailabilityZonesUsedMetric() *Collector_ResetAvailabilityZonesUsedMetric_Call {
-	return &Collector_ResetAvailabilityZonesUsedMetric_Call{Call: _e.mock.On("ResetAvailabilityZonesUsedMetric")}
+//   - namespace string
+//   - name string
+func (_e *Collector_Expecter) ResetAvailabilityZonesUsedMetric(namespace interface{}, name interface{}) *Collector_ResetAvailabilityZonesUsedMetric_Call {
+	return &Collector_ResetAvailabilityZonesUsedMetric_Call{Call: _e.mock.On("ResetAvailabilityZonesUsedMetric", namespace, name)}
 }
 
-func (_c *Collector_ResetAvailabilityZonesUsedMetric_Call) Run(run func()) *Collector_ResetAvailabilityZonesUsedMetric_Call {
+func (_c *Collector_ResetAvailabilityZonesUsedMetric_Call) Run(run func(namespace string, name string)) *Collector_ResetAvailabilityZonesUsedMetric_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
@@ -259,14 +654,82 @@ func (_c *Collector_ResetAvailabilityZonesUsedMetric_Call) Return() *Collector_R
 	return _c
 }
 
-func (_c *Collector_ResetAvailabilityZonesUsedMetric_Call) RunAndReturn(run func()) *Collector_ResetAvailabilityZonesUsedMetric_Call {
+func (_c *Collector_ResetAvailabilityZonesUsedMetric_Call) RunAndReturn(run func(string, string)) *Collector_ResetAvailabilityZonesUsedMetric_Call {
 	_c.Run(run)
 	return _c
 }
 
-// ResetClusterSizeMetric provides a mock function with no fields
-func (_m *Collector) ResetClusterSizeMetric() {
-	_m.Called()
+// ResetBackupMetric provides a mock function with given fields: namespace, name
+func (_m *Collector) ResetBackupMetric(namespace string, name string) {
+	_m.Called(namespace, name)
+}
+
+// Collector_ResetBackupMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetBackupMetric'
+type Collector_ResetBackupMetric_Call struct {
+	*mock.Call
+}
+
+// ResetBackupMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+func (_e *Collector_Expecter) ResetBackupMetric(namespace interface{}, name interface{}) *Collector_ResetBackupMetric_Call {
+	return &Collector_ResetBackupMetric_Call{Call: _e.mock.On("ResetBackupMetric", namespace, name)}
+}
+
+func (_c *Collector_ResetBackupMetric_Call) Run(run func(namespace string, name string)) *Collector_ResetBackupMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Collector_ResetBackupMetric_Call) Return() *Collector_ResetBackupMetric_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Collector_ResetBackupMetric_Call) RunAndReturn(run func(string, string)) *Collector_ResetBackupMetric_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ResetCertificateExpiryMetric provides a mock function with given fields: namespace, name
+func (_m *Collector) ResetCertificateExpiryMetric(namespace string, name string) {
+	_m.Called(namespace, name)
+}
+
+// Collector_ResetCertificateExpiryMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetCertificateExpiryMetric'
+type Collector_ResetCertificateExpiryMetric_Call struct {
+	*mock.Call
+}
+
+// ResetCertificateExpiryMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+func (_e *Collector_Expecter) ResetCertificateExpiryMetric(namespace interface{}, name interface{}) *Collector_ResetCertificateExpiryMetric_Call {
+	return &Collector_ResetCertificateExpiryMetric_Call{Call: _e.mock.On("ResetCertificateExpiryMetric", namespace, name)}
+}
+
+func (_c *Collector_ResetCertificateExpiryMetric_Call) Run(run func(namespace string, name string)) *Collector_ResetCertificateExpiryMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Collector_ResetCertificateExpiryMetric_Call) Return() *Collector_ResetCertificateExpiryMetric_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Collector_ResetCertificateExpiryMetric_Call) RunAndReturn(run func(string, string)) *Collector_ResetCertificateExpiryMetric_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ResetClusterSizeMetric provides a mock function with given fields: namespace, name
+func (_m *Collector) ResetClusterSizeMetric(namespace string, name string) {
+	_m.Called(namespace, name)
 }
 
 // Collector_ResetClusterSizeMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetClusterSizeMetric'
@@ -275,13 +738,15 @@ type Collector_ResetClusterSizeMetric_Call struct {
 }
 
 // ResetClusterSizeMetric is a helper method to define mock.On call
-func (_e *Collector_Expecter) ResetClusterSizeMetric() *Collector_ResetClusterSizeMetric_Call {
-	return &Collector_ResetClusterSizeMetric_Call{Call: _e.mock.On("ResetClusterSizeMetric")}
+//   - namespace string
+//   - name string
+func (_e *Collector_Expecter) ResetClusterSizeMetric(namespace interface{}, name interface{}) *Collector_ResetClusterSizeMetric_Call {
+	return &Collector_ResetClusterSizeMetric_Call{Call: _e.mock.On("ResetClusterSizeMetric", namespace, name)}
 }
 
-func (_c *Collector_ResetClusterSizeMetric_Call) Run(run func()) *Collector_ResetClusterSizeMetric_Call {
+func (_c *Collector_ResetClusterSizeMetric_Call) Run(run func(namespace string, name string)) *Collector_ResetClusterSizeMetric_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
@@ -291,7 +756,41 @@ func (_c *Collector_ResetClusterSizeMetric_Call) Return() *Collector_ResetCluste
 	return _c
 }
 
-func (_c *Collector_ResetClusterSizeMetric_Call) RunAndReturn(run func()) *Collector_ResetClusterSizeMetric_Call {
+func (_c *Collector_ResetClusterSizeMetric_Call) RunAndReturn(run func(string, string)) *Collector_ResetClusterSizeMetric_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ResetJetStreamUsageMetric provides a mock function with given fields: namespace, name
+func (_m *Collector) ResetJetStreamUsageMetric(namespace string, name string) {
+	_m.Called(namespace, name)
+}
+
+// Collector_ResetJetStreamUsageMetric_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetJetStreamUsageMetric'
+type Collector_ResetJetStreamUsageMetric_Call struct {
+	*mock.Call
+}
+
+// ResetJetStreamUsageMetric is a helper method to define mock.On call
+//   - namespace string
+//   - name string
+func (_e *Collector_Expecter) ResetJetStreamUsageMetric(namespace interface{}, name interface{}) *Collector_ResetJetStreamUsageMetric_Call {
+	return &Collector_ResetJetStreamUsageMetric_Call{Call: _e.mock.On("ResetJetStreamUsageMetric", namespace, name)}
+}
+
+func (_c *Collector_ResetJetStreamUsageMetric_Call) Run(run func(namespace string, name string)) *Collector_ResetJetStreamUsageMetric_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Collector_ResetJetStreamUsageMetric_Call) Return() *Collector_ResetJetStreamUsageMetric_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Collector_ResetJetStreamUsageMetric_Call) RunAndReturn(run func(string, string)) *Collector_ResetJetStreamUsageMetric_Call {
 	_c.Run(run)
 	return _c
 }
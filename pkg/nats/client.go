@@ -1,12 +1,26 @@
 package nats
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/kyma-project/nats-manager/pkg/auth"
+	"github.com/kyma-project/nats-manager/pkg/nats/monitor"
 	"github.com/nats-io/nats.go"
 )
 
+const (
+	// KeyValueStreamPrefix is the prefix of the streams which back key-value buckets.
+	KeyValueStreamPrefix = "KV_"
+	// ObjectStoreStreamPrefix is the prefix of the streams which back object store buckets.
+	ObjectStoreStreamPrefix = "OBJ_"
+)
+
 //go:generate go run github.com/vektra/mockery/v2 --name=Client --outpkg=mocks --case=underscore
 type Client interface {
 	// initialize NATS connection
@@ -17,13 +31,113 @@ type Client interface {
 	GetStreams() ([]*nats.StreamInfo, error)
 	// ConsumersExist checks if any consumer exists for the given stream
 	ConsumersExist(streamName string) (bool, error)
+	// ConsumerNames returns the names of the consumers of the given stream
+	ConsumerNames(streamName string) ([]string, error)
+	// StreamInfo returns the info of the given stream
+	StreamInfo(streamName string) (*nats.StreamInfo, error)
+	// CreateStream creates a new stream in NATS JetStream
+	CreateStream(config *nats.StreamConfig) (*nats.StreamInfo, error)
+	// UpdateStream updates the configuration of an existing stream in NATS JetStream
+	UpdateStream(config *nats.StreamConfig) (*nats.StreamInfo, error)
+	// DeleteStream deletes the given stream from NATS JetStream
+	DeleteStream(streamName string) error
+	// ConsumerInfo returns the info of the given consumer of the given stream
+	ConsumerInfo(streamName, consumerName string) (*nats.ConsumerInfo, error)
+	// CreateConsumer creates a new consumer for the given stream
+	CreateConsumer(streamName string, config *nats.ConsumerConfig) (*nats.ConsumerInfo, error)
+	// UpdateConsumer updates the configuration of an existing consumer of the given stream
+	UpdateConsumer(streamName string, config *nats.ConsumerConfig) (*nats.ConsumerInfo, error)
+	// DeleteConsumer deletes the given consumer from the given stream
+	DeleteConsumer(streamName, consumerName string) error
+	// CreateKeyValue creates a new key-value bucket in NATS JetStream
+	CreateKeyValue(config *nats.KeyValueConfig) error
+	// DeleteKeyValue deletes the given key-value bucket from NATS JetStream
+	DeleteKeyValue(bucket string) error
+	// CreateObjectStore creates a new object store bucket in NATS JetStream
+	CreateObjectStore(config *nats.ObjectStoreConfig) error
+	// DeleteObjectStore deletes the given object store bucket from NATS JetStream
+	DeleteObjectStore(bucket string) error
+	// UpdateAccountClaims pushes the given account JWT to the full resolver. It requires a user of the system account.
+	UpdateAccountClaims(accountJWT string) error
+	// DeleteAccountClaims deletes account JWTs from the full resolver with the given delete request signed by
+	// the operator. It requires a user of the system account.
+	DeleteAccountClaims(deleteRequest string) error
+	// SnapshotStream writes a snapshot of the given stream including its consumers to the given writer and
+	// returns the configuration and state of the stream at the time of the snapshot, which are required to restore it.
+	SnapshotStream(streamName string, writer io.Writer) (*nats.StreamInfo, error)
+	// RestoreStream recreates the stream of the given snapshot info from the snapshot data of the given reader.
+	// The stream must not exist.
+	RestoreStream(snapshot *nats.StreamInfo, reader io.Reader) (*nats.StreamInfo, error)
+	// AccountName returns the name of the account of the connected user, which is the public key of the account
+	// in JWT authentication mode.
+	AccountName() (string, error)
+	// MoveStream moves the replica of the given stream of the given account including its consumers from the
+	// given NATS server to another one. The move completes asynchronously, once the new replica caught up.
+	// It requires a user of the system account.
+	MoveStream(accountName, streamName, serverName string) error
+	// RemoveServer removes the given NATS server from the JetStream cluster. It requires a user of the system account.
+	RemoveServer(serverName string) error
+	// ClusterStreams returns the accounts with their streams and consumers, as reported by the given number
+	// of NATS servers. It requires a user of the system account.
+	ClusterStreams(servers int) ([]*monitor.AccountDetail, error)
 	// close NATS connection
 	Close()
 }
 
+var (
+	ErrInvalidRootCAs = errors.New("no valid certificate found in root CAs")
+	ErrStreamList     = errors.New("the streams cannot be listed")
+	ErrClaimsRejected = errors.New("the resolver rejected the request")
+	ErrSnapshotFailed = errors.New("the snapshot of the stream failed")
+	ErrRestoreFailed  = errors.New("the restore of the stream failed")
+	ErrStreamMove     = errors.New("the move of the stream failed")
+	ErrServerRemoval  = errors.New("the removal of the NATS server failed")
+	ErrClusterStreams = errors.New("the streams of the NATS servers cannot be read")
+)
+
+const (
+	accountClaimsUpdateSubject = "$SYS.REQ.CLAIMS.UPDATE"
+	accountClaimsDeleteSubject = "$SYS.REQ.CLAIMS.DELETE"
+	streamSnapshotSubject      = "$JS.API.STREAM.SNAPSHOT.%s"
+	streamRestoreSubject       = "$JS.API.STREAM.RESTORE.%s"
+	streamMoveSubject          = "$JS.API.ACCOUNT.STREAM.MOVE.%s.%s"
+	serverRemoveSubject        = "$JS.API.SERVER.REMOVE"
+	streamListSubject          = "$JS.API.STREAM.LIST"
+	jszPingSubject             = "$SYS.REQ.SERVER.PING.JSZ"
+	userInfoSubject            = "$SYS.REQ.USER.INFO"
+
+	// defaultTimeout is the timeout of the connection and the requests if the Config does not set one.
+	defaultTimeout = 5 * time.Second
+
+	// restoreChunkSize is the size of the chunks of the snapshot data which are sent to restore a stream.
+	restoreChunkSize = 128 * 1024
+	// snapshotStatusOK is the status of the last chunk of a snapshot which was completed successfully.
+	snapshotStatusOK = "204"
+	// statusHeader and descriptionHeader are the headers of the status of a message, e.g. 408 No Interest.
+	statusHeader      = "Status"
+	descriptionHeader = "Description"
+
+	// peerNotMemberErrorCode and serverNotMemberErrorCode are the JetStream error codes of a NATS server which
+	// was already moved off a stream or removed from the cluster.
+	peerNotMemberErrorCode   = 10040
+	serverNotMemberErrorCode = 10044
+	// streamMoveInProgressErrorCode is the JetStream error code of a stream which is already being moved.
+	streamMoveInProgressErrorCode = 10124
+)
+
 type Config struct {
 	URL     string
 	Timeout time.Duration `default:"5s"`
+	// RootCAs are the PEM encoded CA certificates to verify the server certificate with.
+	// If not set, the system root CAs are used for TLS connections.
+	RootCAs []byte
+	// Credentials is the NATS credentials file of the user to connect with. It is required if NATS runs
+	// in operator mode.
+	Credentials []byte
+	// User and Password are the credentials of a user which is configured in the NATS configuration,
+	// e.g. the admin of the system account.
+	User     string
+	Password string
 }
 
 type natsClient struct {
@@ -32,6 +146,9 @@ type natsClient struct {
 }
 
 func NewNatsClient(natsConfig *Config) Client {
+	if natsConfig.Timeout == 0 {
+		natsConfig.Timeout = defaultTimeout
+	}
 	return &natsClient{Config: natsConfig}
 }
 
@@ -41,6 +158,26 @@ func (c *natsClient) Init() error {
 			nats.Timeout(c.Config.Timeout),
 			nats.Name("NATS Manager"),
 		}
+		if len(c.Config.RootCAs) > 0 {
+			rootCAs := x509.NewCertPool()
+			if !rootCAs.AppendCertsFromPEM(c.Config.RootCAs) {
+				return ErrInvalidRootCAs
+			}
+			natsOptions = append(natsOptions, nats.Secure(&tls.Config{
+				RootCAs:    rootCAs,
+				MinVersion: tls.VersionTLS12,
+			}))
+		}
+		if len(c.Config.Credentials) > 0 {
+			userJWT, userSeed, err := auth.ParseCredentials(c.Config.Credentials)
+			if err != nil {
+				return err
+			}
+			natsOptions = append(natsOptions, nats.UserJWTAndSeed(userJWT, string(userSeed)))
+		}
+		if c.Config.User != "" {
+			natsOptions = append(natsOptions, nats.UserInfo(c.Config.User, c.Config.Password))
+		}
 		conn, err := nats.Connect(c.Config.URL, natsOptions...)
 		if err != nil || !conn.IsConnected() {
 			return fmt.Errorf("failed to connect to NATS server: %w", err)
@@ -67,16 +204,35 @@ func (c *natsClient) StreamExists() (bool, error) {
 	return true, nil
 }
 
+// GetStreams lists the streams page by page with the JetStream API, because the stream channel of nats.go drops
+// errors. Otherwise, an account without JetStream would look like an account without streams.
 func (c *natsClient) GetStreams() ([]*nats.StreamInfo, error) {
-	// get JetStream context
-	jetStreamCtx, err := c.conn.JetStream()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get JetStream: %w", err)
-	}
-	// read all the streams from the channel
 	var streams []*nats.StreamInfo
-	for stream := range jetStreamCtx.Streams() {
-		streams = append(streams, stream)
+	for {
+		request, err := json.Marshal(map[string]any{"offset": len(streams)})
+		if err != nil {
+			return nil, err
+		}
+		response := struct {
+			Total   int                `json:"total"`
+			Streams []*nats.StreamInfo `json:"streams"`
+			Error   *jetStreamAPIError `json:"error,omitempty"`
+		}{}
+		err = c.requestJetStreamAPI(streamListSubject, request, &response)
+		if errors.Is(err, nats.ErrNoResponders) {
+			// the JetStream API is not available in the account.
+			return nil, fmt.Errorf("%w: %w", ErrStreamList, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if response.Error != nil {
+			return nil, fmt.Errorf("%w: %s", ErrStreamList, response.Error)
+		}
+		streams = append(streams, response.Streams...)
+		if len(response.Streams) == 0 || len(streams) >= response.Total {
+			break
+		}
 	}
 
 	// if it has no streams, return nil
@@ -104,6 +260,399 @@ func (c *natsClient) ConsumersExist(streamName string) (bool, error) {
 	return true, nil
 }
 
+func (c *natsClient) ConsumerNames(streamName string) ([]string, error) {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range jetStreamCtx.ConsumerNames(streamName) {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (c *natsClient) StreamInfo(streamName string) (*nats.StreamInfo, error) {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return nil, err
+	}
+	return jetStreamCtx.StreamInfo(streamName)
+}
+
+func (c *natsClient) CreateStream(config *nats.StreamConfig) (*nats.StreamInfo, error) {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return nil, err
+	}
+	return jetStreamCtx.AddStream(config)
+}
+
+func (c *natsClient) UpdateStream(config *nats.StreamConfig) (*nats.StreamInfo, error) {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return nil, err
+	}
+	return jetStreamCtx.UpdateStream(config)
+}
+
+func (c *natsClient) DeleteStream(streamName string) error {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return err
+	}
+	return jetStreamCtx.DeleteStream(streamName)
+}
+
+func (c *natsClient) ConsumerInfo(streamName, consumerName string) (*nats.ConsumerInfo, error) {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return nil, err
+	}
+	return jetStreamCtx.ConsumerInfo(streamName, consumerName)
+}
+
+func (c *natsClient) CreateConsumer(streamName string, config *nats.ConsumerConfig) (*nats.ConsumerInfo, error) {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return nil, err
+	}
+	return jetStreamCtx.AddConsumer(streamName, config)
+}
+
+func (c *natsClient) UpdateConsumer(streamName string, config *nats.ConsumerConfig) (*nats.ConsumerInfo, error) {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return nil, err
+	}
+	return jetStreamCtx.UpdateConsumer(streamName, config)
+}
+
+func (c *natsClient) DeleteConsumer(streamName, consumerName string) error {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return err
+	}
+	return jetStreamCtx.DeleteConsumer(streamName, consumerName)
+}
+
+func (c *natsClient) CreateKeyValue(config *nats.KeyValueConfig) error {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return err
+	}
+	_, err = jetStreamCtx.CreateKeyValue(config)
+	return err
+}
+
+func (c *natsClient) DeleteKeyValue(bucket string) error {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return err
+	}
+	return jetStreamCtx.DeleteKeyValue(bucket)
+}
+
+func (c *natsClient) CreateObjectStore(config *nats.ObjectStoreConfig) error {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return err
+	}
+	_, err = jetStreamCtx.CreateObjectStore(config)
+	return err
+}
+
+func (c *natsClient) DeleteObjectStore(bucket string) error {
+	jetStreamCtx, err := c.jetStream()
+	if err != nil {
+		return err
+	}
+	return jetStreamCtx.DeleteObjectStore(bucket)
+}
+
+func (c *natsClient) UpdateAccountClaims(accountJWT string) error {
+	return c.requestClaims(accountClaimsUpdateSubject, accountJWT)
+}
+
+func (c *natsClient) DeleteAccountClaims(deleteRequest string) error {
+	return c.requestClaims(accountClaimsDeleteSubject, deleteRequest)
+}
+
+// claimsResponse is the response of the full resolver to claims requests.
+type claimsResponse struct {
+	Data *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"data,omitempty"`
+	Error *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error,omitempty"`
+}
+
+func (c *natsClient) requestClaims(subject, token string) error {
+	msg, err := c.conn.Request(subject, []byte(token), c.Config.Timeout)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", subject, err)
+	}
+	response := claimsResponse{}
+	if err := json.Unmarshal(msg.Data, &response); err != nil {
+		return fmt.Errorf("failed to parse the response of %s: %w", subject, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%w: %s (code %d)", ErrClaimsRejected, response.Error.Description, response.Error.Code)
+	}
+	return nil
+}
+
+func (c *natsClient) SnapshotStream(streamName string, writer io.Writer) (*nats.StreamInfo, error) {
+	// the chunks of the snapshot are delivered to an inbox, and every chunk must be acknowledged.
+	inbox := nats.NewInbox()
+	sub, err := c.conn.SubscribeSync(inbox)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to the snapshot chunks: %w", err)
+	}
+	defer func() { _ = sub.Unsubscribe() }()
+
+	request, err := json.Marshal(map[string]any{"deliver_subject": inbox})
+	if err != nil {
+		return nil, err
+	}
+	response := struct {
+		Config nats.StreamConfig  `json:"config"`
+		State  nats.StreamState   `json:"state"`
+		Error  *jetStreamAPIError `json:"error,omitempty"`
+	}{}
+	if err = c.requestJetStreamAPI(fmt.Sprintf(streamSnapshotSubject, streamName), request, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotFailed, response.Error)
+	}
+
+	for {
+		chunk, err := sub.NextMsg(c.Config.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to receive a chunk: %w", ErrSnapshotFailed, err)
+		}
+		// the last chunk is empty and its status tells whether the snapshot was completed.
+		if len(chunk.Data) == 0 {
+			if status := chunk.Header.Get(statusHeader); status != "" && status != snapshotStatusOK {
+				return nil, fmt.Errorf("%w: %s %s", ErrSnapshotFailed, status, chunk.Header.Get(descriptionHeader))
+			}
+			return &nats.StreamInfo{Config: response.Config, State: response.State}, nil
+		}
+		if _, err = writer.Write(chunk.Data); err != nil {
+			return nil, err
+		}
+		if chunk.Reply != "" {
+			if err = chunk.Respond(nil); err != nil {
+				return nil, fmt.Errorf("%w: failed to acknowledge a chunk: %w", ErrSnapshotFailed, err)
+			}
+		}
+	}
+}
+
+func (c *natsClient) RestoreStream(snapshot *nats.StreamInfo, reader io.Reader) (*nats.StreamInfo, error) {
+	request, err := json.Marshal(map[string]any{"config": snapshot.Config, "state": snapshot.State})
+	if err != nil {
+		return nil, err
+	}
+	response := struct {
+		DeliverSubject string             `json:"deliver_subject"`
+		Error          *jetStreamAPIError `json:"error,omitempty"`
+	}{}
+	err = c.requestJetStreamAPI(fmt.Sprintf(streamRestoreSubject, snapshot.Config.Name), request, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRestoreFailed, response.Error)
+	}
+
+	// every chunk is acknowledged with an empty response, or with an error.
+	chunk := make([]byte, restoreChunkSize)
+	for {
+		n, readErr := io.ReadFull(reader, chunk)
+		if n > 0 {
+			chunkResponse := struct {
+				Error *jetStreamAPIError `json:"error,omitempty"`
+			}{}
+			if err = c.requestJetStreamAPI(response.DeliverSubject, chunk[:n], &chunkResponse); err != nil {
+				return nil, err
+			}
+			if chunkResponse.Error != nil {
+				return nil, fmt.Errorf("%w: %s", ErrRestoreFailed, chunkResponse.Error)
+			}
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+
+	// an empty chunk completes the restore, which is answered with the info of the restored stream.
+	result := struct {
+		nats.StreamInfo
+		Error *jetStreamAPIError `json:"error,omitempty"`
+	}{}
+	if err = c.requestJetStreamAPI(response.DeliverSubject, nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRestoreFailed, result.Error)
+	}
+	return &result.StreamInfo, nil
+}
+
+func (c *natsClient) AccountName() (string, error) {
+	response := struct {
+		Data struct {
+			Account string `json:"account"`
+		} `json:"data"`
+		Error *jetStreamAPIError `json:"error,omitempty"`
+	}{}
+	if err := c.requestJetStreamAPI(userInfoSubject, nil, &response); err != nil {
+		return "", err
+	}
+	if response.Error != nil {
+		return "", fmt.Errorf("failed to request %s: %s", userInfoSubject, response.Error)
+	}
+	return response.Data.Account, nil
+}
+
+func (c *natsClient) MoveStream(accountName, streamName, serverName string) error {
+	request, err := json.Marshal(map[string]any{"server": serverName})
+	if err != nil {
+		return err
+	}
+	response := struct {
+		Error *jetStreamAPIError `json:"error,omitempty"`
+	}{}
+	err = c.requestJetStreamAPI(fmt.Sprintf(streamMoveSubject, accountName, streamName), request, &response)
+	if err != nil {
+		return err
+	}
+	// a stream which is already being moved or was moved off the NATS server is not moved again.
+	if response.Error != nil && response.Error.ErrorCode != streamMoveInProgressErrorCode &&
+		response.Error.ErrorCode != peerNotMemberErrorCode && response.Error.ErrorCode != serverNotMemberErrorCode {
+		return fmt.Errorf("%w: %s", ErrStreamMove, response.Error)
+	}
+	return nil
+}
+
+func (c *natsClient) RemoveServer(serverName string) error {
+	request, err := json.Marshal(map[string]any{"peer": serverName})
+	if err != nil {
+		return err
+	}
+	response := struct {
+		Success bool               `json:"success,omitempty"`
+		Error   *jetStreamAPIError `json:"error,omitempty"`
+	}{}
+	if err = c.requestJetStreamAPI(serverRemoveSubject, request, &response); err != nil {
+		return err
+	}
+	// a NATS server which is not a member of the cluster anymore was already removed.
+	if response.Error != nil && response.Error.ErrorCode != serverNotMemberErrorCode {
+		return fmt.Errorf("%w: %s", ErrServerRemoval, response.Error)
+	}
+	if response.Error == nil && !response.Success {
+		return fmt.Errorf("%w: %s was not removed", ErrServerRemoval, serverName)
+	}
+	return nil
+}
+
+// ClusterStreams requests the JetStream state of all accounts from every NATS server. Every stream is only
+// reported by the NATS server which leads it, so the streams are incomplete unless all NATS servers respond.
+func (c *natsClient) ClusterStreams(servers int) ([]*monitor.AccountDetail, error) {
+	inbox := nats.NewInbox()
+	sub, err := c.conn.SubscribeSync(inbox)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to the responses of the NATS servers: %w", err)
+	}
+	defer func() { _ = sub.Unsubscribe() }()
+
+	request, err := json.Marshal(map[string]any{
+		"accounts": true, "streams": true, "consumer": true, "stream_leader_only": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = c.conn.PublishRequest(jszPingSubject, inbox, request); err != nil {
+		return nil, fmt.Errorf("failed to request %s: %w", jszPingSubject, err)
+	}
+
+	var accounts []*monitor.AccountDetail
+	for responded := range servers {
+		msg, err := sub.NextMsg(c.Config.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %d of %d NATS servers responded: %w", ErrClusterStreams, responded,
+				servers, err)
+		}
+		response := struct {
+			Data  monitor.Jsz        `json:"data"`
+			Error *jetStreamAPIError `json:"error,omitempty"`
+		}{}
+		if err = json.Unmarshal(msg.Data, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse the response of %s: %w", jszPingSubject, err)
+		}
+		if response.Error != nil {
+			return nil, fmt.Errorf("%w: %s", ErrClusterStreams, response.Error)
+		}
+		accounts = append(accounts, response.Data.AccountDetails...)
+	}
+	return accounts, nil
+}
+
+// jetStreamAPIError is the error of a JetStream API response.
+type jetStreamAPIError struct {
+	Code        int    `json:"code"`
+	ErrorCode   int    `json:"err_code"`
+	Description string `json:"description"`
+}
+
+func (e *jetStreamAPIError) String() string {
+	return fmt.Sprintf("%s (code %d, error code %d)", e.Description, e.Code, e.ErrorCode)
+}
+
+// requestJetStreamAPI sends the request to the given subject of the JetStream API and parses the JSON response.
+// An empty response is not parsed.
+func (c *natsClient) requestJetStreamAPI(subject string, request []byte, response any) error {
+	msg, err := c.conn.Request(subject, request, c.Config.Timeout)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", subject, err)
+	}
+	if len(msg.Data) == 0 {
+		return nil
+	}
+	if err = json.Unmarshal(msg.Data, response); err != nil {
+		return fmt.Errorf("failed to parse the response of %s: %w", subject, err)
+	}
+	return nil
+}
+
+// KeyValueStreamName returns the name of the stream which backs the given key-value bucket.
+func KeyValueStreamName(bucket string) string {
+	return KeyValueStreamPrefix + bucket
+}
+
+// ObjectStoreStreamName returns the name of the stream which backs the given object store bucket.
+func ObjectStoreStreamName(bucket string) string {
+	return ObjectStoreStreamPrefix + bucket
+}
+
+// jetStream returns the JetStream context of the current connection.
+func (c *natsClient) jetStream() (nats.JetStreamContext, error) {
+	jetStreamCtx, err := c.conn.JetStream()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JetStream: %w", err)
+	}
+	return jetStreamCtx, nil
+}
+
 func (c *natsClient) Close() {
 	if c.conn != nil {
 		c.conn.Close()
@@ -116,6 +665,9 @@ type Conn interface {
 	Status() nats.Status
 	JetStream() (nats.JetStreamContext, error)
 	IsConnected() bool
+	Request(subject string, data []byte, timeout time.Duration) (*nats.Msg, error)
+	SubscribeSync(subject string) (*nats.Subscription, error)
+	PublishRequest(subject, reply string, data []byte) error
 	Close()
 }
 
@@ -135,6 +687,18 @@ func (c *natsConn) IsConnected() bool {
 	return c.conn.IsConnected()
 }
 
+func (c *natsConn) Request(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+	return c.conn.Request(subject, data, timeout)
+}
+
+func (c *natsConn) SubscribeSync(subject string) (*nats.Subscription, error) {
+	return c.conn.SubscribeSync(subject)
+}
+
+func (c *natsConn) PublishRequest(subject, reply string, data []byte) error {
+	return c.conn.PublishRequest(subject, reply, data)
+}
+
 func (c *natsConn) Close() {
 	c.conn.Close()
 }
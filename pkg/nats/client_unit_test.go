@@ -1,11 +1,16 @@
 package nats
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	nmnatsmocks "github.com/kyma-project/nats-manager/pkg/nats/mocks"
+	"github.com/nats-io/nats-server/v2/server"
 	natsgo "github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/require"
 )
@@ -101,3 +106,679 @@ func returnEmptyStream() <-chan *natsgo.StreamInfo {
 	}()
 	return ch
 }
+
+func Test_GetStreams(t *testing.T) {
+	firstPage := `{"total":3,"offset":0,"limit":2,"streams":[{"config":{"name":"orders"}},{"config":{"name":"sap"}}]}`
+	secondPage := `{"total":3,"offset":2,"limit":2,"streams":[{"config":{"name":"payments"}}]}`
+	tests := []struct {
+		name         string
+		responses    []string
+		requestError error
+		wantStreams  []string
+		wantErr      error
+	}{
+		{
+			name:        "should return all pages of streams",
+			responses:   []string{firstPage, secondPage},
+			wantStreams: []string{"orders", "sap", "payments"},
+		},
+		{
+			name:      "should return nil if there are no streams",
+			responses: []string{`{"total":0,"offset":0,"limit":256,"streams":null}`},
+		},
+		{
+			name:      "should fail if JetStream is not enabled for the account",
+			responses: []string{`{"error":{"code":503,"err_code":10039,"description":"jetstream not enabled for account"}}`},
+			wantErr:   ErrStreamList,
+		},
+		{
+			name:         "should fail if the JetStream API is not available in the account",
+			responses:    []string{""},
+			requestError: natsgo.ErrNoResponders,
+			wantErr:      ErrStreamList,
+		},
+		{
+			name:         "should fail if the request fails",
+			responses:    []string{""},
+			requestError: natsgo.ErrTimeout,
+			wantErr:      natsgo.ErrTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockNatsConn := &nmnatsmocks.Conn{}
+			offset := 0
+			for _, response := range tt.responses {
+				mockNatsConn.On("Request", streamListSubject, []byte(fmt.Sprintf(`{"offset":%d}`, offset)), time.Second).
+					Return(&natsgo.Msg{Data: []byte(response)}, tt.requestError).Once()
+				offset += 2
+			}
+			natsClient := &natsClient{Config: &Config{Timeout: time.Second}, conn: mockNatsConn}
+
+			// when
+			streams, err := natsClient.GetStreams()
+
+			// then
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, stream := range streams {
+				names = append(names, stream.Config.Name)
+			}
+			require.Equal(t, tt.wantStreams, names)
+			mockNatsConn.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CreateStream(t *testing.T) {
+	fakeError := ErrJetStreamErrorMsg
+	streamConfig := &natsgo.StreamConfig{Name: "orders", Subjects: []string{"orders.>"}}
+	tests := []struct {
+		name                 string
+		createMockNatsClient func() *natsClient
+		expected             *natsgo.StreamInfo
+		err                  error
+	}{
+		{
+			name: "should create the stream",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("AddStream", streamConfig).Return(&natsgo.StreamInfo{Config: *streamConfig}, nil)
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+			expected: &natsgo.StreamInfo{Config: *streamConfig},
+		},
+		{
+			name: "should fail creating the stream",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("AddStream", streamConfig).Return(nil, fakeError)
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+			err: fakeError,
+		},
+		{
+			name: "should fail getting JetStream context",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				mockNatsConn.On("JetStream").Return(nil, fakeError)
+				return &natsClient{conn: mockNatsConn}
+			},
+			err: fmt.Errorf("failed to get JetStream: %w", fakeError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			natsClient := tt.createMockNatsClient()
+
+			actual, err := natsClient.CreateStream(streamConfig)
+
+			require.Equal(t, tt.expected, actual)
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_DeleteStream(t *testing.T) {
+	fakeError := ErrJetStreamErrorMsg
+	tests := []struct {
+		name                 string
+		createMockNatsClient func() *natsClient
+		err                  error
+	}{
+		{
+			name: "should delete the stream",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("DeleteStream", "orders").Return(nil)
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+		},
+		{
+			name: "should fail deleting the stream",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("DeleteStream", "orders").Return(natsgo.ErrStreamNotFound)
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+			err: natsgo.ErrStreamNotFound,
+		},
+		{
+			name: "should fail getting JetStream context",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				mockNatsConn.On("JetStream").Return(nil, fakeError)
+				return &natsClient{conn: mockNatsConn}
+			},
+			err: fmt.Errorf("failed to get JetStream: %w", fakeError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			natsClient := tt.createMockNatsClient()
+
+			err := natsClient.DeleteStream("orders")
+
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ConsumerInfo(t *testing.T) {
+	fakeError := ErrJetStreamErrorMsg
+	tests := []struct {
+		name                 string
+		createMockNatsClient func() *natsClient
+		expected             *natsgo.ConsumerInfo
+		err                  error
+	}{
+		{
+			name: "should return the consumer info",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("ConsumerInfo", "orders", "billing").Return(&natsgo.ConsumerInfo{NumPending: 3}, nil)
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+			expected: &natsgo.ConsumerInfo{NumPending: 3},
+		},
+		{
+			name: "should fail if the consumer does not exist",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("ConsumerInfo", "orders", "billing").Return(nil, natsgo.ErrConsumerNotFound)
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+			err: natsgo.ErrConsumerNotFound,
+		},
+		{
+			name: "should fail getting JetStream context",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				mockNatsConn.On("JetStream").Return(nil, fakeError)
+				return &natsClient{conn: mockNatsConn}
+			},
+			err: fmt.Errorf("failed to get JetStream: %w", fakeError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			natsClient := tt.createMockNatsClient()
+
+			actual, err := natsClient.ConsumerInfo("orders", "billing")
+
+			require.Equal(t, tt.expected, actual)
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ConsumerNames(t *testing.T) {
+	fakeError := ErrJetStreamErrorMsg
+	tests := []struct {
+		name                 string
+		createMockNatsClient func() *natsClient
+		expected             []string
+		err                  error
+	}{
+		{
+			name: "should return the consumer names",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("ConsumerNames", "orders").Return(returnNames("billing", "shipping"))
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+			expected: []string{"billing", "shipping"},
+		},
+		{
+			name: "should return no consumer names",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("ConsumerNames", "orders").Return(returnNames())
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+		},
+		{
+			name: "should fail getting JetStream context",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				mockNatsConn.On("JetStream").Return(nil, fakeError)
+				return &natsClient{conn: mockNatsConn}
+			},
+			err: fmt.Errorf("failed to get JetStream: %w", fakeError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			natsClient := tt.createMockNatsClient()
+
+			actual, err := natsClient.ConsumerNames("orders")
+
+			require.Equal(t, tt.expected, actual)
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func returnNames(names ...string) <-chan string {
+	ch := make(chan string, len(names))
+	for _, name := range names {
+		ch <- name
+	}
+	close(ch)
+	return ch
+}
+
+func Test_CreateKeyValue(t *testing.T) {
+	fakeError := ErrJetStreamErrorMsg
+	config := &natsgo.KeyValueConfig{Bucket: "sessions", History: 1}
+	tests := []struct {
+		name                 string
+		createMockNatsClient func() *natsClient
+		err                  error
+	}{
+		{
+			name: "should create the key-value bucket",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("CreateKeyValue", config).Return(nil, nil)
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+		},
+		{
+			name: "should fail creating the key-value bucket",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("CreateKeyValue", config).Return(nil, fakeError)
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+			err: fakeError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			natsClient := tt.createMockNatsClient()
+
+			err := natsClient.CreateKeyValue(config)
+
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_DeleteObjectStore(t *testing.T) {
+	fakeError := ErrJetStreamErrorMsg
+	tests := []struct {
+		name                 string
+		createMockNatsClient func() *natsClient
+		err                  error
+	}{
+		{
+			name: "should delete the object store bucket",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				jsCtx := &nmnatsmocks.JetStreamContext{}
+				jsCtx.On("DeleteObjectStore", "artifacts").Return(nil)
+				mockNatsConn.On("JetStream").Return(jsCtx, nil)
+				return &natsClient{conn: mockNatsConn}
+			},
+		},
+		{
+			name: "should fail getting JetStream context",
+			createMockNatsClient: func() *natsClient {
+				mockNatsConn := &nmnatsmocks.Conn{}
+				mockNatsConn.On("JetStream").Return(nil, fakeError)
+				return &natsClient{conn: mockNatsConn}
+			},
+			err: fmt.Errorf("failed to get JetStream: %w", fakeError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			natsClient := tt.createMockNatsClient()
+
+			err := natsClient.DeleteObjectStore("artifacts")
+
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_UpdateAccountClaims(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     string
+		requestError error
+		wantErr      error
+	}{
+		{
+			name:     "should push the account JWT",
+			response: `{"data":{"code":200,"message":"jwt updated"}}`,
+		},
+		{
+			name:     "should fail if the resolver rejects the JWT",
+			response: `{"error":{"code":400,"description":"jwt not signed by a trusted operator"}}`,
+			wantErr:  ErrClaimsRejected,
+		},
+		{
+			name:         "should fail if the request fails",
+			requestError: natsgo.ErrTimeout,
+			wantErr:      natsgo.ErrTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockNatsConn := &nmnatsmocks.Conn{}
+			mockNatsConn.On("Request", accountClaimsUpdateSubject, []byte("account-jwt"), time.Second).
+				Return(&natsgo.Msg{Data: []byte(tt.response)}, tt.requestError)
+			natsClient := &natsClient{Config: &Config{Timeout: time.Second}, conn: mockNatsConn}
+
+			// when
+			err := natsClient.UpdateAccountClaims("account-jwt")
+
+			// then
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_MoveStream(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     string
+		requestError error
+		wantErr      error
+	}{
+		{
+			name:     "should move the stream",
+			response: `{"type":"io.nats.jetstream.api.v1.stream_update_response","config":{"name":"test-stream"}}`,
+		},
+		{
+			name:     "should ignore a stream which is already being moved",
+			response: `{"error":{"code":400,"err_code":10124,"description":"stream move already in progress"}}`,
+		},
+		{
+			name:     "should ignore a stream which was moved off the NATS server",
+			response: `{"error":{"code":400,"err_code":10040,"description":"peer not a member"}}`,
+		},
+		{
+			name:     "should fail if there is no other NATS server for the stream",
+			response: `{"error":{"code":400,"err_code":10005,"description":"insufficient resources"}}`,
+			wantErr:  ErrStreamMove,
+		},
+		{
+			name:         "should fail if the request fails",
+			requestError: natsgo.ErrTimeout,
+			wantErr:      natsgo.ErrTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockNatsConn := &nmnatsmocks.Conn{}
+			mockNatsConn.On("Request", "$JS.API.ACCOUNT.STREAM.MOVE.$G.test-stream",
+				[]byte(`{"server":"eventing-nats-2"}`), time.Second).
+				Return(&natsgo.Msg{Data: []byte(tt.response)}, tt.requestError)
+			natsClient := &natsClient{Config: &Config{Timeout: time.Second}, conn: mockNatsConn}
+
+			// when
+			err := natsClient.MoveStream("$G", "test-stream", "eventing-nats-2")
+
+			// then
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			mockNatsConn.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_RemoveServer(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     string
+		requestError error
+		wantErr      error
+	}{
+		{
+			name:     "should remove the server",
+			response: `{"success":true}`,
+		},
+		{
+			name:     "should ignore a server which is not a member of the cluster",
+			response: `{"error":{"code":400,"err_code":10044,"description":"server is not a member of the cluster"}}`,
+		},
+		{
+			name:     "should fail if the server is not removed",
+			response: `{"error":{"code":400,"err_code":10045,"description":"cluster member change is in progress"}}`,
+			wantErr:  ErrServerRemoval,
+		},
+		{
+			name:         "should fail if the request fails",
+			requestError: natsgo.ErrTimeout,
+			wantErr:      natsgo.ErrTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			mockNatsConn := &nmnatsmocks.Conn{}
+			mockNatsConn.On("Request", serverRemoveSubject, []byte(`{"peer":"eventing-nats-2"}`), time.Second).
+				Return(&natsgo.Msg{Data: []byte(tt.response)}, tt.requestError)
+			natsClient := &natsClient{Config: &Config{Timeout: time.Second}, conn: mockNatsConn}
+
+			// when
+			err := natsClient.RemoveServer("eventing-nats-2")
+
+			// then
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			mockNatsConn.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_SnapshotAndRestoreStream(t *testing.T) {
+	// given, a NATS server with a stream and a consumer.
+	natsServer, err := server.NewServer(&server.Options{
+		Host: "127.0.0.1", Port: -1, JetStream: true, StoreDir: t.TempDir(), NoSigs: true,
+	})
+	require.NoError(t, err)
+	natsServer.Start()
+	t.Cleanup(natsServer.Shutdown)
+	require.True(t, natsServer.ReadyForConnections(5*time.Second))
+
+	client := NewNatsClient(&Config{URL: natsServer.ClientURL(), Timeout: 5 * time.Second})
+	require.NoError(t, client.Init())
+	t.Cleanup(client.Close)
+
+	_, err = client.CreateStream(&natsgo.StreamConfig{Name: "orders", Subjects: []string{"orders.>"}})
+	require.NoError(t, err)
+	_, err = client.CreateConsumer("orders", &natsgo.ConsumerConfig{Durable: "billing", AckPolicy: natsgo.AckExplicitPolicy})
+	require.NoError(t, err)
+	conn, err := natsgo.Connect(natsServer.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	jetStreamCtx, err := conn.JetStream()
+	require.NoError(t, err)
+	for i := range 100 {
+		_, err = jetStreamCtx.Publish("orders.created", fmt.Appendf(nil, "order %d", i))
+		require.NoError(t, err)
+	}
+
+	// when
+	var data bytes.Buffer
+	snapshot, err := client.SnapshotStream("orders", &data)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, "orders", snapshot.Config.Name)
+	require.Equal(t, uint64(100), snapshot.State.Msgs)
+	require.NotZero(t, data.Len())
+
+	// when, the stream is restored after it was deleted.
+	_, err = client.RestoreStream(snapshot, bytes.NewReader(data.Bytes()))
+
+	// then, the stream must not exist.
+	require.ErrorIs(t, err, ErrRestoreFailed)
+
+	require.NoError(t, client.DeleteStream("orders"))
+	info, err := client.RestoreStream(snapshot, bytes.NewReader(data.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), info.State.Msgs)
+	consumers, err := client.ConsumerNames("orders")
+	require.NoError(t, err)
+	require.Equal(t, []string{"billing"}, consumers)
+
+	// when, the snapshot of a stream which does not exist is taken.
+	_, err = client.SnapshotStream("payments", &data)
+
+	// then
+	require.ErrorIs(t, err, ErrSnapshotFailed)
+	require.ErrorContains(t, err, "stream not found")
+}
+
+func Test_ClusterStreams(t *testing.T) {
+	// given, a NATS server with a stream and a consumer in an account besides the one of the client.
+	natsServer := startNATSServerWithAccounts(t)
+
+	ordersClient := NewNatsClient(&Config{URL: natsServer.ClientURL(), User: "orders", Password: "orders"})
+	require.NoError(t, ordersClient.Init())
+	t.Cleanup(ordersClient.Close)
+	_, err := ordersClient.CreateStream(&natsgo.StreamConfig{Name: "payments", Subjects: []string{"payments.>"}})
+	require.NoError(t, err)
+	_, err = ordersClient.CreateConsumer("payments", &natsgo.ConsumerConfig{Durable: "billing"})
+	require.NoError(t, err)
+
+	systemClient := NewNatsClient(&Config{
+		URL: natsServer.ClientURL(), User: "admin", Password: "admin", Timeout: 500 * time.Millisecond,
+	})
+	require.NoError(t, systemClient.Init())
+	t.Cleanup(systemClient.Close)
+
+	// when
+	accounts, err := systemClient.ClusterStreams(1)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, "orders", accounts[0].Name)
+	require.Len(t, accounts[0].Streams, 1)
+	require.Equal(t, "payments", accounts[0].Streams[0].Name)
+	require.Len(t, accounts[0].Streams[0].Consumers, 1)
+	require.Equal(t, "billing", accounts[0].Streams[0].Consumers[0].Name)
+
+	// when, more NATS servers are expected than respond.
+	_, err = systemClient.ClusterStreams(2)
+
+	// then
+	require.ErrorIs(t, err, ErrClusterStreams)
+	require.ErrorContains(t, err, "1 of 2 NATS servers responded")
+}
+
+func Test_AccountName(t *testing.T) {
+	// given
+	natsServer := startNATSServerWithAccounts(t)
+	ordersClient := NewNatsClient(&Config{URL: natsServer.ClientURL(), User: "orders", Password: "orders"})
+	require.NoError(t, ordersClient.Init())
+	t.Cleanup(ordersClient.Close)
+
+	// when
+	accountName, err := ordersClient.AccountName()
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, "orders", accountName)
+}
+
+// startNATSServerWithAccounts starts a NATS server with the system account and the account orders with JetStream.
+func startNATSServerWithAccounts(t *testing.T) *server.Server {
+	t.Helper()
+	storeDir := t.TempDir()
+	configFile := filepath.Join(storeDir, "nats.conf")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+jetstream: {store_dir: "`+storeDir+`"}
+accounts: {
+  "$SYS": {users: [{user: "admin", password: "admin"}]},
+  "orders": {users: [{user: "orders", password: "orders"}], jetstream: enabled}
+}
+system_account: "$SYS"
+`), 0o600))
+	options, err := server.ProcessConfigFile(configFile)
+	require.NoError(t, err)
+	options.Host, options.Port, options.NoSigs = "127.0.0.1", -1, true
+	natsServer, err := server.NewServer(options)
+	require.NoError(t, err)
+	natsServer.Start()
+	t.Cleanup(natsServer.Shutdown)
+	require.True(t, natsServer.ReadyForConnections(5*time.Second))
+	return natsServer
+}
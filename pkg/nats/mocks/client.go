@@ -3,9 +3,12 @@
 package mocks
 
 import (
-	mock "github.com/stretchr/testify/mock"
+	io "io"
+
+	monitor "github.com/kyma-project/nats-manager/pkg/nats/monitor"
 
-	nats_go "github.com/nats-io/nats.go"
+	nats "github.com/nats-io/nats.go"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -21,6 +24,61 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// AccountName provides a mock function with no fields
+func (_m *Client) AccountName() (string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccountName")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_AccountName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccountName'
+type Client_AccountName_Call struct {
+	*mock.Call
+}
+
+// AccountName is a helper method to define mock.On call
+func (_e *Client_Expecter) AccountName() *Client_AccountName_Call {
+	return &Client_AccountName_Call{Call: _e.mock.On("AccountName")}
+}
+
+func (_c *Client_AccountName_Call) Run(run func()) *Client_AccountName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Client_AccountName_Call) Return(_a0 string, _a1 error) *Client_AccountName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_AccountName_Call) RunAndReturn(run func() (string, error)) *Client_AccountName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *Client) Close() {
 	_m.Called()
@@ -53,6 +111,181 @@ func (_c *Client_Close_Call) RunAndReturn(run func()) *Client_Close_Call {
 	return _c
 }
 
+// ClusterStreams provides a mock function with given fields: servers
+func (_m *Client) ClusterStreams(servers int) ([]*monitor.AccountDetail, error) {
+	ret := _m.Called(servers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClusterStreams")
+	}
+
+	var r0 []*monitor.AccountDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]*monitor.AccountDetail, error)); ok {
+		return rf(servers)
+	}
+	if rf, ok := ret.Get(0).(func(int) []*monitor.AccountDetail); ok {
+		r0 = rf(servers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*monitor.AccountDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(servers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ClusterStreams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClusterStreams'
+type Client_ClusterStreams_Call struct {
+	*mock.Call
+}
+
+// ClusterStreams is a helper method to define mock.On call
+//   - servers int
+func (_e *Client_Expecter) ClusterStreams(servers interface{}) *Client_ClusterStreams_Call {
+	return &Client_ClusterStreams_Call{Call: _e.mock.On("ClusterStreams", servers)}
+}
+
+func (_c *Client_ClusterStreams_Call) Run(run func(servers int)) *Client_ClusterStreams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int))
+	})
+	return _c
+}
+
+func (_c *Client_ClusterStreams_Call) Return(_a0 []*monitor.AccountDetail, _a1 error) *Client_ClusterStreams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ClusterStreams_Call) RunAndReturn(run func(int) ([]*monitor.AccountDetail, error)) *Client_ClusterStreams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumerInfo provides a mock function with given fields: streamName, consumerName
+func (_m *Client) ConsumerInfo(streamName string, consumerName string) (*nats.ConsumerInfo, error) {
+	ret := _m.Called(streamName, consumerName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumerInfo")
+	}
+
+	var r0 *nats.ConsumerInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*nats.ConsumerInfo, error)); ok {
+		return rf(streamName, consumerName)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *nats.ConsumerInfo); ok {
+		r0 = rf(streamName, consumerName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*nats.ConsumerInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(streamName, consumerName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ConsumerInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumerInfo'
+type Client_ConsumerInfo_Call struct {
+	*mock.Call
+}
+
+// ConsumerInfo is a helper method to define mock.On call
+//   - streamName string
+//   - consumerName string
+func (_e *Client_Expecter) ConsumerInfo(streamName interface{}, consumerName interface{}) *Client_ConsumerInfo_Call {
+	return &Client_ConsumerInfo_Call{Call: _e.mock.On("ConsumerInfo", streamName, consumerName)}
+}
+
+func (_c *Client_ConsumerInfo_Call) Run(run func(streamName string, consumerName string)) *Client_ConsumerInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_ConsumerInfo_Call) Return(_a0 *nats.ConsumerInfo, _a1 error) *Client_ConsumerInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ConsumerInfo_Call) RunAndReturn(run func(string, string) (*nats.ConsumerInfo, error)) *Client_ConsumerInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumerNames provides a mock function with given fields: streamName
+func (_m *Client) ConsumerNames(streamName string) ([]string, error) {
+	ret := _m.Called(streamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumerNames")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(streamName)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(streamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(streamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ConsumerNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumerNames'
+type Client_ConsumerNames_Call struct {
+	*mock.Call
+}
+
+// ConsumerNames is a helper method to define mock.On call
+//   - streamName string
+func (_e *Client_Expecter) ConsumerNames(streamName interface{}) *Client_ConsumerNames_Call {
+	return &Client_ConsumerNames_Call{Call: _e.mock.On("ConsumerNames", streamName)}
+}
+
+func (_c *Client_ConsumerNames_Call) Run(run func(streamName string)) *Client_ConsumerNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Client_ConsumerNames_Call) Return(_a0 []string, _a1 error) *Client_ConsumerNames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ConsumerNames_Call) RunAndReturn(run func(string) ([]string, error)) *Client_ConsumerNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ConsumersExist provides a mock function with given fields: streamName
 func (_m *Client) ConsumersExist(streamName string) (bool, error) {
 	ret := _m.Called(streamName)
@@ -109,29 +342,29 @@ func (_c *Client_ConsumersExist_Call) RunAndReturn(run func(string) (bool, error
 	return _c
 }
 
-// GetStreams provides a mock function with no fields
-func (_m *Client) GetStreams() ([]*nats_go.StreamInfo, error) {
-	ret := _m.Called()
+// CreateConsumer provides a mock function with given fields: streamName, config
+func (_m *Client) CreateConsumer(streamName string, config *nats.ConsumerConfig) (*nats.ConsumerInfo, error) {
+	ret := _m.Called(streamName, config)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStreams")
+		panic("no return value specified for CreateConsumer")
 	}
 
-	var r0 []*nats_go.StreamInfo
+	var r0 *nats.ConsumerInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*nats_go.StreamInfo, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(string, *nats.ConsumerConfig) (*nats.ConsumerInfo, error)); ok {
+		return rf(streamName, config)
 	}
-	if rf, ok := ret.Get(0).(func() []*nats_go.StreamInfo); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string, *nats.ConsumerConfig) *nats.ConsumerInfo); ok {
+		r0 = rf(streamName, config)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*nats_go.StreamInfo)
+			r0 = ret.Get(0).(*nats.ConsumerInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, *nats.ConsumerConfig) error); ok {
+		r1 = rf(streamName, config)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -139,44 +372,46 @@ func (_m *Client) GetStreams() ([]*nats_go.StreamInfo, error) {
 	return r0, r1
 }
 
-// Client_GetStreams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStreams'
-type Client_GetStreams_Call struct {
+// Client_CreateConsumer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateConsumer'
+type Client_CreateConsumer_Call struct {
 	*mock.Call
 }
 
-// GetStreams is a helper method to define mock.On call
-func (_e *Client_Expecter) GetStreams() *Client_GetStreams_Call {
-	return &Client_GetStreams_Call{Call: _e.mock.On("GetStreams")}
+// CreateConsumer is a helper method to define mock.On call
+//   - streamName string
+//   - config *nats.ConsumerConfig
+func (_e *Client_Expecter) CreateConsumer(streamName interface{}, config interface{}) *Client_CreateConsumer_Call {
+	return &Client_CreateConsumer_Call{Call: _e.mock.On("CreateConsumer", streamName, config)}
 }
 
-func (_c *Client_GetStreams_Call) Run(run func()) *Client_GetStreams_Call {
+func (_c *Client_CreateConsumer_Call) Run(run func(streamName string, config *nats.ConsumerConfig)) *Client_CreateConsumer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(string), args[1].(*nats.ConsumerConfig))
 	})
 	return _c
 }
 
-func (_c *Client_GetStreams_Call) Return(_a0 []*nats_go.StreamInfo, _a1 error) *Client_GetStreams_Call {
+func (_c *Client_CreateConsumer_Call) Return(_a0 *nats.ConsumerInfo, _a1 error) *Client_CreateConsumer_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Client_GetStreams_Call) RunAndReturn(run func() ([]*nats_go.StreamInfo, error)) *Client_GetStreams_Call {
+func (_c *Client_CreateConsumer_Call) RunAndReturn(run func(string, *nats.ConsumerConfig) (*nats.ConsumerInfo, error)) *Client_CreateConsumer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Init provides a mock function with no fields
-func (_m *Client) Init() error {
-	ret := _m.Called()
+// CreateKeyValue provides a mock function with given fields: config
+func (_m *Client) CreateKeyValue(config *nats.KeyValueConfig) error {
+	ret := _m.Called(config)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Init")
+		panic("no return value specified for CreateKeyValue")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(*nats.KeyValueConfig) error); ok {
+		r0 = rf(config)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -184,54 +419,103 @@ func (_m *Client) Init() error {
 	return r0
 }
 
-// Client_Init_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Init'
-type Client_Init_Call struct {
+// Client_CreateKeyValue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateKeyValue'
+type Client_CreateKeyValue_Call struct {
 	*mock.Call
 }
 
-// Init is a helper method to define mock.On call
-func (_e *Client_Expecter) Init() *Client_Init_Call {
-	return &Client_Init_Call{Call: _e.mock.On("Init")}
+// CreateKeyValue is a helper method to define mock.On call
+//   - config *nats.KeyValueConfig
+func (_e *Client_Expecter) CreateKeyValue(config interface{}) *Client_CreateKeyValue_Call {
+	return &Client_CreateKeyValue_Call{Call: _e.mock.On("CreateKeyValue", config)}
 }
 
-func (_c *Client_Init_Call) Run(run func()) *Client_Init_Call {
+func (_c *Client_CreateKeyValue_Call) Run(run func(config *nats.KeyValueConfig)) *Client_CreateKeyValue_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(*nats.KeyValueConfig))
 	})
 	return _c
 }
 
-func (_c *Client_Init_Call) Return(_a0 error) *Client_Init_Call {
+func (_c *Client_CreateKeyValue_Call) Return(_a0 error) *Client_CreateKeyValue_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Client_Init_Call) RunAndReturn(run func() error) *Client_Init_Call {
+func (_c *Client_CreateKeyValue_Call) RunAndReturn(run func(*nats.KeyValueConfig) error) *Client_CreateKeyValue_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StreamExists provides a mock function with no fields
-func (_m *Client) StreamExists() (bool, error) {
-	ret := _m.Called()
+// CreateObjectStore provides a mock function with given fields: config
+func (_m *Client) CreateObjectStore(config *nats.ObjectStoreConfig) error {
+	ret := _m.Called(config)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StreamExists")
+		panic("no return value specified for CreateObjectStore")
 	}
 
-	var r0 bool
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*nats.ObjectStoreConfig) error); ok {
+		r0 = rf(config)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_CreateObjectStore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateObjectStore'
+type Client_CreateObjectStore_Call struct {
+	*mock.Call
+}
+
+// CreateObjectStore is a helper method to define mock.On call
+//   - config *nats.ObjectStoreConfig
+func (_e *Client_Expecter) CreateObjectStore(config interface{}) *Client_CreateObjectStore_Call {
+	return &Client_CreateObjectStore_Call{Call: _e.mock.On("CreateObjectStore", config)}
+}
+
+func (_c *Client_CreateObjectStore_Call) Run(run func(config *nats.ObjectStoreConfig)) *Client_CreateObjectStore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*nats.ObjectStoreConfig))
+	})
+	return _c
+}
+
+func (_c *Client_CreateObjectStore_Call) Return(_a0 error) *Client_CreateObjectStore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_CreateObjectStore_Call) RunAndReturn(run func(*nats.ObjectStoreConfig) error) *Client_CreateObjectStore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStream provides a mock function with given fields: config
+func (_m *Client) CreateStream(config *nats.StreamConfig) (*nats.StreamInfo, error) {
+	ret := _m.Called(config)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStream")
+	}
+
+	var r0 *nats.StreamInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (bool, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(*nats.StreamConfig) (*nats.StreamInfo, error)); ok {
+		return rf(config)
 	}
-	if rf, ok := ret.Get(0).(func() bool); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(*nats.StreamConfig) *nats.StreamInfo); ok {
+		r0 = rf(config)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*nats.StreamInfo)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(*nats.StreamConfig) error); ok {
+		r1 = rf(config)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -239,29 +523,851 @@ func (_m *Client) StreamExists() (bool, error) {
 	return r0, r1
 }
 
-// Client_StreamExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamExists'
-type Client_StreamExists_Call struct {
+// Client_CreateStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStream'
+type Client_CreateStream_Call struct {
 	*mock.Call
 }
 
-// StreamExists is a helper method to define mock.On call
-func (_e *Client_Expecter) StreamExists() *Client_StreamExists_Call {
-	return &Client_StreamExists_Call{Call: _e.mock.On("StreamExists")}
+// CreateStream is a helper method to define mock.On call
+//   - config *nats.StreamConfig
+func (_e *Client_Expecter) CreateStream(config interface{}) *Client_CreateStream_Call {
+	return &Client_CreateStream_Call{Call: _e.mock.On("CreateStream", config)}
 }
 
-func (_c *Client_StreamExists_Call) Run(run func()) *Client_StreamExists_Call {
+func (_c *Client_CreateStream_Call) Run(run func(config *nats.StreamConfig)) *Client_CreateStream_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(*nats.StreamConfig))
 	})
 	return _c
 }
 
-func (_c *Client_StreamExists_Call) Return(_a0 bool, _a1 error) *Client_StreamExists_Call {
+func (_c *Client_CreateStream_Call) Return(_a0 *nats.StreamInfo, _a1 error) *Client_CreateStream_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Client_StreamExists_Call) RunAndReturn(run func() (bool, error)) *Client_StreamExists_Call {
+func (_c *Client_CreateStream_Call) RunAndReturn(run func(*nats.StreamConfig) (*nats.StreamInfo, error)) *Client_CreateStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAccountClaims provides a mock function with given fields: deleteRequest
+func (_m *Client) DeleteAccountClaims(deleteRequest string) error {
+	ret := _m.Called(deleteRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAccountClaims")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(deleteRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_DeleteAccountClaims_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAccountClaims'
+type Client_DeleteAccountClaims_Call struct {
+	*mock.Call
+}
+
+// DeleteAccountClaims is a helper method to define mock.On call
+//   - deleteRequest string
+func (_e *Client_Expecter) DeleteAccountClaims(deleteRequest interface{}) *Client_DeleteAccountClaims_Call {
+	return &Client_DeleteAccountClaims_Call{Call: _e.mock.On("DeleteAccountClaims", deleteRequest)}
+}
+
+func (_c *Client_DeleteAccountClaims_Call) Run(run func(deleteRequest string)) *Client_DeleteAccountClaims_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Client_DeleteAccountClaims_Call) Return(_a0 error) *Client_DeleteAccountClaims_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_DeleteAccountClaims_Call) RunAndReturn(run func(string) error) *Client_DeleteAccountClaims_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteConsumer provides a mock function with given fields: streamName, consumerName
+func (_m *Client) DeleteConsumer(streamName string, consumerName string) error {
+	ret := _m.Called(streamName, consumerName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConsumer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(streamName, consumerName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_DeleteConsumer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConsumer'
+type Client_DeleteConsumer_Call struct {
+	*mock.Call
+}
+
+// DeleteConsumer is a helper method to define mock.On call
+//   - streamName string
+//   - consumerName string
+func (_e *Client_Expecter) DeleteConsumer(streamName interface{}, consumerName interface{}) *Client_DeleteConsumer_Call {
+	return &Client_DeleteConsumer_Call{Call: _e.mock.On("DeleteConsumer", streamName, consumerName)}
+}
+
+func (_c *Client_DeleteConsumer_Call) Run(run func(streamName string, consumerName string)) *Client_DeleteConsumer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_DeleteConsumer_Call) Return(_a0 error) *Client_DeleteConsumer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_DeleteConsumer_Call) RunAndReturn(run func(string, string) error) *Client_DeleteConsumer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteKeyValue provides a mock function with given fields: bucket
+func (_m *Client) DeleteKeyValue(bucket string) error {
+	ret := _m.Called(bucket)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteKeyValue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(bucket)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_DeleteKeyValue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteKeyValue'
+type Client_DeleteKeyValue_Call struct {
+	*mock.Call
+}
+
+// DeleteKeyValue is a helper method to define mock.On call
+//   - bucket string
+func (_e *Client_Expecter) DeleteKeyValue(bucket interface{}) *Client_DeleteKeyValue_Call {
+	return &Client_DeleteKeyValue_Call{Call: _e.mock.On("DeleteKeyValue", bucket)}
+}
+
+func (_c *Client_DeleteKeyValue_Call) Run(run func(bucket string)) *Client_DeleteKeyValue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Client_DeleteKeyValue_Call) Return(_a0 error) *Client_DeleteKeyValue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_DeleteKeyValue_Call) RunAndReturn(run func(string) error) *Client_DeleteKeyValue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteObjectStore provides a mock function with given fields: bucket
+func (_m *Client) DeleteObjectStore(bucket string) error {
+	ret := _m.Called(bucket)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteObjectStore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(bucket)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_DeleteObjectStore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteObjectStore'
+type Client_DeleteObjectStore_Call struct {
+	*mock.Call
+}
+
+// DeleteObjectStore is a helper method to define mock.On call
+//   - bucket string
+func (_e *Client_Expecter) DeleteObjectStore(bucket interface{}) *Client_DeleteObjectStore_Call {
+	return &Client_DeleteObjectStore_Call{Call: _e.mock.On("DeleteObjectStore", bucket)}
+}
+
+func (_c *Client_DeleteObjectStore_Call) Run(run func(bucket string)) *Client_DeleteObjectStore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Client_DeleteObjectStore_Call) Return(_a0 error) *Client_DeleteObjectStore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_DeleteObjectStore_Call) RunAndReturn(run func(string) error) *Client_DeleteObjectStore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteStream provides a mock function with given fields: streamName
+func (_m *Client) DeleteStream(streamName string) error {
+	ret := _m.Called(streamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(streamName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_DeleteStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStream'
+type Client_DeleteStream_Call struct {
+	*mock.Call
+}
+
+// DeleteStream is a helper method to define mock.On call
+//   - streamName string
+func (_e *Client_Expecter) DeleteStream(streamName interface{}) *Client_DeleteStream_Call {
+	return &Client_DeleteStream_Call{Call: _e.mock.On("DeleteStream", streamName)}
+}
+
+func (_c *Client_DeleteStream_Call) Run(run func(streamName string)) *Client_DeleteStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Client_DeleteStream_Call) Return(_a0 error) *Client_DeleteStream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_DeleteStream_Call) RunAndReturn(run func(string) error) *Client_DeleteStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStreams provides a mock function with no fields
+func (_m *Client) GetStreams() ([]*nats.StreamInfo, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStreams")
+	}
+
+	var r0 []*nats.StreamInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*nats.StreamInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*nats.StreamInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*nats.StreamInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetStreams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStreams'
+type Client_GetStreams_Call struct {
+	*mock.Call
+}
+
+// GetStreams is a helper method to define mock.On call
+func (_e *Client_Expecter) GetStreams() *Client_GetStreams_Call {
+	return &Client_GetStreams_Call{Call: _e.mock.On("GetStreams")}
+}
+
+func (_c *Client_GetStreams_Call) Run(run func()) *Client_GetStreams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Client_GetStreams_Call) Return(_a0 []*nats.StreamInfo, _a1 error) *Client_GetStreams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetStreams_Call) RunAndReturn(run func() ([]*nats.StreamInfo, error)) *Client_GetStreams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Init provides a mock function with no fields
+func (_m *Client) Init() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Init")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_Init_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Init'
+type Client_Init_Call struct {
+	*mock.Call
+}
+
+// Init is a helper method to define mock.On call
+func (_e *Client_Expecter) Init() *Client_Init_Call {
+	return &Client_Init_Call{Call: _e.mock.On("Init")}
+}
+
+func (_c *Client_Init_Call) Run(run func()) *Client_Init_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Client_Init_Call) Return(_a0 error) *Client_Init_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_Init_Call) RunAndReturn(run func() error) *Client_Init_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveStream provides a mock function with given fields: accountName, streamName, serverName
+func (_m *Client) MoveStream(accountName string, streamName string, serverName string) error {
+	ret := _m.Called(accountName, streamName, serverName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveStream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(accountName, streamName, serverName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_MoveStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveStream'
+type Client_MoveStream_Call struct {
+	*mock.Call
+}
+
+// MoveStream is a helper method to define mock.On call
+//   - accountName string
+//   - streamName string
+//   - serverName string
+func (_e *Client_Expecter) MoveStream(accountName interface{}, streamName interface{}, serverName interface{}) *Client_MoveStream_Call {
+	return &Client_MoveStream_Call{Call: _e.mock.On("MoveStream", accountName, streamName, serverName)}
+}
+
+func (_c *Client_MoveStream_Call) Run(run func(accountName string, streamName string, serverName string)) *Client_MoveStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Client_MoveStream_Call) Return(_a0 error) *Client_MoveStream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_MoveStream_Call) RunAndReturn(run func(string, string, string) error) *Client_MoveStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveServer provides a mock function with given fields: serverName
+func (_m *Client) RemoveServer(serverName string) error {
+	ret := _m.Called(serverName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveServer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(serverName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_RemoveServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveServer'
+type Client_RemoveServer_Call struct {
+	*mock.Call
+}
+
+// RemoveServer is a helper method to define mock.On call
+//   - serverName string
+func (_e *Client_Expecter) RemoveServer(serverName interface{}) *Client_RemoveServer_Call {
+	return &Client_RemoveServer_Call{Call: _e.mock.On("RemoveServer", serverName)}
+}
+
+func (_c *Client_RemoveServer_Call) Run(run func(serverName string)) *Client_RemoveServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Client_RemoveServer_Call) Return(_a0 error) *Client_RemoveServer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_RemoveServer_Call) RunAndReturn(run func(string) error) *Client_RemoveServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreStream provides a mock function with given fields: snapshot, reader
+func (_m *Client) RestoreStream(snapshot *nats.StreamInfo, reader io.Reader) (*nats.StreamInfo, error) {
+	ret := _m.Called(snapshot, reader)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreStream")
+	}
+
+	var r0 *nats.StreamInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*nats.StreamInfo, io.Reader) (*nats.StreamInfo, error)); ok {
+		return rf(snapshot, reader)
+	}
+	if rf, ok := ret.Get(0).(func(*nats.StreamInfo, io.Reader) *nats.StreamInfo); ok {
+		r0 = rf(snapshot, reader)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*nats.StreamInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*nats.StreamInfo, io.Reader) error); ok {
+		r1 = rf(snapshot, reader)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_RestoreStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreStream'
+type Client_RestoreStream_Call struct {
+	*mock.Call
+}
+
+// RestoreStream is a helper method to define mock.On call
+//   - snapshot *nats.StreamInfo
+//   - reader io.Reader
+func (_e *Client_Expecter) RestoreStream(snapshot interface{}, reader interface{}) *Client_RestoreStream_Call {
+	return &Client_RestoreStream_Call{Call: _e.mock.On("RestoreStream", snapshot, reader)}
+}
+
+func (_c *Client_RestoreStream_Call) Run(run func(snapshot *nats.StreamInfo, reader io.Reader)) *Client_RestoreStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*nats.StreamInfo), args[1].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *Client_RestoreStream_Call) Return(_a0 *nats.StreamInfo, _a1 error) *Client_RestoreStream_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_RestoreStream_Call) RunAndReturn(run func(*nats.StreamInfo, io.Reader) (*nats.StreamInfo, error)) *Client_RestoreStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SnapshotStream provides a mock function with given fields: streamName, writer
+func (_m *Client) SnapshotStream(streamName string, writer io.Writer) (*nats.StreamInfo, error) {
+	ret := _m.Called(streamName, writer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SnapshotStream")
+	}
+
+	var r0 *nats.StreamInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, io.Writer) (*nats.StreamInfo, error)); ok {
+		return rf(streamName, writer)
+	}
+	if rf, ok := ret.Get(0).(func(string, io.Writer) *nats.StreamInfo); ok {
+		r0 = rf(streamName, writer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*nats.StreamInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, io.Writer) error); ok {
+		r1 = rf(streamName, writer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_SnapshotStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SnapshotStream'
+type Client_SnapshotStream_Call struct {
+	*mock.Call
+}
+
+// SnapshotStream is a helper method to define mock.On call
+//   - streamName string
+//   - writer io.Writer
+func (_e *Client_Expecter) SnapshotStream(streamName interface{}, writer interface{}) *Client_SnapshotStream_Call {
+	return &Client_SnapshotStream_Call{Call: _e.mock.On("SnapshotStream", streamName, writer)}
+}
+
+func (_c *Client_SnapshotStream_Call) Run(run func(streamName string, writer io.Writer)) *Client_SnapshotStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *Client_SnapshotStream_Call) Return(_a0 *nats.StreamInfo, _a1 error) *Client_SnapshotStream_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_SnapshotStream_Call) RunAndReturn(run func(string, io.Writer) (*nats.StreamInfo, error)) *Client_SnapshotStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamExists provides a mock function with no fields
+func (_m *Client) StreamExists() (bool, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (bool, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_StreamExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamExists'
+type Client_StreamExists_Call struct {
+	*mock.Call
+}
+
+// StreamExists is a helper method to define mock.On call
+func (_e *Client_Expecter) StreamExists() *Client_StreamExists_Call {
+	return &Client_StreamExists_Call{Call: _e.mock.On("StreamExists")}
+}
+
+func (_c *Client_StreamExists_Call) Run(run func()) *Client_StreamExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Client_StreamExists_Call) Return(_a0 bool, _a1 error) *Client_StreamExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_StreamExists_Call) RunAndReturn(run func() (bool, error)) *Client_StreamExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamInfo provides a mock function with given fields: streamName
+func (_m *Client) StreamInfo(streamName string) (*nats.StreamInfo, error) {
+	ret := _m.Called(streamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamInfo")
+	}
+
+	var r0 *nats.StreamInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*nats.StreamInfo, error)); ok {
+		return rf(streamName)
+	}
+	if rf, ok := ret.Get(0).(func(string) *nats.StreamInfo); ok {
+		r0 = rf(streamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*nats.StreamInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(streamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_StreamInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamInfo'
+type Client_StreamInfo_Call struct {
+	*mock.Call
+}
+
+// StreamInfo is a helper method to define mock.On call
+//   - streamName string
+func (_e *Client_Expecter) StreamInfo(streamName interface{}) *Client_StreamInfo_Call {
+	return &Client_StreamInfo_Call{Call: _e.mock.On("StreamInfo", streamName)}
+}
+
+func (_c *Client_StreamInfo_Call) Run(run func(streamName string)) *Client_StreamInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Client_StreamInfo_Call) Return(_a0 *nats.StreamInfo, _a1 error) *Client_StreamInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_StreamInfo_Call) RunAndReturn(run func(string) (*nats.StreamInfo, error)) *Client_StreamInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAccountClaims provides a mock function with given fields: accountJWT
+func (_m *Client) UpdateAccountClaims(accountJWT string) error {
+	ret := _m.Called(accountJWT)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccountClaims")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(accountJWT)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_UpdateAccountClaims_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAccountClaims'
+type Client_UpdateAccountClaims_Call struct {
+	*mock.Call
+}
+
+// UpdateAccountClaims is a helper method to define mock.On call
+//   - accountJWT string
+func (_e *Client_Expecter) UpdateAccountClaims(accountJWT interface{}) *Client_UpdateAccountClaims_Call {
+	return &Client_UpdateAccountClaims_Call{Call: _e.mock.On("UpdateAccountClaims", accountJWT)}
+}
+
+func (_c *Client_UpdateAccountClaims_Call) Run(run func(accountJWT string)) *Client_UpdateAccountClaims_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Client_UpdateAccountClaims_Call) Return(_a0 error) *Client_UpdateAccountClaims_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_UpdateAccountClaims_Call) RunAndReturn(run func(string) error) *Client_UpdateAccountClaims_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateConsumer provides a mock function with given fields: streamName, config
+func (_m *Client) UpdateConsumer(streamName string, config *nats.ConsumerConfig) (*nats.ConsumerInfo, error) {
+	ret := _m.Called(streamName, config)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateConsumer")
+	}
+
+	var r0 *nats.ConsumerInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *nats.ConsumerConfig) (*nats.ConsumerInfo, error)); ok {
+		return rf(streamName, config)
+	}
+	if rf, ok := ret.Get(0).(func(string, *nats.ConsumerConfig) *nats.ConsumerInfo); ok {
+		r0 = rf(streamName, config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*nats.ConsumerInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *nats.ConsumerConfig) error); ok {
+		r1 = rf(streamName, config)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_UpdateConsumer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateConsumer'
+type Client_UpdateConsumer_Call struct {
+	*mock.Call
+}
+
+// UpdateConsumer is a helper method to define mock.On call
+//   - streamName string
+//   - config *nats.ConsumerConfig
+func (_e *Client_Expecter) UpdateConsumer(streamName interface{}, config interface{}) *Client_UpdateConsumer_Call {
+	return &Client_UpdateConsumer_Call{Call: _e.mock.On("UpdateConsumer", streamName, config)}
+}
+
+func (_c *Client_UpdateConsumer_Call) Run(run func(streamName string, config *nats.ConsumerConfig)) *Client_UpdateConsumer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*nats.ConsumerConfig))
+	})
+	return _c
+}
+
+func (_c *Client_UpdateConsumer_Call) Return(_a0 *nats.ConsumerInfo, _a1 error) *Client_UpdateConsumer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_UpdateConsumer_Call) RunAndReturn(run func(string, *nats.ConsumerConfig) (*nats.ConsumerInfo, error)) *Client_UpdateConsumer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStream provides a mock function with given fields: config
+func (_m *Client) UpdateStream(config *nats.StreamConfig) (*nats.StreamInfo, error) {
+	ret := _m.Called(config)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStream")
+	}
+
+	var r0 *nats.StreamInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*nats.StreamConfig) (*nats.StreamInfo, error)); ok {
+		return rf(config)
+	}
+	if rf, ok := ret.Get(0).(func(*nats.StreamConfig) *nats.StreamInfo); ok {
+		r0 = rf(config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*nats.StreamInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*nats.StreamConfig) error); ok {
+		r1 = rf(config)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_UpdateStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStream'
+type Client_UpdateStream_Call struct {
+	*mock.Call
+}
+
+// UpdateStream is a helper method to define mock.On call
+//   - config *nats.StreamConfig
+func (_e *Client_Expecter) UpdateStream(config interface{}) *Client_UpdateStream_Call {
+	return &Client_UpdateStream_Call{Call: _e.mock.On("UpdateStream", config)}
+}
+
+func (_c *Client_UpdateStream_Call) Run(run func(config *nats.StreamConfig)) *Client_UpdateStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*nats.StreamConfig))
+	})
+	return _c
+}
+
+func (_c *Client_UpdateStream_Call) Return(_a0 *nats.StreamInfo, _a1 error) *Client_UpdateStream_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_UpdateStream_Call) RunAndReturn(run func(*nats.StreamConfig) (*nats.StreamInfo, error)) *Client_UpdateStream_Call {
 	_c.Call.Return(run)
 	return _c
 }
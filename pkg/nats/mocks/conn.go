@@ -6,6 +6,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	nats_go "github.com/nats-io/nats.go"
+
+	time "time"
 )
 
 // Conn is an autogenerated mock type for the Conn type
@@ -155,6 +157,114 @@ func (_c *Conn_JetStream_Call) RunAndReturn(run func() (nats_go.JetStreamContext
 	return _c
 }
 
+// PublishRequest provides a mock function with given fields: subject, reply, data
+func (_m *Conn) PublishRequest(subject string, reply string, data []byte) error {
+	ret := _m.Called(subject, reply, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []byte) error); ok {
+		r0 = rf(subject, reply, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Conn_PublishRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishRequest'
+type Conn_PublishRequest_Call struct {
+	*mock.Call
+}
+
+// PublishRequest is a helper method to define mock.On call
+//   - subject string
+//   - reply string
+//   - data []byte
+func (_e *Conn_Expecter) PublishRequest(subject interface{}, reply interface{}, data interface{}) *Conn_PublishRequest_Call {
+	return &Conn_PublishRequest_Call{Call: _e.mock.On("PublishRequest", subject, reply, data)}
+}
+
+func (_c *Conn_PublishRequest_Call) Run(run func(subject string, reply string, data []byte)) *Conn_PublishRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *Conn_PublishRequest_Call) Return(_a0 error) *Conn_PublishRequest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Conn_PublishRequest_Call) RunAndReturn(run func(string, string, []byte) error) *Conn_PublishRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Request provides a mock function with given fields: subject, data, timeout
+func (_m *Conn) Request(subject string, data []byte, timeout time.Duration) (*nats_go.Msg, error) {
+	ret := _m.Called(subject, data, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Request")
+	}
+
+	var r0 *nats_go.Msg
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []byte, time.Duration) (*nats_go.Msg, error)); ok {
+		return rf(subject, data, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(string, []byte, time.Duration) *nats_go.Msg); ok {
+		r0 = rf(subject, data, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*nats_go.Msg)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []byte, time.Duration) error); ok {
+		r1 = rf(subject, data, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Conn_Request_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Request'
+type Conn_Request_Call struct {
+	*mock.Call
+}
+
+// Request is a helper method to define mock.On call
+//   - subject string
+//   - data []byte
+//   - timeout time.Duration
+func (_e *Conn_Expecter) Request(subject interface{}, data interface{}, timeout interface{}) *Conn_Request_Call {
+	return &Conn_Request_Call{Call: _e.mock.On("Request", subject, data, timeout)}
+}
+
+func (_c *Conn_Request_Call) Run(run func(subject string, data []byte, timeout time.Duration)) *Conn_Request_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]byte), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Conn_Request_Call) Return(_a0 *nats_go.Msg, _a1 error) *Conn_Request_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Conn_Request_Call) RunAndReturn(run func(string, []byte, time.Duration) (*nats_go.Msg, error)) *Conn_Request_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Status provides a mock function with no fields
 func (_m *Conn) Status() nats_go.Status {
 	ret := _m.Called()
@@ -200,6 +310,64 @@ func (_c *Conn_Status_Call) RunAndReturn(run func() nats_go.Status) *Conn_Status
 	return _c
 }
 
+// SubscribeSync provides a mock function with given fields: subject
+func (_m *Conn) SubscribeSync(subject string) (*nats_go.Subscription, error) {
+	ret := _m.Called(subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeSync")
+	}
+
+	var r0 *nats_go.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*nats_go.Subscription, error)); ok {
+		return rf(subject)
+	}
+	if rf, ok := ret.Get(0).(func(string) *nats_go.Subscription); ok {
+		r0 = rf(subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*nats_go.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Conn_SubscribeSync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeSync'
+type Conn_SubscribeSync_Call struct {
+	*mock.Call
+}
+
+// SubscribeSync is a helper method to define mock.On call
+//   - subject string
+func (_e *Conn_Expecter) SubscribeSync(subject interface{}) *Conn_SubscribeSync_Call {
+	return &Conn_SubscribeSync_Call{Call: _e.mock.On("SubscribeSync", subject)}
+}
+
+func (_c *Conn_SubscribeSync_Call) Run(run func(subject string)) *Conn_SubscribeSync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Conn_SubscribeSync_Call) Return(_a0 *nats_go.Subscription, _a1 error) *Conn_SubscribeSync_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Conn_SubscribeSync_Call) RunAndReturn(run func(string) (*nats_go.Subscription, error)) *Conn_SubscribeSync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewConn creates a new instance of Conn. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewConn(t interface {
@@ -0,0 +1,166 @@
+package monitor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout is used for the requests to the monitoring endpoint if the Config has no timeout.
+	DefaultTimeout = 5 * time.Second
+
+	gatewayzPath = "/gatewayz"
+	healthzPath  = "/healthz"
+	jszPath      = "/jsz"
+	// jszServerPath counts the streams and consumers which the NATS server leads.
+	jszServerPath = jszPath + "?raft=true"
+	// jszStreamsPath lists the streams of all accounts which are led by the NATS server, so that every stream
+	// of the cluster is listed by exactly one NATS server with the state of all of its replicas.
+	jszStreamsPath = jszPath + "?accounts=true&streams=true&config=true&stream-leader-only=true"
+	routezPath     = "/routez"
+	varzPath       = "/varz"
+)
+
+var (
+	ErrInvalidRootCAs   = errors.New("no valid certificate found in root CAs")
+	ErrUnexpectedStatus = errors.New("unexpected status code from the NATS monitoring endpoint")
+)
+
+// Client reads the state of a NATS server from its HTTP monitoring endpoint.
+//
+//go:generate go run github.com/vektra/mockery/v2 --name=Client --outpkg=mocks --case=underscore
+type Client interface {
+	// Gatewayz returns the gateway connections of the NATS server.
+	Gatewayz(ctx context.Context) (*Gatewayz, error)
+	// Healthz returns the health of the NATS server. With JetStream, the NATS server is only healthy
+	// once JetStream is current and all streams and consumers on the server have caught up.
+	Healthz(ctx context.Context) (*Healthz, error)
+	// Jsz returns the JetStream state of the NATS server, including the meta group of the cluster.
+	Jsz(ctx context.Context) (*Jsz, error)
+	// JszStreams returns the JetStream state of the NATS server including the streams of all accounts
+	// which are led by the NATS server.
+	JszStreams(ctx context.Context) (*Jsz, error)
+	// Routez returns the routes of the NATS server to the other NATS servers of the cluster.
+	Routez(ctx context.Context) (*Routez, error)
+	// Varz returns the general information and configuration of the NATS server.
+	Varz(ctx context.Context) (*Varz, error)
+}
+
+type Config struct {
+	// URL of the monitoring endpoint, for example, http://eventing-nats.kyma-system.svc.cluster.local:8222.
+	URL     string
+	Timeout time.Duration
+	// RootCAs are the PEM encoded CA certificates to verify the server certificate with.
+	// If not set, the system root CAs are used for HTTPS.
+	RootCAs []byte
+}
+
+type httpClient struct {
+	url    string
+	client *http.Client
+}
+
+// NewClient returns a Client for the monitoring endpoint in the given Config.
+func NewClient(config *Config) (Client, error) {
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert // it is always a transport
+	if len(config.RootCAs) > 0 {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(config.RootCAs) {
+			return nil, ErrInvalidRootCAs
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
+
+	return &httpClient{
+		url:    strings.TrimSuffix(config.URL, "/"),
+		client: &http.Client{Timeout: timeout, Transport: transport},
+	}, nil
+}
+
+func (c *httpClient) Gatewayz(ctx context.Context) (*Gatewayz, error) {
+	gatewayz := &Gatewayz{}
+	if err := c.get(ctx, gatewayzPath, gatewayz); err != nil {
+		return nil, err
+	}
+	return gatewayz, nil
+}
+
+func (c *httpClient) Healthz(ctx context.Context) (*Healthz, error) {
+	healthz := &Healthz{}
+	// an unhealthy NATS server responds with 503 and the reason in the body.
+	if err := c.get(ctx, healthzPath, healthz, http.StatusOK, http.StatusServiceUnavailable); err != nil {
+		return nil, err
+	}
+	return healthz, nil
+}
+
+func (c *httpClient) Jsz(ctx context.Context) (*Jsz, error) {
+	jsz := &Jsz{}
+	if err := c.get(ctx, jszServerPath, jsz); err != nil {
+		return nil, err
+	}
+	return jsz, nil
+}
+
+func (c *httpClient) JszStreams(ctx context.Context) (*Jsz, error) {
+	jsz := &Jsz{}
+	if err := c.get(ctx, jszStreamsPath, jsz); err != nil {
+		return nil, err
+	}
+	return jsz, nil
+}
+
+func (c *httpClient) Routez(ctx context.Context) (*Routez, error) {
+	routez := &Routez{}
+	if err := c.get(ctx, routezPath, routez); err != nil {
+		return nil, err
+	}
+	return routez, nil
+}
+
+func (c *httpClient) Varz(ctx context.Context) (*Varz, error) {
+	varz := &Varz{}
+	if err := c.get(ctx, varzPath, varz); err != nil {
+		return nil, err
+	}
+	return varz, nil
+}
+
+// get decodes the JSON response of the given monitoring path into the result. Responses with another status
+// than the accepted ones, by default only 200, are rejected.
+func (c *httpClient) get(ctx context.Context, path string, result any, acceptedStatuses ...int) error {
+	if len(acceptedStatuses) == 0 {
+		acceptedStatuses = []int{http.StatusOK}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	response, err := c.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", path, err)
+	}
+	defer response.Body.Close()
+
+	if !slices.Contains(acceptedStatuses, response.StatusCode) {
+		return fmt.Errorf("%w: %s returned %d", ErrUnexpectedStatus, path, response.StatusCode)
+	}
+	if err = json.NewDecoder(response.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package monitor
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const gatewayzResponse = `{
+  "server_id": "NCSMNIM4TX",
+  "now": "2026-03-01T10:00:00Z",
+  "name": "eu",
+  "host": "0.0.0.0",
+  "port": 7522,
+  "outbound_gateways": {
+    "us": {
+      "configured": true,
+      "connection": {"cid": 7, "ip": "10.0.0.12", "port": 7522, "start": "2026-03-01T09:00:00Z", "name": "NDXUS3B2"}
+    },
+    "ap": {
+      "configured": true
+    }
+  },
+  "inbound_gateways": {
+    "us": [
+      {"configured": false, "connection": {"cid": 9, "ip": "10.0.0.13", "port": 41234, "name": "NDXUS3B3"}}
+    ]
+  }
+}`
+
+func Test_Gatewayz(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		givenStatus   int
+		givenBody     string
+		wantError     error
+		wantAnyError  bool
+		wantConnected map[string]bool
+	}{
+		{
+			name:        "should return the gateways",
+			givenStatus: http.StatusOK,
+			givenBody:   gatewayzResponse,
+			wantConnected: map[string]bool{
+				"us": true,
+				"ap": false,
+			},
+		},
+		{
+			name:        "should fail if the endpoint does not return OK",
+			givenStatus: http.StatusServiceUnavailable,
+			wantError:   ErrUnexpectedStatus,
+		},
+		{
+			name:         "should fail if the response is no valid JSON",
+			givenStatus:  http.StatusOK,
+			givenBody:    "gateways",
+			wantAnyError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, gatewayzPath, r.URL.Path)
+				w.WriteHeader(tc.givenStatus)
+				_, _ = w.Write([]byte(tc.givenBody))
+			}))
+			t.Cleanup(server.Close)
+			client, err := NewClient(&Config{URL: server.URL + "/"})
+			require.NoError(t, err)
+
+			// when
+			gotGatewayz, err := client.Gatewayz(context.Background())
+
+			// then
+			if tc.wantError != nil || tc.wantAnyError {
+				require.Error(t, err)
+				if tc.wantError != nil {
+					require.ErrorIs(t, err, tc.wantError)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "eu", gotGatewayz.Name)
+			require.Len(t, gotGatewayz.OutboundGateways, len(tc.wantConnected))
+			for name, connected := range tc.wantConnected {
+				require.Equal(t, connected, gotGatewayz.OutboundGateways[name].Connection != nil, name)
+			}
+			require.Len(t, gotGatewayz.InboundGateways["us"], 1)
+		})
+	}
+}
+
+func Test_Healthz(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		givenStatus int
+		givenBody   string
+		wantError   error
+		wantHealthy bool
+		wantReason  string
+	}{
+		{
+			name:        "should return a healthy server",
+			givenStatus: http.StatusOK,
+			givenBody:   `{"status":"ok"}`,
+			wantHealthy: true,
+		},
+		{
+			name:        "should return an unhealthy server with the reason",
+			givenStatus: http.StatusServiceUnavailable,
+			givenBody:   `{"status":"unavailable","status_code":503,"error":"JetStream stream 'orders' is not current"}`,
+			wantHealthy: false,
+			wantReason:  "JetStream stream 'orders' is not current",
+		},
+		{
+			name:        "should fail if the endpoint returns another status",
+			givenStatus: http.StatusNotFound,
+			wantError:   ErrUnexpectedStatus,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, healthzPath, r.URL.Path)
+				w.WriteHeader(tc.givenStatus)
+				_, _ = w.Write([]byte(tc.givenBody))
+			}))
+			t.Cleanup(server.Close)
+			client, err := NewClient(&Config{URL: server.URL})
+			require.NoError(t, err)
+
+			// when
+			gotHealthz, err := client.Healthz(context.Background())
+
+			// then
+			if tc.wantError != nil {
+				require.ErrorIs(t, err, tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantHealthy, gotHealthz.IsHealthy())
+			require.Equal(t, tc.wantReason, gotHealthz.Error)
+		})
+	}
+}
+
+func Test_NewClient_TLS(t *testing.T) {
+	t.Parallel()
+
+	// given
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(gatewayzResponse))
+	}))
+	t.Cleanup(server.Close)
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// when
+	_, err := NewClient(&Config{URL: server.URL, RootCAs: []byte("invalid")})
+
+	// then
+	require.ErrorIs(t, err, ErrInvalidRootCAs)
+
+	// when
+	untrustingClient, err := NewClient(&Config{URL: server.URL})
+	require.NoError(t, err)
+	_, err = untrustingClient.Gatewayz(context.Background())
+
+	// then the self-signed certificate of the test server is not trusted by default.
+	require.Error(t, err)
+
+	// when
+	client, err := NewClient(&Config{URL: server.URL, RootCAs: serverCA})
+	require.NoError(t, err)
+	_, err = client.Gatewayz(context.Background())
+
+	// then
+	require.NoError(t, err)
+}
+
+func Test_ClusterEndpoints(t *testing.T) {
+	t.Parallel()
+
+	// given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case varzPath:
+			_, _ = w.Write([]byte(`{"server_id":"NCSMNIM4TX","server_name":"eventing-nats-0","version":"2.12.1","connections":12,` +
+				`"cluster":{"name":"eventing-nats","urls":["eventing-nats-1.eventing-nats:6222"]}}`))
+		case routezPath:
+			_, _ = w.Write([]byte(`{"server_id":"NCSMNIM4TX","server_name":"eventing-nats-0","num_routes":2,` +
+				`"routes":[{"rid":1,"remote_id":"NDX1","remote_name":"eventing-nats-1","ip":"10.0.0.2","port":6222},` +
+				`{"rid":2,"remote_id":"NDX1","remote_name":"eventing-nats-1","ip":"10.0.0.2","port":6222}]}`))
+		case jszPath:
+			if r.URL.Query().Get("streams") == "true" {
+				_, _ = w.Write([]byte(`{"server_id":"NCSMNIM4TX","account_details":[{"name":"nats-manager","id":"ADX",` +
+					`"stream_detail":[{"name":"sap","config":{"num_replicas":3},"cluster":{"leader":"eventing-nats-0",` +
+					`"replicas":[{"name":"eventing-nats-1","current":true},{"name":"eventing-nats-2","current":false}]}}]}]}`))
+				return
+			}
+			require.Equal(t, "true", r.URL.Query().Get("raft"))
+			_, _ = w.Write([]byte(`{"server_id":"NCSMNIM4TX","memory":512,"storage":2048,"streams":3,` +
+				`"streams_leader":1,"consumers":6,"consumers_leader":2,"meta_cluster":{"name":"eventing-nats",` +
+				`"leader":"eventing-nats-1","cluster_size":2,"replicas":[{"name":"eventing-nats-1","current":true}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(&Config{URL: server.URL})
+	require.NoError(t, err)
+
+	// when
+	gotVarz, err := client.Varz(context.Background())
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, "eventing-nats-0", gotVarz.ServerName)
+	require.Equal(t, "eventing-nats", gotVarz.Cluster.Name)
+	require.Equal(t, 12, gotVarz.Connections)
+
+	// when
+	gotRoutez, err := client.Routez(context.Background())
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, 2, gotRoutez.NumRoutes)
+	require.Len(t, gotRoutez.Routes, 2)
+	require.Equal(t, "eventing-nats-1", gotRoutez.Routes[0].RemoteName)
+
+	// when
+	gotJsz, err := client.Jsz(context.Background())
+
+	// then
+	require.NoError(t, err)
+	require.False(t, gotJsz.Disabled)
+	require.Equal(t, int64(512), gotJsz.Memory)
+	require.Equal(t, int64(2048), gotJsz.Store)
+	require.Equal(t, 1, gotJsz.StreamsLeader)
+	require.Equal(t, 2, gotJsz.ConsumersLeader)
+	require.NotNil(t, gotJsz.Meta)
+	require.Equal(t, "eventing-nats-1", gotJsz.Meta.Leader)
+	require.Len(t, gotJsz.Meta.Replicas, 1)
+	require.True(t, gotJsz.Meta.Replicas[0].Current)
+
+	// when
+	gotJsz, err = client.JszStreams(context.Background())
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, gotJsz.AccountDetails, 1)
+	require.Equal(t, "ADX", gotJsz.AccountDetails[0].ID)
+	require.Len(t, gotJsz.AccountDetails[0].Streams, 1)
+	gotStream := gotJsz.AccountDetails[0].Streams[0]
+	require.Equal(t, "sap", gotStream.Name)
+	require.Equal(t, 3, gotStream.Config.Replicas)
+	require.Equal(t, "eventing-nats-0", gotStream.Cluster.Leader)
+	require.Len(t, gotStream.Cluster.Replicas, 2)
+	require.False(t, gotStream.Cluster.Replicas[1].Current)
+}
@@ -0,0 +1,172 @@
+package monitor
+
+import "time"
+
+// The types in this file contain the fields of the NATS monitoring endpoints which the NATS manager uses.
+// See https://docs.nats.io/running-a-nats-service/nats_admin/monitoring for the complete responses.
+
+// Gatewayz is the response of the /gatewayz endpoint.
+type Gatewayz struct {
+	ServerID string    `json:"server_id"`
+	Now      time.Time `json:"now"`
+	// Name of the gateway, i.e. of the NATS cluster in the supercluster.
+	Name string `json:"name,omitempty"`
+	Host string `json:"host,omitempty"`
+	Port int    `json:"port,omitempty"`
+	// OutboundGateways are the connections to the remote gateways by their name.
+	OutboundGateways map[string]*RemoteGatewayz `json:"outbound_gateways"`
+	// InboundGateways are the connections from the remote gateways by their name.
+	InboundGateways map[string][]*RemoteGatewayz `json:"inbound_gateways"`
+}
+
+// RemoteGatewayz is a connection to or from a remote gateway.
+type RemoteGatewayz struct {
+	// IsConfigured is true if the remote gateway is configured explicitly and not discovered.
+	IsConfigured bool      `json:"configured"`
+	Connection   *ConnInfo `json:"connection,omitempty"`
+}
+
+// ConnInfo describes a connection of a NATS server.
+type ConnInfo struct {
+	Cid   uint64    `json:"cid"`
+	IP    string    `json:"ip"`
+	Port  int       `json:"port"`
+	Start time.Time `json:"start"`
+	// Name is the server ID of the remote NATS server.
+	Name string `json:"name,omitempty"`
+}
+
+// HealthzStatusOK is the status of a healthy NATS server.
+const HealthzStatusOK = "ok"
+
+// Healthz is the response of the /healthz endpoint.
+type Healthz struct {
+	Status     string `json:"status"`
+	StatusCode int    `json:"status_code,omitempty"`
+	// Error is the reason why the NATS server is unhealthy.
+	Error string `json:"error,omitempty"`
+}
+
+// IsHealthy returns true if the NATS server reported to be healthy.
+func (h *Healthz) IsHealthy() bool {
+	return h.Status == HealthzStatusOK
+}
+
+// Varz is the response of the /varz endpoint.
+type Varz struct {
+	ServerID   string `json:"server_id"`
+	ServerName string `json:"server_name"`
+	Version    string `json:"version"`
+	// Connections is the number of the current client connections.
+	Connections int `json:"connections"`
+	// Cluster is the configuration of the cluster listener, its name is empty if clustering is disabled.
+	Cluster ClusterOptsVarz `json:"cluster,omitempty"`
+}
+
+// ClusterOptsVarz is the configuration of the cluster listener of a NATS server.
+type ClusterOptsVarz struct {
+	Name string `json:"name,omitempty"`
+	// URLs are the configured routes to the other NATS servers of the cluster.
+	URLs []string `json:"urls,omitempty"`
+}
+
+// Routez is the response of the /routez endpoint.
+type Routez struct {
+	ServerID   string `json:"server_id"`
+	ServerName string `json:"server_name"`
+	NumRoutes  int    `json:"num_routes"`
+	// Routes are the connections to the other NATS servers of the cluster. With route pooling,
+	// there are multiple routes to each NATS server.
+	Routes []*RouteInfo `json:"routes"`
+}
+
+// RouteInfo is a route to another NATS server of the cluster.
+type RouteInfo struct {
+	Rid      uint64 `json:"rid"`
+	RemoteID string `json:"remote_id"`
+	// RemoteName is the server name of the other NATS server.
+	RemoteName string `json:"remote_name,omitempty"`
+	IP         string `json:"ip"`
+	Port       int    `json:"port"`
+}
+
+// Jsz is the response of the /jsz endpoint.
+type Jsz struct {
+	ServerID string    `json:"server_id"`
+	Now      time.Time `json:"now"`
+	// Disabled is true if JetStream is not enabled on the NATS server.
+	Disabled bool `json:"disabled,omitempty"`
+	// Memory is the number of bytes which JetStream uses in the memory storage.
+	Memory int64 `json:"memory"`
+	// Store is the number of bytes which JetStream uses in the file storage.
+	Store int64 `json:"storage"`
+	// StreamsLeader and ConsumersLeader are the numbers of streams and consumers of all accounts which
+	// the NATS server leads, so that every stream and consumer is counted by exactly one NATS server.
+	StreamsLeader   int `json:"streams_leader,omitempty"`
+	ConsumersLeader int `json:"consumers_leader,omitempty"`
+	// Config is the JetStream configuration of the NATS server.
+	Config *JetStreamConfig `json:"config,omitempty"`
+	// Meta is the state of the JetStream meta group, it is only set in cluster mode.
+	Meta *MetaClusterInfo `json:"meta_cluster,omitempty"`
+	// AccountDetails are the accounts with their streams, they are only set if requested.
+	AccountDetails []*AccountDetail `json:"account_details,omitempty"`
+}
+
+// JetStreamConfig is the JetStream configuration of a NATS server.
+type JetStreamConfig struct {
+	// MaxMemory is the max_memory_store of the NATS server in bytes.
+	MaxMemory int64 `json:"max_memory"`
+	// MaxStore is the max_file_store of the NATS server in bytes.
+	MaxStore int64 `json:"max_storage"`
+}
+
+// MetaClusterInfo is the state of the JetStream meta group, which manages the streams and consumers of the cluster.
+type MetaClusterInfo struct {
+	Name string `json:"name,omitempty"`
+	// Leader is the server name of the meta leader, it is empty while no meta leader is elected.
+	Leader      string      `json:"leader,omitempty"`
+	ClusterSize int         `json:"cluster_size"`
+	Replicas    []*PeerInfo `json:"replicas,omitempty"`
+}
+
+// AccountDetail is the JetStream state of an account on a NATS server.
+type AccountDetail struct {
+	Name string `json:"name"`
+	// ID is the name of the account in NATS, which is the public key of the account in operator mode.
+	ID      string          `json:"id"`
+	Streams []*StreamDetail `json:"stream_detail,omitempty"`
+}
+
+// StreamDetail is a stream of an account.
+type StreamDetail struct {
+	Name string `json:"name"`
+	// Cluster is the state of the replicas of the stream. Only the leader of the stream knows all replicas.
+	Cluster *ClusterInfo  `json:"cluster,omitempty"`
+	Config  *StreamConfig `json:"config,omitempty"`
+	// Consumers are the consumers of the stream, they are only set if requested.
+	Consumers []*ConsumerDetail `json:"consumer_detail,omitempty"`
+}
+
+// ConsumerDetail is a consumer of a stream.
+type ConsumerDetail struct {
+	Name string `json:"name"`
+}
+
+// ClusterInfo is the state of the replicas of a stream.
+type ClusterInfo struct {
+	Leader string `json:"leader,omitempty"`
+	// Replicas are the replicas of the stream besides the leader.
+	Replicas []*PeerInfo `json:"replicas,omitempty"`
+}
+
+// StreamConfig is the configuration of a stream.
+type StreamConfig struct {
+	Replicas int `json:"num_replicas"`
+}
+
+// PeerInfo is another NATS server in the JetStream meta group or in the replicas of a stream.
+type PeerInfo struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+	Offline bool   `json:"offline,omitempty"`
+}
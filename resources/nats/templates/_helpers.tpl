@@ -101,3 +101,21 @@ It will generate a new password if .Values.auth.adminPassword is not provided.
 {{- printf "%s" $result -}}
 {{- end -}}
 {{- end -}}
+
+{{/*
+Return the name of the Secret with the certificate of a TLS listener.
+*/}}
+{{- define "nats.tlsSecretName" -}}
+{{- default (printf "%s-tls" .root.Release.Name) .listener.secretName -}}
+{{- end }}
+
+{{/*
+Return the tls block of a listener which uses the certificates mounted to the given directory.
+*/}}
+{{- define "nats.tlsConfig" -}}
+tls {
+  cert_file: "{{ . }}/tls.crt"
+  key_file: "{{ . }}/tls.key"
+  ca_file: "{{ . }}/ca.crt"
+}
+{{- end }}
@@ -166,6 +166,11 @@ func NewTestEnvironment(projectRootDir string,
 		natsManager,
 		allowedNATSCR,
 		collector,
+		nmctrl.StatusCheckBackoff{
+			MinInterval: nmctrl.DefaultStatusCheckMinInterval,
+			MaxInterval: nmctrl.DefaultStatusCheckMaxInterval,
+		},
+		nmctrl.DefaultStorageWarningPercent,
 	)
 	if err = (natsReconciler).SetupWithManager(ctrlMgr); err != nil {
 		return nil, err
@@ -248,6 +253,10 @@ func (env TestEnvironment) EnsureK8sResourceUpdated(t *testing.T, obj client.Obj
 	require.NoError(t, env.k8sClient.Update(env.Context, obj))
 }
 
+func (env TestEnvironment) UpdateK8sResource(obj client.Object) error {
+	return env.k8sClient.Update(env.Context, obj)
+}
+
 func (env TestEnvironment) UpdatedNATSInK8s(nats *nmapiv1alpha1.NATS, options ...testutils.NATSOption) error {
 	natsOnK8s, err := env.GetNATSFromK8s(nats.Name, nats.Namespace)
 	if err != nil {
@@ -2,10 +2,12 @@ package testutils
 
 import (
 	"errors"
+	"time"
 
 	nmapiv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
 	kcorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -17,8 +19,17 @@ var (
 )
 
 type (
-	Option     func(*unstructured.Unstructured) error
-	NATSOption func(*nmapiv1alpha1.NATS) error
+	Option                func(*unstructured.Unstructured) error
+	NATSOption            func(*nmapiv1alpha1.NATS) error
+	NATSStreamOption      func(*nmapiv1alpha1.NATSStream) error
+	NATSConsumerOption    func(*nmapiv1alpha1.NATSConsumer) error
+	NATSKeyValueOption    func(*nmapiv1alpha1.NATSKeyValue) error
+	NATSObjectStoreOption func(*nmapiv1alpha1.NATSObjectStore) error
+	NATSAccountOption     func(*nmapiv1alpha1.NATSAccount) error
+	NATSBackupOption      func(*nmapiv1alpha1.NATSBackup) error
+	NATSRestoreOption     func(*nmapiv1alpha1.NATSRestore) error
+
+	NATSBackupScheduleOption func(*nmapiv1alpha1.NATSBackupSchedule) error
 )
 
 func WithNATSCRDefaults() NATSOption {
@@ -281,3 +292,409 @@ func WithNATSResources(resources kcorev1.ResourceRequirements) NATSOption {
 		return nil
 	}
 }
+
+func WithNATSTLS(tls nmapiv1alpha1.TLS) NATSOption {
+	return func(nats *nmapiv1alpha1.NATS) error {
+		nats.Spec.TLS = tls
+		return nil
+	}
+}
+
+func WithNATSLeafNodes(leafNodes nmapiv1alpha1.LeafNodes) NATSOption {
+	return func(nats *nmapiv1alpha1.NATS) error {
+		nats.Spec.LeafNodes = leafNodes
+		return nil
+	}
+}
+
+func WithNATSGateways(gateways nmapiv1alpha1.Gateways) NATSOption {
+	return func(nats *nmapiv1alpha1.NATS) error {
+		nats.Spec.Gateways = gateways
+		return nil
+	}
+}
+
+func WithNATSEncryption(encryption nmapiv1alpha1.JetStreamEncryption) NATSOption {
+	return func(nats *nmapiv1alpha1.NATS) error {
+		nats.Spec.Encryption = encryption
+		return nil
+	}
+}
+
+func WithNATSAuthMode(mode string) NATSOption {
+	return func(nats *nmapiv1alpha1.NATS) error {
+		nats.Spec.Auth.Mode = mode
+		return nil
+	}
+}
+
+func WithNATSDefaultAccount(account string) NATSOption {
+	return func(nats *nmapiv1alpha1.NATS) error {
+		nats.Spec.Auth.DefaultAccount = account
+		return nil
+	}
+}
+
+func WithNATSDeletionPolicy(policy nmapiv1alpha1.DeletionPolicy, gracePeriod time.Duration) NATSOption {
+	return func(nats *nmapiv1alpha1.NATS) error {
+		nats.Spec.DeletionPolicy = policy
+		nats.Spec.DeletionGracePeriod = &kmetav1.Duration{Duration: gracePeriod}
+		return nil
+	}
+}
+
+func WithNATSDeletionProtection(protectedStreams, ignoredStreams []string) NATSOption {
+	return func(nats *nmapiv1alpha1.NATS) error {
+		nats.Spec.DeletionProtection = nmapiv1alpha1.DeletionProtection{
+			ProtectedStreams: protectedStreams,
+			IgnoredStreams:   ignoredStreams,
+		}
+		return nil
+	}
+}
+
+func WithNATSStreamName(name string) NATSStreamOption {
+	return func(stream *nmapiv1alpha1.NATSStream) error {
+		stream.Name = name
+		return nil
+	}
+}
+
+func WithNATSStreamNamespace(namespace string) NATSStreamOption {
+	return func(stream *nmapiv1alpha1.NATSStream) error {
+		stream.Namespace = namespace
+		return nil
+	}
+}
+
+func WithNATSStreamFinalizer(finalizer string) NATSStreamOption {
+	return func(stream *nmapiv1alpha1.NATSStream) error {
+		controllerutil.AddFinalizer(stream, finalizer)
+		return nil
+	}
+}
+
+func WithNATSStreamDeletionTimestamp() NATSStreamOption {
+	return func(stream *nmapiv1alpha1.NATSStream) error {
+		now := kmetav1.Now()
+		stream.DeletionTimestamp = &now
+		return nil
+	}
+}
+
+func WithNATSStreamSpec(spec nmapiv1alpha1.NATSStreamSpec) NATSStreamOption {
+	return func(stream *nmapiv1alpha1.NATSStream) error {
+		stream.Spec = spec
+		return nil
+	}
+}
+
+func WithNATSStreamSpecName(name string) NATSStreamOption {
+	return func(stream *nmapiv1alpha1.NATSStream) error {
+		stream.Spec.Name = name
+		return nil
+	}
+}
+
+func WithNATSStreamSubjects(subjects ...string) NATSStreamOption {
+	return func(stream *nmapiv1alpha1.NATSStream) error {
+		stream.Spec.Subjects = subjects
+		return nil
+	}
+}
+
+func WithNATSStreamState(state string) NATSStreamOption {
+	return func(stream *nmapiv1alpha1.NATSStream) error {
+		stream.Status.State = state
+		return nil
+	}
+}
+
+func WithNATSConsumerName(name string) NATSConsumerOption {
+	return func(consumer *nmapiv1alpha1.NATSConsumer) error {
+		consumer.Name = name
+		return nil
+	}
+}
+
+func WithNATSConsumerNamespace(namespace string) NATSConsumerOption {
+	return func(consumer *nmapiv1alpha1.NATSConsumer) error {
+		consumer.Namespace = namespace
+		return nil
+	}
+}
+
+func WithNATSConsumerStreamRef(streamName string) NATSConsumerOption {
+	return func(consumer *nmapiv1alpha1.NATSConsumer) error {
+		consumer.Spec.StreamRef = nmapiv1alpha1.StreamReference{Name: streamName}
+		return nil
+	}
+}
+
+func WithNATSConsumerFinalizer(finalizer string) NATSConsumerOption {
+	return func(consumer *nmapiv1alpha1.NATSConsumer) error {
+		controllerutil.AddFinalizer(consumer, finalizer)
+		return nil
+	}
+}
+
+func WithNATSConsumerDeletionTimestamp() NATSConsumerOption {
+	return func(consumer *nmapiv1alpha1.NATSConsumer) error {
+		now := kmetav1.Now()
+		consumer.DeletionTimestamp = &now
+		return nil
+	}
+}
+
+func WithNATSConsumerSpec(spec nmapiv1alpha1.NATSConsumerSpec) NATSConsumerOption {
+	return func(consumer *nmapiv1alpha1.NATSConsumer) error {
+		consumer.Spec = spec
+		return nil
+	}
+}
+
+func WithNATSKeyValueName(name string) NATSKeyValueOption {
+	return func(kv *nmapiv1alpha1.NATSKeyValue) error {
+		kv.Name = name
+		return nil
+	}
+}
+
+func WithNATSKeyValueFinalizer(finalizer string) NATSKeyValueOption {
+	return func(kv *nmapiv1alpha1.NATSKeyValue) error {
+		controllerutil.AddFinalizer(kv, finalizer)
+		return nil
+	}
+}
+
+func WithNATSKeyValueDeletionTimestamp() NATSKeyValueOption {
+	return func(kv *nmapiv1alpha1.NATSKeyValue) error {
+		now := kmetav1.Now()
+		kv.DeletionTimestamp = &now
+		return nil
+	}
+}
+
+func WithNATSKeyValueSpec(spec nmapiv1alpha1.NATSKeyValueSpec) NATSKeyValueOption {
+	return func(kv *nmapiv1alpha1.NATSKeyValue) error {
+		kv.Spec = spec
+		return nil
+	}
+}
+
+func WithNATSObjectStoreName(name string) NATSObjectStoreOption {
+	return func(objectStore *nmapiv1alpha1.NATSObjectStore) error {
+		objectStore.Name = name
+		return nil
+	}
+}
+
+func WithNATSObjectStoreFinalizer(finalizer string) NATSObjectStoreOption {
+	return func(objectStore *nmapiv1alpha1.NATSObjectStore) error {
+		controllerutil.AddFinalizer(objectStore, finalizer)
+		return nil
+	}
+}
+
+func WithNATSObjectStoreDeletionTimestamp() NATSObjectStoreOption {
+	return func(objectStore *nmapiv1alpha1.NATSObjectStore) error {
+		now := kmetav1.Now()
+		objectStore.DeletionTimestamp = &now
+		return nil
+	}
+}
+
+func WithNATSObjectStoreSpec(spec nmapiv1alpha1.NATSObjectStoreSpec) NATSObjectStoreOption {
+	return func(objectStore *nmapiv1alpha1.NATSObjectStore) error {
+		objectStore.Spec = spec
+		return nil
+	}
+}
+
+func WithNATSAccountName(name string) NATSAccountOption {
+	return func(account *nmapiv1alpha1.NATSAccount) error {
+		account.Name = name
+		return nil
+	}
+}
+
+func WithNATSAccountNamespace(namespace string) NATSAccountOption {
+	return func(account *nmapiv1alpha1.NATSAccount) error {
+		account.Namespace = namespace
+		return nil
+	}
+}
+
+func WithNATSAccountFinalizer(finalizer string) NATSAccountOption {
+	return func(account *nmapiv1alpha1.NATSAccount) error {
+		controllerutil.AddFinalizer(account, finalizer)
+		return nil
+	}
+}
+
+func WithNATSAccountDeletionTimestamp() NATSAccountOption {
+	return func(account *nmapiv1alpha1.NATSAccount) error {
+		now := kmetav1.Now()
+		account.DeletionTimestamp = &now
+		return nil
+	}
+}
+
+func WithNATSAccountSpec(spec nmapiv1alpha1.NATSAccountSpec) NATSAccountOption {
+	return func(account *nmapiv1alpha1.NATSAccount) error {
+		account.Spec = spec
+		return nil
+	}
+}
+
+func WithNATSBackupName(name string) NATSBackupOption {
+	return func(natsBackup *nmapiv1alpha1.NATSBackup) error {
+		natsBackup.Name = name
+		return nil
+	}
+}
+
+func WithNATSBackupNamespace(namespace string) NATSBackupOption {
+	return func(natsBackup *nmapiv1alpha1.NATSBackup) error {
+		natsBackup.Namespace = namespace
+		return nil
+	}
+}
+
+func WithNATSBackupFinalizer(finalizer string) NATSBackupOption {
+	return func(natsBackup *nmapiv1alpha1.NATSBackup) error {
+		controllerutil.AddFinalizer(natsBackup, finalizer)
+		return nil
+	}
+}
+
+func WithNATSBackupDeletionTimestamp() NATSBackupOption {
+	return func(natsBackup *nmapiv1alpha1.NATSBackup) error {
+		now := kmetav1.Now()
+		natsBackup.DeletionTimestamp = &now
+		return nil
+	}
+}
+
+func WithNATSBackupStreams(streams ...string) NATSBackupOption {
+	return func(natsBackup *nmapiv1alpha1.NATSBackup) error {
+		natsBackup.Spec.Streams = streams
+		return nil
+	}
+}
+
+func WithNATSBackupCreationTimestamp(creationTimestamp time.Time) NATSBackupOption {
+	return func(natsBackup *nmapiv1alpha1.NATSBackup) error {
+		natsBackup.CreationTimestamp = kmetav1.NewTime(creationTimestamp)
+		return nil
+	}
+}
+
+// WithNATSBackupOwner sets the given NATSBackupSchedule as the controller of the NATSBackup.
+func WithNATSBackupOwner(backupSchedule *nmapiv1alpha1.NATSBackupSchedule) NATSBackupOption {
+	return func(natsBackup *nmapiv1alpha1.NATSBackup) error {
+		natsBackup.Namespace = backupSchedule.Namespace
+		natsBackup.OwnerReferences = append(natsBackup.OwnerReferences, *kmetav1.NewControllerRef(backupSchedule,
+			nmapiv1alpha1.GroupVersion.WithKind("NATSBackupSchedule")))
+		return nil
+	}
+}
+
+func WithNATSBackupStatus(status nmapiv1alpha1.NATSBackupStatus) NATSBackupOption {
+	return func(natsBackup *nmapiv1alpha1.NATSBackup) error {
+		natsBackup.Status = status
+		return nil
+	}
+}
+
+func WithNATSRestoreName(name string) NATSRestoreOption {
+	return func(natsRestore *nmapiv1alpha1.NATSRestore) error {
+		natsRestore.Name = name
+		return nil
+	}
+}
+
+func WithNATSRestoreNamespace(namespace string) NATSRestoreOption {
+	return func(natsRestore *nmapiv1alpha1.NATSRestore) error {
+		natsRestore.Namespace = namespace
+		return nil
+	}
+}
+
+func WithNATSRestoreBackupName(backupName string) NATSRestoreOption {
+	return func(natsRestore *nmapiv1alpha1.NATSRestore) error {
+		natsRestore.Spec.BackupName = backupName
+		return nil
+	}
+}
+
+func WithNATSRestoreStreams(streams ...string) NATSRestoreOption {
+	return func(natsRestore *nmapiv1alpha1.NATSRestore) error {
+		natsRestore.Spec.Streams = streams
+		return nil
+	}
+}
+
+func WithNATSRestoreStatus(status nmapiv1alpha1.NATSRestoreStatus) NATSRestoreOption {
+	return func(natsRestore *nmapiv1alpha1.NATSRestore) error {
+		natsRestore.Status = status
+		return nil
+	}
+}
+
+func WithNATSBackupScheduleName(name string) NATSBackupScheduleOption {
+	return func(backupSchedule *nmapiv1alpha1.NATSBackupSchedule) error {
+		backupSchedule.Name = name
+		return nil
+	}
+}
+
+func WithNATSBackupScheduleFinalizer(finalizer string) NATSBackupScheduleOption {
+	return func(backupSchedule *nmapiv1alpha1.NATSBackupSchedule) error {
+		controllerutil.AddFinalizer(backupSchedule, finalizer)
+		return nil
+	}
+}
+
+func WithNATSBackupScheduleDeletionTimestamp() NATSBackupScheduleOption {
+	return func(backupSchedule *nmapiv1alpha1.NATSBackupSchedule) error {
+		now := kmetav1.Now()
+		backupSchedule.DeletionTimestamp = &now
+		return nil
+	}
+}
+
+func WithNATSBackupScheduleCreationTimestamp(creationTimestamp time.Time) NATSBackupScheduleOption {
+	return func(backupSchedule *nmapiv1alpha1.NATSBackupSchedule) error {
+		backupSchedule.CreationTimestamp = kmetav1.NewTime(creationTimestamp)
+		return nil
+	}
+}
+
+func WithNATSBackupScheduleSchedule(schedule string) NATSBackupScheduleOption {
+	return func(backupSchedule *nmapiv1alpha1.NATSBackupSchedule) error {
+		backupSchedule.Spec.Schedule = schedule
+		return nil
+	}
+}
+
+func WithNATSBackupScheduleSuspend() NATSBackupScheduleOption {
+	return func(backupSchedule *nmapiv1alpha1.NATSBackupSchedule) error {
+		backupSchedule.Spec.Suspend = true
+		return nil
+	}
+}
+
+func WithNATSBackupScheduleRetention(retention nmapiv1alpha1.BackupRetention) NATSBackupScheduleOption {
+	return func(backupSchedule *nmapiv1alpha1.NATSBackupSchedule) error {
+		backupSchedule.Spec.Retention = retention
+		return nil
+	}
+}
+
+func WithNATSBackupScheduleStatus(status nmapiv1alpha1.NATSBackupScheduleStatus) NATSBackupScheduleOption {
+	return func(backupSchedule *nmapiv1alpha1.NATSBackupSchedule) error {
+		backupSchedule.Status = status
+		return nil
+	}
+}
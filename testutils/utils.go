@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktypes "k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -204,6 +205,232 @@ func NewNATSCR(opts ...NATSOption) *nmapiv1alpha1.NATS {
 	return nats
 }
 
+func NewNATSStreamCR(opts ...NATSStreamOption) *nmapiv1alpha1.NATSStream {
+	name := fmt.Sprintf(NameFormat, GetRandString(randomNameLen))
+	namespace := fmt.Sprintf(NamespaceFormat, GetRandString(randomNameLen))
+
+	stream := &nmapiv1alpha1.NATSStream{
+		TypeMeta: kmetav1.TypeMeta{
+			APIVersion: "v1alpha1",
+			Kind:       "NATSStream",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: nmapiv1alpha1.NATSStreamSpec{
+			Subjects:  []string{name + ".>"},
+			Retention: nmapiv1alpha1.RetentionPolicyLimits,
+			Replicas:  1,
+			Storage:   nmapiv1alpha1.StorageTypeFile,
+			Discard:   nmapiv1alpha1.DiscardPolicyOld,
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(stream); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return stream
+}
+
+func NewNATSConsumerCR(opts ...NATSConsumerOption) *nmapiv1alpha1.NATSConsumer {
+	name := fmt.Sprintf(NameFormat, GetRandString(randomNameLen))
+	namespace := fmt.Sprintf(NamespaceFormat, GetRandString(randomNameLen))
+
+	consumer := &nmapiv1alpha1.NATSConsumer{
+		TypeMeta: kmetav1.TypeMeta{
+			APIVersion: "v1alpha1",
+			Kind:       "NATSConsumer",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: nmapiv1alpha1.NATSConsumerSpec{
+			AckPolicy:     nmapiv1alpha1.AckPolicyExplicit,
+			DeliverPolicy: nmapiv1alpha1.DeliverPolicyAll,
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(consumer); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return consumer
+}
+
+func NewNATSKeyValueCR(opts ...NATSKeyValueOption) *nmapiv1alpha1.NATSKeyValue {
+	name := fmt.Sprintf(NameFormat, GetRandString(randomNameLen))
+	namespace := fmt.Sprintf(NamespaceFormat, GetRandString(randomNameLen))
+
+	kv := &nmapiv1alpha1.NATSKeyValue{
+		TypeMeta: kmetav1.TypeMeta{
+			APIVersion: "v1alpha1",
+			Kind:       "NATSKeyValue",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: nmapiv1alpha1.NATSKeyValueSpec{
+			History:  1,
+			Replicas: 1,
+			Storage:  nmapiv1alpha1.StorageTypeFile,
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(kv); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return kv
+}
+
+func NewNATSObjectStoreCR(opts ...NATSObjectStoreOption) *nmapiv1alpha1.NATSObjectStore {
+	name := fmt.Sprintf(NameFormat, GetRandString(randomNameLen))
+	namespace := fmt.Sprintf(NamespaceFormat, GetRandString(randomNameLen))
+
+	objectStore := &nmapiv1alpha1.NATSObjectStore{
+		TypeMeta: kmetav1.TypeMeta{
+			APIVersion: "v1alpha1",
+			Kind:       "NATSObjectStore",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: nmapiv1alpha1.NATSObjectStoreSpec{
+			Replicas: 1,
+			Storage:  nmapiv1alpha1.StorageTypeFile,
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(objectStore); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return objectStore
+}
+
+func NewNATSBackupCR(opts ...NATSBackupOption) *nmapiv1alpha1.NATSBackup {
+	name := fmt.Sprintf(NameFormat, GetRandString(randomNameLen))
+	namespace := fmt.Sprintf(NamespaceFormat, GetRandString(randomNameLen))
+
+	natsBackup := &nmapiv1alpha1.NATSBackup{
+		TypeMeta: kmetav1.TypeMeta{
+			APIVersion: "v1alpha1",
+			Kind:       "NATSBackup",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: nmapiv1alpha1.NATSBackupSpec{
+			Storage: nmapiv1alpha1.BackupStorage{
+				Filesystem: &nmapiv1alpha1.FilesystemBackupStorage{},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(natsBackup); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return natsBackup
+}
+
+func NewNATSRestoreCR(opts ...NATSRestoreOption) *nmapiv1alpha1.NATSRestore {
+	name := fmt.Sprintf(NameFormat, GetRandString(randomNameLen))
+	namespace := fmt.Sprintf(NamespaceFormat, GetRandString(randomNameLen))
+
+	natsRestore := &nmapiv1alpha1.NATSRestore{
+		TypeMeta: kmetav1.TypeMeta{
+			APIVersion: "v1alpha1",
+			Kind:       "NATSRestore",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(natsRestore); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return natsRestore
+}
+
+func NewNATSBackupScheduleCR(opts ...NATSBackupScheduleOption) *nmapiv1alpha1.NATSBackupSchedule {
+	name := fmt.Sprintf(NameFormat, GetRandString(randomNameLen))
+	namespace := fmt.Sprintf(NamespaceFormat, GetRandString(randomNameLen))
+
+	backupSchedule := &nmapiv1alpha1.NATSBackupSchedule{
+		TypeMeta: kmetav1.TypeMeta{
+			APIVersion: "v1alpha1",
+			Kind:       "NATSBackupSchedule",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       ktypes.UID(GetRandString(randomNameLen)),
+		},
+		Spec: nmapiv1alpha1.NATSBackupScheduleSpec{
+			Schedule: "@daily",
+			Storage: nmapiv1alpha1.BackupStorage{
+				Filesystem: &nmapiv1alpha1.FilesystemBackupStorage{},
+			},
+			Retention: nmapiv1alpha1.BackupRetention{Count: nmapiv1alpha1.DefaultBackupRetentionCount},
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(backupSchedule); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return backupSchedule
+}
+
+func NewNATSAccountCR(opts ...NATSAccountOption) *nmapiv1alpha1.NATSAccount {
+	name := fmt.Sprintf(NameFormat, GetRandString(randomNameLen))
+	namespace := fmt.Sprintf(NamespaceFormat, GetRandString(randomNameLen))
+
+	account := &nmapiv1alpha1.NATSAccount{
+		TypeMeta: kmetav1.TypeMeta{
+			APIVersion: "v1alpha1",
+			Kind:       "NATSAccount",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       ktypes.UID(GetRandString(randomNameLen)),
+		},
+	}
+
+	for _, opt := range opts {
+		if err := opt(account); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return account
+}
+
 func NewDestinationRuleCRD() *kapiextv1.CustomResourceDefinition {
 	result := &kapiextv1.CustomResourceDefinition{
 		TypeMeta: kmetav1.TypeMeta{